@@ -29,6 +29,8 @@ import (
 const (
 	ValidateCRDWebhookConfigName    = "kubeedge-crds-validate-webhook-configuration"
 	ValidateDeviceModelWebhookName  = "validatedevicemodel.kubeedge.io"
+	ValidateDeviceWebhookName       = "validatedevice.kubeedge.io"
+	ValidateDeviceAlertRuleName     = "validatedevicealertrule.kubeedge.io"
 	ValidateRuleWebhookName         = "validatedrule.kubeedge.io"
 	ValidateRuleEndpointWebhookName = "validatedruleendpoint.kubeedge.io"
 	ValidateNodeUpgradeWebhookName  = "validatenodeupgradejob.kubeedge.io"
@@ -100,6 +102,8 @@ func Run(opt *options.AdmissionOptions) error {
 	}
 
 	http.HandleFunc("/devicemodels", serveDeviceModel)
+	http.HandleFunc("/devices", serveDevice)
+	http.HandleFunc("/devicealertrules", serveDeviceAlertRule)
 	http.HandleFunc("/rules", serveRule)
 	http.HandleFunc("/ruleendpoints", serveRuleEndpoint)
 	http.HandleFunc("/offlinemigration", serveOfflineMigration)
@@ -189,6 +193,60 @@ func (ac *AdmissionController) registerWebhooks(opt *options.AdmissionOptions, c
 				SideEffects:             &noneSideEffect,
 				AdmissionReviewVersions: []string{"v1"},
 			},
+			// Device Validating Webhook
+			{
+				Name: ValidateDeviceWebhookName,
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Operations: []admissionregistrationv1.OperationType{
+						admissionregistrationv1.Create,
+						admissionregistrationv1.Update,
+					},
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{"devices.kubeedge.io"},
+						APIVersions: []string{"v1alpha2"},
+						Resources:   []string{"devices"},
+					},
+				}},
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Namespace: opt.AdmissionServiceNamespace,
+						Name:      opt.AdmissionServiceName,
+						Path:      strPtr("/devices"),
+						Port:      &opt.Port,
+					},
+					CABundle: cabundle,
+				},
+				FailurePolicy:           &ignorePolicy,
+				SideEffects:             &noneSideEffect,
+				AdmissionReviewVersions: []string{"v1"},
+			},
+			// Device Alert Rule Validating Webhook
+			{
+				Name: ValidateDeviceAlertRuleName,
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Operations: []admissionregistrationv1.OperationType{
+						admissionregistrationv1.Create,
+						admissionregistrationv1.Update,
+					},
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{"devices.kubeedge.io"},
+						APIVersions: []string{"v1alpha2"},
+						Resources:   []string{"devicealertrules"},
+					},
+				}},
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Namespace: opt.AdmissionServiceNamespace,
+						Name:      opt.AdmissionServiceName,
+						Path:      strPtr("/devicealertrules"),
+						Port:      &opt.Port,
+					},
+					CABundle: cabundle,
+				},
+				FailurePolicy:           &ignorePolicy,
+				SideEffects:             &noneSideEffect,
+				AdmissionReviewVersions: []string{"v1"},
+			},
 			// Rule Validating Webhook
 			{
 				Name: ValidateRuleWebhookName,
@@ -362,6 +420,14 @@ func (ac *AdmissionController) registerWebhooks(opt *options.AdmissionOptions, c
 		[]admissionregistrationv1.MutatingWebhookConfiguration{offlineMigrationWebhook, mutatingWebhook})
 }
 
+func (ac *AdmissionController) getDevice(namespace, name string) (*v1alpha2.Device, error) {
+	return ac.CrdClient.DevicesV1alpha2().Devices(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (ac *AdmissionController) getDeviceModel(namespace, name string) (*v1alpha2.DeviceModel, error) {
+	return ac.CrdClient.DevicesV1alpha2().DeviceModels(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
 func (ac *AdmissionController) getRuleEndpoint(namespace, name string) (*v1.RuleEndpoint, error) {
 	return ac.CrdClient.RulesV1().RuleEndpoints(namespace).Get(context.TODO(), name, metav1.GetOptions{})
 }
@@ -0,0 +1,91 @@
+package admissioncontroller
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	devicesv1alpha2 "github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2/validation"
+)
+
+func admitDevice(review admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	reviewResponse := admissionv1.AdmissionResponse{}
+
+	switch review.Request.Operation {
+	case admissionv1.Create, admissionv1.Update:
+		deserializer := codecs.UniversalDeserializer()
+		device := devicesv1alpha2.Device{}
+		if _, _, err := deserializer.Decode(review.Request.Object.Raw, nil, &device); err != nil {
+			klog.Errorf("validation failed with error: %v", err)
+			return toAdmissionResponse(err)
+		}
+		if review.Request.Operation == admissionv1.Update {
+			oldDevice := devicesv1alpha2.Device{}
+			if _, _, err := deserializer.Decode(review.Request.OldObject.Raw, nil, &oldDevice); err != nil {
+				klog.Errorf("validation failed with error: %v", err)
+				return toAdmissionResponse(err)
+			}
+			// the reported values are updated by cloudcore, only the changes of users are validated
+			if !deviceChanged(&oldDevice, &device) {
+				reviewResponse.Allowed = true
+				return &reviewResponse
+			}
+		}
+		if err := validateDevice(&device); err != nil {
+			return toAdmissionResponse(err)
+		}
+		reviewResponse.Allowed = true
+		return &reviewResponse
+	case admissionv1.Delete, admissionv1.Connect:
+		//no rule defined for above operations, greenlight for all of above.
+		reviewResponse.Allowed = true
+		return &reviewResponse
+	default:
+		err := fmt.Errorf("unsupported webhook operation %v", review.Request.Operation)
+		klog.Errorf("Unsupported webhook operation %v", review.Request.Operation)
+		return toAdmissionResponse(err)
+	}
+}
+
+// deviceChanged returns whether the model reference, the property visitors or the desired values are changed
+func deviceChanged(oldDevice, device *devicesv1alpha2.Device) bool {
+	if !reflect.DeepEqual(oldDevice.Spec.DeviceModelRef, device.Spec.DeviceModelRef) ||
+		!reflect.DeepEqual(oldDevice.Spec.PropertyVisitors, device.Spec.PropertyVisitors) ||
+		len(oldDevice.Status.Twins) != len(device.Status.Twins) {
+		return true
+	}
+	for i := range device.Status.Twins {
+		if oldDevice.Status.Twins[i].PropertyName != device.Status.Twins[i].PropertyName ||
+			oldDevice.Status.Twins[i].Desired.Value != device.Status.Twins[i].Desired.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func validateDevice(device *devicesv1alpha2.Device) error {
+	var model *devicesv1alpha2.DeviceModel
+	if device.Spec.DeviceModelRef != nil && device.Spec.DeviceModelRef.Name != "" {
+		var err error
+		model, err = controller.getDeviceModel(device.Namespace, device.Spec.DeviceModelRef.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("cant get device model %s/%s. Reason: %w", device.Namespace, device.Spec.DeviceModelRef.Name, err)
+		}
+		if err != nil {
+			model = nil
+		}
+	}
+	if errs := validation.ValidateDevice(device, model); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	return nil
+}
+
+func serveDevice(w http.ResponseWriter, r *http.Request) {
+	serve(w, r, admitDevice)
+}
@@ -0,0 +1,85 @@
+package admissioncontroller
+
+import (
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	devicesv1alpha2 "github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2/validation"
+)
+
+func admitDeviceAlertRule(review admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	reviewResponse := admissionv1.AdmissionResponse{}
+
+	switch review.Request.Operation {
+	case admissionv1.Create, admissionv1.Update:
+		deserializer := codecs.UniversalDeserializer()
+		rule := devicesv1alpha2.DeviceAlertRule{}
+		if _, _, err := deserializer.Decode(review.Request.Object.Raw, nil, &rule); err != nil {
+			klog.Errorf("validation failed with error: %v", err)
+			return toAdmissionResponse(err)
+		}
+		if err := validateDeviceAlertRule(&rule); err != nil {
+			return toAdmissionResponse(err)
+		}
+		reviewResponse.Allowed = true
+		return &reviewResponse
+	case admissionv1.Delete, admissionv1.Connect:
+		//no rule defined for above operations, greenlight for all of above.
+		reviewResponse.Allowed = true
+		return &reviewResponse
+	default:
+		err := fmt.Errorf("unsupported webhook operation %v", review.Request.Operation)
+		klog.Errorf("Unsupported webhook operation %v", review.Request.Operation)
+		return toAdmissionResponse(err)
+	}
+}
+
+// validateDeviceAlertRule validates the desired values written by the rule against the device models of the target devices
+func validateDeviceAlertRule(rule *devicesv1alpha2.DeviceAlertRule) error {
+	models := make(map[string]*devicesv1alpha2.DeviceModel)
+	for _, write := range rule.Spec.Actions.DesiredWrites {
+		if _, ok := models[write.DeviceName]; ok {
+			continue
+		}
+		model, err := deviceModelOfDevice(rule.Namespace, write.DeviceName)
+		if err != nil {
+			return err
+		}
+		models[write.DeviceName] = model
+	}
+	if errs := validation.ValidateAlertDesiredWrites(rule, models); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	return nil
+}
+
+// deviceModelOfDevice returns the device model of the device, or nil if the device or its device model does not exist
+func deviceModelOfDevice(namespace, name string) (*devicesv1alpha2.DeviceModel, error) {
+	device, err := controller.getDevice(namespace, name)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cant get device %s/%s. Reason: %w", namespace, name, err)
+	}
+	if device.Spec.DeviceModelRef == nil || device.Spec.DeviceModelRef.Name == "" {
+		return nil, nil
+	}
+	model, err := controller.getDeviceModel(namespace, device.Spec.DeviceModelRef.Name)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cant get device model %s/%s. Reason: %w", namespace, device.Spec.DeviceModelRef.Name, err)
+	}
+	return model, nil
+}
+
+func serveDeviceAlertRule(w http.ResponseWriter, r *http.Request) {
+	serve(w, r, admitDeviceAlertRule)
+}
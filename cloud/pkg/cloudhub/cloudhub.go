@@ -11,6 +11,7 @@ import (
 	hubconfig "github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/config"
 	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/dispatcher"
 	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/handler"
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/revocation"
 	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/servers"
 	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/servers/httpserver"
 	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/servers/udsserver"
@@ -18,6 +19,7 @@ import (
 	"github.com/kubeedge/kubeedge/cloud/pkg/common/informers"
 	"github.com/kubeedge/kubeedge/cloud/pkg/common/modules"
 	"github.com/kubeedge/kubeedge/cloud/pkg/sessionmanager"
+	"github.com/kubeedge/kubeedge/common/constants"
 	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/cloudcore/v1alpha1"
 )
 
@@ -38,6 +40,10 @@ func newCloudHub(modules *v1alpha1.Modules) *cloudHub {
 	// declare used informer
 	clusterObjectSyncInformer := crdFactory.Reliablesyncs().V1alpha1().ClusterObjectSyncs()
 	objectSyncInformer := crdFactory.Reliablesyncs().V1alpha1().ObjectSyncs()
+	secretInformer := informers.GetInformersManager().Secret(constants.SystemNamespace, revocation.SecretName)
+
+	// keep the revoked edge certificates and blocked edge nodes in sync
+	revocation.Watch(secretInformer)
 
 	sessionManager := sessionmanager.NewSessionManager(modules)
 
@@ -47,6 +53,7 @@ func newCloudHub(modules *v1alpha1.Modules) *cloudHub {
 
 	messageHandler := handler.NewMessageHandler(
 		int(hubconfig.Config.KeepaliveInterval),
+		int(hubconfig.Config.MaxKeepaliveInterval),
 		sessionManager, client.GetCRDClient(), messageDispatcher)
 
 	ch := &cloudHub{
@@ -57,6 +64,7 @@ func newCloudHub(modules *v1alpha1.Modules) *cloudHub {
 
 	ch.informersSyncedFuncs = append(ch.informersSyncedFuncs, clusterObjectSyncInformer.Informer().HasSynced)
 	ch.informersSyncedFuncs = append(ch.informersSyncedFuncs, objectSyncInformer.Informer().HasSynced)
+	ch.informersSyncedFuncs = append(ch.informersSyncedFuncs, secretInformer.HasSynced)
 
 	return ch
 }
@@ -98,6 +98,15 @@ func ConstructConnectMessage(info *model.HubInfo, isConnected bool) *beehivemode
 	return msg
 }
 
+// ConstructSessionTokenMessage constructs the message carrying the session token
+// which is used by edge node to resume the session when reconnecting
+func ConstructSessionTokenMessage(nodeID, token string) *beehivemodel.Message {
+	msg := beehivemodel.NewMessage("")
+	msg.BuildRouter(model.SrcCloudHub, model.GpHub, model.NewResource(model.ResSession, nodeID, nil), model.OpSessionToken)
+	msg.FillBody(token)
+	return msg
+}
+
 func DeepCopy(msg *beehivemodel.Message) *beehivemodel.Message {
 	if msg == nil {
 		return nil
@@ -18,8 +18,10 @@ package common
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
@@ -44,6 +46,11 @@ type NodeMessagePool struct {
 	// NoAckMessageQueue store message key that will send to edge node
 	// and do not require acknowledgement from edge node.
 	NoAckMessageQueue workqueue.RateLimitingInterface
+
+	// pendingAckKeys records the key of messages that are enqueued
+	// but not acknowledged by edge node yet.
+	pendingAckKeys sets.String
+	pendingLock    sync.Mutex
 }
 
 // InitNodeMessagePool init node message pool for node
@@ -54,7 +61,59 @@ func InitNodeMessagePool(nodeID string) *NodeMessagePool {
 		AckMessageQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), nodeID),
 		NoAckMessageStore: cache.NewStore(NoAckMessageKeyFunc),
 		NoAckMessageQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), nodeID),
+		pendingAckKeys:    sets.NewString(),
+	}
+}
+
+// ResumeNodeMessagePool init a new message pool for the resumed session of node.
+// The new pool shares the message stores with the given pool, and enqueues the
+// messages that have not been sent or acknowledged in the given pool again.
+func ResumeNodeMessagePool(nodeID string, pool *NodeMessagePool) *NodeMessagePool {
+	resumed := &NodeMessagePool{
+		InitTime:          time.Now().Unix(),
+		AckMessageStore:   pool.AckMessageStore,
+		AckMessageQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), nodeID),
+		NoAckMessageStore: pool.NoAckMessageStore,
+		NoAckMessageQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), nodeID),
+		pendingAckKeys:    sets.NewString(),
+	}
+
+	for _, key := range pool.PendingAckKeys() {
+		if _, exist, _ := resumed.AckMessageStore.GetByKey(key); !exist {
+			continue
+		}
+		resumed.MarkAckPending(key)
+		resumed.AckMessageQueue.Add(key)
 	}
+
+	// NoAckMessage is deleted from the store once it is sent,
+	// so all the messages in the store are not sent yet
+	for _, key := range resumed.NoAckMessageStore.ListKeys() {
+		resumed.NoAckMessageQueue.Add(key)
+	}
+
+	return resumed
+}
+
+// MarkAckPending records the message key as waiting for acknowledgement
+func (nsp *NodeMessagePool) MarkAckPending(key string) {
+	nsp.pendingLock.Lock()
+	defer nsp.pendingLock.Unlock()
+	nsp.pendingAckKeys.Insert(key)
+}
+
+// MarkAcked removes the message key from the keys waiting for acknowledgement
+func (nsp *NodeMessagePool) MarkAcked(key string) {
+	nsp.pendingLock.Lock()
+	defer nsp.pendingLock.Unlock()
+	nsp.pendingAckKeys.Delete(key)
+}
+
+// PendingAckKeys returns the keys of messages waiting for acknowledgement
+func (nsp *NodeMessagePool) PendingAckKeys() []string {
+	nsp.pendingLock.Lock()
+	defer nsp.pendingLock.Unlock()
+	return nsp.pendingAckKeys.List()
 }
 
 // GetAckMessage get message that requires ack with the key
@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	beehivemodel "github.com/kubeedge/beehive/pkg/core/model"
+)
+
+func TestResumeNodeMessagePool(t *testing.T) {
+	pool := InitNodeMessagePool("node")
+
+	noAckMsg := beehivemodel.NewMessage("")
+	if err := pool.NoAckMessageStore.Add(noAckMsg); err != nil {
+		t.Fatalf("failed to add message: %v", err)
+	}
+
+	pool.MarkAckPending("pending")
+	pool.MarkAckPending("acked")
+	pool.MarkAckPending("missing")
+	pool.MarkAcked("acked")
+	for _, key := range []string{"pending", "acked"} {
+		msg := beehivemodel.NewMessage("").BuildRouter("edgecontroller", "resource", "node/node/default/pod/"+key, "update")
+		msg.Content = &v1.Pod{ObjectMeta: metav1.ObjectMeta{UID: types.UID(key)}}
+		if err := pool.AckMessageStore.Add(msg); err != nil {
+			t.Fatalf("failed to add message: %v", err)
+		}
+	}
+	pool.ShutDown()
+
+	resumed := ResumeNodeMessagePool("node", pool)
+	if resumed.AckMessageStore != pool.AckMessageStore || resumed.NoAckMessageStore != pool.NoAckMessageStore {
+		t.Errorf("expected resumed pool sharing the message stores")
+	}
+	if keys := resumed.PendingAckKeys(); len(keys) != 1 || keys[0] != "pending" {
+		t.Errorf("expected pending ack keys [pending], got %v", keys)
+	}
+	if resumed.AckMessageQueue.Len() != 1 {
+		t.Errorf("expected 1 ack message enqueued, got %d", resumed.AckMessageQueue.Len())
+	}
+	if resumed.NoAckMessageQueue.Len() != 1 {
+		t.Errorf("expected 1 no ack message enqueued, got %d", resumed.NoAckMessageQueue.Len())
+	}
+}
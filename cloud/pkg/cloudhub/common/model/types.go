@@ -14,32 +14,35 @@ import (
 
 // constants for resource types
 const (
-	ResNode   = "node"
-	ResMember = "membership"
-	ResTwin   = "twin"
-	ResAuth   = "auth_info"
-	ResDevice = "device"
+	ResNode    = "node"
+	ResMember  = "membership"
+	ResTwin    = "twin"
+	ResAuth    = "auth_info"
+	ResDevice  = "device"
+	ResSession = "session"
 )
 
 // constants for resource operations
 const (
-	OpGet        = "get"
-	OpResult     = "get_result"
-	OpList       = "list"
-	OpDetail     = "detail"
-	OpDelta      = "delta"
-	OpDoc        = "document"
-	OpUpdate     = "updated"
-	OpInsert     = "insert"
-	OpDelete     = "deleted"
-	OpConnect    = "connected"
-	OpDisConnect = "disconnected"
-	OpKeepalive  = "keepalive"
+	OpGet          = "get"
+	OpResult       = "get_result"
+	OpList         = "list"
+	OpDetail       = "detail"
+	OpDelta        = "delta"
+	OpDoc          = "document"
+	OpUpdate       = "updated"
+	OpInsert       = "insert"
+	OpDelete       = "deleted"
+	OpConnect      = "connected"
+	OpDisConnect   = "disconnected"
+	OpKeepalive    = "keepalive"
+	OpSessionToken = "sessiontoken"
 )
 
 // GpResource constants for message group
 const (
 	GpResource = "resource"
+	GpHub      = "hub"
 )
 
 // constants for message source
@@ -50,8 +53,9 @@ const (
 
 // constants for identifier information for edge hub
 const (
-	ProjectID = "project_id"
-	NodeID    = "node_id"
+	ProjectID    = "project_id"
+	NodeID       = "node_id"
+	SessionToken = "session_token"
 )
 
 var cloudModuleArray = []string{
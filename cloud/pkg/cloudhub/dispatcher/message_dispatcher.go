@@ -18,6 +18,7 @@ package dispatcher
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -36,6 +37,7 @@ import (
 	"github.com/kubeedge/kubeedge/cloud/pkg/sessionmanager"
 	"github.com/kubeedge/kubeedge/cloud/pkg/synccontroller"
 	commonconst "github.com/kubeedge/kubeedge/common/constants"
+	commontypes "github.com/kubeedge/kubeedge/common/types"
 	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
 	"github.com/kubeedge/kubeedge/pkg/apis/reliablesyncs/v1alpha1"
 	reliableclient "github.com/kubeedge/kubeedge/pkg/client/clientset/versioned"
@@ -177,6 +179,14 @@ func (md *messageDispatcher) DispatchUpstream(message *beehivemodel.Message, inf
 			klog.Errorf("node %s receive keep alive message err: %v", info.NodeID, err)
 		}
 
+		// keepalive message of edge node in older version carries no link quality report
+		report := &commontypes.KeepaliveRequest{}
+		if data, err := message.GetContentData(); err == nil && json.Unmarshal(data, report) == nil {
+			if err := md.SessionManager.ReceiveKeepaliveReport(info.NodeID, message, report); err != nil {
+				klog.Errorf("node %s receive keepalive report err: %v", info.NodeID, err)
+			}
+		}
+
 	case common.IsVolumeResource(message.GetResource()):
 		beehivecontext.SendResp(*message)
 
@@ -244,6 +254,7 @@ func (md *messageDispatcher) enqueueAckMessage(nodeID string, msg *beehivemodel.
 				klog.Errorf("fail to add message %v nodeStore, err: %v", msg, err)
 				return
 			}
+			nodeMessagePool.MarkAckPending(messageKey)
 			nodeQueue.Add(messageKey)
 		}
 	}()
@@ -449,6 +460,8 @@ func noAckRequired(msg *beehivemodel.Message) bool {
 		return true
 	case strings.Contains(msgResource, "twin/cloud_updated"):
 		return true
+	case strings.HasSuffix(msgResource, "command/invoke"):
+		return true
 	case strings.Contains(msgResource, beehivemodel.ResourceTypeServiceAccountToken):
 		return true
 	case isVolumeOperation(msg.GetOperation()):
@@ -17,6 +17,8 @@ limitations under the License.
 package handler
 
 import (
+	"crypto/x509"
+	"fmt"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -24,6 +26,7 @@ import (
 	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/common"
 	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/common/model"
 	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/dispatcher"
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/revocation"
 	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/session"
 	"github.com/kubeedge/kubeedge/cloud/pkg/sessionmanager"
 	reliableclient "github.com/kubeedge/kubeedge/pkg/client/clientset/versioned"
@@ -50,24 +53,30 @@ type Handler interface {
 
 func NewMessageHandler(
 	KeepaliveInterval int,
+	MaxKeepaliveInterval int,
 	manager *sessionmanager.SessionManager,
 	reliableClient reliableclient.Interface,
 	dispatcher dispatcher.MessageDispatcher) Handler {
 	messageHandler := &messageHandler{
-		KeepaliveInterval: KeepaliveInterval,
-		SessionManager:    manager,
-		MessageDispatcher: dispatcher,
-		reliableClient:    reliableClient,
+		KeepaliveInterval:    KeepaliveInterval,
+		MaxKeepaliveInterval: MaxKeepaliveInterval,
+		SessionManager:       manager,
+		MessageDispatcher:    dispatcher,
+		reliableClient:       reliableClient,
 	}
 
 	// init handler that process upstream message
 	messageHandler.initServerEntries()
 
+	// disconnect the edge nodes once they are blocked or their certificates are revoked
+	revocation.OnUpdate(messageHandler.terminateRevokedSessions)
+
 	return messageHandler
 }
 
 type messageHandler struct {
-	KeepaliveInterval int
+	KeepaliveInterval    int
+	MaxKeepaliveInterval int
 
 	// SessionManager
 	SessionManager *sessionmanager.SessionManager
@@ -111,11 +120,31 @@ func (mh *messageHandler) HandleConnection(connection conn.Connection) {
 		return
 	}
 
+	if err := verifyNodeCert(nodeID, connection.ConnectionState().PeerCertificates); err != nil {
+		klog.Errorf("Fail to serve node %s, %v", nodeID, err)
+		if err := connection.Close(); err != nil {
+			klog.Errorf("failed to close connection of node %s, err: %v", nodeID, err)
+		}
+		return
+	}
+
 	nodeInfo := &model.HubInfo{ProjectID: projectID, NodeID: nodeID, CloudID: mh.SessionManager.GetCloudID()}
 
-	if err := mh.OnEdgeNodeConnect(nodeInfo, connection); err != nil {
-		klog.Errorf("publish connect event for node %s, err %v", nodeInfo.NodeID, err)
-		return
+	sessionToken := connection.ConnectionState().Headers.Get(model.SessionToken)
+	resumedPool, resumed := mh.SessionManager.ResumeSession(nodeID, sessionToken)
+	if !resumed {
+		// the message pool of the suspended session has been shut down and
+		// can not be reused, drop it before a new pool is created
+		if pool, dropped := mh.SessionManager.DropSuspendedSession(nodeID); dropped {
+			klog.Infof("edge node %s connects without resuming its suspended session", nodeID)
+			mh.MessageDispatcher.DeleteNodeMessagePool(nodeID, pool)
+		}
+
+		// the node is still connected for the edge controller if the session is resumed
+		if err := mh.OnEdgeNodeConnect(nodeInfo, connection); err != nil {
+			klog.Errorf("publish connect event for node %s, err %v", nodeInfo.NodeID, err)
+			return
+		}
 	}
 
 	// start a goroutine for serving the node connection
@@ -126,28 +155,94 @@ func (mh *messageHandler) HandleConnection(connection conn.Connection) {
 		// nodeMessagePool := common.InitNodeMessagePool(nodeID)
 		// mh.MessageDispatcher.AddNodeMessagePool(nodeID, nodeMessagePool)
 		// check for an exist one
-		nodeMessagePool := mh.MessageDispatcher.GetNodeMessagePool(nodeID)
+		var nodeMessagePool *common.NodeMessagePool
+		if resumed {
+			klog.Infof("edge node %s resumes its session", nodeID)
+			nodeMessagePool = common.ResumeNodeMessagePool(nodeID, resumedPool)
+			mh.MessageDispatcher.AddNodeMessagePool(nodeID, nodeMessagePool)
+		} else {
+			nodeMessagePool = mh.MessageDispatcher.GetNodeMessagePool(nodeID)
+		}
 
 		keepaliveInterval := time.Duration(mh.KeepaliveInterval) * time.Second
 		// create a node session for each edge node
 		nodeSession := session.NewNodeSession(nodeID, projectID, mh.SessionManager.GetCloudID(), connection,
 			keepaliveInterval, nodeMessagePool, mh.reliableClient)
+		nodeSession.SetMaxKeepaliveInterval(time.Duration(mh.MaxKeepaliveInterval) * time.Second)
 		// add node session to the session manager
 		mh.SessionManager.AddSession(nodeSession)
 
+		// issue the session token used by edge node to resume the session
+		if mh.SessionManager.ResumeGracePeriod > 0 {
+			tokenMsg := common.ConstructSessionTokenMessage(nodeID, nodeSession.GetSessionToken())
+			if err := connection.WriteMessageAsync(tokenMsg); err != nil {
+				klog.Errorf("failed to send session token to node %s, err: %v", nodeID, err)
+			}
+		}
+
 		// start session for each edge node and it will keep running until
 		// it encounters some Transport Error from underlying connection.
 		nodeSession.Start()
 
 		klog.Infof("edge node %s for project %s disConnected", nodeInfo.NodeID, nodeInfo.ProjectID)
 
-		// clean node message pool and session
-		mh.MessageDispatcher.DeleteNodeMessagePool(nodeInfo.NodeID, nodeMessagePool)
-		mh.SessionManager.DeleteSession(nodeSession)
-		mh.OnEdgeNodeDisconnect(nodeInfo, connection)
+		// the node has reconnected and taken over the session
+		if !mh.SessionManager.DeleteSession(nodeSession) && sessionReplaced(mh.SessionManager, nodeSession) {
+			return
+		}
+
+		cleanup := func() {
+			// clean node message pool and session
+			mh.MessageDispatcher.DeleteNodeMessagePool(nodeInfo.NodeID, nodeMessagePool)
+			mh.OnEdgeNodeDisconnect(nodeInfo, connection)
+		}
+
+		// keep the message pool for resumption if the connection is broken
+		if nodeSession.GetTerminateErr() == session.TransportErr &&
+			mh.SessionManager.SuspendSession(nodeSession, cleanup) {
+			return
+		}
+		cleanup()
 	}()
 }
 
+// verifyNodeCert verifies that the node connects with its own certificate
+// which is neither revoked nor belongs to a blocked node, the legacy
+// certificates not bound to any node are accepted until the node is upgraded
+func verifyNodeCert(nodeID string, certs []*x509.Certificate) error {
+	if len(certs) == 0 {
+		return fmt.Errorf("no certificate presented")
+	}
+	if cn := certs[0].Subject.CommonName; cn != nodeID && cn != revocation.LegacyEdgeCertCommonName {
+		return fmt.Errorf("certificate is issued to %q instead of the node", cn)
+	}
+	return revocation.Verify(nodeID, certs[0])
+}
+
+// sessionReplaced checks whether the current session of the node is resumed from the given session
+func sessionReplaced(manager *sessionmanager.SessionManager, nodeSession sessionmanager.NodeSession) bool {
+	current, exists := manager.GetSession(nodeSession.GetNodeID())
+	return exists && current.GetNodeMessagePool() != nodeSession.GetNodeMessagePool() &&
+		current.GetNodeMessagePool().AckMessageStore == nodeSession.GetNodeMessagePool().AckMessageStore
+}
+
+// terminateRevokedSessions terminates the sessions of the blocked nodes
+// and the nodes connected with revoked certificates
+func (mh *messageHandler) terminateRevokedSessions() {
+	mh.SessionManager.NodeSessions.Range(func(_, value interface{}) bool {
+		nodeSession, ok := value.(*session.NodeSession)
+		if !ok {
+			return true
+		}
+		if err := revocation.Verify(nodeSession.GetNodeID(), nodeSession.GetPeerCertificate()); err != nil {
+			klog.Warningf("terminate session of node %s, %v", nodeSession.GetNodeID(), err)
+			nodeSession.SetTerminateErr(session.RevokedErr)
+			nodeSession.Terminating()
+		}
+		return true
+	})
+}
+
 func (mh *messageHandler) OnEdgeNodeConnect(info *model.HubInfo, connection conn.Connection) error {
 	err := mh.MessageDispatcher.Publish(common.ConstructConnectMessage(info, true))
 	if err != nil {
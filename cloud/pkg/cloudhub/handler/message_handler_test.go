@@ -0,0 +1,234 @@
+/*
+Copyright 2022 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	beehivemodel "github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/common"
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/common/model"
+	tf "github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/common/testing"
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/revocation"
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/session"
+	"github.com/kubeedge/kubeedge/cloud/pkg/sessionmanager"
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/cloudcore/v1alpha1"
+	"github.com/kubeedge/kubeedge/pkg/client/clientset/versioned/fake"
+	"github.com/kubeedge/viaduct/pkg/conn"
+	mockcon "github.com/kubeedge/viaduct/pkg/conn/testing"
+)
+
+// fakeDispatcher keeps the node message pools and records the published messages
+type fakeDispatcher struct {
+	pools     sync.Map
+	lock      sync.Mutex
+	published []*beehivemodel.Message
+}
+
+func (fd *fakeDispatcher) DispatchDownstream() {}
+
+func (fd *fakeDispatcher) DispatchUpstream(*beehivemodel.Message, *model.HubInfo) {}
+
+func (fd *fakeDispatcher) AddNodeMessagePool(nodeID string, pool *common.NodeMessagePool) {
+	fd.pools.Store(nodeID, pool)
+}
+
+func (fd *fakeDispatcher) DeleteNodeMessagePool(nodeID string, pool *common.NodeMessagePool) {
+	if current, exists := fd.pools.Load(nodeID); exists && current == pool {
+		fd.pools.Delete(nodeID)
+	}
+}
+
+func (fd *fakeDispatcher) GetNodeMessagePool(nodeID string) *common.NodeMessagePool {
+	pool, _ := fd.pools.LoadOrStore(nodeID, common.InitNodeMessagePool(nodeID))
+	return pool.(*common.NodeMessagePool)
+}
+
+func (fd *fakeDispatcher) Publish(msg *beehivemodel.Message) error {
+	fd.lock.Lock()
+	defer fd.lock.Unlock()
+	fd.published = append(fd.published, msg)
+	return nil
+}
+
+func (fd *fakeDispatcher) CheckPools() {}
+
+func (fd *fakeDispatcher) publishedOperations() []string {
+	fd.lock.Lock()
+	defer fd.lock.Unlock()
+	var operations []string
+	for _, msg := range fd.published {
+		operations = append(operations, msg.GetOperation())
+	}
+	return operations
+}
+
+func newTestHandler() (*messageHandler, *fakeDispatcher) {
+	conf := v1alpha1.NewDefaultCloudCoreConfig()
+	manager := sessionmanager.NewSessionManager(conf.Modules)
+	manager.ResumeGracePeriod = time.Minute
+
+	dispatcher := &fakeDispatcher{}
+	return &messageHandler{
+		KeepaliveInterval:    30,
+		MaxKeepaliveInterval: 30,
+		SessionManager:       manager,
+		MessageDispatcher:    dispatcher,
+		reliableClient:       &fake.Clientset{},
+	}, dispatcher
+}
+
+func newTestConnection(t *testing.T, token string) *mockcon.MockConnection {
+	mockConn := mockcon.NewMockConnection(gomock.NewController(t))
+	headers := http.Header{}
+	headers.Set("node_id", tf.TestNodeID)
+	headers.Set("project_id", tf.TestProjectID)
+	if token != "" {
+		headers.Set(model.SessionToken, token)
+	}
+	cert := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: tf.TestNodeID}}
+	mockConn.EXPECT().ConnectionState().Return(conn.ConnectionState{
+		Headers:          headers,
+		PeerCertificates: []*x509.Certificate{cert},
+	}).AnyTimes()
+	mockConn.EXPECT().WriteMessageAsync(gomock.Any()).Return(nil).AnyTimes()
+	mockConn.EXPECT().Close().Return(nil).AnyTimes()
+	return mockConn
+}
+
+// suspendTestSession suspends a disconnected session of the test node like HandleConnection does
+func suspendTestSession(t *testing.T, mh *messageHandler, dispatcher *fakeDispatcher, onExpire func()) *session.NodeSession {
+	pool := dispatcher.GetNodeMessagePool(tf.TestNodeID)
+	oldSession := session.NewNodeSession(tf.TestNodeID, tf.TestProjectID, tf.TestCloudID,
+		newTestConnection(t, ""), tf.KeepaliveInterval, pool, &fake.Clientset{})
+	oldSession.SetTerminateErr(session.TransportErr)
+	oldSession.Terminating()
+	if !mh.SessionManager.SuspendSession(oldSession, onExpire) {
+		t.Fatalf("expected session suspended")
+	}
+	return oldSession
+}
+
+func waitForSession(t *testing.T, mh *messageHandler) sessionmanager.NodeSession {
+	for i := 0; i < 100; i++ {
+		if nodeSession, exists := mh.SessionManager.GetSession(tf.TestNodeID); exists {
+			return nodeSession
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected session of node %s added", tf.TestNodeID)
+	return nil
+}
+
+func TestHandleConnectionWithoutTokenInGracePeriod(t *testing.T) {
+	mh, dispatcher := newTestHandler()
+
+	var expired int
+	var expiredLock sync.Mutex
+	oldSession := suspendTestSession(t, mh, dispatcher, func() {
+		expiredLock.Lock()
+		expired++
+		expiredLock.Unlock()
+	})
+	oldPool := oldSession.GetNodeMessagePool()
+
+	mh.HandleConnection(newTestConnection(t, ""))
+	nodeSession := waitForSession(t, mh)
+	defer nodeSession.Terminating()
+
+	pool := nodeSession.GetNodeMessagePool()
+	if pool == oldPool || pool.AckMessageStore == oldPool.AckMessageStore {
+		t.Errorf("expected a new message pool created instead of the suspended one")
+	}
+	if pool.AckMessageQueue.ShuttingDown() || pool.NoAckMessageQueue.ShuttingDown() {
+		t.Errorf("expected message queues of the new session running")
+	}
+	if dispatcher.GetNodeMessagePool(tf.TestNodeID) != pool {
+		t.Errorf("expected the new message pool registered in dispatcher")
+	}
+	if _, resumed := mh.SessionManager.ResumeSession(tf.TestNodeID, oldSession.GetSessionToken()); resumed {
+		t.Errorf("expected suspended session dropped")
+	}
+	if operations := dispatcher.publishedOperations(); len(operations) != 1 || operations[0] != model.OpConnect {
+		t.Errorf("expected only connect event published, but got %v", operations)
+	}
+
+	expiredLock.Lock()
+	defer expiredLock.Unlock()
+	if expired != 0 {
+		t.Errorf("expected the dropped session never cleaned up by the grace timer")
+	}
+}
+
+func TestHandleConnectionResumeSession(t *testing.T) {
+	mh, dispatcher := newTestHandler()
+
+	oldSession := suspendTestSession(t, mh, dispatcher, func() {})
+	oldPool := oldSession.GetNodeMessagePool()
+
+	mh.HandleConnection(newTestConnection(t, oldSession.GetSessionToken()))
+	nodeSession := waitForSession(t, mh)
+	defer nodeSession.Terminating()
+
+	pool := nodeSession.GetNodeMessagePool()
+	if pool.AckMessageStore != oldPool.AckMessageStore {
+		t.Errorf("expected messages of the suspended session resumed")
+	}
+	if operations := dispatcher.publishedOperations(); len(operations) != 0 {
+		t.Errorf("expected no event published for resumed session, but got %v", operations)
+	}
+}
+
+func TestVerifyNodeCert(t *testing.T) {
+	tests := []struct {
+		name    string
+		certs   []*x509.Certificate
+		wantErr bool
+	}{
+		{
+			name:    "no certificate",
+			wantErr: true,
+		},
+		{
+			name:    "certificate of another node",
+			certs:   []*x509.Certificate{{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "other-node"}}},
+			wantErr: true,
+		},
+		{
+			name:  "legacy certificate",
+			certs: []*x509.Certificate{{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: revocation.LegacyEdgeCertCommonName}}},
+		},
+		{
+			name:  "certificate of the node",
+			certs: []*x509.Certificate{{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: tf.TestNodeID}}},
+		},
+	}
+
+	for _, test := range tests {
+		if err := verifyNodeCert(tf.TestNodeID, test.certs); (err != nil) != test.wantErr {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.wantErr, err)
+		}
+	}
+}
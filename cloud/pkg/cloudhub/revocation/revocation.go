@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocation
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/kubeedge/common/constants"
+)
+
+// The revocation list is stored in the secret SecretName in the namespace constants.SystemNamespace,
+// each entry of the data is separated by newline or comma, and lines starting with '#' are ignored.
+// For example, to revoke a certificate and block a node:
+//
+//	kubectl -n kubeedge create secret generic revocationsecret \
+//	  --from-literal=revokedserials=5f3a9c0b --from-literal=blockednodes=edge-node-1
+const (
+	SecretName = "revocationsecret"
+	// RevokedSerialsDataName is the key of the revoked certificate serial numbers in hex,
+	// the serial number is case-insensitive and may contain colons, as printed by openssl
+	RevokedSerialsDataName = "revokedserials"
+	// BlockedNodesDataName is the key of the blocked node names
+	BlockedNodesDataName = "blockednodes"
+)
+
+// LegacyEdgeCertCommonName is the common name of the edge certificates issued before
+// they are bound to the node names. These certificates are still accepted to connect
+// until the edge nodes are upgraded, but can only be renewed with a bootstrap token.
+const LegacyEdgeCertCommonName = "kubeedge.io"
+
+// List is the revoked edge certificates and blocked edge nodes
+type List struct {
+	lock    sync.RWMutex
+	serials sets.String
+	nodes   sets.String
+
+	handlers []func()
+}
+
+var defaultList = NewList()
+
+// NewList returns an empty revocation list
+func NewList() *List {
+	return &List{
+		serials: sets.NewString(),
+		nodes:   sets.NewString(),
+	}
+}
+
+// Update replaces the revocation list with the content of the secret,
+// a nil secret clears the list.
+func (l *List) Update(secret *corev1.Secret) {
+	serials, nodes := sets.NewString(), sets.NewString()
+	if secret != nil {
+		for _, serial := range parseEntries(secret.Data[RevokedSerialsDataName]) {
+			serials.Insert(normalizeSerial(serial))
+		}
+		nodes.Insert(parseEntries(secret.Data[BlockedNodesDataName])...)
+	}
+
+	l.lock.Lock()
+	l.serials, l.nodes = serials, nodes
+	handlers := l.handlers
+	l.lock.Unlock()
+
+	klog.Infof("revocation list updated, %d certificates revoked, %d nodes blocked", serials.Len(), nodes.Len())
+	for _, handler := range handlers {
+		handler()
+	}
+}
+
+// OnUpdate registers the handler called after the revocation list is updated
+func (l *List) OnUpdate(handler func()) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.handlers = append(l.handlers, handler)
+}
+
+// IsCertRevoked checks whether the serial number of the certificate is revoked
+func (l *List) IsCertRevoked(cert *x509.Certificate) bool {
+	if cert == nil || cert.SerialNumber == nil {
+		return false
+	}
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.serials.Has(cert.SerialNumber.Text(16))
+}
+
+// IsNodeBlocked checks whether the node is blocked
+func (l *List) IsNodeBlocked(nodeID string) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.nodes.Has(nodeID)
+}
+
+// Verify returns an error if the node is blocked or the certificate of the node is revoked,
+// the cert can be nil if the node does not present its certificate
+func (l *List) Verify(nodeID string, cert *x509.Certificate) error {
+	if nodeID != "" && l.IsNodeBlocked(nodeID) {
+		return fmt.Errorf("node %s is blocked", nodeID)
+	}
+	if l.IsCertRevoked(cert) {
+		return fmt.Errorf("certificate %s of node %s is revoked", cert.SerialNumber.Text(16), nodeID)
+	}
+	return nil
+}
+
+// VerifyPeerCertificate can be used as tls.Config.VerifyPeerCertificate
+// to reject the revoked certificates during the TLS handshake
+func (l *List) VerifyPeerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse peer certificate: %v", err)
+	}
+	if l.IsCertRevoked(cert) {
+		return fmt.Errorf("certificate %s is revoked", cert.SerialNumber.Text(16))
+	}
+	return nil
+}
+
+// Watch keeps the revocation list in sync with the revocation secret through the secret informer
+func (l *List) Watch(informer cache.SharedIndexInformer) {
+	isRevocationSecret := func(obj interface{}) bool {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		secret, ok := obj.(*corev1.Secret)
+		return ok && secret.Namespace == constants.SystemNamespace && secret.Name == SecretName
+	}
+	informer.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: isRevocationSecret,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				l.Update(obj.(*corev1.Secret))
+			},
+			UpdateFunc: func(_, newObj interface{}) {
+				l.Update(newObj.(*corev1.Secret))
+			},
+			DeleteFunc: func(interface{}) {
+				l.Update(nil)
+			},
+		},
+	})
+}
+
+func parseEntries(data []byte) []string {
+	var entries []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, entry := range strings.Split(line, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+// normalizeSerial converts the serial number to the format of big.Int.Text(16)
+func normalizeSerial(serial string) string {
+	serial = strings.ToLower(strings.ReplaceAll(serial, ":", ""))
+	serial = strings.TrimPrefix(serial, "0x")
+	if trimmed := strings.TrimLeft(serial, "0"); trimmed != "" {
+		return trimmed
+	}
+	return "0"
+}
+
+// Update replaces the default revocation list with the content of the secret
+func Update(secret *corev1.Secret) {
+	defaultList.Update(secret)
+}
+
+// OnUpdate registers the handler called after the default revocation list is updated
+func OnUpdate(handler func()) {
+	defaultList.OnUpdate(handler)
+}
+
+// IsCertRevoked checks whether the certificate is revoked in the default revocation list
+func IsCertRevoked(cert *x509.Certificate) bool {
+	return defaultList.IsCertRevoked(cert)
+}
+
+// IsNodeBlocked checks whether the node is blocked in the default revocation list
+func IsNodeBlocked(nodeID string) bool {
+	return defaultList.IsNodeBlocked(nodeID)
+}
+
+// Verify verifies the node and its certificate with the default revocation list
+func Verify(nodeID string, cert *x509.Certificate) error {
+	return defaultList.Verify(nodeID, cert)
+}
+
+// VerifyPeerCertificate verifies the peer certificate with the default revocation list
+func VerifyPeerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	return defaultList.VerifyPeerCertificate(rawCerts, verifiedChains)
+}
+
+// Watch keeps the default revocation list in sync with the revocation secret
+func Watch(informer cache.SharedIndexInformer) {
+	defaultList.Watch(informer)
+}
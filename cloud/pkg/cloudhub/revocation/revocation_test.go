@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocation
+
+import (
+	"crypto/x509"
+	"math/big"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestList(t *testing.T) {
+	list := NewList()
+	updated := 0
+	list.OnUpdate(func() { updated++ })
+
+	list.Update(&corev1.Secret{
+		Data: map[string][]byte{
+			RevokedSerialsDataName: []byte("# stolen devices\n5F:3A:9C:0B\n0x00ff, 10\n"),
+			BlockedNodesDataName:   []byte("edge-node-1\n\nedge-node-2"),
+		},
+	})
+	if updated != 1 {
+		t.Errorf("expected update handler to be called once, got %d", updated)
+	}
+
+	for serial, want := range map[int64]bool{0x5f3a9c0b: true, 0xff: true, 0x10: true, 0x11: false} {
+		cert := &x509.Certificate{SerialNumber: big.NewInt(serial)}
+		if got := list.IsCertRevoked(cert); got != want {
+			t.Errorf("IsCertRevoked(%x) = %v, want %v", serial, got, want)
+		}
+	}
+	if list.IsCertRevoked(nil) {
+		t.Errorf("nil certificate should not be revoked")
+	}
+
+	if !list.IsNodeBlocked("edge-node-2") || list.IsNodeBlocked("edge-node-3") {
+		t.Errorf("unexpected blocked nodes %v", list.nodes.List())
+	}
+	if err := list.Verify("edge-node-1", nil); err == nil {
+		t.Errorf("expected blocked node to fail verification")
+	}
+	if err := list.Verify("edge-node-3", &x509.Certificate{SerialNumber: big.NewInt(0x10)}); err == nil {
+		t.Errorf("expected revoked certificate to fail verification")
+	}
+	if err := list.Verify("edge-node-3", &x509.Certificate{SerialNumber: big.NewInt(0x11)}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	list.Update(nil)
+	if list.IsNodeBlocked("edge-node-1") || list.IsCertRevoked(&x509.Certificate{SerialNumber: big.NewInt(0x10)}) {
+		t.Errorf("expected the revocation list to be cleared")
+	}
+}
+
+func TestVerifyPeerCertificate(t *testing.T) {
+	list := NewList()
+	if err := list.VerifyPeerCertificate(nil, nil); err != nil {
+		t.Errorf("unexpected error without peer certificate: %v", err)
+	}
+	if err := list.VerifyPeerCertificate([][]byte{[]byte("invalid")}, nil); err == nil {
+		t.Errorf("expected error for invalid peer certificate")
+	}
+}
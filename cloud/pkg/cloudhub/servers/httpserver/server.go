@@ -35,7 +35,9 @@ import (
 	"k8s.io/klog/v2"
 
 	hubconfig "github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/config"
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/revocation"
 	"github.com/kubeedge/kubeedge/common/constants"
+	"github.com/kubeedge/kubeedge/pkg/util/pki"
 )
 
 // StartHTTPServer starts the http service
@@ -61,7 +63,9 @@ func StartHTTPServer() {
 		Handler: serverContainer,
 		TLSConfig: &tls.Config{
 			Certificates: []tls.Certificate{cert},
-			ClientAuth:   tls.NoClientCert,
+			// request the edge certificate for certificate rotation,
+			// it is verified by verifyCert when signing the new certificate
+			ClientAuth: tls.RequestClientCert,
 		},
 	}
 	klog.Exit(server.ListenAndServeTLS("", ""))
@@ -86,16 +90,40 @@ func EncodeCertPEM(cert *x509.Certificate) []byte {
 
 // edgeCoreClientCert will verify the certificate of EdgeCore or token then create EdgeCoreCert and return it
 func edgeCoreClientCert(request *restful.Request, response *restful.Response) {
+	nodeName := request.Request.Header.Get(constants.NodeName)
+	if revocation.IsNodeBlocked(nodeName) {
+		klog.Errorf("failed to sign the certificate for edgenode: %s, the node is blocked", nodeName)
+		response.WriteHeader(http.StatusForbidden)
+		if _, err := response.Write([]byte("the node is blocked")); err != nil {
+			klog.Errorf("failed to write response, err: %v", err)
+		}
+		return
+	}
 	if cert := request.Request.TLS.PeerCertificates; len(cert) > 0 {
+		if err := verifyCertOwner(cert[0], nodeName); err != nil {
+			klog.Errorf("failed to sign the certificate for edgenode: %s, %v", nodeName, err)
+			response.WriteHeader(http.StatusForbidden)
+			if _, err := response.Write([]byte(err.Error())); err != nil {
+				klog.Errorf("failed to write response, err: %v", err)
+			}
+			return
+		}
 		if err := verifyCert(cert[0]); err != nil {
 			klog.Errorf("failed to sign the certificate for edgenode: %s, failed to verify the certificate", request.Request.Header.Get(constants.NodeName))
 			response.WriteHeader(http.StatusUnauthorized)
 			if _, err := response.Write([]byte(err.Error())); err != nil {
 				klog.Errorf("failed to write response, err: %v", err)
 			}
-		} else {
-			signEdgeCert(response, request.Request)
+			return
+		}
+		// the legacy certificate is not bound to any node, it can not
+		// prove the identity of the node without the bootstrap token
+		if cert[0].Subject.CommonName == revocation.LegacyEdgeCertCommonName &&
+			!verifyAuthorization(response, request.Request) {
+			klog.Errorf("failed to sign the certificate for edgenode: %s, invalid token to renew the legacy certificate", nodeName)
+			return
 		}
+		signEdgeCert(response, request.Request)
 		return
 	}
 	if verifyAuthorization(response, request.Request) {
@@ -105,6 +133,15 @@ func edgeCoreClientCert(request *restful.Request, response *restful.Response) {
 	}
 }
 
+// verifyCertOwner checks that the edge certificate to rotate is issued to the node,
+// the legacy certificate is checked with the bootstrap token instead
+func verifyCertOwner(cert *x509.Certificate, nodeName string) error {
+	if cn := cert.Subject.CommonName; cn != nodeName && cn != revocation.LegacyEdgeCertCommonName {
+		return fmt.Errorf("certificate is issued to %q instead of node %s", cn, nodeName)
+	}
+	return nil
+}
+
 // verifyCert verifies the edge certificate by CA certificate when edge certificates rotate.
 func verifyCert(cert *x509.Certificate) error {
 	roots := x509.NewCertPool()
@@ -119,6 +156,9 @@ func verifyCert(cert *x509.Certificate) error {
 	if _, err := cert.Verify(opts); err != nil {
 		return fmt.Errorf("failed to verify edge certificate: %v", err)
 	}
+	if revocation.IsCertRevoked(cert) {
+		return fmt.Errorf("edge certificate %s is revoked", cert.SerialNumber.Text(16))
+	}
 	return nil
 }
 
@@ -185,6 +225,23 @@ func signEdgeCert(w http.ResponseWriter, r *http.Request) {
 		klog.Errorf("fail to ParseCertificateRequest of edgenode: %s! error:%v", r.Header.Get(constants.NodeName), err)
 		return
 	}
+	if err := verifyKeyAlgorithm(csr); err != nil {
+		klog.Errorf("refuse to sign the certificate for edgenode: %s! error:%v", r.Header.Get(constants.NodeName), err)
+		w.WriteHeader(http.StatusForbidden)
+		if _, err := w.Write([]byte(err.Error())); err != nil {
+			klog.Errorf("failed to write http response, err: %v", err)
+		}
+		return
+	}
+	nodeName := r.Header.Get(constants.NodeName)
+	if nodeName == "" {
+		klog.Errorf("refuse to sign the certificate without node name")
+		w.WriteHeader(http.StatusBadRequest)
+		if _, err := w.Write([]byte("node name is required")); err != nil {
+			klog.Errorf("failed to write http response, err: %v", err)
+		}
+		return
+	}
 	usagesStr := r.Header.Get("ExtKeyUsages")
 	var usages []x509.ExtKeyUsage
 	if usagesStr == "" {
@@ -197,7 +254,9 @@ func signEdgeCert(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	klog.V(4).Infof("receive sign crt request, ExtKeyUsages: %v", usages)
-	clientCertDER, err := signCerts(csr.Subject, csr.PublicKey, usages)
+	// the certificate is always issued to the node name, which is checked when the node connects
+	subject := pkix.Name{CommonName: nodeName, Organization: csr.Subject.Organization}
+	clientCertDER, err := signCerts(subject, csr.PublicKey, usages)
 	if err != nil {
 		klog.Errorf("fail to signCerts for edgenode:%s! error:%v", r.Header.Get(constants.NodeName), err)
 		return
@@ -208,6 +267,28 @@ func signEdgeCert(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// verifyKeyAlgorithm verifies the signature of the CSR and
+// checks whether the key algorithm of the CSR is allowed
+func verifyKeyAlgorithm(csr *x509.CertificateRequest) error {
+	if err := csr.CheckSignature(); err != nil {
+		return fmt.Errorf("invalid CSR signature: %v", err)
+	}
+	algorithm, err := pki.KeyAlgorithmOf(csr.PublicKey)
+	if err != nil {
+		return err
+	}
+	allowed := hubconfig.Config.CloudHub.EdgeCertKeyAlgorithms
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, a := range allowed {
+		if a == algorithm {
+			return nil
+		}
+	}
+	return fmt.Errorf("key algorithm %s is not allowed, allowed key algorithms: %v", algorithm, allowed)
+}
+
 // signCerts will create a certificate for EdgeCore
 func signCerts(subInfo pkix.Name, pbKey crypto.PublicKey, usages []x509.ExtKeyUsage) ([]byte, error) {
 	cfgs := &certutil.Config{
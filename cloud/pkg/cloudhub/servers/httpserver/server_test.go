@@ -0,0 +1,192 @@
+package httpserver
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/golang-jwt/jwt"
+
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/config"
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/revocation"
+	"github.com/kubeedge/kubeedge/common/constants"
+	"github.com/kubeedge/kubeedge/pkg/util/pki"
+)
+
+func TestVerifyKeyAlgorithm(t *testing.T) {
+	newCSR := func(algorithm string) *x509.CertificateRequest {
+		key, err := pki.GenerateKey(algorithm)
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		der, err := x509.CreateCertificateRequest(rand.Reader,
+			&x509.CertificateRequest{Subject: pkix.Name{CommonName: "kubeedge.io"}}, key)
+		if err != nil {
+			t.Fatalf("failed to create CSR: %v", err)
+		}
+		csr, err := x509.ParseCertificateRequest(der)
+		if err != nil {
+			t.Fatalf("failed to parse CSR: %v", err)
+		}
+		return csr
+	}
+
+	defer func(allowed []string) {
+		config.Config.EdgeCertKeyAlgorithms = allowed
+	}(config.Config.EdgeCertKeyAlgorithms)
+
+	config.Config.EdgeCertKeyAlgorithms = nil
+	if err := verifyKeyAlgorithm(newCSR(pki.KeyAlgorithmEd25519)); err != nil {
+		t.Errorf("expected all key algorithms to be allowed, got %v", err)
+	}
+
+	config.Config.EdgeCertKeyAlgorithms = []string{pki.KeyAlgorithmECDSAP384, pki.KeyAlgorithmRSA2048}
+	if err := verifyKeyAlgorithm(newCSR(pki.KeyAlgorithmECDSAP384)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := verifyKeyAlgorithm(newCSR(pki.KeyAlgorithmECDSAP256)); err == nil {
+		t.Errorf("expected ECDSA-P256 to be refused")
+	}
+
+	csr := newCSR(pki.KeyAlgorithmECDSAP384)
+	csr.Signature[len(csr.Signature)-1] ^= 0xff
+	if err := verifyKeyAlgorithm(csr); err == nil {
+		t.Errorf("expected CSR with invalid signature to be refused")
+	}
+}
+
+func TestVerifyCertOwner(t *testing.T) {
+	newCert := func(cn string) *x509.Certificate {
+		return &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+	}
+	if err := verifyCertOwner(newCert("node-1"), "node-1"); err != nil {
+		t.Errorf("expected certificate of the node to be renewed, got %v", err)
+	}
+	if err := verifyCertOwner(newCert(revocation.LegacyEdgeCertCommonName), "node-1"); err != nil {
+		t.Errorf("expected legacy certificate to be checked by the token, got %v", err)
+	}
+	if err := verifyCertOwner(newCert("node-2"), "node-1"); err == nil {
+		t.Errorf("expected certificate of another node to be rejected")
+	}
+}
+
+func TestEdgeCoreClientCert(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "KubeEdge"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	caCert, _ := x509.ParseCertificate(caDER)
+	caKeyDER, _ := x509.MarshalECPrivateKey(caKey)
+
+	defer func(hub config.Configure) {
+		config.Config = hub
+	}(config.Config)
+	config.Config.Ca, config.Config.CaKey = caDER, caKeyDER
+	config.Config.EdgeCertSigningDuration = 1
+
+	issue := func(cn string) *x509.Certificate {
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(time.Now().UnixNano()),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, caKey.Public(), caKey)
+		if err != nil {
+			t.Fatalf("failed to create certificate: %v", err)
+		}
+		cert, _ := x509.ParseCertificate(der)
+		return cert
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}).SignedString(caKeyDER)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	key, err := pki.GenerateKey(pki.KeyAlgorithmECDSAP256)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	if err != nil {
+		t.Fatalf("failed to create CSR: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		cert  *x509.Certificate
+		token string
+		code  int
+	}{
+		{
+			name: "certificate of the node",
+			cert: issue("node-1"),
+			code: http.StatusOK,
+		},
+		{
+			name: "certificate of another node",
+			cert: issue("node-2"),
+			code: http.StatusForbidden,
+		},
+		{
+			name: "legacy certificate without token",
+			cert: issue(revocation.LegacyEdgeCertCommonName),
+			code: http.StatusUnauthorized,
+		},
+		{
+			name:  "legacy certificate with token",
+			cert:  issue(revocation.LegacyEdgeCertCommonName),
+			token: token,
+			code:  http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, constants.DefaultCertURL, bytes.NewReader(csr))
+		request.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{test.cert}}
+		request.Header.Set(constants.NodeName, "node-1")
+		if test.token != "" {
+			request.Header.Set("authorization", "Bearer "+test.token)
+		}
+		recorder := httptest.NewRecorder()
+		edgeCoreClientCert(restful.NewRequest(request), restful.NewResponse(recorder))
+		if recorder.Code != test.code {
+			t.Errorf("%s: expected status %d, got %d: %s", test.name, test.code, recorder.Code, recorder.Body.String())
+			continue
+		}
+		if test.code != http.StatusOK {
+			continue
+		}
+		cert, err := x509.ParseCertificate(recorder.Body.Bytes())
+		if err != nil {
+			t.Errorf("%s: failed to parse the signed certificate: %v", test.name, err)
+		} else if cert.Subject.CommonName != "node-1" {
+			t.Errorf("%s: expected certificate issued to node-1, got %s", test.name, cert.Subject.CommonName)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	hubconfig "github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/config"
 	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/handler"
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/revocation"
 	"github.com/kubeedge/viaduct/pkg/api"
 	"github.com/kubeedge/viaduct/pkg/server"
 )
@@ -40,10 +41,13 @@ func createTLSConfig(ca, cert, key []byte) tls.Config {
 		panic(err)
 	}
 	return tls.Config{
-		ClientCAs:    pool,
-		ClientAuth:   tls.NoClientCert,
-		Certificates: []tls.Certificate{certificate},
-		MinVersion:   tls.VersionTLS12,
+		ClientCAs: pool,
+		// the edge nodes get their certificates from the http server with token,
+		// and must connect with them to be checked against the revocation list
+		ClientAuth:            tls.RequireAndVerifyClientCert,
+		VerifyPeerCertificate: revocation.VerifyPeerCertificate,
+		Certificates:          []tls.Certificate{certificate},
+		MinVersion:            tls.VersionTLS12,
 		// has to match cipher used by NewPrivateKey method, currently is ECDSA
 		CipherSuites: []uint16{
 			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
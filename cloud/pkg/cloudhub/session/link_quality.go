@@ -0,0 +1,170 @@
+/*
+Copyright 2022 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	beehivemodel "github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/common/model"
+	"github.com/kubeedge/kubeedge/cloud/pkg/common/client"
+	"github.com/kubeedge/kubeedge/cloud/pkg/common/monitor"
+	commontypes "github.com/kubeedge/kubeedge/common/types"
+)
+
+// annotations of node recording the link quality reported by edge node
+const (
+	LinkRTTAnnotationKey       = "kubeedge.io/link-rtt-ms"
+	LinkLossRatioAnnotationKey = "kubeedge.io/link-loss-ratio"
+	LinkQualityAnnotationKey   = "kubeedge.io/link-quality"
+
+	LinkQualityGood     = "good"
+	LinkQualityDegraded = "degraded"
+)
+
+var (
+	// the link is regarded as degraded if the rtt or loss ratio exceeds the threshold
+	degradedRTT       = time.Second
+	degradedLossRatio = 0.1
+
+	// the minimum interval to update the link quality annotations of node
+	linkAnnotateInterval = time.Minute
+)
+
+// SetMaxKeepaliveInterval sets the upper bound of the adapted keepalive interval,
+// the keepalive interval is not adapted if max is not greater than the initial one
+func (ns *NodeSession) SetMaxKeepaliveInterval(max time.Duration) {
+	ns.maxKeepaliveInterval = max
+}
+
+func (ns *NodeSession) getKeepaliveInterval() time.Duration {
+	return time.Duration(atomic.LoadInt64(&ns.adaptedKeepaliveInterval))
+}
+
+// ReceiveKeepaliveReport records the link quality reported in the keepalive message of edge node,
+// adapts the keepalive interval to it and replies to the keepalive message for rtt measurement
+func (ns *NodeSession) ReceiveKeepaliveReport(msg *beehivemodel.Message, report *commontypes.KeepaliveRequest) {
+	monitor.NodeLinkRTT.WithLabelValues(ns.nodeID).Set(float64(report.RTT))
+	monitor.NodeLinkLossRatio.WithLabelValues(ns.nodeID).Set(report.LossRatio)
+
+	if ns.maxKeepaliveInterval > ns.keepaliveInterval {
+		interval := adaptKeepaliveInterval(ns.keepaliveInterval, ns.maxKeepaliveInterval, report)
+		if old := time.Duration(atomic.SwapInt64(&ns.adaptedKeepaliveInterval, int64(interval))); old != interval {
+			klog.V(4).Infof("keepalive interval of node %s is adapted from %s to %s", ns.nodeID, old, interval)
+		}
+	}
+
+	ns.annotateLinkQuality(report)
+
+	// the edge which does not expect pong may take it as a downstream message
+	if !report.ExpectPong {
+		return
+	}
+	pong := beehivemodel.NewMessage(msg.GetID()).
+		BuildRouter(model.SrcCloudHub, model.GpHub, model.NewResource(model.ResNode, ns.nodeID, nil), model.OpKeepalive).
+		FillBody("pong")
+	if err := ns.connection.WriteMessageAsync(pong); err != nil {
+		klog.Errorf("failed to reply keepalive message to node %s, err: %v", ns.nodeID, err)
+	}
+}
+
+// adaptKeepaliveInterval waits for at least two heart beats, extends the interval by the
+// expected retransmission of lost heart beats and the rtt, and keeps it in [min, max]
+func adaptKeepaliveInterval(min, max time.Duration, report *commontypes.KeepaliveRequest) time.Duration {
+	interval := min
+	if heartbeat := 2 * time.Duration(report.Heartbeat) * time.Second; heartbeat > interval {
+		interval = heartbeat
+	}
+	if report.LossRatio > 0 && report.LossRatio < 1 {
+		interval = time.Duration(float64(interval) / (1 - report.LossRatio))
+	}
+	interval += 2 * time.Duration(report.RTT) * time.Millisecond
+
+	if interval < min {
+		return min
+	}
+	if report.LossRatio >= 1 || interval > max {
+		return max
+	}
+	return interval
+}
+
+func linkQuality(report *commontypes.KeepaliveRequest) string {
+	if time.Duration(report.RTT)*time.Millisecond > degradedRTT || report.LossRatio > degradedLossRatio {
+		return LinkQualityDegraded
+	}
+	return LinkQualityGood
+}
+
+// annotateLinkQuality updates the link quality annotations of node, at most once per
+// linkAnnotateInterval unless the link quality changes between good and degraded
+func (ns *NodeSession) annotateLinkQuality(report *commontypes.KeepaliveRequest) {
+	kubeClient := client.GetKubeClient()
+	if kubeClient == nil {
+		return
+	}
+
+	quality := linkQuality(report)
+	if !ns.shouldAnnotateLink(quality, time.Now()) {
+		return
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				LinkRTTAnnotationKey:       strconv.FormatInt(report.RTT, 10),
+				LinkLossRatioAnnotationKey: fmt.Sprintf("%.2f", report.LossRatio),
+				LinkQualityAnnotationKey:   quality,
+			},
+		},
+	})
+	if err != nil {
+		klog.Errorf("failed to marshal link quality annotations of node %s, err: %v", ns.nodeID, err)
+		return
+	}
+
+	go func() {
+		_, err := kubeClient.CoreV1().Nodes().Patch(context.Background(), ns.nodeID,
+			types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			klog.Errorf("failed to annotate link quality of node %s, err: %v", ns.nodeID, err)
+		}
+	}()
+}
+
+// shouldAnnotateLink checks whether the link quality annotations need to be updated,
+// and records the annotated link quality if so
+func (ns *NodeSession) shouldAnnotateLink(quality string, now time.Time) bool {
+	ns.linkLock.Lock()
+	defer ns.linkLock.Unlock()
+
+	if quality == ns.linkQuality && now.Sub(ns.linkAnnotateTime) < linkAnnotateInterval {
+		return false
+	}
+	ns.linkQuality = quality
+	ns.linkAnnotateTime = now
+	return true
+}
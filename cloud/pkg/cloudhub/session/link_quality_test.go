@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	beehivemodel "github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/common"
+	tf "github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/common/testing"
+	commontypes "github.com/kubeedge/kubeedge/common/types"
+	"github.com/kubeedge/kubeedge/pkg/client/clientset/versioned/fake"
+	mockcon "github.com/kubeedge/viaduct/pkg/conn/testing"
+)
+
+func TestAdaptKeepaliveInterval(t *testing.T) {
+	tests := []struct {
+		name   string
+		report commontypes.KeepaliveRequest
+		want   time.Duration
+	}{
+		{
+			name:   "healthy link",
+			report: commontypes.KeepaliveRequest{Heartbeat: 10, RTT: 50},
+			want:   30*time.Second + 100*time.Millisecond,
+		},
+		{
+			name:   "slow heartbeat",
+			report: commontypes.KeepaliveRequest{Heartbeat: 20, RTT: 500},
+			want:   41 * time.Second,
+		},
+		{
+			name:   "lossy link",
+			report: commontypes.KeepaliveRequest{Heartbeat: 15, LossRatio: 0.5},
+			want:   60 * time.Second,
+		},
+		{
+			name:   "all lost",
+			report: commontypes.KeepaliveRequest{Heartbeat: 15, LossRatio: 1},
+			want:   90 * time.Second,
+		},
+		{
+			name:   "exceed max",
+			report: commontypes.KeepaliveRequest{Heartbeat: 60},
+			want:   90 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adaptKeepaliveInterval(30*time.Second, 90*time.Second, &tt.report); got != tt.want {
+				t.Errorf("adaptKeepaliveInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkQuality(t *testing.T) {
+	if got := linkQuality(&commontypes.KeepaliveRequest{RTT: 100}); got != LinkQualityGood {
+		t.Errorf("expected %s, got %s", LinkQualityGood, got)
+	}
+	if got := linkQuality(&commontypes.KeepaliveRequest{RTT: 2000}); got != LinkQualityDegraded {
+		t.Errorf("expected %s, got %s", LinkQualityDegraded, got)
+	}
+	if got := linkQuality(&commontypes.KeepaliveRequest{LossRatio: 0.3}); got != LinkQualityDegraded {
+		t.Errorf("expected %s, got %s", LinkQualityDegraded, got)
+	}
+}
+
+func TestReceiveKeepaliveReportPong(t *testing.T) {
+	tests := []struct {
+		name   string
+		report commontypes.KeepaliveRequest
+		pongs  int
+	}{
+		{
+			name:   "edge expects pong",
+			report: commontypes.KeepaliveRequest{Heartbeat: 15, ExpectPong: true},
+			pongs:  1,
+		},
+		{
+			name:   "edge does not expect pong",
+			report: commontypes.KeepaliveRequest{Heartbeat: 15},
+			pongs:  0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+			mockConn := mockcon.NewMockConnection(mockController)
+			mockConn.EXPECT().WriteMessageAsync(gomock.Any()).Return(nil).Times(test.pongs)
+
+			nodeSession := NewNodeSession(tf.TestNodeID, tf.TestProjectID, tf.TestCloudID, mockConn,
+				tf.KeepaliveInterval, common.InitNodeMessagePool(tf.TestNodeID), &fake.Clientset{})
+			nodeSession.ReceiveKeepaliveReport(beehivemodel.NewMessage(""), &test.report)
+		})
+	}
+}
+
+func TestShouldAnnotateLink(t *testing.T) {
+	nodeSession := &NodeSession{}
+	now := time.Now()
+	if !nodeSession.shouldAnnotateLink(LinkQualityGood, now) {
+		t.Errorf("expected the first link quality annotated")
+	}
+	if nodeSession.shouldAnnotateLink(LinkQualityGood, now.Add(time.Second)) {
+		t.Errorf("expected the same link quality not annotated within %s", linkAnnotateInterval)
+	}
+	if !nodeSession.shouldAnnotateLink(LinkQualityDegraded, now.Add(time.Second)) {
+		t.Errorf("expected the changed link quality annotated")
+	}
+	if !nodeSession.shouldAnnotateLink(LinkQualityDegraded, now.Add(2*linkAnnotateInterval)) {
+		t.Errorf("expected the link quality annotated after %s", linkAnnotateInterval)
+	}
+}
@@ -18,14 +18,17 @@ package session
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
 	beehivemodel "github.com/kubeedge/beehive/pkg/core/model"
@@ -40,6 +43,7 @@ import (
 	reliableclient "github.com/kubeedge/kubeedge/pkg/client/clientset/versioned"
 	"github.com/kubeedge/kubeedge/pkg/metaserver/util"
 	"github.com/kubeedge/viaduct/pkg/conn"
+	"github.com/kubeedge/viaduct/pkg/smgr"
 )
 
 var sendRetryInterval = 5 * time.Second
@@ -50,6 +54,7 @@ const (
 	TransportErr
 	NodeStopErr
 	QueueShutdownErr
+	RevokedErr
 )
 
 // ErrWaitTimeout is returned when the condition exited without success.
@@ -70,6 +75,18 @@ type NodeSession struct {
 	// are received from the peer.
 	keepaliveInterval time.Duration
 
+	// maxKeepaliveInterval is the upper bound of the keepalive interval
+	// adapted to the link quality reported by edge node
+	maxKeepaliveInterval time.Duration
+
+	// adaptedKeepaliveInterval is the keepalive interval in use
+	adaptedKeepaliveInterval int64
+
+	// linkQuality and linkAnnotateTime record the last link quality annotated to node
+	linkQuality      string
+	linkAnnotateTime time.Time
+	linkLock         sync.Mutex
+
 	// keepaliveChan defines a chan which will receive the keepalive message
 	keepaliveChan chan struct{}
 
@@ -91,6 +108,9 @@ type NodeSession struct {
 	// cloudID is the identifier of where the edge node connected cloud hub.
 	cloudID string
 
+	// sessionToken is used by the edge node to resume this session after reconnecting
+	sessionToken string
+
 	ctx        context.Context
 	cancelFunc context.CancelFunc
 }
@@ -104,17 +124,19 @@ func NewNodeSession(
 ) *NodeSession {
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	return &NodeSession{
-		ctx:               ctx,
-		cancelFunc:        cancelFunc,
-		nodeID:            nodeID,
-		projectID:         projectID,
-		connection:        connection,
-		keepaliveInterval: keepaliveInterval,
-		keepaliveChan:     make(chan struct{}, 1),
-		nodeMessagePool:   nodeMessagePool,
-		reliableClient:    reliableClient,
-		terminateErr:      NoErr,
-		cloudID:           cloudID,
+		ctx:                      ctx,
+		cancelFunc:               cancelFunc,
+		nodeID:                   nodeID,
+		projectID:                projectID,
+		connection:               connection,
+		keepaliveInterval:        keepaliveInterval,
+		keepaliveChan:            make(chan struct{}, 1),
+		adaptedKeepaliveInterval: int64(keepaliveInterval),
+		nodeMessagePool:          nodeMessagePool,
+		reliableClient:           reliableClient,
+		terminateErr:             NoErr,
+		cloudID:                  cloudID,
+		sessionToken:             uuid.New().String(),
 	}
 }
 
@@ -122,6 +144,14 @@ func (ns *NodeSession) GetNodeID() string {
 	return ns.nodeID
 }
 
+// GetPeerCertificate returns the certificate presented by edge node, nil if no certificate
+func (ns *NodeSession) GetPeerCertificate() *x509.Certificate {
+	if certs := ns.connection.ConnectionState().PeerCertificates; len(certs) > 0 {
+		return certs[0]
+	}
+	return nil
+}
+
 // KeepAliveMessage receive keepalive message from edge node
 func (ns *NodeSession) KeepAliveMessage() {
 	select {
@@ -154,7 +184,7 @@ func (ns *NodeSession) Start() {
 // KeepAliveCheck
 // A goroutine running KeepAliveCheck is started for each connection.
 func (ns *NodeSession) KeepAliveCheck() {
-	keepaliveTimer := time.NewTimer(ns.keepaliveInterval)
+	keepaliveTimer := time.NewTimer(ns.getKeepaliveInterval())
 
 	for {
 		// timer may be not active, and fired
@@ -165,7 +195,7 @@ func (ns *NodeSession) KeepAliveCheck() {
 			}
 		}
 
-		keepaliveTimer.Reset(ns.keepaliveInterval)
+		keepaliveTimer.Reset(ns.getKeepaliveInterval())
 
 		select {
 		case <-ns.ctx.Done():
@@ -192,47 +222,72 @@ func (ns *NodeSession) KeepAliveCheck() {
 // SendAckMessage loops forever sending message that require acknowledgment
 // to the edge node until an error is encountered (or the connection is closed).
 func (ns *NodeSession) SendAckMessage() {
+	ns.sendByClass(ns.nodeMessagePool.AckMessageQueue, ns.nodeMessagePool.GetAckMessage, ns.syncAckMessage)
+}
+
+// SendNoAckMessage loops forever sending the message that does not require acknowledgment
+// to the edge node until an error is encountered (or the connection is closed).
+func (ns *NodeSession) SendNoAckMessage() {
+	ns.sendByClass(ns.nodeMessagePool.NoAckMessageQueue, ns.nodeMessagePool.GetNoAckMessage, ns.syncNoAckMessage)
+}
+
+// sendByClass dispatches the message keys of queue to one worker per stream class,
+// so that a class waiting for acknowledgment does not block the other classes.
+// The queue does not hand out a key again before it is done, which keeps the
+// messages of the same resource in order.
+func (ns *NodeSession) sendByClass(queue workqueue.RateLimitingInterface,
+	getMessage func(key string) (*beehivemodel.Message, error),
+	syncMessage func(key string) (bool, error)) {
+	classQueues := make(map[smgr.StreamClass]workqueue.Interface)
+	defer func() {
+		for _, classQueue := range classQueues {
+			classQueue.ShutDown()
+		}
+	}()
+
 	for {
-		select {
-		case <-ns.ctx.Done():
+		key, quit := queue.Get()
+		if quit {
+			ns.SetTerminateErr(QueueShutdownErr)
+			klog.Errorf("message queue for node %s has shutdown", ns.nodeID)
+			ns.Terminating()
 			return
+		}
 
-		default:
-			exit, err := ns.syncAckMessage()
-			if err != nil {
-				klog.Errorf("syncAckMessage err: %v", err)
-			}
-
-			if exit {
-				ns.Terminating()
+		class := smgr.StreamClassDefault
+		if msg, err := getMessage(key.(string)); err == nil {
+			class = smgr.ClassifyMessage(msg)
+		}
 
-				// exit loop
-				return
-			}
+		classQueue, ok := classQueues[class]
+		if !ok {
+			classQueue = workqueue.New()
+			classQueues[class] = classQueue
+			go ns.runClassWorker(class, queue, classQueue, syncMessage)
 		}
+		classQueue.Add(key)
 	}
 }
 
-// SendNoAckMessage loops forever sending the message that does not require acknowledgment
-// to the edge node until an error is encountered (or the connection is closed).
-func (ns *NodeSession) SendNoAckMessage() {
+// runClassWorker sends the messages of one stream class one by one
+func (ns *NodeSession) runClassWorker(class smgr.StreamClass, queue workqueue.RateLimitingInterface,
+	classQueue workqueue.Interface, syncMessage func(key string) (bool, error)) {
 	for {
-		select {
-		case <-ns.ctx.Done():
+		key, quit := classQueue.Get()
+		if quit {
 			return
+		}
 
-		default:
-			exit, err := ns.syncNoAckMessage()
-			if err != nil {
-				klog.Errorf("syncNoAckMessage err: %v", err)
-			}
-
-			if exit {
-				ns.Terminating()
+		exit, err := syncMessage(key.(string))
+		queue.Done(key)
+		classQueue.Done(key)
+		if err != nil {
+			klog.Errorf("sync %s message for node %s err: %v", class, ns.nodeID, err)
+		}
 
-				// exit loop
-				return
-			}
+		if exit {
+			ns.Terminating()
+			return
 		}
 	}
 }
@@ -262,21 +317,11 @@ func (ns *NodeSession) GetTerminateErr() int32 {
 	return atomic.LoadInt32(&ns.terminateErr)
 }
 
-func (ns *NodeSession) syncNoAckMessage() (bool, error) {
-	key, quit := ns.nodeMessagePool.NoAckMessageQueue.Get()
-	if quit {
-		ns.SetTerminateErr(QueueShutdownErr)
-		return true, fmt.Errorf("NoAckMessageQueue for node %s has shutdown", ns.nodeID)
-	}
-
-	defer func() {
-		// NoAckMessage will be deleted no matter send success or failure
-		ns.nodeMessagePool.NoAckMessageQueue.Forget(key)
-		// You must call Done with item when you have finished processing it.
-		ns.nodeMessagePool.NoAckMessageQueue.Done(key)
-	}()
+func (ns *NodeSession) syncNoAckMessage(key string) (bool, error) {
+	// NoAckMessage will be deleted no matter send success or failure
+	defer ns.nodeMessagePool.NoAckMessageQueue.Forget(key)
 
-	msg, err := ns.nodeMessagePool.GetNoAckMessage(key.(string))
+	msg, err := ns.nodeMessagePool.GetNoAckMessage(key)
 	if err != nil {
 		return false, err
 	}
@@ -306,15 +351,8 @@ func (ns *NodeSession) syncNoAckMessage() (bool, error) {
 	return false, nil
 }
 
-func (ns *NodeSession) syncAckMessage() (bool, error) {
-	key, quit := ns.nodeMessagePool.AckMessageQueue.Get()
-	if quit {
-		ns.SetTerminateErr(QueueShutdownErr)
-		return true, fmt.Errorf("AckMessageQueue for node %s has shutdown", ns.nodeID)
-	}
-	defer ns.nodeMessagePool.AckMessageQueue.Done(key)
-
-	msg, err := ns.nodeMessagePool.GetAckMessage(key.(string))
+func (ns *NodeSession) syncAckMessage(key string) (bool, error) {
+	msg, err := ns.nodeMessagePool.GetAckMessage(key)
 	if err != nil {
 		return false, err
 	}
@@ -329,6 +367,7 @@ func (ns *NodeSession) syncAckMessage() (bool, error) {
 	case err == nil:
 		// no err, forget this key and return
 		ns.nodeMessagePool.AckMessageQueue.Forget(key)
+		ns.nodeMessagePool.MarkAcked(key)
 		return false, nil
 
 	case err == ErrWaitTimeout:
@@ -579,6 +618,10 @@ func (ns *NodeSession) GetNodeConnectedCloudID() string {
 	return ns.cloudID
 }
 
+func (ns *NodeSession) GetSessionToken() string {
+	return ns.sessionToken
+}
+
 func (ns *NodeSession) GetNodeMessagePool() *common.NodeMessagePool {
 	return ns.nodeMessagePool
 }
@@ -33,6 +33,7 @@ import (
 	reliableclient "github.com/kubeedge/kubeedge/pkg/client/clientset/versioned"
 	"github.com/kubeedge/kubeedge/pkg/client/clientset/versioned/fake"
 	mockcon "github.com/kubeedge/viaduct/pkg/conn/testing"
+	"github.com/kubeedge/viaduct/pkg/smgr"
 )
 
 func TestNodeSessionKeepAliveCheck(t *testing.T) {
@@ -341,6 +342,46 @@ func TestNodeSessionSendAckMessage(t *testing.T) {
 	}
 }
 
+// TestNodeSessionSendAckMessageByClass checks that the pod messages waiting
+// for acknowledgment do not block the messages of the other stream classes
+func TestNodeSessionSendAckMessageByClass(t *testing.T) {
+	client := &fake.Clientset{}
+
+	wg := sync.WaitGroup{}
+	stopCh := make(chan struct{})
+
+	session, mockController, mockConn := openNodeSession(t, &wg, stopCh, tf.NormalSendKeepaliveInterval, client)
+	defer mockController.Finish()
+
+	mockConn.EXPECT().Close().AnyTimes()
+	mockConn.EXPECT().WriteMessageAsync(gomock.Any()).DoAndReturn(func(msg *beehivemodel.Message) error {
+		// the edge node never acknowledges the pod messages
+		if smgr.ClassifyMessage(msg) != smgr.StreamClassPod {
+			session.ReceiveMessageAck(msg.GetID())
+		}
+		return nil
+	}).AnyTimes()
+
+	reactor := tf.NewObjectSyncReactor(client, tf.NoErrors)
+
+	pool := session.GetNodeMessagePool()
+	enqueueAckMessage(pool, tf.NewPodMessage(tf.NewTestPodResource(tf.TestPodName, tf.TestPodUID, "1"), "update"))
+	enqueueAckMessage(pool, tf.NewConfigMapMessage(tf.NewTestConfigMapResource(tf.TestConfigMapName, tf.TestConfigMapUID, "2"), "update"))
+
+	// sleep 2 second, less than the send retry interval of the pod message
+	time.Sleep(2 * time.Second)
+
+	if err := reactor.CheckObjectSyncs([]*v1alpha1.ObjectSync{
+		tf.NewObjectSync(tf.NewTestConfigMapResource(tf.TestConfigMapName, tf.TestConfigMapUID, "2"), "ConfigMap"),
+	}); err != nil {
+		t.Errorf("config message is blocked by pod message: %v", err)
+	}
+
+	close(stopCh)
+	session.Terminating()
+	wg.Wait()
+}
+
 func normalSimulateMessageFunc(pool *common.NodeMessagePool, messages []*beehivemodel.Message) {
 	for _, message := range messages {
 		enqueueAckMessage(pool, message)
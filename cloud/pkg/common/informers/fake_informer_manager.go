@@ -123,3 +123,8 @@ func (fm *fakeManager) EdgeNode() cache.SharedIndexInformer {
 	klog.Errorf("Not implemented")
 	return nil
 }
+
+func (fm *fakeManager) Secret(namespace, name string) cache.SharedIndexInformer {
+	klog.Errorf("Not implemented")
+	return nil
+}
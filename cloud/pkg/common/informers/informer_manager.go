@@ -24,6 +24,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic/dynamicinformer"
@@ -64,6 +65,8 @@ type newInformer func() cache.SharedIndexInformer
 
 type KubeEdgeCustomInformer interface {
 	EdgeNode() cache.SharedIndexInformer
+	// Secret returns the informer watching only the secret namespace/name
+	Secret(namespace, name string) cache.SharedIndexInformer
 }
 
 type Manager interface {
@@ -173,6 +176,16 @@ func (ifs *informers) EdgeNode() cache.SharedIndexInformer {
 	})
 }
 
+func (ifs *informers) Secret(namespace, name string) cache.SharedIndexInformer {
+	return ifs.getInformer(fmt.Sprintf("secretinformer/%s/%s", namespace, name), func() cache.SharedIndexInformer {
+		optionModifier := func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}
+		lw := cache.NewFilteredListWatchFromClient(ifs.kubeClient.CoreV1().RESTClient(), "secrets", namespace, optionModifier)
+		return cache.NewSharedIndexInformer(lw, &v1.Secret{}, ifs.defaultResync, cache.Indexers{})
+	})
+}
+
 //Note: please WaitForCache after getting an informer from factory, example:
 //	informer := informerFactory.ForResource(gvr)
 //	for gvr, cacheSync := range informerFactory.WaitForCacheSync(beehiveContext.Done()) {
@@ -41,7 +41,13 @@ const (
 
 	ResourceDevice               = "device"
 	ResourceTypeTwinEdgeUpdated  = "twin/edge_updated"
+	ResourceTypeTwinConflict     = "twin/conflict"
 	ResourceTypeMembershipDetail = "membership/detail"
+
+	ResourceTypeDeviceCommandResult = "command/result"
+	ResourceTypeDeviceAlert         = "alert"
+	ResourceTypeDeviceStateUpdate   = "state/update"
+	ResourceTypeDeviceHistory       = "device/history"
 )
 
 // BuildResource return a string as "beehive/pkg/core/model".Message.Router.Resource
@@ -148,6 +154,16 @@ func GetResourceTypeForDevice(resource string) (string, error) {
 		return ResourceTypeTwinEdgeUpdated, nil
 	} else if strings.Contains(resource, ResourceTypeMembershipDetail) {
 		return ResourceTypeMembershipDetail, nil
+	} else if strings.HasSuffix(resource, ResourceTypeTwinConflict) {
+		return ResourceTypeTwinConflict, nil
+	} else if strings.HasSuffix(resource, ResourceTypeDeviceCommandResult) {
+		return ResourceTypeDeviceCommandResult, nil
+	} else if strings.HasSuffix(resource, "/"+ResourceTypeDeviceAlert) {
+		return ResourceTypeDeviceAlert, nil
+	} else if strings.HasSuffix(resource, ResourceTypeDeviceStateUpdate) {
+		return ResourceTypeDeviceStateUpdate, nil
+	} else if strings.HasSuffix(resource, "/"+ResourceTypeDeviceHistory) {
+		return ResourceTypeDeviceHistory, nil
 	}
 
 	return "", fmt.Errorf("unknown resource, found: %s", resource)
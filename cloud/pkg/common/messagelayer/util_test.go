@@ -188,6 +188,46 @@ func TestGetResourceTypeForDevice(t *testing.T) {
 			ResourceTypeMembershipDetail,
 			nil,
 		},
+		{
+			"GetResourceTypeForDevice() ResourceTypeDeviceCommandResult: success",
+			args{
+				resource: fmt.Sprintf("node/%s/device/%s/%s", "nid", "did", ResourceTypeDeviceCommandResult),
+			},
+			ResourceTypeDeviceCommandResult,
+			nil,
+		},
+		{
+			"GetResourceTypeForDevice() ResourceTypeDeviceStateUpdate: success",
+			args{
+				resource: fmt.Sprintf("node/%s/device/%s/%s", "nid", "did", ResourceTypeDeviceStateUpdate),
+			},
+			ResourceTypeDeviceStateUpdate,
+			nil,
+		},
+		{
+			"GetResourceTypeForDevice() ResourceTypeDeviceHistory: success",
+			args{
+				resource: fmt.Sprintf("node/%s/%s", "nid", ResourceTypeDeviceHistory),
+			},
+			ResourceTypeDeviceHistory,
+			nil,
+		},
+		{
+			"GetResourceTypeForDevice() ResourceTypeTwinConflict: success",
+			args{
+				resource: fmt.Sprintf("node/%s/device/%s/%s", "nid", "did", ResourceTypeTwinConflict),
+			},
+			ResourceTypeTwinConflict,
+			nil,
+		},
+		{
+			"GetResourceTypeForDevice() ResourceTypeDeviceAlert: success",
+			args{
+				resource: fmt.Sprintf("node/%s/device/%s/%s", "nid", "did", ResourceTypeDeviceAlert),
+			},
+			ResourceTypeDeviceAlert,
+			nil,
+		},
 		{
 			"GetResourceTypeForDevice() Case 2: no resourceType",
 			args{
@@ -49,6 +49,26 @@ var (
 			Help:      "Number of nodes that connected to the cloudHub instance",
 		},
 	)
+
+	NodeLinkRTT = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Subsystem: CloudHubSubsystem,
+			Name:      "node_link_rtt_milliseconds",
+			Help:      "Round trip time of keepalive messages measured by edge node",
+		},
+		[]string{"node"},
+	)
+
+	NodeLinkLossRatio = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Subsystem: CloudHubSubsystem,
+			Name:      "node_link_loss_ratio",
+			Help:      "Ratio of recent keepalive messages lost between edge node and cloudHub",
+		},
+		[]string{"node"},
+	)
 )
 
 var registerOnce sync.Once
@@ -58,6 +78,8 @@ func registerMetrics() {
 	registerOnce.Do(func() {
 		prometheus.MustRegister(
 			ConnectedNodes,
+			NodeLinkRTT,
+			NodeLinkLossRatio,
 		)
 	})
 }
@@ -78,6 +100,8 @@ func loopPrometheusPush(config config.MonitorServer) {
 	for range time.Tick(time.Duration(conf.IntervalS) * time.Second) {
 		err := push.New(conf.Server, conf.Job).
 			Collector(ConnectedNodes).
+			Collector(NodeLinkRTT).
+			Collector(NodeLinkLossRatio).
             Grouping("instance", mikunode.GetNodeId()).
 			Add();
 		if err != nil {
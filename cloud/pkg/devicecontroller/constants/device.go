@@ -35,11 +35,13 @@ const (
 
 	DeviceProfileJSON = "deviceProfile.json"
 
-	ResourceTypeDeviceModel  = "devicemodel"
-	ResourceTypeDevice       = "device"
-	ResourceTypeDeviceMapper = "devicemapper"
-
-	KindTypeDevice      = "Device"
-	KindTypeDeviceModel = "DeviceModel"
-	UnixNetworkType     = "unix"
+	ResourceTypeDeviceModel     = "devicemodel"
+	ResourceTypeDevice          = "device"
+	ResourceTypeDeviceMapper    = "devicemapper"
+	ResourceTypeDeviceAlertRule = "devicealertrule"
+
+	KindTypeDevice          = "Device"
+	KindTypeDeviceModel     = "DeviceModel"
+	KindTypeDeviceAlertRule = "DeviceAlertRule"
+	UnixNetworkType         = "unix"
 )
@@ -2,8 +2,14 @@ package constants
 
 // Service level constants
 const (
-	ResourceTypeTwinEdgeUpdated  = "twin/edge_updated"
-	ResourceTypeMembershipDetail = "membership/detail"
+	ResourceTypeTwinEdgeUpdated     = "twin/edge_updated"
+	ResourceTypeTwinConflict        = "twin/conflict"
+	ResourceTypeMembershipDetail    = "membership/detail"
+	ResourceTypeDeviceCommandInvoke = "command/invoke"
+	ResourceTypeDeviceCommandResult = "command/result"
+	ResourceTypeDeviceAlert         = "alert"
+	ResourceTypeDeviceStateUpdate   = "state/update"
+	ResourceTypeDeviceHistory       = "device/history"
 
 	// Group
 	GroupTwin     = "twin"
@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+
+	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
+	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/cloud/pkg/common/messagelayer"
+	"github.com/kubeedge/kubeedge/cloud/pkg/common/modules"
+	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/constants"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+)
+
+// syncDeviceAlertRule is used to get device alert rule events from informer
+func (dc *DownstreamController) syncDeviceAlertRule() {
+	for {
+		select {
+		case <-beehiveContext.Done():
+			klog.Info("Stop syncDeviceAlertRule")
+			return
+		case e := <-dc.deviceAlertRuleManager.Events():
+			rule, ok := e.Object.(*v1alpha2.DeviceAlertRule)
+			if !ok {
+				klog.Warningf("Object type: %T unsupported", e.Object)
+				continue
+			}
+			switch e.Type {
+			case watch.Added:
+				dc.deviceAlertRuleAdded(rule)
+			case watch.Modified:
+				dc.deviceAlertRuleUpdated(rule)
+			case watch.Deleted:
+				dc.deviceAlertRuleDeleted(rule)
+			default:
+				klog.Warningf("DeviceAlertRule event type: %s unsupported", e.Type)
+			}
+		}
+	}
+}
+
+// deviceAlertRuleAdded sends the rule to the edge node which the device is bound to,
+// a rule of a device which does not exist yet is sent when the device is added
+func (dc *DownstreamController) deviceAlertRuleAdded(rule *v1alpha2.DeviceAlertRule) {
+	dc.deviceAlertRuleManager.DeviceAlertRule.Store(deviceAlertRuleKey(rule), rule)
+	if nodeName := dc.nodeNameOfDevice(rule.Namespace, rule.Spec.DeviceName); nodeName != "" {
+		dc.sendDeviceAlertRuleMsg(rule, nodeName, model.InsertOperation)
+	}
+}
+
+func (dc *DownstreamController) deviceAlertRuleUpdated(rule *v1alpha2.DeviceAlertRule) {
+	value, ok := dc.deviceAlertRuleManager.DeviceAlertRule.Load(deviceAlertRuleKey(rule))
+	dc.deviceAlertRuleManager.DeviceAlertRule.Store(deviceAlertRuleKey(rule), rule)
+	if !ok {
+		dc.deviceAlertRuleAdded(rule)
+		return
+	}
+	cachedRule := value.(*v1alpha2.DeviceAlertRule)
+	// the status is reported by edge, only the spec needs to be synced
+	if reflect.DeepEqual(cachedRule.Spec, rule.Spec) {
+		return
+	}
+
+	nodeName := dc.nodeNameOfDevice(rule.Namespace, rule.Spec.DeviceName)
+	cachedNodeName := dc.nodeNameOfDevice(cachedRule.Namespace, cachedRule.Spec.DeviceName)
+	if nodeName == cachedNodeName {
+		if nodeName != "" {
+			dc.sendDeviceAlertRuleMsg(rule, nodeName, model.UpdateOperation)
+		}
+		return
+	}
+	// the rule is moved to the device on another node
+	if cachedNodeName != "" {
+		dc.sendDeviceAlertRuleMsg(cachedRule, cachedNodeName, model.DeleteOperation)
+	}
+	if nodeName != "" {
+		dc.sendDeviceAlertRuleMsg(rule, nodeName, model.InsertOperation)
+	}
+}
+
+func (dc *DownstreamController) deviceAlertRuleDeleted(rule *v1alpha2.DeviceAlertRule) {
+	dc.deviceAlertRuleManager.DeviceAlertRule.Delete(deviceAlertRuleKey(rule))
+	if nodeName := dc.nodeNameOfDevice(rule.Namespace, rule.Spec.DeviceName); nodeName != "" {
+		dc.sendDeviceAlertRuleMsg(rule, nodeName, model.DeleteOperation)
+	}
+}
+
+// sendDeviceAlertRulesOfDevice sends the rules of the device to the edge node which the device is bound to,
+// it is called when the device is added to or deleted from the node
+func (dc *DownstreamController) sendDeviceAlertRulesOfDevice(device *v1alpha2.Device, operation string) {
+	nodeName := deviceNodeName(device)
+	if nodeName == "" {
+		return
+	}
+	dc.deviceAlertRuleManager.DeviceAlertRule.Range(func(_, value interface{}) bool {
+		rule := value.(*v1alpha2.DeviceAlertRule)
+		if rule.Namespace == device.Namespace && rule.Spec.DeviceName == device.Name {
+			dc.sendDeviceAlertRuleMsg(rule, nodeName, operation)
+		}
+		return true
+	})
+}
+
+func (dc *DownstreamController) sendDeviceAlertRuleMsg(rule *v1alpha2.DeviceAlertRule, nodeName, operation string) {
+	rule = rule.DeepCopy()
+	rule.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   v1alpha2.GroupName,
+		Version: v1alpha2.Version,
+		Kind:    constants.KindTypeDeviceAlertRule,
+	})
+	modelMsg := model.NewMessage("").
+		SetResourceVersion(rule.ResourceVersion).
+		FillBody(rule)
+	modelResource, err := messagelayer.BuildResource(nodeName, rule.Namespace, constants.ResourceTypeDeviceAlertRule, rule.Name)
+	if err != nil {
+		klog.Warningf("Built message resource failed for device alert rule, rule: %s, operation: %s, error: %s", rule.Name, operation, err)
+		return
+	}
+	modelMsg.BuildRouter(modules.DeviceControllerModuleName, constants.GroupResource, modelResource, operation)
+
+	err = dc.messageLayer.Send(*modelMsg)
+	if err != nil {
+		klog.Errorf("Failed to send device alert rule message %v, rule: %s, operation: %s, error: %v",
+			modelMsg, rule.Name, operation, err)
+	}
+}
+
+// nodeNameOfDevice returns the edge node which the device is bound to, or empty if the device is not found
+func (dc *DownstreamController) nodeNameOfDevice(namespace, name string) string {
+	value, ok := dc.deviceManager.Device.Load(name)
+	if !ok {
+		return ""
+	}
+	device := value.(*v1alpha2.Device)
+	if device.Namespace != namespace {
+		return ""
+	}
+	return deviceNodeName(device)
+}
+
+func deviceNodeName(device *v1alpha2.Device) string {
+	if device.Spec.NodeSelector == nil || len(device.Spec.NodeSelector.NodeSelectorTerms) == 0 || len(device.Spec.NodeSelector.NodeSelectorTerms[0].MatchExpressions) == 0 || len(device.Spec.NodeSelector.NodeSelectorTerms[0].MatchExpressions[0].Values) == 0 {
+		return ""
+	}
+	return device.Spec.NodeSelector.NodeSelectorTerms[0].MatchExpressions[0].Values[0]
+}
+
+func deviceAlertRuleKey(rule *v1alpha2.DeviceAlertRule) string {
+	return rule.Namespace + "/" + rule.Name
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
+	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/cloud/pkg/common/messagelayer"
+	"github.com/kubeedge/kubeedge/cloud/pkg/common/modules"
+	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/constants"
+	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/types"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	crdClientset "github.com/kubeedge/kubeedge/pkg/client/clientset/versioned"
+)
+
+const (
+	// defaultDeviceCommandTimeoutSeconds is the timeout of a command invocation without timeoutSeconds specified
+	defaultDeviceCommandTimeoutSeconds = 30
+	// deviceCommandResultGracePeriod is the extra time to wait for the result to travel back from edge
+	deviceCommandResultGracePeriod = 10 * time.Second
+)
+
+// syncDeviceCommandRequest is used to get device command request events from informer
+func (dc *DownstreamController) syncDeviceCommandRequest() {
+	for {
+		select {
+		case <-beehiveContext.Done():
+			klog.Info("Stop syncDeviceCommandRequest")
+			return
+		case e := <-dc.deviceCommandRequestManager.Events():
+			request, ok := e.Object.(*v1alpha2.DeviceCommandRequest)
+			if !ok {
+				klog.Warningf("Object type: %T unsupported", e.Object)
+				continue
+			}
+			switch e.Type {
+			case watch.Added, watch.Modified:
+				dc.deviceCommandRequestUpdated(request)
+			case watch.Deleted:
+				dc.pendingCommands.Delete(deviceCommandKey(request.Namespace, request.Name))
+			default:
+				klog.Warningf("DeviceCommandRequest event type: %s unsupported", e.Type)
+			}
+		}
+	}
+}
+
+// deviceCommandRequestUpdated routes a new request to the edge node, or waits for the result
+// of a request which is sent already, e.g. before cloudcore restarts
+func (dc *DownstreamController) deviceCommandRequestUpdated(request *v1alpha2.DeviceCommandRequest) {
+	switch request.Status.Phase {
+	case "", v1alpha2.DeviceCommandRequestPending:
+		dc.sendDeviceCommand(request)
+	case v1alpha2.DeviceCommandRequestSent:
+		sentTime := time.Now()
+		if request.Status.SentTime != nil {
+			sentTime = request.Status.SentTime.Time
+		}
+		dc.waitDeviceCommandResult(request, sentTime)
+	}
+}
+
+// sendDeviceCommand sends the command invocation to the edge node which the device is bound to
+func (dc *DownstreamController) sendDeviceCommand(request *v1alpha2.DeviceCommandRequest) {
+	nodeName, err := dc.validateDeviceCommandRequest(request)
+	if err != nil {
+		klog.Warningf("Reject DeviceCommandRequest %s/%s: %v", request.Namespace, request.Name, err)
+		dc.failDeviceCommandRequest(request.Namespace, request.Name, err.Error())
+		return
+	}
+
+	// mark the request as sent before sending, so that the result never races with this status update
+	sentTime := metav1.Now()
+	err = updateDeviceCommandRequestStatus(dc.crdClient, request.Namespace, request.Name, func(status *v1alpha2.DeviceCommandRequestStatus) {
+		status.Phase = v1alpha2.DeviceCommandRequestSent
+		status.NodeName = nodeName
+		status.SentTime = &sentTime
+	})
+	if err != nil {
+		klog.Errorf("Failed to update status of DeviceCommandRequest %s/%s, err: %v", request.Namespace, request.Name, err)
+		return
+	}
+
+	resource, err := messagelayer.BuildResourceForDevice(nodeName, "device/"+request.Spec.DeviceName+"/"+constants.ResourceTypeDeviceCommandInvoke, "")
+	if err != nil {
+		klog.Warningf("Built message resource failed with error: %s", err)
+		dc.failDeviceCommandRequest(request.Namespace, request.Name, err.Error())
+		return
+	}
+	msg := model.NewMessage("")
+	msg.BuildRouter(modules.DeviceControllerModuleName, constants.GroupTwin, resource, model.UpdateOperation)
+	content := types.DeviceCommandInvocation{
+		Namespace:      request.Namespace,
+		Name:           request.Name,
+		Command:        request.Spec.Command,
+		Parameters:     request.Spec.Parameters,
+		TimeoutSeconds: deviceCommandTimeoutSeconds(request),
+	}
+	content.EventID = uuid.New().String()
+	content.Timestamp = time.Now().UnixNano() / 1e6
+	msg.Content = content
+
+	if err := dc.messageLayer.Send(*msg); err != nil {
+		klog.Errorf("Failed to send device command message %v due to error %v", msg, err)
+		dc.failDeviceCommandRequest(request.Namespace, request.Name, fmt.Sprintf("failed to send the command to edge node %s: %v", nodeName, err))
+		return
+	}
+	dc.waitDeviceCommandResult(request, sentTime.Time)
+}
+
+// validateDeviceCommandRequest checks the command is declared by the model of the device,
+// and returns the edge node which the device is bound to
+func (dc *DownstreamController) validateDeviceCommandRequest(request *v1alpha2.DeviceCommandRequest) (string, error) {
+	value, ok := dc.deviceManager.Device.Load(request.Spec.DeviceName)
+	if !ok {
+		return "", fmt.Errorf("device %s not found", request.Spec.DeviceName)
+	}
+	device := value.(*v1alpha2.Device)
+	if device.Namespace != request.Namespace {
+		return "", fmt.Errorf("device %s not found in namespace %s", request.Spec.DeviceName, request.Namespace)
+	}
+	if device.Spec.NodeSelector == nil || len(device.Spec.NodeSelector.NodeSelectorTerms) == 0 || len(device.Spec.NodeSelector.NodeSelectorTerms[0].MatchExpressions) == 0 || len(device.Spec.NodeSelector.NodeSelectorTerms[0].MatchExpressions[0].Values) == 0 {
+		return "", fmt.Errorf("device %s is not bound to any edge node", device.Name)
+	}
+	if device.Spec.DeviceModelRef == nil {
+		return "", fmt.Errorf("device %s has no device model", device.Name)
+	}
+
+	value, ok = dc.deviceModelManager.DeviceModel.Load(device.Spec.DeviceModelRef.Name)
+	if !ok {
+		return "", fmt.Errorf("device model %s not found", device.Spec.DeviceModelRef.Name)
+	}
+	deviceModel := value.(*v1alpha2.DeviceModel)
+	var command *v1alpha2.DeviceCommand
+	for i := range deviceModel.Spec.Commands {
+		if deviceModel.Spec.Commands[i].Name == request.Spec.Command {
+			command = &deviceModel.Spec.Commands[i]
+			break
+		}
+	}
+	if command == nil {
+		return "", fmt.Errorf("command %s is not declared by device model %s", request.Spec.Command, deviceModel.Name)
+	}
+	for name := range request.Spec.Parameters {
+		if !containsString(command.Parameters, name) {
+			return "", fmt.Errorf("parameter %s is not declared by command %s", name, command.Name)
+		}
+	}
+
+	return device.Spec.NodeSelector.NodeSelectorTerms[0].MatchExpressions[0].Values[0], nil
+}
+
+// waitDeviceCommandResult fails the request if the result does not come back from edge in time
+func (dc *DownstreamController) waitDeviceCommandResult(request *v1alpha2.DeviceCommandRequest, sentTime time.Time) {
+	key := deviceCommandKey(request.Namespace, request.Name)
+	if _, loaded := dc.pendingCommands.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+
+	timeout := time.Duration(deviceCommandTimeoutSeconds(request))*time.Second + deviceCommandResultGracePeriod
+	namespace, name := request.Namespace, request.Name
+	time.AfterFunc(time.Until(sentTime.Add(timeout)), func() {
+		if _, ok := dc.pendingCommands.LoadAndDelete(key); !ok {
+			return
+		}
+		dc.failDeviceCommandRequest(namespace, name, "timed out waiting for the result from edge node")
+	})
+}
+
+func (dc *DownstreamController) failDeviceCommandRequest(namespace, name, message string) {
+	err := completeDeviceCommandRequest(dc.crdClient, namespace, name, func(status *v1alpha2.DeviceCommandRequestStatus) {
+		status.Phase = v1alpha2.DeviceCommandRequestFailed
+		status.Message = message
+	})
+	if err != nil {
+		klog.Errorf("Failed to update status of DeviceCommandRequest %s/%s, err: %v", namespace, name, err)
+	}
+}
+
+// completeDeviceCommandRequest sets the final status of a request unless it is completed already
+func completeDeviceCommandRequest(crdClient crdClientset.Interface, namespace, name string, complete func(*v1alpha2.DeviceCommandRequestStatus)) error {
+	return updateDeviceCommandRequestStatus(crdClient, namespace, name, func(status *v1alpha2.DeviceCommandRequestStatus) {
+		complete(status)
+		now := metav1.Now()
+		status.CompletionTime = &now
+	})
+}
+
+// updateDeviceCommandRequestStatus updates the status of a request which is not completed yet
+func updateDeviceCommandRequestStatus(crdClient crdClientset.Interface, namespace, name string, update func(*v1alpha2.DeviceCommandRequestStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		request, err := crdClient.DevicesV1alpha2().DeviceCommandRequests(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if isDeviceCommandRequestCompleted(request) {
+			return nil
+		}
+		update(&request.Status)
+		_, err = crdClient.DevicesV1alpha2().DeviceCommandRequests(namespace).UpdateStatus(context.Background(), request, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func isDeviceCommandRequestCompleted(request *v1alpha2.DeviceCommandRequest) bool {
+	return request.Status.Phase == v1alpha2.DeviceCommandRequestSucceeded || request.Status.Phase == v1alpha2.DeviceCommandRequestFailed
+}
+
+func deviceCommandTimeoutSeconds(request *v1alpha2.DeviceCommandRequest) int32 {
+	if request.Spec.TimeoutSeconds == nil || *request.Spec.TimeoutSeconds <= 0 {
+		return defaultDeviceCommandTimeoutSeconds
+	}
+	return *request.Spec.TimeoutSeconds
+}
+
+func deviceCommandKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
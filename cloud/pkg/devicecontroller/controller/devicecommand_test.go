@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/manager"
+	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/types"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	"github.com/kubeedge/kubeedge/pkg/client/clientset/versioned/fake"
+)
+
+func TestValidateDeviceCommandRequest(t *testing.T) {
+	dc := &DownstreamController{
+		deviceManager:      &manager.DeviceManager{},
+		deviceModelManager: &manager.DeviceModelManager{},
+	}
+	dc.deviceModelManager.DeviceModel.Store("sensor-model", &v1alpha2.DeviceModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "sensor-model", Namespace: "default"},
+		Spec: v1alpha2.DeviceModelSpec{
+			Commands: []v1alpha2.DeviceCommand{{Name: "reset", Parameters: []string{"mode"}}},
+		},
+	})
+	dc.deviceManager.Device.Store("sensor", &v1alpha2.Device{
+		ObjectMeta: metav1.ObjectMeta{Name: "sensor", Namespace: "default"},
+		Spec: v1alpha2.DeviceSpec{
+			DeviceModelRef: &v1.LocalObjectReference{Name: "sensor-model"},
+			NodeSelector: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{{
+					MatchExpressions: []v1.NodeSelectorRequirement{{Key: "", Operator: v1.NodeSelectorOpIn, Values: []string{"edge-node"}}},
+				}},
+			},
+		},
+	})
+	dc.deviceManager.Device.Store("unbound", &v1alpha2.Device{
+		ObjectMeta: metav1.ObjectMeta{Name: "unbound", Namespace: "default"},
+		Spec:       v1alpha2.DeviceSpec{DeviceModelRef: &v1.LocalObjectReference{Name: "sensor-model"}},
+	})
+
+	tests := []struct {
+		name     string
+		spec     v1alpha2.DeviceCommandRequestSpec
+		wantNode string
+		wantErr  bool
+	}{
+		{
+			name:     "valid request",
+			spec:     v1alpha2.DeviceCommandRequestSpec{DeviceName: "sensor", Command: "reset", Parameters: map[string]string{"mode": "hard"}},
+			wantNode: "edge-node",
+		},
+		{
+			name:    "device not found",
+			spec:    v1alpha2.DeviceCommandRequestSpec{DeviceName: "missing", Command: "reset"},
+			wantErr: true,
+		},
+		{
+			name:    "device not bound to node",
+			spec:    v1alpha2.DeviceCommandRequestSpec{DeviceName: "unbound", Command: "reset"},
+			wantErr: true,
+		},
+		{
+			name:    "command not declared",
+			spec:    v1alpha2.DeviceCommandRequestSpec{DeviceName: "sensor", Command: "reboot"},
+			wantErr: true,
+		},
+		{
+			name:    "parameter not declared",
+			spec:    v1alpha2.DeviceCommandRequestSpec{DeviceName: "sensor", Command: "reset", Parameters: map[string]string{"delay": "1"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &v1alpha2.DeviceCommandRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "request", Namespace: "default"},
+				Spec:       tt.spec,
+			}
+			node, err := dc.validateDeviceCommandRequest(request)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateDeviceCommandRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if node != tt.wantNode {
+				t.Errorf("validateDeviceCommandRequest() = %v, want %v", node, tt.wantNode)
+			}
+		})
+	}
+
+	request := &v1alpha2.DeviceCommandRequest{ObjectMeta: metav1.ObjectMeta{Name: "request", Namespace: "other"}, Spec: v1alpha2.DeviceCommandRequestSpec{DeviceName: "sensor", Command: "reset"}}
+	if _, err := dc.validateDeviceCommandRequest(request); err == nil {
+		t.Errorf("validateDeviceCommandRequest() expected error for device in another namespace")
+	}
+}
+
+func TestRecordDeviceCommandResult(t *testing.T) {
+	request := &v1alpha2.DeviceCommandRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "request", Namespace: "default"},
+		Spec:       v1alpha2.DeviceCommandRequestSpec{DeviceName: "sensor", Command: "reset"},
+		Status:     v1alpha2.DeviceCommandRequestStatus{Phase: v1alpha2.DeviceCommandRequestSent, NodeName: "edge-node"},
+	}
+	crdClient := fake.NewSimpleClientset()
+	if _, err := crdClient.DevicesV1alpha2().DeviceCommandRequests("default").Create(context.Background(), request, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	uc := &UpstreamController{
+		crdClient: crdClient,
+		dc:        &DownstreamController{deviceManager: &manager.DeviceManager{}},
+	}
+	result := &types.DeviceCommandResult{Namespace: "default", Name: "request", Succeeded: true, Result: "done"}
+
+	if err := uc.recordDeviceCommandResult("other-node", result); err != nil {
+		t.Fatalf("recordDeviceCommandResult() error = %v", err)
+	}
+	got, err := crdClient.DevicesV1alpha2().DeviceCommandRequests("default").Get(context.Background(), "request", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != v1alpha2.DeviceCommandRequestSent {
+		t.Errorf("expected the result from another node dropped, but got phase %v", got.Status.Phase)
+	}
+
+	if err := uc.recordDeviceCommandResult("edge-node", result); err != nil {
+		t.Fatalf("recordDeviceCommandResult() error = %v", err)
+	}
+	got, err = crdClient.DevicesV1alpha2().DeviceCommandRequests("default").Get(context.Background(), "request", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != v1alpha2.DeviceCommandRequestSucceeded || got.Status.Result != "done" {
+		t.Errorf("expected the request completed by the result, but got status %+v", got.Status)
+	}
+}
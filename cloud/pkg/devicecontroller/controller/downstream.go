@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -41,17 +42,24 @@ import (
 	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/manager"
 	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/types"
 	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	crdClientset "github.com/kubeedge/kubeedge/pkg/client/clientset/versioned"
 	crdinformers "github.com/kubeedge/kubeedge/pkg/client/informers/externalversions"
 )
 
 // DownstreamController watch kubernetes api server and send change to edge
 type DownstreamController struct {
 	kubeClient   kubernetes.Interface
+	crdClient    crdClientset.Interface
 	messageLayer messagelayer.MessageLayer
 
-	deviceManager      *manager.DeviceManager
-	deviceModelManager *manager.DeviceModelManager
-	configMapManager   *manager.ConfigMapManager
+	deviceManager               *manager.DeviceManager
+	deviceModelManager          *manager.DeviceModelManager
+	configMapManager            *manager.ConfigMapManager
+	deviceCommandRequestManager *manager.DeviceCommandRequestManager
+	deviceAlertRuleManager      *manager.DeviceAlertRuleManager
+
+	// pendingCommands holds the keys of command requests waiting for results from edge
+	pendingCommands sync.Map
 }
 
 // syncDeviceModel is used to get events from informer
@@ -393,6 +401,7 @@ func (dc *DownstreamController) deviceAdded(device *v1alpha2.Device) {
 
 		dc.sendDeviceModelMsg(device, model.InsertOperation)
 		dc.sendDeviceMsg(device, model.InsertOperation)
+		dc.sendDeviceAlertRulesOfDevice(device, model.InsertOperation)
 	}
 }
 
@@ -430,7 +439,7 @@ func createDevice(device *v1alpha2.Device) types.Device {
 		if err != nil {
 			klog.Warningf("Failed to parse cloud version due to error %v", err)
 		}
-		twinVersion := &types.TwinVersion{CloudVersion: cloudVersion, EdgeVersion: 0}
+		twinVersion := desiredTwinVersion(&device.Status.Twins[i], cloudVersion)
 		msgTwin := &types.MsgTwin{
 			Expected:        expected,
 			Optional:        optional,
@@ -662,7 +671,7 @@ func addDeletedTwins(oldTwin []v1alpha2.Twin, newTwin []v1alpha2.Twin, twin map[
 			if err != nil {
 				klog.Warningf("Failed to parse cloud version due to error %v", err)
 			}
-			twinVersion := &types.TwinVersion{CloudVersion: cloudVersion, EdgeVersion: 0}
+			twinVersion := desiredTwinVersion(&oldTwin[i], cloudVersion)
 			msgTwin := &types.MsgTwin{
 				Expected:        expected,
 				Optional:        optional,
@@ -674,6 +683,16 @@ func addDeletedTwins(oldTwin []v1alpha2.Twin, newTwin []v1alpha2.Twin, twin map[
 	}
 }
 
+// desiredTwinVersion returns the version of the desired value sent to edge. The edge version of the
+// desired value merged by edge is carried, edge rejects the updates which are not based on it as conflicts
+func desiredTwinVersion(twin *v1alpha2.Twin, cloudVersion int64) *types.TwinVersion {
+	version := &types.TwinVersion{CloudVersion: cloudVersion}
+	if twin.DesiredVersion != nil {
+		version.EdgeVersion = twin.DesiredVersion.EdgeVersion
+	}
+	return version
+}
+
 // ifTwinPresent checks if twin is present in the array of twins
 func ifTwinPresent(twin v1alpha2.Twin, newTwins []v1alpha2.Twin) bool {
 	for _, dtwin := range newTwins {
@@ -705,7 +724,7 @@ func addUpdatedTwins(newTwin []v1alpha2.Twin, twin map[string]*types.MsgTwin, ve
 		if err != nil {
 			klog.Warningf("Failed to parse cloud version due to error %v", err)
 		}
-		twinVersion := &types.TwinVersion{CloudVersion: cloudVersion, EdgeVersion: 0}
+		twinVersion := desiredTwinVersion(&newTwin[i], cloudVersion)
 		msgTwin := &types.MsgTwin{
 			Expected:        expected,
 			Optional:        optional,
@@ -949,6 +968,7 @@ func (dc *DownstreamController) deviceDeleted(device *v1alpha2.Device) {
 		}
 		dc.sendDeviceModelMsg(device, model.DeleteOperation)
 		dc.sendDeviceMsg(device, model.DeleteOperation)
+		dc.sendDeviceAlertRulesOfDevice(device, model.DeleteOperation)
 	}
 }
 
@@ -963,6 +983,9 @@ func (dc *DownstreamController) Start() error {
 	time.Sleep(1 * time.Second)
 	go dc.syncDevice()
 
+	go dc.syncDeviceCommandRequest()
+	go dc.syncDeviceAlertRule()
+
 	return nil
 }
 
@@ -980,12 +1003,27 @@ func NewDownstreamController(crdInformerFactory crdinformers.SharedInformerFacto
 		return nil, err
 	}
 
+	deviceCommandRequestManager, err := manager.NewDeviceCommandRequestManager(crdInformerFactory.Devices().V1alpha2().DeviceCommandRequests().Informer())
+	if err != nil {
+		klog.Warningf("Create device command request manager failed with error: %s", err)
+		return nil, err
+	}
+
+	deviceAlertRuleManager, err := manager.NewDeviceAlertRuleManager(crdInformerFactory.Devices().V1alpha2().DeviceAlertRules().Informer())
+	if err != nil {
+		klog.Warningf("Create device alert rule manager failed with error: %s", err)
+		return nil, err
+	}
+
 	dc := &DownstreamController{
-		kubeClient:         client.GetKubeClient(),
-		deviceManager:      deviceManager,
-		deviceModelManager: deviceModelManager,
-		messageLayer:       messagelayer.DeviceControllerMessageLayer(),
-		configMapManager:   manager.NewConfigMapManager(),
+		kubeClient:                  client.GetKubeClient(),
+		crdClient:                   client.GetCRDClient(),
+		deviceManager:               deviceManager,
+		deviceModelManager:          deviceModelManager,
+		deviceCommandRequestManager: deviceCommandRequestManager,
+		deviceAlertRuleManager:      deviceAlertRuleManager,
+		messageLayer:                messagelayer.DeviceControllerMessageLayer(),
+		configMapManager:            manager.NewConfigMapManager(),
 	}
 	return dc, nil
 }
@@ -19,8 +19,16 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strconv"
+	"time"
 
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 
 	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
@@ -34,6 +42,7 @@ import (
 	commonconst "github.com/kubeedge/kubeedge/common/constants"
 	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
 	crdClientset "github.com/kubeedge/kubeedge/pkg/client/clientset/versioned"
+	crdscheme "github.com/kubeedge/kubeedge/pkg/client/clientset/versioned/scheme"
 )
 
 // DeviceStatus is structure to patch device status
@@ -42,18 +51,32 @@ type DeviceStatus struct {
 }
 
 const (
+	// EventReasonAlertFiring is the reason of the event recorded when a device alert fires
+	EventReasonAlertFiring = "AlertFiring"
+	// EventReasonAlertResolved is the reason of the event recorded when a device alert resolves
+	EventReasonAlertResolved = "AlertResolved"
+	// EventReasonTwinConflict is the reason of the event recorded when edge rejects a desired value due to version conflict
+	EventReasonTwinConflict = "TwinConflict"
 	// MergePatchType is patch type
 	MergePatchType = "application/merge-patch+json"
 	// ResourceTypeDevices is plural of device resource in apiserver
 	ResourceTypeDevices = "devices"
+	// eventBusSource is the source of the messages published to the cloud by the edge eventbus
+	eventBusSource = "bus"
 )
 
 // UpstreamController subscribe messages from edge and sync to k8s api server
 type UpstreamController struct {
 	crdClient    crdClientset.Interface
 	messageLayer messagelayer.MessageLayer
+	recorder     record.EventRecorder
 	// message channel
-	deviceStatusChan chan model.Message
+	deviceStatusChan        chan model.Message
+	deviceStateChan         chan model.Message
+	deviceCommandResultChan chan model.Message
+	deviceAlertChan         chan model.Message
+	deviceTwinConflictChan  chan model.Message
+	deviceHistoryChan       chan model.Message
 
 	// downstream controller to update device status in cache
 	dc *DownstreamController
@@ -64,11 +87,21 @@ func (uc *UpstreamController) Start() error {
 	klog.Info("Start upstream devicecontroller")
 
 	uc.deviceStatusChan = make(chan model.Message, config.Config.Buffer.UpdateDeviceStatus)
+	uc.deviceStateChan = make(chan model.Message, config.Config.Buffer.UpdateDeviceStatus)
+	uc.deviceCommandResultChan = make(chan model.Message, config.Config.Buffer.UpdateDeviceCommandResult)
+	uc.deviceAlertChan = make(chan model.Message, config.Config.Buffer.UpdateDeviceAlert)
+	uc.deviceTwinConflictChan = make(chan model.Message, config.Config.Buffer.UpdateDeviceStatus)
+	uc.deviceHistoryChan = make(chan model.Message, config.Config.Buffer.UpdateDeviceStatus)
 	go uc.dispatchMessage()
 
 	for i := 0; i < int(config.Config.Load.UpdateDeviceStatusWorkers); i++ {
 		go uc.updateDeviceStatus()
+		go uc.updateDeviceState()
 	}
+	go uc.updateDeviceCommandResult()
+	go uc.updateDeviceAlert()
+	go uc.updateDeviceTwinConflict()
+	go uc.uploadDeviceHistory()
 	return nil
 }
 
@@ -98,6 +131,16 @@ func (uc *UpstreamController) dispatchMessage() {
 		switch resourceType {
 		case constants.ResourceTypeTwinEdgeUpdated:
 			uc.deviceStatusChan <- msg
+		case constants.ResourceTypeDeviceStateUpdate:
+			uc.deviceStateChan <- msg
+		case constants.ResourceTypeDeviceCommandResult:
+			uc.deviceCommandResultChan <- msg
+		case constants.ResourceTypeDeviceAlert:
+			uc.deviceAlertChan <- msg
+		case constants.ResourceTypeTwinConflict:
+			uc.deviceTwinConflictChan <- msg
+		case constants.ResourceTypeDeviceHistory:
+			uc.deviceHistoryChan <- msg
 		case constants.ResourceTypeMembershipDetail:
 		default:
 			klog.Warningf("Message: %s, with resource type: %s not intended for device controller", msg.GetID(), resourceType)
@@ -134,23 +177,7 @@ func (uc *UpstreamController) updateDeviceStatus() {
 				continue
 			}
 			deviceStatus := &DeviceStatus{Status: cacheDevice.Status}
-			for twinName, twin := range msgTwin.Twin {
-				for i, cacheTwin := range deviceStatus.Status.Twins {
-					if twinName == cacheTwin.PropertyName && twin.Actual != nil && twin.Actual.Value != nil {
-						reported := v1alpha2.TwinProperty{}
-						reported.Value = *twin.Actual.Value
-						reported.Metadata = make(map[string]string)
-						if twin.Actual.Metadata != nil {
-							reported.Metadata["timestamp"] = strconv.FormatInt(twin.Actual.Metadata.Timestamp, 10)
-						}
-						if twin.Metadata != nil {
-							reported.Metadata["type"] = twin.Metadata.Type
-						}
-						deviceStatus.Status.Twins[i].Reported = reported
-						break
-					}
-				}
-			}
+			updateStatusTwins(deviceStatus.Status.Twins, msgTwin.Twin)
 
 			// Store the status in cache so that when update is received by informer, it is not processed by downstream controller
 			cacheDevice.Status = deviceStatus.Status
@@ -167,22 +194,379 @@ func (uc *UpstreamController) updateDeviceStatus() {
 				continue
 			}
 			//send confirm message to edge twin
-			resMsg := model.NewMessage(msg.GetID())
+			if err := uc.responseToEdge(msg); err != nil {
+				klog.Warningf("Message: %s process failure, %v", msg.GetID(), err)
+				continue
+			}
+			klog.Infof("Message: %s process successfully", msg.GetID())
+		}
+	}
+}
+
+// updateStatusTwins updates the reported values of the twins, and the desired values updated on edge with
+// their versions, so that the following desired updates of cloud are based on the edge versions
+func updateStatusTwins(twins []v1alpha2.Twin, msgTwins map[string]*types.MsgTwin) {
+	for twinName, twin := range msgTwins {
+		for i, cacheTwin := range twins {
+			if twinName != cacheTwin.PropertyName || twin == nil {
+				continue
+			}
+			if twin.Actual != nil && twin.Actual.Value != nil {
+				reported := v1alpha2.TwinProperty{}
+				reported.Value = *twin.Actual.Value
+				reported.Metadata = make(map[string]string)
+				if twin.Actual.Metadata != nil {
+					reported.Metadata["timestamp"] = strconv.FormatInt(twin.Actual.Metadata.Timestamp, 10)
+				}
+				if twin.Metadata != nil {
+					reported.Metadata["type"] = twin.Metadata.Type
+				}
+				twins[i].Reported = reported
+			}
+			// the reports may arrive out of order, the desired value is not replaced by an older one
+			if twin.Expected != nil && twin.Expected.Value != nil && twin.ExpectedVersion != nil &&
+				!isTwinVersionOlder(twin.ExpectedVersion, cacheTwin.DesiredVersion) {
+				twins[i].Desired.Value = *twin.Expected.Value
+				twins[i].DesiredVersion = &v1alpha2.TwinVersion{
+					CloudVersion: twin.ExpectedVersion.CloudVersion,
+					EdgeVersion:  twin.ExpectedVersion.EdgeVersion,
+				}
+			}
+			break
+		}
+	}
+}
+
+// isTwinVersionOlder returns whether the version is older than the current version
+func isTwinVersionOlder(version *types.TwinVersion, current *v1alpha2.TwinVersion) bool {
+	if current == nil {
+		return false
+	}
+	if version.EdgeVersion != current.EdgeVersion {
+		return version.EdgeVersion < current.EdgeVersion
+	}
+	return version.CloudVersion < current.CloudVersion
+}
+
+func (uc *UpstreamController) updateDeviceState() {
+	for {
+		select {
+		case <-beehiveContext.Done():
+			klog.Info("Stop updateDeviceState")
+			return
+		case msg := <-uc.deviceStateChan:
+			klog.Infof("Message: %s, operation is: %s, and resource is: %s", msg.GetID(), msg.GetOperation(), msg.GetResource())
+			contentData, err := msg.GetContentData()
+			if err != nil {
+				klog.Warningf("Failed to get content data of message %s, err: %v", msg.GetID(), err)
+				continue
+			}
+			stateUpdate := &types.DeviceStateUpdate{}
+			if err := json.Unmarshal(contentData, stateUpdate); err != nil {
+				klog.Warningf("Unmarshall failed due to error %v", err)
+				continue
+			}
+			deviceID, err := messagelayer.GetDeviceID(msg.GetResource())
+			if err != nil {
+				klog.Warning("Failed to get device id")
+				continue
+			}
+			device, ok := uc.dc.deviceManager.Device.Load(deviceID)
+			if !ok {
+				klog.Warningf("Device %s does not exist in downstream controller", deviceID)
+				continue
+			}
+			cacheDevice, ok := device.(*v1alpha2.Device)
+			if !ok {
+				klog.Warning("Failed to assert to CacheDevice type")
+				continue
+			}
+
+			// Store the state in cache so that when update is received by informer, it is not processed by downstream controller
+			cacheDevice.Status.State = stateUpdate.Device.State
+			cacheDevice.Status.LastOnlineTime = stateUpdate.Device.LastOnline
+			uc.dc.deviceManager.Device.Store(deviceID, cacheDevice)
+
+			// only patch the state, the twins are patched by updateDeviceStatus concurrently
+			body, err := json.Marshal(map[string]interface{}{
+				"status": map[string]string{
+					"state":          cacheDevice.Status.State,
+					"lastOnlineTime": cacheDevice.Status.LastOnlineTime,
+				},
+			})
+			if err != nil {
+				klog.Errorf("Failed to marshal state of device %v", deviceID)
+				continue
+			}
+			err = uc.crdClient.DevicesV1alpha2().RESTClient().Patch(MergePatchType).Namespace(cacheDevice.Namespace).Resource(ResourceTypeDevices).Name(deviceID).Body(body).Do(context.Background()).Error()
+			if err != nil {
+				klog.Errorf("Failed to patch state %s of device %v in namespace %v, err: %v", cacheDevice.Status.State, deviceID, cacheDevice.Namespace, err)
+				continue
+			}
+
+			if err := uc.responseToEdge(msg); err != nil {
+				klog.Warningf("Message: %s process failure, %v", msg.GetID(), err)
+				continue
+			}
+			klog.Infof("Message: %s process successfully", msg.GetID())
+		}
+	}
+}
+
+func (uc *UpstreamController) updateDeviceCommandResult() {
+	for {
+		select {
+		case <-beehiveContext.Done():
+			klog.Info("Stop updateDeviceCommandResult")
+			return
+		case msg := <-uc.deviceCommandResultChan:
+			klog.Infof("Message: %s, operation is: %s, and resource is: %s", msg.GetID(), msg.GetOperation(), msg.GetResource())
+			contentData, err := msg.GetContentData()
+			if err != nil {
+				klog.Warningf("Failed to get content data of message %s, err: %v", msg.GetID(), err)
+				continue
+			}
+			result := &types.DeviceCommandResult{}
+			if err := json.Unmarshal(contentData, result); err != nil {
+				klog.Warningf("Unmarshall failed due to error %v", err)
+				continue
+			}
 			nodeID, err := messagelayer.GetNodeID(msg)
 			if err != nil {
-				klog.Warningf("Message: %s process failure, get node id failed with error: %s", msg.GetID(), err)
+				klog.Warningf("Failed to get node id of message %s, err: %v", msg.GetID(), err)
 				continue
 			}
-			resource, err := messagelayer.BuildResourceForDevice(nodeID, "twin", "")
+
+			if err := uc.recordDeviceCommandResult(nodeID, result); err != nil {
+				klog.Errorf("Failed to update status of DeviceCommandRequest %s/%s, err: %v", result.Namespace, result.Name, err)
+				continue
+			}
+
+			if err := uc.responseToEdge(msg); err != nil {
+				klog.Warningf("Message: %s process failure, %v", msg.GetID(), err)
+				continue
+			}
+			klog.Infof("Message: %s process successfully", msg.GetID())
+		}
+	}
+}
+
+// recordDeviceCommandResult completes the request with the result, the results sent by
+// the nodes other than the one which the request is sent to are dropped
+func (uc *UpstreamController) recordDeviceCommandResult(nodeID string, result *types.DeviceCommandResult) error {
+	request, err := uc.crdClient.DevicesV1alpha2().DeviceCommandRequests(result.Namespace).Get(context.Background(), result.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		klog.Warningf("DeviceCommandRequest %s/%s of result does not exist", result.Namespace, result.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ownerNode := request.Status.NodeName
+	if ownerNode == "" {
+		ownerNode = uc.dc.nodeNameOfDevice(request.Namespace, request.Spec.DeviceName)
+	}
+	if ownerNode != nodeID {
+		klog.Warningf("Drop the result of DeviceCommandRequest %s/%s from node %s, it is sent to node %q", result.Namespace, result.Name, nodeID, ownerNode)
+		return nil
+	}
+
+	// stop waiting for the result, the request is completed anyway
+	uc.dc.pendingCommands.Delete(deviceCommandKey(result.Namespace, result.Name))
+	return completeDeviceCommandRequest(uc.crdClient, result.Namespace, result.Name, func(status *v1alpha2.DeviceCommandRequestStatus) {
+		status.Phase = v1alpha2.DeviceCommandRequestFailed
+		if result.Succeeded {
+			status.Phase = v1alpha2.DeviceCommandRequestSucceeded
+		}
+		status.StatusCode = result.StatusCode
+		status.Result = result.Result
+		status.Message = result.Message
+	})
+}
+
+func (uc *UpstreamController) updateDeviceAlert() {
+	for {
+		select {
+		case <-beehiveContext.Done():
+			klog.Info("Stop updateDeviceAlert")
+			return
+		case msg := <-uc.deviceAlertChan:
+			klog.Infof("Message: %s, operation is: %s, and resource is: %s", msg.GetID(), msg.GetOperation(), msg.GetResource())
+			contentData, err := msg.GetContentData()
+			if err != nil {
+				klog.Warningf("Failed to get content data of message %s, err: %v", msg.GetID(), err)
+				continue
+			}
+			alert := &types.DeviceAlert{}
+			if err := json.Unmarshal(contentData, alert); err != nil {
+				klog.Warningf("Unmarshall failed due to error %v", err)
+				continue
+			}
+			nodeID, err := messagelayer.GetNodeID(msg)
+			if err != nil {
+				klog.Warningf("Failed to get node id of message %s, err: %v", msg.GetID(), err)
+				continue
+			}
+
+			if err := uc.recordDeviceAlert(nodeID, alert); err != nil {
+				klog.Errorf("Failed to update status of DeviceAlertRule %s/%s, err: %v", alert.Namespace, alert.Name, err)
+				continue
+			}
+
+			if err := uc.responseToEdge(msg); err != nil {
+				klog.Warningf("Message: %s process failure, %v", msg.GetID(), err)
+				continue
+			}
+			klog.Infof("Message: %s process successfully", msg.GetID())
+		}
+	}
+}
+
+// recordDeviceAlert updates the status of the rule with the alert, and records an event if the rule asks for.
+// The alerts from the nodes which the device of the rule is not bound to, and the stale alerts are dropped
+func (uc *UpstreamController) recordDeviceAlert(nodeID string, alert *types.DeviceAlert) error {
+	transitionTime := metav1.NewTime(time.Unix(0, alert.Timestamp*int64(time.Millisecond)))
+	var rule *v1alpha2.DeviceAlertRule
+	var skipped bool
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		skipped = false
+		rule, err = uc.crdClient.DevicesV1alpha2().DeviceAlertRules(alert.Namespace).Get(context.Background(), alert.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if ownerNode := uc.dc.nodeNameOfDevice(rule.Namespace, rule.Spec.DeviceName); ownerNode != nodeID {
+			klog.Warningf("Drop the alert of DeviceAlertRule %s/%s from node %s, the device is bound to node %q", alert.Namespace, alert.Name, nodeID, ownerNode)
+			skipped = true
+			return nil
+		}
+		// the alerts may arrive out of order after the edge node reconnects
+		if rule.Status.LastTransitionTime != nil && rule.Status.LastTransitionTime.After(transitionTime.Time) {
+			klog.Warningf("Drop the stale alert of DeviceAlertRule %s/%s from node %s", alert.Namespace, alert.Name, nodeID)
+			skipped = true
+			return nil
+		}
+		rule.Status.NodeName = nodeID
+		rule.Status.Firing = alert.Firing
+		rule.Status.Message = alert.Message
+		rule.Status.LastTransitionTime = &transitionTime
+		rule, err = uc.crdClient.DevicesV1alpha2().DeviceAlertRules(alert.Namespace).UpdateStatus(context.Background(), rule, metav1.UpdateOptions{})
+		return err
+	})
+	if apierrors.IsNotFound(err) {
+		klog.Warningf("DeviceAlertRule %s/%s of alert does not exist", alert.Namespace, alert.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if skipped {
+		return nil
+	}
+
+	if rule.Spec.Actions.RecordEvent {
+		if alert.Firing {
+			uc.recorder.Event(rule, v1.EventTypeWarning, EventReasonAlertFiring, alert.Message)
+		} else {
+			uc.recorder.Event(rule, v1.EventTypeNormal, EventReasonAlertResolved, alert.Message)
+		}
+	}
+	return nil
+}
+
+func (uc *UpstreamController) updateDeviceTwinConflict() {
+	for {
+		select {
+		case <-beehiveContext.Done():
+			klog.Info("Stop updateDeviceTwinConflict")
+			return
+		case msg := <-uc.deviceTwinConflictChan:
+			klog.Infof("Message: %s, operation is: %s, and resource is: %s", msg.GetID(), msg.GetOperation(), msg.GetResource())
+			contentData, err := msg.GetContentData()
+			if err != nil {
+				klog.Warningf("Failed to get content data of message %s, err: %v", msg.GetID(), err)
+				continue
+			}
+			conflict := &types.DeviceTwinConflict{}
+			if err := json.Unmarshal(contentData, conflict); err != nil {
+				klog.Warningf("Unmarshall failed due to error %v", err)
+				continue
+			}
+			deviceID, err := messagelayer.GetDeviceID(msg.GetResource())
 			if err != nil {
-				klog.Warningf("Message: %s process failure, build message resource failed with error: %s", msg.GetID(), err)
+				klog.Warning("Failed to get device id")
+				continue
+			}
+			nodeID, err := messagelayer.GetNodeID(msg)
+			if err != nil {
+				klog.Warningf("Failed to get node id of message %s, err: %v", msg.GetID(), err)
+				continue
+			}
+
+			uc.recordDeviceTwinConflict(nodeID, deviceID, conflict)
+
+			if err := uc.responseToEdge(msg); err != nil {
+				klog.Warningf("Message: %s process failure, %v", msg.GetID(), err)
+				continue
+			}
+			klog.Infof("Message: %s process successfully", msg.GetID())
+		}
+	}
+}
+
+// recordDeviceTwinConflict records the events of the twin conflicts on the device, the conflicts of the deleted
+// devices and the conflicts from the nodes which the device is not bound to are dropped
+func (uc *UpstreamController) recordDeviceTwinConflict(nodeID, deviceID string, conflict *types.DeviceTwinConflict) {
+	device, ok := uc.dc.deviceManager.Device.Load(deviceID)
+	if !ok {
+		klog.Warningf("Device %s does not exist in downstream controller", deviceID)
+		return
+	}
+	cacheDevice, ok := device.(*v1alpha2.Device)
+	if !ok {
+		return
+	}
+	if ownerNode := deviceNodeName(cacheDevice); ownerNode != nodeID {
+		klog.Warningf("Drop the twin conflicts of device %s from node %s, the device is bound to node %q", deviceID, nodeID, ownerNode)
+		return
+	}
+	for twinName, twinConflict := range conflict.Twin {
+		uc.recorder.Event(cacheDevice, v1.EventTypeWarning, EventReasonTwinConflict, twinConflictMessage(twinName, twinConflict))
+	}
+}
+
+// uploadDeviceHistory forwards the history uploaded by edge to the router as if it is published by the
+// edge eventbus to the topic, and confirms it so that edge uploads the next batch
+func (uc *UpstreamController) uploadDeviceHistory() {
+	for {
+		select {
+		case <-beehiveContext.Done():
+			klog.Info("Stop uploadDeviceHistory")
+			return
+		case msg := <-uc.deviceHistoryChan:
+			klog.Infof("Message: %s, operation is: %s, and resource is: %s", msg.GetID(), msg.GetOperation(), msg.GetResource())
+			contentData, err := msg.GetContentData()
+			if err != nil {
+				klog.Warningf("Failed to get content data of message %s, err: %v", msg.GetID(), err)
 				continue
 			}
-			resMsg.BuildRouter(modules.DeviceControllerModuleName, constants.GroupTwin, resource, model.ResponseOperation)
-			resMsg.Content = commonconst.MessageSuccessfulContent
-			err = uc.messageLayer.Response(*resMsg)
+			history := &types.DeviceHistory{}
+			if err := json.Unmarshal(contentData, history); err != nil {
+				klog.Warningf("Unmarshall failed due to error %v", err)
+				continue
+			}
+			nodeID, err := messagelayer.GetNodeID(msg)
 			if err != nil {
-				klog.Warningf("Message: %s process failure, response failed with error: %s", msg.GetID(), err)
+				klog.Warningf("Failed to get node id of message %s, err: %v", msg.GetID(), err)
+				continue
+			}
+
+			forward := model.NewMessage("").BuildRouter(eventBusSource, modules.UserGroup,
+				fmt.Sprintf("node/%s/%s", nodeID, history.Topic), model.UploadOperation).FillBody(string(history.Batch))
+			beehiveContext.Send(modules.RouterModuleName, *forward)
+
+			if err := uc.responseToEdge(msg); err != nil {
+				klog.Warningf("Message: %s process failure, %v", msg.GetID(), err)
 				continue
 			}
 			klog.Infof("Message: %s process successfully", msg.GetID())
@@ -190,6 +574,43 @@ func (uc *UpstreamController) updateDeviceStatus() {
 	}
 }
 
+// twinConflictMessage returns the message of the event recorded for the conflict
+func twinConflictMessage(twinName string, conflict *types.TwinConflict) string {
+	value := func(v *types.TwinValue) string {
+		if v == nil || v.Value == nil {
+			return ""
+		}
+		return *v.Value
+	}
+	version := func(v *types.TwinVersion) string {
+		if v == nil {
+			return "none"
+		}
+		return fmt.Sprintf("cloud %d edge %d", v.CloudVersion, v.EdgeVersion)
+	}
+	return fmt.Sprintf("desired value %q of twin %s (version %s) from %s is rejected due to version conflict, the desired value %q (version %s) on edge is kept",
+		value(conflict.Rejected), twinName, version(conflict.RejectedVersion), conflict.Source, value(conflict.Current), version(conflict.CurrentVersion))
+}
+
+// responseToEdge sends confirm message of msg to edge twin
+func (uc *UpstreamController) responseToEdge(msg model.Message) error {
+	resMsg := model.NewMessage(msg.GetID())
+	nodeID, err := messagelayer.GetNodeID(msg)
+	if err != nil {
+		return fmt.Errorf("get node id failed with error: %s", err)
+	}
+	resource, err := messagelayer.BuildResourceForDevice(nodeID, "twin", "")
+	if err != nil {
+		return fmt.Errorf("build message resource failed with error: %s", err)
+	}
+	resMsg.BuildRouter(modules.DeviceControllerModuleName, constants.GroupTwin, resource, model.ResponseOperation)
+	resMsg.Content = commonconst.MessageSuccessfulContent
+	if err := uc.messageLayer.Response(*resMsg); err != nil {
+		return fmt.Errorf("response failed with error: %s", err)
+	}
+	return nil
+}
+
 func (uc *UpstreamController) unmarshalDeviceStatusMessage(msg model.Message) (*types.DeviceTwinUpdate, error) {
 	contentData, err := msg.GetContentData()
 	if err != nil {
@@ -205,9 +626,12 @@ func (uc *UpstreamController) unmarshalDeviceStatusMessage(msg model.Message) (*
 
 // NewUpstreamController create UpstreamController from config
 func NewUpstreamController(dc *DownstreamController) (*UpstreamController, error) {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: keclient.GetKubeClient().CoreV1().Events("")})
 	uc := &UpstreamController{
 		crdClient:    keclient.GetCRDClient(),
 		messageLayer: messagelayer.DeviceControllerMessageLayer(),
+		recorder:     eventBroadcaster.NewRecorder(crdscheme.Scheme, v1.EventSource{Component: modules.DeviceControllerModuleName}),
 		dc:           dc,
 	}
 	return uc, nil
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/manager"
+	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/types"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	"github.com/kubeedge/kubeedge/pkg/client/clientset/versioned/fake"
+)
+
+func TestUpdateStatusTwins(t *testing.T) {
+	value := func(v string) *types.TwinValue {
+		return &types.TwinValue{Value: &v, Metadata: &types.ValueMetadata{Timestamp: 1000}}
+	}
+	statusTwins := func() []v1alpha2.Twin {
+		return []v1alpha2.Twin{{
+			PropertyName:   "temperature",
+			Desired:        v1alpha2.TwinProperty{Value: "20", Metadata: map[string]string{"type": "int"}},
+			DesiredVersion: &v1alpha2.TwinVersion{CloudVersion: 5, EdgeVersion: 2},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		msgTwins map[string]*types.MsgTwin
+		want     v1alpha2.Twin
+	}{
+		{
+			name: "reported value",
+			msgTwins: map[string]*types.MsgTwin{"temperature": {
+				Actual:   value("21"),
+				Metadata: &types.TypeMetadata{Type: "int"},
+			}},
+			want: v1alpha2.Twin{
+				PropertyName:   "temperature",
+				Desired:        v1alpha2.TwinProperty{Value: "20", Metadata: map[string]string{"type": "int"}},
+				Reported:       v1alpha2.TwinProperty{Value: "21", Metadata: map[string]string{"timestamp": "1000", "type": "int"}},
+				DesiredVersion: &v1alpha2.TwinVersion{CloudVersion: 5, EdgeVersion: 2},
+			},
+		},
+		{
+			name: "desired value updated on edge",
+			msgTwins: map[string]*types.MsgTwin{"temperature": {
+				Expected:        value("25"),
+				ExpectedVersion: &types.TwinVersion{CloudVersion: 5, EdgeVersion: 3},
+			}},
+			want: v1alpha2.Twin{
+				PropertyName:   "temperature",
+				Desired:        v1alpha2.TwinProperty{Value: "25", Metadata: map[string]string{"type": "int"}},
+				DesiredVersion: &v1alpha2.TwinVersion{CloudVersion: 5, EdgeVersion: 3},
+			},
+		},
+		{
+			name: "outdated desired value",
+			msgTwins: map[string]*types.MsgTwin{"temperature": {
+				Expected:        value("22"),
+				ExpectedVersion: &types.TwinVersion{CloudVersion: 5, EdgeVersion: 1},
+			}},
+			want: statusTwins()[0],
+		},
+		{
+			name:     "unknown twin",
+			msgTwins: map[string]*types.MsgTwin{"humidity": {Actual: value("60")}},
+			want:     statusTwins()[0],
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			twins := statusTwins()
+			updateStatusTwins(twins, test.msgTwins)
+			if !reflect.DeepEqual(twins[0], test.want) {
+				t.Errorf("updateStatusTwins() = %+v, want %+v", twins[0], test.want)
+			}
+		})
+	}
+}
+
+func TestDesiredTwinVersion(t *testing.T) {
+	version := desiredTwinVersion(&v1alpha2.Twin{}, 7)
+	if *version != (types.TwinVersion{CloudVersion: 7}) {
+		t.Errorf("desiredTwinVersion() = %+v, want cloud 7 edge 0", *version)
+	}
+	version = desiredTwinVersion(&v1alpha2.Twin{DesiredVersion: &v1alpha2.TwinVersion{CloudVersion: 3, EdgeVersion: 4}}, 7)
+	if *version != (types.TwinVersion{CloudVersion: 7, EdgeVersion: 4}) {
+		t.Errorf("desiredTwinVersion() = %+v, want cloud 7 edge 4", *version)
+	}
+}
+
+func TestTwinConflictMessage(t *testing.T) {
+	rejected, current := "30", "25"
+	message := twinConflictMessage("temperature", &types.TwinConflict{
+		Source:          "cloud",
+		Rejected:        &types.TwinValue{Value: &rejected},
+		RejectedVersion: &types.TwinVersion{CloudVersion: 9, EdgeVersion: 2},
+		Current:         &types.TwinValue{Value: &current},
+		CurrentVersion:  &types.TwinVersion{CloudVersion: 5, EdgeVersion: 3},
+	})
+	for _, want := range []string{`"30"`, "cloud 9 edge 2", `"25"`, "cloud 5 edge 3", "temperature"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("twinConflictMessage() = %q, want it to contain %s", message, want)
+		}
+	}
+}
+
+func TestRecordDeviceTwinConflict(t *testing.T) {
+	dc := &DownstreamController{deviceManager: &manager.DeviceManager{}}
+	dc.deviceManager.Device.Store("sensor", &v1alpha2.Device{
+		ObjectMeta: metav1.ObjectMeta{Name: "sensor", Namespace: "default"},
+		Spec: v1alpha2.DeviceSpec{
+			NodeSelector: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{{
+					MatchExpressions: []v1.NodeSelectorRequirement{{Key: "", Operator: v1.NodeSelectorOpIn, Values: []string{"edge-node"}}},
+				}},
+			},
+		},
+	})
+	recorder := record.NewFakeRecorder(10)
+	uc := &UpstreamController{dc: dc, recorder: recorder}
+	rejected := "30"
+	conflict := &types.DeviceTwinConflict{Twin: map[string]*types.TwinConflict{
+		"temperature": {Source: "cloud", Rejected: &types.TwinValue{Value: &rejected}},
+	}}
+
+	uc.recordDeviceTwinConflict("other-node", "sensor", conflict)
+	uc.recordDeviceTwinConflict("edge-node", "missing", conflict)
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected the conflicts from another node or of a missing device dropped, but got %d events", len(recorder.Events))
+	}
+
+	uc.recordDeviceTwinConflict("edge-node", "sensor", conflict)
+	if len(recorder.Events) != 1 {
+		t.Errorf("expected an event recorded for the conflict, but got %d events", len(recorder.Events))
+	}
+}
+
+func TestRecordDeviceAlert(t *testing.T) {
+	rule := &v1alpha2.DeviceAlertRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "rule", Namespace: "default"},
+		Spec: v1alpha2.DeviceAlertRuleSpec{
+			DeviceName: "sensor",
+			Actions:    v1alpha2.AlertActions{RecordEvent: true},
+		},
+	}
+	crdClient := fake.NewSimpleClientset()
+	if _, err := crdClient.DevicesV1alpha2().DeviceAlertRules("default").Create(context.Background(), rule, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	dc := &DownstreamController{deviceManager: &manager.DeviceManager{}}
+	dc.deviceManager.Device.Store("sensor", &v1alpha2.Device{
+		ObjectMeta: metav1.ObjectMeta{Name: "sensor", Namespace: "default"},
+		Spec: v1alpha2.DeviceSpec{
+			NodeSelector: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{{
+					MatchExpressions: []v1.NodeSelectorRequirement{{Key: "", Operator: v1.NodeSelectorOpIn, Values: []string{"edge-node"}}},
+				}},
+			},
+		},
+	})
+	recorder := record.NewFakeRecorder(10)
+	uc := &UpstreamController{crdClient: crdClient, dc: dc, recorder: recorder}
+
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	tests := []struct {
+		name       string
+		nodeID     string
+		alert      *types.DeviceAlert
+		wantFiring bool
+		wantEvent  bool
+	}{
+		{
+			name:   "alert from another node",
+			nodeID: "other-node",
+			alert:  &types.DeviceAlert{Namespace: "default", Name: "rule", Firing: true, BaseMessage: types.BaseMessage{Timestamp: now}},
+		},
+		{
+			name:       "alert from the node of the device",
+			nodeID:     "edge-node",
+			alert:      &types.DeviceAlert{Namespace: "default", Name: "rule", Firing: true, BaseMessage: types.BaseMessage{Timestamp: now}},
+			wantFiring: true,
+			wantEvent:  true,
+		},
+		{
+			name:       "stale alert",
+			nodeID:     "edge-node",
+			alert:      &types.DeviceAlert{Namespace: "default", Name: "rule", Firing: false, BaseMessage: types.BaseMessage{Timestamp: now - 1000}},
+			wantFiring: true,
+		},
+	}
+
+	for _, test := range tests {
+		if err := uc.recordDeviceAlert(test.nodeID, test.alert); err != nil {
+			t.Fatalf("%s: recordDeviceAlert() error = %v", test.name, err)
+		}
+		got, err := crdClient.DevicesV1alpha2().DeviceAlertRules("default").Get(context.Background(), "rule", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status.Firing != test.wantFiring {
+			t.Errorf("%s: expected firing %v, but got %v", test.name, test.wantFiring, got.Status.Firing)
+		}
+		select {
+		case event := <-recorder.Events:
+			if !test.wantEvent {
+				t.Errorf("%s: expected no event recorded, but got %q", test.name, event)
+			}
+		default:
+			if test.wantEvent {
+				t.Errorf("%s: expected an event recorded", test.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package manager
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/config"
+)
+
+// DeviceAlertRuleManager is a manager watch DeviceAlertRule change event
+type DeviceAlertRuleManager struct {
+	// events from watch kubernetes api server
+	events chan watch.Event
+
+	// DeviceAlertRule, key is namespace/name, value is *v1alpha2.DeviceAlertRule{}
+	DeviceAlertRule sync.Map
+}
+
+// Events return a channel, can receive all DeviceAlertRule event
+func (darm *DeviceAlertRuleManager) Events() chan watch.Event {
+	return darm.events
+}
+
+// NewDeviceAlertRuleManager create DeviceAlertRuleManager from config
+func NewDeviceAlertRuleManager(si cache.SharedIndexInformer) (*DeviceAlertRuleManager, error) {
+	events := make(chan watch.Event, config.Config.Buffer.DeviceAlertRuleEvent)
+	rh := NewCommonResourceEventHandler(events)
+	si.AddEventHandler(rh)
+
+	return &DeviceAlertRuleManager{events: events}, nil
+}
@@ -0,0 +1,28 @@
+package manager
+
+import (
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/config"
+)
+
+// DeviceCommandRequestManager is a manager watch DeviceCommandRequest change event
+type DeviceCommandRequestManager struct {
+	// events from watch kubernetes api server
+	events chan watch.Event
+}
+
+// Events return a channel, can receive all DeviceCommandRequest event
+func (dcm *DeviceCommandRequestManager) Events() chan watch.Event {
+	return dcm.events
+}
+
+// NewDeviceCommandRequestManager create DeviceCommandRequestManager from config
+func NewDeviceCommandRequestManager(si cache.SharedIndexInformer) (*DeviceCommandRequestManager, error) {
+	events := make(chan watch.Event, config.Config.Buffer.DeviceCommandRequestEvent)
+	rh := NewCommonResourceEventHandler(events)
+	si.AddEventHandler(rh)
+
+	return &DeviceCommandRequestManager{events: events}, nil
+}
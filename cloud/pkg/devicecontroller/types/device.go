@@ -1,5 +1,7 @@
 package types
 
+import "encoding/json"
+
 // Device the struct of device
 type Device struct {
 	ID          string              `json:"id,omitempty"`
@@ -106,3 +108,67 @@ type DeviceTwinUpdate struct {
 	BaseMessage
 	Twin map[string]*MsgTwin `json:"twin"`
 }
+
+// DeviceCommandInvocation the struct of device command invocation sent to edge
+type DeviceCommandInvocation struct {
+	BaseMessage
+	// Namespace and Name of the DeviceCommandRequest, used to match the result
+	Namespace      string            `json:"namespace"`
+	Name           string            `json:"name"`
+	Command        string            `json:"command"`
+	Parameters     map[string]string `json:"parameters,omitempty"`
+	TimeoutSeconds int32             `json:"timeout_seconds"`
+}
+
+// DeviceCommandResult the struct of device command result reported by edge
+type DeviceCommandResult struct {
+	BaseMessage
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Succeeded  bool   `json:"succeeded"`
+	StatusCode string `json:"status_code,omitempty"`
+	Result     string `json:"result,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// DeviceAlert the struct of device alert fired or resolved on edge
+type DeviceAlert struct {
+	BaseMessage
+	// Namespace and Name of the DeviceAlertRule
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Device    string `json:"device"`
+	Property  string `json:"property"`
+	Value     string `json:"value,omitempty"`
+	Firing    bool   `json:"firing"`
+	Message   string `json:"message,omitempty"`
+}
+
+// DeviceHistory the batch of device property history uploaded by edge,
+// it is forwarded to the topic of the router eventbus source
+type DeviceHistory struct {
+	Topic string          `json:"topic"`
+	Batch json.RawMessage `json:"batch"`
+}
+
+// TwinConflict the desired value rejected by edge due to version conflict, and the current desired value on edge
+type TwinConflict struct {
+	// Source is where the rejected update comes from, cloud or edge
+	Source          string       `json:"source"`
+	Rejected        *TwinValue   `json:"rejected,omitempty"`
+	RejectedVersion *TwinVersion `json:"rejected_version,omitempty"`
+	Current         *TwinValue   `json:"current,omitempty"`
+	CurrentVersion  *TwinVersion `json:"current_version,omitempty"`
+}
+
+// DeviceTwinConflict the struct of twin conflicts reported by edge
+type DeviceTwinConflict struct {
+	BaseMessage
+	Twin map[string]*TwinConflict `json:"twin"`
+}
+
+// DeviceStateUpdate the struct of device state update reported by edge
+type DeviceStateUpdate struct {
+	BaseMessage
+	Device Device `json:"device"`
+}
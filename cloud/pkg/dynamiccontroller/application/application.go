@@ -9,6 +9,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/klog/v2"
@@ -178,6 +179,17 @@ func (c *Center) ProcessApplication(app *metaserver.Application) (interface{}, e
 			return nil, err
 		}
 		return retObj, nil
+	case metaserver.Discovery:
+		// the key of discovery application is the path of the discovery document
+		if !metaserver.IsDiscoveryPath(app.Key) {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("%s is not a discovery path", app.Key))
+		}
+		doc, err := client.GetKubeClient().Discovery().RESTClient().Get().AbsPath(app.Key).
+			SetHeader("Accept", runtime.ContentTypeJSON).Do(context.TODO()).Raw()
+		if err != nil {
+			return nil, err
+		}
+		return doc, nil
 	default:
 		return nil, fmt.Errorf("unsupported Application Verb type :%v", app.Verb)
 	}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	beehivemodel "github.com/kubeedge/beehive/pkg/core/model"
 	"github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/common"
 	"github.com/kubeedge/kubeedge/cloud/pkg/common/monitor"
 	"github.com/kubeedge/kubeedge/cloud/pkg/sessionmanager/identity"
+	commontypes "github.com/kubeedge/kubeedge/common/types"
 	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/cloudcore/v1alpha1"
 	"k8s.io/klog/v2"
 )
@@ -22,6 +25,8 @@ type NodeSession interface {
 	KeepAliveMessage()
 	// KeepAliveCheck A goroutine running KeepAliveCheck is started for each connection.
 	KeepAliveCheck()
+	// ReceiveKeepaliveReport receive the link quality reported in keepalive message from edge node
+	ReceiveKeepaliveReport(msg *beehivemodel.Message, report *commontypes.KeepaliveRequest)
 
 	// SendAckMessage loops forever sending message that require acknowledgment
 	// to the edge node until an error is encountered (or the connection is closed).
@@ -45,6 +50,16 @@ type NodeSession interface {
 
 	// GetNodeMessagePool return the common.NodeMessagePool
 	GetNodeMessagePool() *common.NodeMessagePool
+	// GetSessionToken return the token used by edge node to resume the session
+	GetSessionToken() string
+}
+
+// suspendedSession is the session of a disconnected edge node, which can be
+// resumed by the edge node with the session token within the grace period.
+type suspendedSession struct {
+	token       string
+	messagePool *common.NodeMessagePool
+	timer       *time.Timer
 }
 
 // SessionManager is the manager for node session, and itself have a Identity to store the cloud identity.
@@ -58,14 +73,22 @@ type SessionManager struct {
 	NodeLimit int32
 	// NodeSessions maps a node ID to NodeSession
 	NodeSessions sync.Map
+	// ResumeGracePeriod is the period that the session of a disconnected
+	// edge node can be resumed, 0 means session resumption is disabled
+	ResumeGracePeriod time.Duration
+	// suspendedSessions maps a node ID to suspendedSession
+	suspendedSessions map[string]*suspendedSession
+	suspendedLock     sync.Mutex
 }
 
 // NewSessionManager initializes a new SessionManager
 func NewSessionManager(modules *v1alpha1.Modules) *SessionManager {
 	return &SessionManager{
-		Identity:     identity.NewCloudIdentity(modules),
-		NodeLimit:    modules.CloudHub.NodeLimit,
-		NodeSessions: sync.Map{},
+		Identity:          identity.NewCloudIdentity(modules),
+		NodeLimit:         modules.CloudHub.NodeLimit,
+		NodeSessions:      sync.Map{},
+		ResumeGracePeriod: time.Duration(modules.CloudHub.SessionResumeGracePeriod) * time.Second,
+		suspendedSessions: make(map[string]*suspendedSession),
 	}
 }
 
@@ -86,22 +109,105 @@ func (sm *SessionManager) AddSession(session NodeSession) {
 	monitor.ConnectedNodes.Set(float64(atomic.AddInt32(&sm.NodeNumber, 1)))
 }
 
-// DeleteSession delete the node session from session manager
-func (sm *SessionManager) DeleteSession(session NodeSession) {
+// DeleteSession delete the node session from session manager,
+// it returns false if the session is not the current session of the node
+func (sm *SessionManager) DeleteSession(session NodeSession) bool {
 	cacheSession, exist := sm.GetSession(session.GetNodeID())
 	if !exist {
 		klog.Warningf("session not found for node %s", session.GetNodeID())
-		return
+		return false
 	}
 
 	// This usually happens when the node is disconnect then quickly reconnect
 	if cacheSession != session {
 		klog.Warningf("the session %s already deleted", session.GetNodeID())
-		return
+		return false
 	}
 
 	sm.NodeSessions.Delete(session.GetNodeID())
 	monitor.ConnectedNodes.Set(float64(atomic.AddInt32(&sm.NodeNumber, -1)))
+	monitor.NodeLinkRTT.DeleteLabelValues(session.GetNodeID())
+	monitor.NodeLinkLossRatio.DeleteLabelValues(session.GetNodeID())
+	return true
+}
+
+// SuspendSession keeps the message pool of the disconnected node session for
+// ResumeGracePeriod, onExpire is called if the session is not resumed in time.
+// It returns false if session resumption is disabled.
+func (sm *SessionManager) SuspendSession(session NodeSession, onExpire func()) bool {
+	if sm.ResumeGracePeriod <= 0 {
+		return false
+	}
+
+	nodeID := session.GetNodeID()
+	suspended := &suspendedSession{
+		token:       session.GetSessionToken(),
+		messagePool: session.GetNodeMessagePool(),
+	}
+
+	sm.suspendedLock.Lock()
+	defer sm.suspendedLock.Unlock()
+
+	if old, exists := sm.suspendedSessions[nodeID]; exists {
+		old.timer.Stop()
+	}
+	suspended.timer = time.AfterFunc(sm.ResumeGracePeriod, func() {
+		sm.suspendedLock.Lock()
+		// the session may be resumed or replaced by another suspended session
+		current, exists := sm.suspendedSessions[nodeID]
+		expired := exists && current == suspended
+		if expired {
+			delete(sm.suspendedSessions, nodeID)
+		}
+		sm.suspendedLock.Unlock()
+
+		if expired {
+			klog.Infof("session of node %s is not resumed in %s, clean it", nodeID, sm.ResumeGracePeriod)
+			onExpire()
+		}
+	})
+	sm.suspendedSessions[nodeID] = suspended
+
+	klog.Infof("session of node %s is suspended for %s", nodeID, sm.ResumeGracePeriod)
+	return true
+}
+
+// ResumeSession returns the message pool of the suspended session
+// or the current session of the node which matches the token.
+func (sm *SessionManager) ResumeSession(nodeID, token string) (*common.NodeMessagePool, bool) {
+	if sm.ResumeGracePeriod <= 0 || token == "" {
+		return nil, false
+	}
+
+	if session, exists := sm.GetSession(nodeID); exists && session.GetSessionToken() == token {
+		return session.GetNodeMessagePool(), true
+	}
+
+	sm.suspendedLock.Lock()
+	defer sm.suspendedLock.Unlock()
+
+	suspended, exists := sm.suspendedSessions[nodeID]
+	if !exists || suspended.token != token {
+		return nil, false
+	}
+	suspended.timer.Stop()
+	delete(sm.suspendedSessions, nodeID)
+	return suspended.messagePool, true
+}
+
+// DropSuspendedSession drops the suspended session of the node and returns its
+// message pool, it is called when the node connects without resuming the session.
+func (sm *SessionManager) DropSuspendedSession(nodeID string) (*common.NodeMessagePool, bool) {
+	sm.suspendedLock.Lock()
+	defer sm.suspendedLock.Unlock()
+
+	suspended, exists := sm.suspendedSessions[nodeID]
+	if !exists {
+		return nil, false
+	}
+	suspended.timer.Stop()
+	delete(sm.suspendedSessions, nodeID)
+	return suspended.messagePool, true
 }
 
 // GetSession get the node session for the node
@@ -130,6 +236,17 @@ func (sm *SessionManager) KeepAliveMessage(nodeID string) error {
 	return nil
 }
 
+// ReceiveKeepaliveReport receive the link quality reported in keepalive message from edge node
+func (sm *SessionManager) ReceiveKeepaliveReport(nodeID string, msg *beehivemodel.Message, report *commontypes.KeepaliveRequest) error {
+	session, exist := sm.GetSession(nodeID)
+	if !exist {
+		return fmt.Errorf("session not found for node %s", nodeID)
+	}
+
+	session.ReceiveKeepaliveReport(msg, report)
+	return nil
+}
+
 // ReceiveMessageAck receive the message ack from edge node
 func (sm *SessionManager) ReceiveMessageAck(nodeID, parentID string) error {
 	session, exist := sm.GetSession(nodeID)
@@ -18,6 +18,7 @@ package sessionmanager
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	session2 "github.com/kubeedge/kubeedge/cloud/pkg/cloudhub/session"
@@ -110,3 +111,50 @@ func TestManager_ReceiveMessageAck(t *testing.T) {
 		t.Errorf("expected err but got nil")
 	}
 }
+
+func TestSuspendResumeSession(t *testing.T) {
+	client := &fake.Clientset{}
+	nmp := common.InitNodeMessagePool(tf.TestNodeID)
+	mockController := gomock.NewController(t)
+	mockConn := mockcon.NewMockConnection(mockController)
+	session := session2.NewNodeSession(tf.TestNodeID, tf.TestProjectID, tf.TestCloudID, mockConn, tf.KeepaliveInterval, nmp, client)
+	conf := v1alpha1.NewDefaultCloudCoreConfig()
+
+	manager := NewSessionManager(conf.Modules)
+	if manager.SuspendSession(session, func() {}) {
+		t.Errorf("expected session not suspended when resumption is disabled")
+	}
+
+	manager.ResumeGracePeriod = time.Minute
+	manager.AddSession(session)
+	if _, resumed := manager.ResumeSession(tf.TestNodeID, session.GetSessionToken()); !resumed {
+		t.Errorf("expected current session resumed with its token")
+	}
+
+	manager.DeleteSession(session)
+	if !manager.SuspendSession(session, func() {}) {
+		t.Errorf("expected session suspended")
+	}
+	if _, resumed := manager.ResumeSession(tf.TestNodeID, "invalid-token"); resumed {
+		t.Errorf("expected session not resumed with invalid token")
+	}
+	pool, resumed := manager.ResumeSession(tf.TestNodeID, session.GetSessionToken())
+	if !resumed || pool != nmp {
+		t.Errorf("expected suspended session resumed with its message pool")
+	}
+	if _, resumed := manager.ResumeSession(tf.TestNodeID, session.GetSessionToken()); resumed {
+		t.Errorf("expected session can be resumed only once")
+	}
+
+	expired := make(chan struct{})
+	manager.ResumeGracePeriod = 10 * time.Millisecond
+	manager.SuspendSession(session, func() { close(expired) })
+	select {
+	case <-expired:
+	case <-time.After(time.Second):
+		t.Errorf("expected suspended session expired")
+	}
+	if _, resumed := manager.ResumeSession(tf.TestNodeID, session.GetSessionToken()); resumed {
+		t.Errorf("expected expired session not resumed")
+	}
+}
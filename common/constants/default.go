@@ -48,6 +48,9 @@ const (
 	DefaultMqttCertFile = "/etc/kubeedge/certs/server.crt"
 	DefaultMqttKeyFile  = "/etc/kubeedge/certs/server.key"
 
+	// DefaultMetaEncryptionKeyFile is the key file to encrypt the sensitive metas stored at edge
+	DefaultMetaEncryptionKeyFile = "/etc/kubeedge/keys/meta.key"
+
 	// Bootstrap file, contains token used by edgecore to apply for ca/cert
 	BootstrapFile = "/etc/kubeedge/bootstrap-edgecore.conf"
 
@@ -101,6 +104,10 @@ const (
 	DefaultRemoteQueryTimeout = 60
 	DefaultMetaServerAddr     = "127.0.0.1:10550"
 
+	// DeviceTwin
+	DefaultDMISockPath   = "/etc/kubeedge/dmi.sock"
+	DefaultDMITCPAddress = "0.0.0.0:10352"
+
 	// Config
 	DefaultKubeContentType         = "application/vnd.kubernetes.protobuf"
 	DefaultKubeNamespace           = v1.NamespaceAll
@@ -153,6 +160,11 @@ const (
 	DefaultDeviceModelEventBuffer    = 1
 	DefaultUpdateDeviceStatusWorkers = 1
 
+	DefaultDeviceCommandRequestEventBuffer = 1
+	DefaultUpdateDeviceCommandResultBuffer = 1024
+	DefaultDeviceAlertRuleEventBuffer      = 1
+	DefaultUpdateDeviceAlertBuffer         = 1024
+
 	// NodeUpgradeJobController
 	DefaultNodeUpgradeJobStatusBuffer = 1024
 	DefaultNodeUpgradeJobEventBuffer  = 1
@@ -175,6 +187,9 @@ const (
 	ResourceTypePersistentVolumeClaim = "persistentvolumeclaim"
 	ResourceTypeVolumeAttachment      = "volumeattachment"
 
+	// ResourceTypeDiscovery is the type of the discovery documents cached by metaserver
+	ResourceTypeDiscovery = "discovery"
+
 	CSIResourceTypeVolume                     = "volume"
 	CSIOperationTypeCreateVolume              = "createvolume"
 	CSIOperationTypeDeleteVolume              = "deletevolume"
@@ -28,6 +28,19 @@ type NodeStatusRequest struct {
 	ExtendResources map[v1.ResourceName][]ExtendResource
 }
 
+// KeepaliveRequest is Message.Content of keepalive message which comes from edge
+type KeepaliveRequest struct {
+	// Heartbeat is the current heart beat interval of edge in second
+	Heartbeat int32
+	// RTT is the round trip time of keepalive messages measured by edge in millisecond
+	RTT int64
+	// LossRatio is the ratio of recent keepalive messages that got no response
+	LossRatio float64
+	// ExpectPong tells that the edge handles the pong replied to keepalive message,
+	// the cloud does not reply to the edge which does not set it
+	ExpectPong bool
+}
+
 // NodeUpgradeJobRequest is upgrade msg coming from cloud to edge
 type NodeUpgradeJobRequest struct {
 	UpgradeID   string
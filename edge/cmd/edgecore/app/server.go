@@ -218,5 +218,5 @@ func registerModules(c *v1alpha2.EdgeCoreConfig) {
 	appsd.Register(c.Modules.Appsd)
 	test.Register(c.Modules.DBTest)
 	// Note: Need to put it to the end, and wait for all models to register before executing
-	dbm.InitDBConfig(c.DataBase.DriverName, c.DataBase.AliasName, c.DataBase.DataSource)
+	dbm.InitDB(c.DataBase)
 }
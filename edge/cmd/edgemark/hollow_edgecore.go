@@ -116,7 +116,7 @@ func run(config *hollowEdgeNodeConfig) {
 	edgehub.Register(c.Modules.EdgeHub, c.Modules.Edged.HostnameOverride)
 	metamanager.Register(c.Modules.MetaManager)
 
-	dbm.InitDBConfig(c.DataBase.DriverName, c.DataBase.AliasName, c.DataBase.DataSource)
+	dbm.InitDB(c.DataBase)
 
 	// start all modules
 	core.Run()
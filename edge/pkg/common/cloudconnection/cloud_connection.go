@@ -19,6 +19,7 @@ package cloudconnection
 import (
 	"errors"
 	"sync"
+	"time"
 )
 
 // constants for cloud connection
@@ -32,6 +33,10 @@ var (
 	// successfully established between edge and cloud
 	isCloudConnected = false
 
+	// lostAt is the time when the connection is lost, it is zero if the connection
+	// has never been established since the process starts
+	lostAt time.Time
+
 	lock sync.RWMutex
 
 	// ErrConnectionLost is sentinel err to indicates connection is lost between EdgeCore and CloudCore
@@ -44,9 +49,23 @@ var (
 func SetConnected(isConnected bool) {
 	lock.Lock()
 	defer lock.Unlock()
+	if isCloudConnected && !isConnected {
+		lostAt = time.Now()
+	}
 	isCloudConnected = isConnected
 }
 
+// LastConnected returns the last time the cloud is connected, it is now if connected
+// and zero if the connection has never been established since the process starts
+func LastConnected() time.Time {
+	lock.RLock()
+	defer lock.RUnlock()
+	if isCloudConnected {
+		return time.Now()
+	}
+	return lostAt
+}
+
 // IsConnected return isCloudConnected
 func IsConnected() bool {
 	lock.RLock()
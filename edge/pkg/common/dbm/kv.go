@@ -0,0 +1,181 @@
+package dbm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// DriverSqlite is the driver name of the sqlite database accessed by beego orm
+	DriverSqlite = "sqlite3"
+	// DriverBolt is the driver name of the embedded key-value store
+	DriverBolt = "bolt"
+)
+
+// KVAccess is the key-value store used instead of DBAccess when the driver is bolt
+var KVAccess KVStore
+
+// KVStore is the embedded key-value storage of the edge state, the records
+// of a table are saved in a bucket and identified by their primary keys
+type KVStore interface {
+	// View runs fn in a read-only transaction
+	View(fn func(tx KVTx) error) error
+	// Update runs fn in a read-write transaction, which is rolled back if fn returns error
+	Update(fn func(tx KVTx) error) error
+	// Snapshot writes a consistent copy of the store to the path
+	Snapshot(path string) error
+	Close() error
+}
+
+// KVTx is a transaction of the key-value store
+type KVTx interface {
+	// Get decodes the record of the key into out, it returns false if the record does not exist
+	Get(table, key string, out interface{}) (bool, error)
+	// Put encodes the record and saves it with the key
+	Put(table, key string, obj interface{}) error
+	Delete(table, key string) error
+	// ForEach calls fn for the records of the table in the order of key
+	ForEach(table string, fn func(key string, value []byte) error) error
+	// ForEachPrefix calls fn for the records whose keys start with the prefix in the order of key
+	ForEachPrefix(table, prefix string, fn func(key string, value []byte) error) error
+	// NextID returns the next auto increment id of the table
+	NextID(table string) (int64, error)
+	// ReserveID makes sure the ids returned by NextID are greater than id
+	ReserveID(table string, id int64) error
+}
+
+// UseKV checks whether the edge state is saved in the key-value store
+func UseKV() bool {
+	return KVAccess != nil
+}
+
+type boltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens the bolt file as the key-value store, the file is
+// locked until the store is closed
+func OpenBoltStore(path string) (KVStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt file %s: %v", path, err)
+	}
+	return &boltStore{db: db}, nil
+}
+
+func (s *boltStore) View(fn func(tx KVTx) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (s *boltStore) Update(fn func(tx KVTx) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (s *boltStore) Snapshot(path string) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(path, 0600)
+	})
+}
+
+func (s *boltStore) Close() error {
+	return s.db.Close()
+}
+
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+func (t *boltTx) bucket(table string) (*bolt.Bucket, error) {
+	if !t.tx.Writable() {
+		return t.tx.Bucket([]byte(table)), nil
+	}
+	return t.tx.CreateBucketIfNotExists([]byte(table))
+}
+
+func (t *boltTx) Get(table, key string, out interface{}) (bool, error) {
+	b, err := t.bucket(table)
+	if err != nil || b == nil {
+		return false, err
+	}
+	value := b.Get([]byte(key))
+	if value == nil {
+		return false, nil
+	}
+	if err := json.Unmarshal(value, out); err != nil {
+		return false, fmt.Errorf("failed to decode %s/%s: %v", table, key, err)
+	}
+	return true, nil
+}
+
+func (t *boltTx) Put(table, key string, obj interface{}) error {
+	b, err := t.bucket(table)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s/%s: %v", table, key, err)
+	}
+	return b.Put([]byte(key), value)
+}
+
+func (t *boltTx) Delete(table, key string) error {
+	b, err := t.bucket(table)
+	if err != nil || b == nil {
+		return err
+	}
+	return b.Delete([]byte(key))
+}
+
+func (t *boltTx) ForEach(table string, fn func(key string, value []byte) error) error {
+	b, err := t.bucket(table)
+	if err != nil || b == nil {
+		return err
+	}
+	return b.ForEach(func(k, v []byte) error {
+		return fn(string(k), v)
+	})
+}
+
+func (t *boltTx) ForEachPrefix(table, prefix string, fn func(key string, value []byte) error) error {
+	b, err := t.bucket(table)
+	if err != nil || b == nil {
+		return err
+	}
+	c := b.Cursor()
+	p := []byte(prefix)
+	for k, v := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v = c.Next() {
+		if err := fn(string(k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *boltTx) NextID(table string) (int64, error) {
+	b, err := t.bucket(table)
+	if err != nil {
+		return 0, err
+	}
+	id, err := b.NextSequence()
+	return int64(id), err
+}
+
+func (t *boltTx) ReserveID(table string, id int64) error {
+	b, err := t.bucket(table)
+	if err != nil {
+		return err
+	}
+	if uint64(id) > b.Sequence() {
+		return b.SetSequence(uint64(id))
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package dbm
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testRecord struct {
+	ID    int64  `orm:"column(id);size(64);auto;pk"`
+	Name  string `orm:"column(name);size(64)"`
+	Value string `orm:"column(value);null;type(text)"`
+}
+
+func TestKVStore(t *testing.T) {
+	store, err := OpenBoltStore(filepath.Join(t.TempDir(), "edgecore.bolt"))
+	if err != nil {
+		t.Fatalf("failed to open bolt store: %v", err)
+	}
+	defer store.Close()
+
+	err = store.Update(func(tx KVTx) error {
+		for _, name := range []string{"a", "b", "ab"} {
+			if err := KVInsert(tx, "record", &testRecord{Name: name, Value: name}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+
+	err = store.Update(func(tx KVTx) error {
+		return KVInsert(tx, "record", &testRecord{ID: 1, Name: "dup"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		t.Errorf("expected unique constraint error, got %v", err)
+	}
+
+	var records []testRecord
+	if err := store.View(func(tx KVTx) error {
+		return KVList(tx, "record", &records, Cond{Expr: "name__startswith", Value: "a"})
+	}); err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(records) != 2 || records[0].ID != 1 || records[1].ID != 3 {
+		t.Errorf("unexpected records %+v", records)
+	}
+
+	var updated, deleted int64
+	if err := store.Update(func(tx KVTx) error {
+		if updated, err = KVUpdate(tx, "record", new(testRecord), map[string]interface{}{"value": "c"}, Cond{Expr: "Name", Value: "b"}); err != nil {
+			return err
+		}
+		deleted, err = KVDelete(tx, "record", new(testRecord), Cond{Expr: "name", Value: "a"})
+		return err
+	}); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	if updated != 1 || deleted != 1 {
+		t.Errorf("expected 1 updated and 1 deleted, got %d and %d", updated, deleted)
+	}
+
+	records = nil
+	if err := store.View(func(tx KVTx) error {
+		return KVList(tx, "record", &records)
+	}); err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(records) != 2 || records[0].Value != "c" || records[1].Name != "ab" {
+		t.Errorf("unexpected records %+v", records)
+	}
+}
+
+type testKeyedRecord struct {
+	Key   string `orm:"column(key); size(256); pk"`
+	Value string `orm:"column(value); null; type(text)"`
+}
+
+func TestKVListByPrimaryKey(t *testing.T) {
+	store, err := OpenBoltStore(filepath.Join(t.TempDir(), "edgecore.bolt"))
+	if err != nil {
+		t.Fatalf("failed to open bolt store: %v", err)
+	}
+	defer store.Close()
+
+	err = store.Update(func(tx KVTx) error {
+		for _, key := range []string{"/a/1", "/a/2", "/ab/1", "/b/1"} {
+			if err := KVPut(tx, "keyed", &testKeyedRecord{Key: key, Value: key}); err != nil {
+				return err
+			}
+		}
+		return KVInsert(tx, "record", &testRecord{Name: "a"})
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		conds []Cond
+		want  []string
+	}{
+		{name: "exact key", conds: []Cond{{Expr: "key", Value: "/a/2"}}, want: []string{"/a/2"}},
+		{name: "missing key", conds: []Cond{{Expr: "Key", Value: "/c"}}, want: []string{}},
+		{name: "exact key not matching other conditions", conds: []Cond{{Expr: "key", Value: "/a/2"}, {Expr: "value", Value: "x"}}, want: []string{}},
+		{name: "key prefix", conds: []Cond{{Expr: "key__startswith", Value: "/a/"}}, want: []string{"/a/1", "/a/2"}},
+		{name: "prefix of keys", conds: []Cond{{Expr: "key__startswith", Value: "/a"}}, want: []string{"/a/1", "/a/2", "/ab/1"}},
+	}
+	for _, c := range cases {
+		var records []testKeyedRecord
+		if err := store.View(func(tx KVTx) error {
+			return KVList(tx, "keyed", &records, c.conds...)
+		}); err != nil {
+			t.Fatalf("%s: failed to list: %v", c.name, err)
+		}
+		keys := []string{}
+		for _, r := range records {
+			keys = append(keys, r.Key)
+		}
+		if !reflect.DeepEqual(keys, c.want) {
+			t.Errorf("%s: expected %v, but got %v", c.name, c.want, keys)
+		}
+	}
+
+	var records []testRecord
+	if err := store.View(func(tx KVTx) error {
+		return KVList(tx, "record", &records, Cond{Expr: "ID", Value: int64(1)})
+	}); err != nil || len(records) != 1 || records[0].Name != "a" {
+		t.Errorf("expected the record got by id, but got %+v, %v", records, err)
+	}
+}
@@ -0,0 +1,296 @@
+package dbm
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	bolt "go.etcd.io/bbolt"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+)
+
+// quotaPeriod is the period to delete the records beyond the quotas
+const quotaPeriod = 10 * time.Minute
+
+// QuotaFunc deletes the records of the resource type beyond the quota, the records
+// updated before the time are expired, it returns the number of deleted records
+type QuotaFunc func(resType string, maxCount int32, maxSize int64, before time.Time) (int64, error)
+
+var (
+	quotaFuncs []QuotaFunc
+	openFuncs  []func() error
+	// maintenanceLock serializes the snapshot, compaction and quota enforcement
+	maintenanceLock sync.Mutex
+)
+
+// RegisterQuotaFunc registers the function to enforce the quotas of the tables of a module
+func RegisterQuotaFunc(fn QuotaFunc) {
+	quotaFuncs = append(quotaFuncs, fn)
+}
+
+// RegisterOpenFunc registers the function run after the database is opened and before any module
+// starts, edgecore exits if the function fails
+func RegisterOpenFunc(fn func() error) {
+	openFuncs = append(openFuncs, fn)
+}
+
+// InitDB checks the integrity of the database, inits DB info and starts the background maintenance
+func InitDB(c *v1alpha2.DataBase) {
+	m := c.Maintenance
+	if m != nil && m.IntegrityCheck {
+		if err := checkAndRecover(c.DriverName, c.DataSource, m.SnapshotPath); err != nil {
+			klog.Exitf("Failed to recover the database: %v", err)
+		}
+	}
+	InitDBConfig(c.DriverName, c.AliasName, c.DataSource)
+	if !UseKV() && c.JournalMode != "" {
+		var mode string
+		if err := DBAccess.Raw("PRAGMA journal_mode = " + c.JournalMode).QueryRow(&mode); err != nil {
+			klog.Errorf("failed to set journal mode %s: %v", c.JournalMode, err)
+		} else {
+			klog.Infof("database journal mode is %s", mode)
+		}
+	}
+	for _, fn := range openFuncs {
+		if err := fn(); err != nil {
+			klog.Exitf("Failed to init the database: %v", err)
+		}
+	}
+
+	SetQuotas(c.Quotas)
+	if len(c.Quotas) > 0 {
+		quotas := c.Quotas
+		go wait.Forever(func() {
+			enforceQuotas(quotas)
+		}, quotaPeriod)
+	}
+	if m == nil {
+		return
+	}
+	if m.SnapshotPeriod > 0 && m.SnapshotPath != "" {
+		go wait.Forever(func() {
+			if err := snapshot(m.SnapshotPath); err != nil {
+				klog.Errorf("failed to take snapshot of the database: %v", err)
+			}
+		}, time.Duration(m.SnapshotPeriod)*time.Second)
+	}
+	if m.CompactionPeriod > 0 && !UseKV() {
+		go func() {
+			// the database is not compacted at startup to not delay the modules
+			ticker := time.NewTicker(time.Duration(m.CompactionPeriod) * time.Second)
+			defer ticker.Stop()
+			for range ticker.C {
+				if err := compact(); err != nil {
+					klog.Errorf("failed to compact the database: %v", err)
+				}
+			}
+		}()
+	}
+}
+
+// checkAndRecover moves the corrupted database aside and recovers it from the snapshot,
+// a new database is created if there is no valid snapshot. The other errors, like a locked
+// or inaccessible file, are returned since they are not fixed by dropping the data
+func checkAndRecover(driverName, dataSource, snapshotPath string) error {
+	if _, err := os.Stat(dataSource); os.IsNotExist(err) {
+		return nil
+	}
+	err := checkIntegrity(driverName, dataSource)
+	if err == nil {
+		return nil
+	}
+	if !isCorrupted(err) {
+		return fmt.Errorf("failed to check the integrity of database %s: %v", dataSource, err)
+	}
+	klog.Errorf("database %s is corrupted: %v", dataSource, err)
+
+	// the snapshot is checked before the database is moved aside,
+	// so that the database is kept if the snapshot can not be checked
+	recoverable := false
+	if snapshotPath == "" {
+		klog.Warningf("no snapshot is configured, a new database is created")
+	} else if _, err := os.Stat(snapshotPath); os.IsNotExist(err) {
+		klog.Warningf("no snapshot %s, a new database is created", snapshotPath)
+	} else if err := checkIntegrity(driverName, snapshotPath); err == nil {
+		recoverable = true
+	} else if isCorrupted(err) {
+		klog.Warningf("snapshot %s is corrupted: %v, a new database is created", snapshotPath, err)
+	} else {
+		return fmt.Errorf("failed to check the integrity of snapshot %s: %v", snapshotPath, err)
+	}
+
+	corrupted := fmt.Sprintf("%s.corrupted.%d", dataSource, time.Now().Unix())
+	// the journal files of sqlite belong to the corrupted database
+	for _, suffix := range []string{"", "-wal", "-shm", "-journal"} {
+		if err := os.Rename(dataSource+suffix, corrupted+suffix); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to move the corrupted database aside: %v", err)
+		}
+	}
+	klog.Warningf("corrupted database is moved to %s", corrupted)
+
+	if !recoverable {
+		return nil
+	}
+	if err := copyFile(snapshotPath, dataSource); err != nil {
+		return fmt.Errorf("failed to recover from snapshot %s: %v", snapshotPath, err)
+	}
+	klog.Warningf("database %s is recovered from snapshot %s", dataSource, snapshotPath)
+	return nil
+}
+
+// corruptionError indicates the content of the database file is corrupted
+type corruptionError struct {
+	err error
+}
+
+func (e *corruptionError) Error() string {
+	return e.err.Error()
+}
+
+func isCorrupted(err error) bool {
+	var corruption *corruptionError
+	return errors.As(err, &corruption)
+}
+
+// checkIntegrity checks the database file without registering it to beego orm,
+// it returns corruptionError if the content of the file is corrupted
+func checkIntegrity(driverName, path string) (err error) {
+	if driverName == DriverBolt {
+		// bolt may panic on the corrupted pages
+		defer func() {
+			if r := recover(); r != nil {
+				err = &corruptionError{err: fmt.Errorf("%v", r)}
+			}
+		}()
+		db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second, ReadOnly: true})
+		if err == bolt.ErrInvalid || err == bolt.ErrChecksum {
+			return &corruptionError{err: err}
+		}
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		return db.View(func(tx *bolt.Tx) error {
+			var checkErr error
+			// drain the channel to let the check finish before the transaction is closed
+			for err := range tx.Check() {
+				if checkErr == nil {
+					checkErr = &corruptionError{err: err}
+				}
+			}
+			return checkErr
+		})
+	}
+
+	db, err := sql.Open(DriverSqlite, path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	var result string
+	if err := db.QueryRow("PRAGMA quick_check").Scan(&result); err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrCorrupt || sqliteErr.Code == sqlite3.ErrNotADB) {
+			return &corruptionError{err: err}
+		}
+		return err
+	}
+	if result != "ok" {
+		return &corruptionError{err: fmt.Errorf("quick check: %s", result)}
+	}
+	return nil
+}
+
+// snapshot writes a consistent copy of the database to a temporary file and
+// replaces the snapshot with it, so that the last snapshot is kept if it fails
+func snapshot(snapshotPath string) error {
+	maintenanceLock.Lock()
+	defer maintenanceLock.Unlock()
+
+	tmp := snapshotPath + ".tmp"
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var err error
+	if UseKV() {
+		err = KVAccess.Snapshot(tmp)
+	} else {
+		_, err = DBAccess.Raw("VACUUM INTO ?", tmp).Exec()
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, snapshotPath); err != nil {
+		return err
+	}
+	klog.V(4).Infof("snapshot of the database is saved to %s", snapshotPath)
+	return nil
+}
+
+// compact checkpoints the write-ahead log and rebuilds the database file to release the free pages
+func compact() error {
+	maintenanceLock.Lock()
+	defer maintenanceLock.Unlock()
+
+	if _, err := DBAccess.Raw("PRAGMA wal_checkpoint(TRUNCATE)").Exec(); err != nil {
+		return err
+	}
+	if _, err := DBAccess.Raw("VACUUM").Exec(); err != nil {
+		return err
+	}
+	klog.Infof("database is compacted")
+	return nil
+}
+
+// enforceQuotas deletes the records beyond the quotas by the registered functions
+func enforceQuotas(quotas []v1alpha2.DataBaseQuota) {
+	maintenanceLock.Lock()
+	defer maintenanceLock.Unlock()
+
+	for _, q := range quotas {
+		var before time.Time
+		if q.Retention > 0 {
+			before = time.Now().Add(-time.Duration(q.Retention) * time.Second)
+		}
+		for _, fn := range quotaFuncs {
+			num, err := fn(q.ResourceType, q.MaxCount, q.MaxSize, before)
+			if err != nil {
+				klog.Errorf("failed to enforce the quota of %s: %v", q.ResourceType, err)
+				continue
+			}
+			if num > 0 {
+				klog.Infof("deleted %d records of %s beyond the quota", num, q.ResourceType)
+			}
+		}
+	}
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,124 @@
+package dbm
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckAndRecover(t *testing.T) {
+	dir := t.TempDir()
+	dataSource := filepath.Join(dir, "edgecore.db")
+	snapshotPath := filepath.Join(dir, "edgecore.db.snapshot")
+
+	db, err := sql.Open(DriverSqlite, dataSource)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	for _, stmt := range []string{
+		"CREATE TABLE meta (key TEXT PRIMARY KEY, value TEXT)",
+		"INSERT INTO meta VALUES ('key', 'value')",
+		"VACUUM INTO '" + snapshotPath + "'",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("failed to exec %s: %v", stmt, err)
+		}
+	}
+	db.Close()
+
+	if err := checkAndRecover(DriverSqlite, dataSource, snapshotPath); err != nil {
+		t.Fatalf("failed to check the valid database: %v", err)
+	}
+	if matches, _ := filepath.Glob(dataSource + ".corrupted.*"); len(matches) != 0 {
+		t.Fatalf("expected the valid database kept, but got %v", matches)
+	}
+
+	// corrupt the pages after the header
+	f, err := os.OpenFile(dataSource, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("failed to open database file: %v", err)
+	}
+	garbage := make([]byte, 4096)
+	for i := range garbage {
+		garbage[i] = 0xff
+	}
+	if _, err := f.WriteAt(garbage, 100); err != nil {
+		t.Fatalf("failed to corrupt database: %v", err)
+	}
+	f.Close()
+	if err := checkIntegrity(DriverSqlite, dataSource); err == nil {
+		t.Fatalf("expected the corrupted database detected")
+	}
+
+	if err := checkAndRecover(DriverSqlite, dataSource, snapshotPath); err != nil {
+		t.Fatalf("failed to recover the database: %v", err)
+	}
+	if matches, _ := filepath.Glob(dataSource + ".corrupted.*"); len(matches) != 1 {
+		t.Errorf("expected the corrupted database moved aside, but got %v", matches)
+	}
+	db, err = sql.Open(DriverSqlite, dataSource)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	var value string
+	if err := db.QueryRow("SELECT value FROM meta WHERE key = 'key'").Scan(&value); err != nil || value != "value" {
+		t.Errorf("expected the database recovered from snapshot, but got %s, %v", value, err)
+	}
+}
+
+func TestBoltSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "edgecore.bolt.snapshot")
+	store, err := OpenBoltStore(filepath.Join(dir, "edgecore.bolt"))
+	if err != nil {
+		t.Fatalf("failed to open bolt store: %v", err)
+	}
+	defer store.Close()
+	if err := store.Update(func(tx KVTx) error {
+		return tx.Put("meta", "key", "value")
+	}); err != nil {
+		t.Fatalf("failed to put: %v", err)
+	}
+	if err := store.Snapshot(snapshotPath); err != nil {
+		t.Fatalf("failed to take snapshot: %v", err)
+	}
+	if err := checkIntegrity(DriverBolt, snapshotPath); err != nil {
+		t.Errorf("expected valid snapshot, but got %v", err)
+	}
+}
+
+func TestCheckAndRecoverErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	// a file which is not a database is corrupted
+	dataSource := filepath.Join(dir, "edgecore.db")
+	if err := os.WriteFile(dataSource, []byte("not a database file, but long enough to have a header of sqlite"), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := checkIntegrity(DriverSqlite, dataSource); !isCorrupted(err) {
+		t.Errorf("expected corruption error, but got %v", err)
+	}
+	if err := checkAndRecover(DriverSqlite, dataSource, ""); err != nil {
+		t.Fatalf("failed to recover the database: %v", err)
+	}
+	if matches, _ := filepath.Glob(dataSource + ".corrupted.*"); len(matches) != 1 {
+		t.Errorf("expected the corrupted database moved aside, but got %v", matches)
+	}
+
+	// the database locked by others is not corrupted
+	path := filepath.Join(dir, "edgecore.bolt")
+	store, err := OpenBoltStore(path)
+	if err != nil {
+		t.Fatalf("failed to open bolt store: %v", err)
+	}
+	defer store.Close()
+	err = checkAndRecover(DriverBolt, path, "")
+	if err == nil || isCorrupted(err) {
+		t.Errorf("expected error checking the locked database, but got %v", err)
+	}
+	if matches, _ := filepath.Glob(path + ".corrupted.*"); len(matches) != 0 {
+		t.Errorf("expected the locked database kept, but got %v", matches)
+	}
+}
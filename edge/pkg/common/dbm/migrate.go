@@ -0,0 +1,119 @@
+package dbm
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/astaxie/beego/orm"
+	"k8s.io/klog/v2"
+)
+
+// migrateAlias is the beego orm alias of the sqlite database during migration,
+// beego orm requires the first database registered is named default
+const migrateAlias = "default"
+
+// Migrate copies the records of the registered models from the source database to
+// the target database, the drivers must be different and the target must not exist.
+// It must not be called when edgecore is running.
+func Migrate(fromDriver, fromSource, toDriver, toSource string) error {
+	if fromDriver == toDriver {
+		return fmt.Errorf("the source and target drivers are both %s", fromDriver)
+	}
+	for _, driver := range []string{fromDriver, toDriver} {
+		if driver != DriverSqlite && driver != DriverBolt {
+			return fmt.Errorf("unsupported driver %s", driver)
+		}
+	}
+	if _, err := os.Stat(fromSource); err != nil {
+		return fmt.Errorf("invalid source %s: %v", fromSource, err)
+	}
+	if _, err := os.Stat(toSource); !os.IsNotExist(err) {
+		return fmt.Errorf("the target %s must not exist", toSource)
+	}
+
+	var (
+		kv        KVStore
+		sqlite    orm.Ormer
+		err       error
+		kvSource  = toSource
+		sqlSource = fromSource
+	)
+	if fromDriver == DriverBolt {
+		kvSource, sqlSource = fromSource, toSource
+	}
+	if kv, err = OpenBoltStore(kvSource); err != nil {
+		return err
+	}
+	defer kv.Close()
+	if err = orm.RegisterDriver(DriverSqlite, orm.DRSqlite); err != nil {
+		return fmt.Errorf("failed to register driver: %v", err)
+	}
+	if err = orm.RegisterDataBase(migrateAlias, DriverSqlite, sqlSource); err != nil {
+		return fmt.Errorf("failed to register db: %v", err)
+	}
+	if toDriver == DriverSqlite {
+		if err = orm.RunSyncdb(migrateAlias, false, false); err != nil {
+			return fmt.Errorf("failed to create tables: %v", err)
+		}
+	}
+	sqlite = orm.NewOrm()
+	if err = sqlite.Using(migrateAlias); err != nil {
+		return err
+	}
+
+	for _, m := range RegisteredModels() {
+		records := reflect.New(reflect.SliceOf(reflect.TypeOf(m.Model).Elem()))
+		if fromDriver == DriverSqlite {
+			err = migrateToKV(sqlite, kv, m.Table, records)
+		} else {
+			err = migrateToSqlite(kv, sqlite, m.Table, records)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to migrate table %s: %v", m.Table, err)
+		}
+		klog.Infof("migrated %d records of table %s", records.Elem().Len(), m.Table)
+	}
+	return nil
+}
+
+func migrateToKV(from orm.Ormer, to KVStore, table string, records reflect.Value) error {
+	// all the records instead of the default limit 1000 of beego orm
+	if _, err := from.QueryTable(table).Limit(-1).All(records.Interface()); err != nil {
+		return err
+	}
+	return to.Update(func(tx KVTx) error {
+		slice := records.Elem()
+		for i := 0; i < slice.Len(); i++ {
+			if err := KVInsert(tx, table, slice.Index(i).Addr().Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func migrateToSqlite(from KVStore, to orm.Ormer, table string, records reflect.Value) (err error) {
+	if err = from.View(func(tx KVTx) error {
+		return KVList(tx, table, records.Interface())
+	}); err != nil {
+		return err
+	}
+	if err = to.Begin(); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			RollbackTransaction(to)
+		} else {
+			err = to.Commit()
+		}
+	}()
+	slice := records.Elem()
+	for i := 0; i < slice.Len(); i++ {
+		if _, err = to.Insert(slice.Index(i).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
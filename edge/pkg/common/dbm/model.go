@@ -0,0 +1,327 @@
+package dbm
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/astaxie/beego/orm"
+)
+
+// Model is a table of the edge database
+type Model struct {
+	Table string
+	// Model is the pointer to the struct of the records
+	Model interface{}
+}
+
+var (
+	models     []Model
+	modelsLock sync.Mutex
+)
+
+// RegisterModel registers the model to beego orm, and records the table
+// so that the records can be migrated between the storage backends
+func RegisterModel(table string, model interface{}) {
+	orm.RegisterModel(model)
+	modelsLock.Lock()
+	defer modelsLock.Unlock()
+	models = append(models, Model{Table: table, Model: model})
+}
+
+// RegisteredModels returns the models registered
+func RegisteredModels() []Model {
+	modelsLock.Lock()
+	defer modelsLock.Unlock()
+	return append([]Model{}, models...)
+}
+
+// Cond is a condition on a column of the records, the expression is the column
+// (or field) name with an optional operator suffix the same as beego orm:
+// "key" matches the value exactly and "key__startswith" matches the prefix
+type Cond struct {
+	Expr  string
+	Value interface{}
+}
+
+// IDKey returns the key of the record with an auto increment id,
+// which is padded so that the records are listed in the order of id
+func IDKey(id int64) string {
+	return fmt.Sprintf("%020d", id)
+}
+
+type modelField struct {
+	index  int
+	column string
+	pk     bool
+	auto   bool
+}
+
+func modelFields(t reflect.Type) []modelField {
+	var fields []modelField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		mf := modelField{index: i, column: strings.ToLower(f.Name)}
+		for _, tag := range strings.Split(f.Tag.Get("orm"), ";") {
+			tag = strings.TrimSpace(tag)
+			switch {
+			case strings.HasPrefix(tag, "column(") && strings.HasSuffix(tag, ")"):
+				mf.column = strings.TrimSuffix(strings.TrimPrefix(tag, "column("), ")")
+			case tag == "pk":
+				mf.pk = true
+			case tag == "auto":
+				mf.auto = true
+			}
+		}
+		fields = append(fields, mf)
+	}
+	return fields
+}
+
+// fieldByName returns the field by column name or field name, both are case insensitive as beego orm
+func fieldByName(v reflect.Value, name string) (reflect.Value, error) {
+	t := v.Type()
+	for _, f := range modelFields(t) {
+		if strings.EqualFold(f.column, name) || strings.EqualFold(t.Field(f.index).Name, name) {
+			return v.Field(f.index), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("unknown column %s of %s", name, t.Name())
+}
+
+func pkField(v reflect.Value) (reflect.Value, modelField, error) {
+	for _, f := range modelFields(v.Type()) {
+		if f.pk {
+			return v.Field(f.index), f, nil
+		}
+	}
+	return reflect.Value{}, modelField{}, fmt.Errorf("no primary key in %s", v.Type().Name())
+}
+
+// KVKey returns the key of the record in the key-value store, which is the primary key
+func KVKey(obj interface{}) (string, error) {
+	pk, _, err := pkField(reflect.Indirect(reflect.ValueOf(obj)))
+	if err != nil {
+		return "", err
+	}
+	switch pk.Kind() {
+	case reflect.String:
+		return pk.String(), nil
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return IDKey(pk.Int()), nil
+	default:
+		return "", fmt.Errorf("unsupported primary key type %v", pk.Type())
+	}
+}
+
+// Match checks whether the record meets all the conditions
+func Match(obj interface{}, conds ...Cond) (bool, error) {
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	for _, cond := range conds {
+		name, op := cond.Expr, ""
+		if i := strings.Index(name, "__"); i >= 0 {
+			name, op = name[:i], name[i+2:]
+		}
+		field, err := fieldByName(v, name)
+		if err != nil {
+			return false, err
+		}
+		actual, expected := fmt.Sprint(field.Interface()), fmt.Sprint(cond.Value)
+		switch op {
+		case "", "exact":
+			if actual != expected {
+				return false, nil
+			}
+		case "startswith":
+			if !strings.HasPrefix(actual, expected) {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported operator %s", op)
+		}
+	}
+	return true, nil
+}
+
+// SetColumns sets the columns of the record, the values are converted to the types of the fields
+func SetColumns(obj interface{}, cols map[string]interface{}) error {
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	for name, value := range cols {
+		field, err := fieldByName(v, name)
+		if err != nil {
+			return err
+		}
+		rv := reflect.ValueOf(value)
+		if !rv.IsValid() {
+			field.Set(reflect.Zero(field.Type()))
+			continue
+		}
+		if !rv.Type().ConvertibleTo(field.Type()) {
+			return fmt.Errorf("can not set column %s to %v", name, value)
+		}
+		field.Set(rv.Convert(field.Type()))
+	}
+	return nil
+}
+
+// KVInsert inserts the record, the auto increment primary key is set if it is zero.
+// The error of inserting an existing record is recognized as non unique error by dao
+func KVInsert(tx KVTx, table string, obj interface{}) error {
+	pk, f, err := pkField(reflect.Indirect(reflect.ValueOf(obj)))
+	if err != nil {
+		return err
+	}
+	if f.auto {
+		if pk.Int() == 0 {
+			id, err := tx.NextID(table)
+			if err != nil {
+				return err
+			}
+			pk.SetInt(id)
+		} else if err := tx.ReserveID(table, pk.Int()); err != nil {
+			return err
+		}
+	}
+	key, err := KVKey(obj)
+	if err != nil {
+		return err
+	}
+	exists, err := tx.Get(table, key, new(json.RawMessage))
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("UNIQUE constraint failed: %s.%s", table, f.column)
+	}
+	return tx.Put(table, key, obj)
+}
+
+// KVPut inserts or replaces the record
+func KVPut(tx KVTx, table string, obj interface{}) error {
+	key, err := KVKey(obj)
+	if err != nil {
+		return err
+	}
+	return tx.Put(table, key, obj)
+}
+
+// KVList decodes the records meeting the conditions into out, which is a pointer to a slice of model.
+// The record is got directly if the primary key is matched exactly, and only the records in the range
+// are decoded if the primary key is matched by prefix, otherwise all the records are scanned
+func KVList(tx KVTx, table string, out interface{}, conds ...Cond) error {
+	slice := reflect.ValueOf(out).Elem()
+	elemType := slice.Type().Elem()
+	slice.Set(reflect.MakeSlice(slice.Type(), 0, 0))
+	appendMatched := func(key string, value []byte) error {
+		obj := reflect.New(elemType)
+		if err := json.Unmarshal(value, obj.Interface()); err != nil {
+			return fmt.Errorf("failed to decode %s/%s: %v", table, key, err)
+		}
+		matched, err := Match(obj.Interface(), conds...)
+		if err != nil || !matched {
+			return err
+		}
+		slice.Set(reflect.Append(slice, obj.Elem()))
+		return nil
+	}
+
+	key, byPrefix, ok := pkCond(elemType, conds)
+	switch {
+	case !ok:
+		return tx.ForEach(table, appendMatched)
+	case byPrefix:
+		return tx.ForEachPrefix(table, key, appendMatched)
+	}
+	obj := reflect.New(elemType)
+	exists, err := tx.Get(table, key, obj.Interface())
+	if err != nil || !exists {
+		return err
+	}
+	matched, err := Match(obj.Interface(), conds...)
+	if err != nil || !matched {
+		return err
+	}
+	slice.Set(reflect.Append(slice, obj.Elem()))
+	return nil
+}
+
+// pkCond returns the key or the key prefix in the key-value store if any condition is on the primary key
+func pkCond(t reflect.Type, conds []Cond) (key string, byPrefix bool, ok bool) {
+	var pk modelField
+	found := false
+	for _, f := range modelFields(t) {
+		if f.pk {
+			pk, found = f, true
+			break
+		}
+	}
+	if !found {
+		return "", false, false
+	}
+	pkKind := t.Field(pk.index).Type.Kind()
+	for _, cond := range conds {
+		name, op := cond.Expr, ""
+		if i := strings.Index(name, "__"); i >= 0 {
+			name, op = name[:i], name[i+2:]
+		}
+		if !strings.EqualFold(pk.column, name) && !strings.EqualFold(t.Field(pk.index).Name, name) {
+			continue
+		}
+		switch {
+		case pkKind == reflect.String && (op == "" || op == "exact"):
+			return fmt.Sprint(cond.Value), false, true
+		case pkKind == reflect.String && op == "startswith":
+			return fmt.Sprint(cond.Value), true, true
+		case op == "" || op == "exact":
+			// the auto increment ids are saved by the padded keys
+			if id, err := strconv.ParseInt(fmt.Sprint(cond.Value), 10, 64); err == nil {
+				return IDKey(id), false, true
+			}
+		}
+	}
+	return "", false, false
+}
+
+// KVUpdate sets the columns of the records meeting the conditions, model is a pointer to the struct of the records
+func KVUpdate(tx KVTx, table string, model interface{}, cols map[string]interface{}, conds ...Cond) (int64, error) {
+	records := reflect.New(reflect.SliceOf(reflect.TypeOf(model).Elem()))
+	if err := KVList(tx, table, records.Interface(), conds...); err != nil {
+		return 0, err
+	}
+	slice := records.Elem()
+	for i := 0; i < slice.Len(); i++ {
+		obj := slice.Index(i).Addr().Interface()
+		if err := SetColumns(obj, cols); err != nil {
+			return 0, err
+		}
+		if err := KVPut(tx, table, obj); err != nil {
+			return 0, err
+		}
+	}
+	return int64(slice.Len()), nil
+}
+
+// KVDelete deletes the records meeting the conditions, model is a pointer to the struct of the records
+func KVDelete(tx KVTx, table string, model interface{}, conds ...Cond) (int64, error) {
+	records := reflect.New(reflect.SliceOf(reflect.TypeOf(model).Elem()))
+	if err := KVList(tx, table, records.Interface(), conds...); err != nil {
+		return 0, err
+	}
+	slice := records.Elem()
+	for i := 0; i < slice.Len(); i++ {
+		key, err := KVKey(slice.Index(i).Addr().Interface())
+		if err != nil {
+			return 0, err
+		}
+		if err := tx.Delete(table, key); err != nil {
+			return 0, err
+		}
+	}
+	return int64(slice.Len()), nil
+}
@@ -0,0 +1,90 @@
+package dbm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+)
+
+// ErrQuotaExceeded is returned when the new records are refused beyond the quota
+var ErrQuotaExceeded = errors.New("database quota exceeded")
+
+var (
+	quotaLock   sync.RWMutex
+	quotaLimits map[string]v1alpha2.DataBaseQuota
+)
+
+// SetQuotas sets the quotas checked by CheckQuota
+func SetQuotas(qs []v1alpha2.DataBaseQuota) {
+	m := make(map[string]v1alpha2.DataBaseQuota, len(qs))
+	for _, q := range qs {
+		m[q.ResourceType] = q
+	}
+	quotaLock.Lock()
+	defer quotaLock.Unlock()
+	quotaLimits = m
+}
+
+// CheckQuota checks the records of the resource type before new records are added. It is used
+// by the resources which can not be rebuilt from the cloud, so the new records are refused
+// instead of deleting the existing ones. stat returns the number and the total size of the
+// records after the new records are added, it is only called if the resource type has a quota.
+func CheckQuota(resType string, stat func() (int64, int64, error)) error {
+	quotaLock.RLock()
+	q, ok := quotaLimits[resType]
+	quotaLock.RUnlock()
+	if !ok || (q.MaxCount <= 0 && q.MaxSize <= 0) {
+		return nil
+	}
+	count, size, err := stat()
+	if err != nil {
+		return err
+	}
+	if q.MaxCount > 0 && count > int64(q.MaxCount) {
+		return fmt.Errorf("%w: %d records of %s beyond the max count %d", ErrQuotaExceeded, count, resType, q.MaxCount)
+	}
+	if q.MaxSize > 0 && size > q.MaxSize {
+		return fmt.Errorf("%w: %d bytes of %s beyond the max size %d", ErrQuotaExceeded, size, resType, q.MaxSize)
+	}
+	return nil
+}
+
+// RecordStat is the update time and value size of a record checked against the quota
+type RecordStat struct {
+	Key string
+	// UpdateTime is the unix time of the last update, the records without it never expire
+	UpdateTime int64
+	Size       int64
+}
+
+// ExceededRecords returns the keys of the records beyond the quota, the least recently updated
+// records are returned first to be deleted. The records with the same update time are kept
+// in the given order, so the records listed first are kept first.
+func ExceededRecords(stats []RecordStat, maxCount int32, maxSize int64, before time.Time) []string {
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].UpdateTime > stats[j].UpdateTime
+	})
+	var (
+		keys     []string
+		count    int32
+		size     int64
+		exceeded bool
+	)
+	for _, stat := range stats {
+		if !exceeded {
+			exceeded = (maxCount > 0 && count >= maxCount) || (maxSize > 0 && size+stat.Size > maxSize)
+		}
+		expired := !before.IsZero() && stat.UpdateTime != 0 && stat.UpdateTime < before.Unix()
+		if exceeded || expired {
+			keys = append(keys, stat.Key)
+			continue
+		}
+		count++
+		size += stat.Size
+	}
+	return keys
+}
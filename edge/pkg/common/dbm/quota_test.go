@@ -0,0 +1,66 @@
+package dbm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+)
+
+func TestExceededRecords(t *testing.T) {
+	now := time.Now()
+	stats := func() []RecordStat {
+		return []RecordStat{
+			{Key: "old", UpdateTime: now.Add(-2 * time.Hour).Unix(), Size: 10},
+			{Key: "new", UpdateTime: now.Unix(), Size: 10},
+			{Key: "legacy", Size: 10},
+			{Key: "recent", UpdateTime: now.Add(-time.Minute).Unix(), Size: 30},
+		}
+	}
+	cases := []struct {
+		name     string
+		maxCount int32
+		maxSize  int64
+		before   time.Time
+		want     []string
+	}{
+		{name: "no quota"},
+		{name: "max count", maxCount: 2, want: []string{"old", "legacy"}},
+		{name: "max size", maxSize: 45, want: []string{"old", "legacy"}},
+		{name: "retention", before: now.Add(-time.Hour), want: []string{"old"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ExceededRecords(stats(), c.maxCount, c.maxSize, c.before); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("expected %v, but got %v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	defer SetQuotas(nil)
+	SetQuotas([]v1alpha2.DataBaseQuota{{ResourceType: "sub_topics", MaxCount: 2, MaxSize: 100}})
+
+	called := false
+	stat := func(count, size int64) func() (int64, int64, error) {
+		return func() (int64, int64, error) {
+			called = true
+			return count, size, nil
+		}
+	}
+	if err := CheckQuota("device_twin", stat(10, 1000)); err != nil || called {
+		t.Errorf("expected resource type without quota not checked, but got %v", err)
+	}
+	if err := CheckQuota("sub_topics", stat(2, 100)); err != nil {
+		t.Errorf("expected records within the quota, but got %v", err)
+	}
+	if err := CheckQuota("sub_topics", stat(3, 10)); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("expected records beyond the max count refused, but got %v", err)
+	}
+	if err := CheckQuota("sub_topics", stat(1, 101)); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("expected records beyond the max size refused, but got %v", err)
+	}
+}
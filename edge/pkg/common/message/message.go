@@ -19,11 +19,13 @@ const (
 	OperationGetResult         = "get_result"
 	OperationResponse          = "response"
 	OperationKeepalive         = "keepalive"
+	OperationSessionToken      = "sessiontoken"
 
 	ResourceGroupName = "resource"
 	TwinGroupName     = "twin"
 	FuncGroupName     = "func"
 	UserGroupName     = "user"
+	HubGroupName      = "hub"
 
 	ResourceNode = "node"
 )
@@ -17,6 +17,7 @@ limitations under the License.
 package dmiclient
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"sync"
@@ -24,9 +25,14 @@ import (
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 	"k8s.io/klog/v2"
 
 	deviceconst "github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/constants"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/config"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
 	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
 	dmiapi "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
@@ -56,11 +62,23 @@ func init() {
 }
 
 func (dc *DMIClient) connect() error {
-	dialer := func(addr string, t time.Duration) (net.Conn, error) {
-		return net.Dial(deviceconst.UnixNetworkType, addr)
-	}
-
-	conn, err := grpc.Dial(dc.socket, grpc.WithInsecure(), grpc.WithDialer(dialer))
+	network, address := dtcommon.ParseDMIAddress(dc.socket)
+	var opts []grpc.DialOption
+	if network == dtcommon.TCPNetworkType {
+		// the mappers on other hosts are called with mutual TLS
+		tlsConfig, err := dmiTLSConfig()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		dialer := func(addr string, t time.Duration) (net.Conn, error) {
+			return net.Dial(deviceconst.UnixNetworkType, addr)
+		}
+		opts = append(opts, grpc.WithInsecure(), grpc.WithDialer(dialer))
+	}
+
+	conn, err := grpc.Dial(address, opts...)
 	if err != nil {
 		klog.Errorf("did not connect: %v\n", err)
 		return err
@@ -77,6 +95,16 @@ func (dc *DMIClient) connect() error {
 	return nil
 }
 
+// dmiTLSConfig returns the TLS config of DMI over TCP, the mappers of tcp:// addresses
+// can only be called when DMI over TCP is enabled
+func dmiTLSConfig() (*tls.Config, error) {
+	dmi := config.Get().DMI
+	if dmi == nil || dmi.TCP == nil || !dmi.TCP.Enable {
+		return nil, fmt.Errorf("DMI over TCP is not enabled")
+	}
+	return dtcommon.DMITLSConfig(dmi.TCP)
+}
+
 func (dc *DMIClient) close() {
 	dc.Conn.Close()
 	dc.CancelFunc()
@@ -87,6 +115,7 @@ func createDeviceRequest(device *v1alpha2.Device) (*dmiapi.RegisterDeviceRequest
 	if err != nil {
 		return nil, err
 	}
+	setDesiredVersions(d, device)
 
 	return &dmiapi.RegisterDeviceRequest{
 		Device: d,
@@ -104,6 +133,7 @@ func updateDeviceRequest(device *v1alpha2.Device) (*dmiapi.UpdateDeviceRequest,
 	if err != nil {
 		return nil, err
 	}
+	setDesiredVersions(d, device)
 
 	return &dmiapi.UpdateDeviceRequest{
 		Device: d,
@@ -138,6 +168,48 @@ func removeDeviceModelRequest(deviceModelName string) (*dmiapi.RemoveDeviceModel
 	}, nil
 }
 
+func invokeDeviceCommandRequest(deviceName, command string, parameters map[string]string, timeout time.Duration) *dmiapi.InvokeDeviceCommandRequest {
+	return &dmiapi.InvokeDeviceCommandRequest{
+		DeviceName:  deviceName,
+		CommandName: command,
+		Parameters:  parameters,
+		Timeout:     int64(timeout / time.Second),
+	}
+}
+
+// setDesiredVersions sets the desired versions of the twins of the converted device to the versions of the device twins
+func setDesiredVersions(d *dmiapi.Device, device *v1alpha2.Device) {
+	versions := make(map[string]*v1alpha2.TwinVersion, len(device.Status.Twins))
+	for _, twin := range device.Status.Twins {
+		versions[twin.PropertyName] = twin.DesiredVersion
+	}
+	for _, twin := range d.GetStatus().GetTwins() {
+		twin.DesiredVersion = desiredVersion(versions[twin.PropertyName])
+	}
+}
+
+func desiredVersion(version *v1alpha2.TwinVersion) *dmiapi.TwinVersion {
+	if version == nil {
+		return nil
+	}
+	return &dmiapi.TwinVersion{Cloud: version.CloudVersion, Edge: version.EdgeVersion}
+}
+
+func updateDeviceStatusRequest(deviceName string, desired map[string]string, versions map[string]*v1alpha2.TwinVersion) *dmiapi.UpdateDeviceStatusRequest {
+	twins := make([]*dmiapi.Twin, 0, len(desired))
+	for property, value := range desired {
+		twins = append(twins, &dmiapi.Twin{
+			PropertyName:   property,
+			Desired:        &dmiapi.TwinProperty{Value: value},
+			DesiredVersion: desiredVersion(versions[property]),
+		})
+	}
+	return &dmiapi.UpdateDeviceStatusRequest{
+		DeviceName:    deviceName,
+		DesiredDevice: &dmiapi.DeviceStatus{Twins: twins},
+	}
+}
+
 func (dcs *DMIClients) getDMIClientByProtocol(protocol string) (*DMIClient, error) {
 	dcs.mutex.Lock()
 	defer dcs.mutex.Unlock()
@@ -310,3 +382,66 @@ func (dcs *DMIClients) UpdateDeviceModel(model *v1alpha2.DeviceModel) error {
 	}
 	return nil
 }
+
+// UpdateDeviceStatus sends the desired values of the device properties and their versions to the mapper of its protocol
+func (dcs *DMIClients) UpdateDeviceStatus(device *v1alpha2.Device, desired map[string]string, versions map[string]*v1alpha2.TwinVersion) error {
+	protocol, err := dtcommon.GetProtocolNameOfDevice(device)
+	if err != nil {
+		return err
+	}
+
+	dc, err := dcs.getDMIClientConn(protocol)
+	if err != nil {
+		return err
+	}
+
+	defer dc.close()
+
+	_, err = dc.Client.UpdateDeviceStatus(dc.Ctx, updateDeviceStatusRequest(device.Name, desired, versions))
+	return err
+}
+
+// InvokeDeviceCommand invokes the command on the device through the mapper of its protocol,
+// the invocation is canceled if the mapper does not return before the timeout
+func (dcs *DMIClients) InvokeDeviceCommand(device *v1alpha2.Device, command string, parameters map[string]string, timeout time.Duration) (*dmiapi.InvokeDeviceCommandResponse, error) {
+	protocol, err := dtcommon.GetProtocolNameOfDevice(device)
+	if err != nil {
+		return nil, err
+	}
+
+	dc, err := dcs.getDMIClientConn(protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	defer dc.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return dc.Client.InvokeDeviceCommand(ctx, invokeDeviceCommandRequest(device.Name, command, parameters, timeout))
+}
+
+// CheckMapperHealth checks the mapper of the protocol through the standard grpc health service,
+// a mapper which is reachable but does not implement the health service is considered healthy
+func (dcs *DMIClients) CheckMapperHealth(protocol string, timeout time.Duration) error {
+	dc, err := dcs.getDMIClientConn(protocol)
+	if err != nil {
+		return err
+	}
+
+	defer dc.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	resp, err := healthpb.NewHealthClient(dc.Conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return err
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("mapper of protocol %s is %s", protocol, resp.GetStatus())
+	}
+	return nil
+}
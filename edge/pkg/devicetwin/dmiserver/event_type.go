@@ -24,6 +24,8 @@ import (
 
 const (
 	PropertyType = "type"
+	// PropertyTimestamp is the metadata of the time in unix milliseconds when the value is collected
+	PropertyTimestamp = "timestamp"
 )
 
 // DeviceTwinUpdate the structure of device twin update.
@@ -32,6 +34,12 @@ type DeviceTwinUpdate struct {
 	Twin map[string]*types.MsgTwin `json:"twin"`
 }
 
+// DeviceStateUpdate the structure of device state update.
+type DeviceStateUpdate struct {
+	types.BaseMessage
+	State string `json:"state"`
+}
+
 // getTimestamp get current timestamp.
 func getTimestamp() int64 {
 	return time.Now().UnixNano() / 1e6
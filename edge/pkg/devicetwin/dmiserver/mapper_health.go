@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dmiserver
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dmiclient"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	pb "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
+)
+
+const (
+	// MapperHealthCheckInterval is the interval between two health checks of a mapper
+	MapperHealthCheckInterval = 10 * time.Second
+	// MapperHealthCheckTimeout is the timeout of a single health check
+	MapperHealthCheckTimeout = 3 * time.Second
+	// MapperFailureThreshold is the number of consecutive failed health checks
+	// after which the mapper and its devices are marked offline
+	MapperFailureThreshold = 3
+	// MapperRestoreTimeout is the time to wait for a re-registered mapper to serve
+	// before its devices and device models are sent again
+	MapperRestoreTimeout = 30 * time.Second
+)
+
+// healthCheckFunc checks the mapper of the protocol, it is replaced in tests
+var healthCheckFunc = dmiclient.DMIClientsImp.CheckMapperHealth
+
+type mapperHealthChecker struct {
+	dmiCache *DMICache
+	// failures is the number of consecutive failed health checks, key is mapper name
+	failures map[string]int
+}
+
+// StartMapperHealthCheck checks the registered mappers periodically, a mapper which fails
+// MapperFailureThreshold checks in a row is marked Offline together with all its devices
+func StartMapperHealthCheck(cache *DMICache) {
+	checker := &mapperHealthChecker{
+		dmiCache: cache,
+		failures: make(map[string]int),
+	}
+	wait.Until(checker.check, MapperHealthCheckInterval, beehiveContext.Done())
+}
+
+func (c *mapperHealthChecker) check() {
+	c.dmiCache.MapperMu.Lock()
+	mappers := make([]*pb.MapperInfo, 0, len(c.dmiCache.MapperList))
+	for _, mapper := range c.dmiCache.MapperList {
+		mappers = append(mappers, mapper)
+	}
+	c.dmiCache.MapperMu.Unlock()
+
+	for _, mapper := range mappers {
+		err := healthCheckFunc(mapper.Protocol, MapperHealthCheckTimeout)
+		if err == nil {
+			c.failures[mapper.Name] = 0
+			if setMapperState(c.dmiCache, mapper, dtcommon.MapperStateOnline) {
+				klog.Infof("mapper %s is online again", mapper.Name)
+				markDevicesState(c.dmiCache, mapper.Protocol, dtcommon.DeviceStateUnknown)
+			}
+			continue
+		}
+
+		c.failures[mapper.Name]++
+		klog.V(4).Infof("health check of mapper %s failed %d times with err: %v", mapper.Name, c.failures[mapper.Name], err)
+		if c.failures[mapper.Name] < MapperFailureThreshold {
+			continue
+		}
+		if setMapperState(c.dmiCache, mapper, dtcommon.MapperStateOffline) {
+			klog.Warningf("mapper %s is offline, last health check failed with err: %v", mapper.Name, err)
+			markDevicesState(c.dmiCache, mapper.Protocol, dtcommon.DeviceStateOffline)
+		}
+	}
+}
+
+// setMapperState updates the state of the mapper unless it is re-registered in the meantime,
+// and returns whether the state is changed
+func setMapperState(cache *DMICache, mapper *pb.MapperInfo, state string) bool {
+	cache.MapperMu.Lock()
+	defer cache.MapperMu.Unlock()
+	if cache.MapperList[mapper.Name] != mapper || mapper.State == state {
+		return false
+	}
+	mapper.State = state
+	if err := saveMapper(mapper, cache.MapperIdentities[mapper.Name]); err != nil {
+		klog.Errorf("fail to save state of mapper %s to db with err: %v", mapper.Name, err)
+	}
+	return true
+}
+
+// markDevicesState updates the state of all devices handled by the mapper of the protocol
+func markDevicesState(cache *DMICache, protocol, state string) {
+	for _, device := range devicesOfProtocol(cache, protocol) {
+		if err := handleDeviceState(device.Name, state); err != nil {
+			klog.Errorf("fail to mark device %s %s with err: %v", device.Name, state, err)
+		}
+	}
+}
+
+func devicesOfProtocol(cache *DMICache, protocol string) []*v1alpha2.Device {
+	cache.DeviceMu.Lock()
+	defer cache.DeviceMu.Unlock()
+	var devices []*v1alpha2.Device
+	for _, device := range cache.DeviceList {
+		p, err := dtcommon.GetProtocolNameOfDevice(device)
+		if err != nil {
+			continue
+		}
+		if p == protocol {
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
+
+func deviceModelsOfProtocol(cache *DMICache, protocol string) []*v1alpha2.DeviceModel {
+	cache.DeviceModelMu.Lock()
+	defer cache.DeviceModelMu.Unlock()
+	var models []*v1alpha2.DeviceModel
+	for _, model := range cache.DeviceModelList {
+		if model.Spec.Protocol == protocol {
+			models = append(models, model)
+		}
+	}
+	return models
+}
+
+// restoreMapper sends the device models and the devices of the protocol again to a re-registered
+// mapper, which may have lost them when it restarted
+func restoreMapper(cache *DMICache, protocol string) {
+	err := wait.PollImmediate(time.Second, MapperRestoreTimeout, func() (bool, error) {
+		return healthCheckFunc(protocol, MapperHealthCheckTimeout) == nil, nil
+	})
+	if err != nil {
+		klog.Errorf("fail to restore mapper of protocol %s, it does not serve in %v", protocol, MapperRestoreTimeout)
+		return
+	}
+
+	for _, model := range deviceModelsOfProtocol(cache, protocol) {
+		if err := dmiclient.DMIClientsImp.CreateDeviceModel(model); err != nil {
+			klog.Errorf("fail to create device model %s in mapper of protocol %s with err: %v", model.Name, protocol, err)
+		}
+	}
+	for _, device := range devicesOfProtocol(cache, protocol) {
+		if err := dmiclient.DMIClientsImp.RegisterDevice(device); err != nil {
+			klog.Errorf("fail to register device %s in mapper of protocol %s with err: %v", device.Name, protocol, err)
+		}
+	}
+	klog.Infof("success to restore devices and device models of mapper of protocol %s", protocol)
+}
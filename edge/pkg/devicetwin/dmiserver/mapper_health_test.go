@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dmiserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubeedge/beehive/pkg/common"
+	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/modules"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	pb "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
+)
+
+func TestMapperHealthCheck(t *testing.T) {
+	store, err := dbm.OpenBoltStore(filepath.Join(t.TempDir(), "edgecore.bolt"))
+	if err != nil {
+		t.Fatalf("failed to open bolt store: %v", err)
+	}
+	dbm.KVAccess = store
+	defer func() {
+		dbm.KVAccess = nil
+		store.Close()
+	}()
+
+	beehiveContext.InitContext([]string{common.MsgCtxTypeChannel})
+	beehiveContext.AddModule(&common.ModuleInfo{ModuleName: modules.TwinGroup, ModuleType: common.MsgCtxTypeChannel})
+	beehiveContext.AddModuleGroup(modules.TwinGroup, modules.TwinGroup)
+
+	healthy := true
+	healthCheckFunc = func(protocol string, timeout time.Duration) error {
+		if healthy {
+			return nil
+		}
+		return errors.New("connection refused")
+	}
+
+	mapper := &pb.MapperInfo{Name: "modbus-mapper", Protocol: "modbus", State: dtcommon.MapperStateOnline}
+	cache := &DMICache{
+		MapperMu:        &sync.Mutex{},
+		DeviceMu:        &sync.Mutex{},
+		DeviceModelMu:   &sync.Mutex{},
+		MapperList:      map[string]*pb.MapperInfo{mapper.Name: mapper},
+		DeviceModelList: map[string]*v1alpha2.DeviceModel{},
+		DeviceList: map[string]*v1alpha2.Device{
+			"sensor": {Spec: v1alpha2.DeviceSpec{Protocol: v1alpha2.ProtocolConfig{Modbus: &v1alpha2.ProtocolConfigModbus{}}}},
+			"camera": {Spec: v1alpha2.DeviceSpec{Protocol: v1alpha2.ProtocolConfig{OpcUA: &v1alpha2.ProtocolConfigOpcUA{}}}},
+		},
+	}
+	cache.DeviceList["sensor"].Name = "sensor"
+	cache.DeviceList["camera"].Name = "camera"
+	checker := &mapperHealthChecker{dmiCache: cache, failures: make(map[string]int)}
+
+	healthy = false
+	for i := 1; i < MapperFailureThreshold; i++ {
+		checker.check()
+		if mapper.State != dtcommon.MapperStateOnline {
+			t.Fatalf("mapper is marked %s after %d failed checks", mapper.State, i)
+		}
+	}
+	checker.check()
+	if mapper.State != dtcommon.MapperStateOffline {
+		t.Fatalf("mapper is marked %s after %d failed checks, want %s", mapper.State, MapperFailureThreshold, dtcommon.MapperStateOffline)
+	}
+	expectDeviceState(t, "sensor", dtcommon.DeviceStateOffline)
+
+	healthy = true
+	checker.check()
+	if mapper.State != dtcommon.MapperStateOnline {
+		t.Fatalf("mapper is marked %s after it recovers, want %s", mapper.State, dtcommon.MapperStateOnline)
+	}
+	expectDeviceState(t, "sensor", dtcommon.DeviceStateUnknown)
+}
+
+func expectDeviceState(t *testing.T, deviceName, state string) {
+	msg, err := beehiveContext.Receive(modules.TwinGroup)
+	if err != nil {
+		t.Fatalf("failed to receive the state of device %s: %v", deviceName, err)
+	}
+	topic, err := base64.URLEncoding.DecodeString(msg.GetResource())
+	if err != nil {
+		t.Fatalf("failed to decode the topic: %v", err)
+	}
+	if want := dtcommon.DeviceETPrefix + deviceName + dtcommon.DeviceETStateUpdateSuffix; string(topic) != want {
+		t.Fatalf("got topic %s, want %s", topic, want)
+	}
+	var update DeviceStateUpdate
+	if err := json.Unmarshal([]byte(msg.GetContent().(string)), &update); err != nil {
+		t.Fatalf("failed to unmarshal the state update: %v", err)
+	}
+	if update.State != state {
+		t.Errorf("got state %s of device %s, want %s", update.State, deviceName, state)
+	}
+}
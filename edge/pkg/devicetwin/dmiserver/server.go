@@ -17,17 +17,21 @@ limitations under the License.
 package dmiserver
 
 import (
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 	"sync"
 	"time"
 
 	"golang.org/x/net/context"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/reflection"
 	"k8s.io/klog/v2"
 
@@ -38,17 +42,19 @@ import (
 	"github.com/kubeedge/kubeedge/common/constants"
 	messagepkg "github.com/kubeedge/kubeedge/edge/pkg/common/message"
 	"github.com/kubeedge/kubeedge/edge/pkg/common/modules"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/config"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dmiclient"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtseries"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
+	edgecore "github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
 	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
 	pb "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
 )
 
 const (
-	SockPath = "/etc/kubeedge/dmi.sock"
-	Limit    = 1000
-	Burst    = 100
+	Limit = 1000
+	Burst = 100
 )
 
 type server struct {
@@ -56,6 +62,12 @@ type server struct {
 	dmiCache *DMICache
 }
 
+// SavedMapper is the mapper info saved in the db, with the identity of the mapper registered over TCP
+type SavedMapper struct {
+	*pb.MapperInfo
+	Identity string `json:"identity,omitempty"`
+}
+
 type DMICache struct {
 	MapperMu        *sync.Mutex
 	DeviceMu        *sync.Mutex
@@ -63,6 +75,11 @@ type DMICache struct {
 	MapperList      map[string]*pb.MapperInfo
 	DeviceModelList map[string]*v1alpha2.DeviceModel
 	DeviceList      map[string]*v1alpha2.Device
+	// MapperIdentities keeps the identities of the mappers registered over TCP, keyed by the mapper name,
+	// it is guarded by MapperMu
+	MapperIdentities map[string]string
+	// Series keeps the history of the reported values, it is nil when the time-series buffer is disabled
+	Series *dtseries.Store
 }
 
 func (s *server) MapperRegister(ctx context.Context, in *pb.MapperRegisterRequest) (*pb.MapperRegisterResponse, error) {
@@ -75,17 +92,41 @@ func (s *server) MapperRegister(ctx context.Context, in *pb.MapperRegisterReques
 		return nil, fmt.Errorf("fail to register mapper %s because the protocol is nil", in.Mapper.Name)
 	}
 
-	klog.V(4).Infof("receive mapper register: %+v", in.Mapper)
-	err := saveMapper(in.Mapper)
+	identity, err := mapperIdentity(ctx, in.Mapper.Protocol)
 	if err != nil {
-		klog.Errorf("fail to save mapper %s to db with err: %v", in.Mapper.Name, err)
+		klog.Errorf("fail to register mapper %s with err: %v", in.Mapper.Name, err)
 		return nil, err
 	}
+
+	klog.V(4).Infof("receive mapper register: %+v", in.Mapper)
+	in.Mapper.State = dtcommon.MapperStateOnline
 	s.dmiCache.MapperMu.Lock()
+	if err := checkMapperOwner(s.dmiCache, in.Mapper, identity); err != nil {
+		s.dmiCache.MapperMu.Unlock()
+		klog.Errorf("fail to register mapper %s with err: %v", in.Mapper.Name, err)
+		return nil, err
+	}
+	if err := saveMapper(in.Mapper, identity); err != nil {
+		s.dmiCache.MapperMu.Unlock()
+		klog.Errorf("fail to save mapper %s to db with err: %v", in.Mapper.Name, err)
+		return nil, err
+	}
+	registered, ok := s.dmiCache.MapperList[in.Mapper.Name]
 	s.dmiCache.MapperList[in.Mapper.Name] = in.Mapper
+	s.dmiCache.MapperIdentities[in.Mapper.Name] = identity
 	s.dmiCache.MapperMu.Unlock()
+	dmiclient.DMIClientsImp.CreateDMIClient(in.Mapper.Protocol, string(in.Mapper.Address))
+
+	if ok && registered.State == dtcommon.MapperStateOffline {
+		klog.Infof("mapper %s is online again", in.Mapper.Name)
+		markDevicesState(s.dmiCache, in.Mapper.Protocol, dtcommon.DeviceStateUnknown)
+	}
 
 	if !in.WithData {
+		if ok {
+			// the mapper is re-registered, e.g. it restarted and lost its devices
+			go restoreMapper(s.dmiCache, in.Mapper.Protocol)
+		}
 		return &pb.MapperRegisterResponse{}, nil
 	}
 
@@ -123,7 +164,6 @@ func (s *server) MapperRegister(ctx context.Context, in *pb.MapperRegisterReques
 			deviceModelList = append(deviceModelList, dm)
 		}
 	}
-	dmiclient.DMIClientsImp.CreateDMIClient(in.Mapper.Protocol, string(in.Mapper.Address))
 
 	return &pb.MapperRegisterResponse{
 		DeviceList: deviceList,
@@ -131,6 +171,62 @@ func (s *server) MapperRegister(ctx context.Context, in *pb.MapperRegisterReques
 	}, nil
 }
 
+// mapperIdentity returns the identity of the mapper registering over TCP, which is the common name of
+// its verified client certificate, or the protocol if the common name is empty. The protocol must be
+// the common name or one of the DNS names of the certificate, so the mapper can only register the
+// protocols its certificate is issued for. The identity of the mappers on the unix domain socket is empty.
+func mapperIdentity(ctx context.Context, protocol string) (string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return "", nil
+	}
+	if len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", fmt.Errorf("the client certificate of the mapper is not verified")
+	}
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	if !certIssuedFor(cert, protocol) {
+		return "", fmt.Errorf("the client certificate of the mapper is not issued for protocol %s", protocol)
+	}
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName, nil
+	}
+	return protocol, nil
+}
+
+func certIssuedFor(cert *x509.Certificate, name string) bool {
+	if cert.Subject.CommonName == name {
+		return true
+	}
+	for _, dnsName := range cert.DNSNames {
+		if dnsName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMapperOwner refuses the mapper to change the address of a mapper registered with the same name
+// or protocol by another identity, so the calls to the devices can not be redirected to another mapper.
+// The caller must hold MapperMu.
+func checkMapperOwner(cache *DMICache, mapper *pb.MapperInfo, identity string) error {
+	for name, registered := range cache.MapperList {
+		if name != mapper.Name && registered.Protocol != mapper.Protocol {
+			continue
+		}
+		if string(registered.Address) == string(mapper.Address) {
+			continue
+		}
+		if owner := cache.MapperIdentities[name]; owner != identity {
+			return fmt.Errorf("mapper %s of protocol %s is registered by another identity %q", name, registered.Protocol, owner)
+		}
+	}
+	return nil
+}
+
 func (s *server) ReportDeviceStatus(ctx context.Context, in *pb.ReportDeviceStatusRequest) (*pb.ReportDeviceStatusResponse, error) {
 	if !s.limiter.Allow() {
 		return nil, fmt.Errorf("fail to report device status because of too many request: %s", in.DeviceName)
@@ -149,13 +245,80 @@ func (s *server) ReportDeviceStatus(ctx context.Context, in *pb.ReportDeviceStat
 			return nil, err
 		}
 		handleDeviceTwin(in.DeviceName, msg)
+		if s.dmiCache.Series != nil {
+			s.dmiCache.Series.Append(in.DeviceName, twin.PropertyName, dtseries.Point{
+				Timestamp: reportedTimestamp(twin.Reported),
+				Value:     twin.Reported.Value,
+			})
+		}
+	}
+
+	if in.ReportedDevice.State != "" {
+		if err := handleDeviceState(in.DeviceName, in.ReportedDevice.State); err != nil {
+			klog.Errorf("fail to create message data for state of device %s with err: %v", in.DeviceName, err)
+			return nil, err
+		}
 	}
 
 	return &pb.ReportDeviceStatusResponse{}, nil
 }
 
+func (s *server) QueryDeviceData(ctx context.Context, in *pb.QueryDeviceDataRequest) (*pb.QueryDeviceDataResponse, error) {
+	if !s.limiter.Allow() {
+		return nil, fmt.Errorf("fail to query device data because of too many request: %s", in.DeviceName)
+	}
+	if s.dmiCache.Series == nil {
+		return nil, fmt.Errorf("fail to query device data of %s because the time-series buffer is disabled", in.DeviceName)
+	}
+	if in.Step < 0 {
+		return nil, fmt.Errorf("fail to query device data of %s because the step %d is negative", in.DeviceName, in.Step)
+	}
+
+	points := s.dmiCache.Series.Query(in.DeviceName, in.PropertyName, in.Start, in.End)
+	resp := &pb.QueryDeviceDataResponse{}
+	if in.Step > 0 {
+		for _, a := range dtseries.Downsample(points, in.Step) {
+			resp.Aggregates = append(resp.Aggregates, &pb.DataAggregate{
+				Timestamp: a.Timestamp,
+				Count:     a.Count,
+				Min:       a.Min,
+				Max:       a.Max,
+				Avg:       a.Avg,
+			})
+		}
+		return resp, nil
+	}
+	for _, p := range points {
+		resp.Points = append(resp.Points, &pb.DataPoint{Timestamp: p.Timestamp, Value: p.Value})
+	}
+	return resp, nil
+}
+
+// reportedTimestamp returns the time when the value is collected by the mapper, or now if the mapper does not tell
+func reportedTimestamp(property *pb.TwinProperty) int64 {
+	if timestamp, ok := property.Metadata[PropertyTimestamp]; ok {
+		if t, err := strconv.ParseInt(timestamp, 10, 64); err == nil && t > 0 {
+			return t
+		}
+	}
+	return getTimestamp()
+}
+
 func handleDeviceTwin(deviceName string, payload []byte) {
-	topic := dtcommon.DeviceETPrefix + deviceName + dtcommon.TwinETUpdateSuffix
+	sendToDeviceTwin(dtcommon.DeviceETPrefix+deviceName+dtcommon.TwinETUpdateSuffix, payload)
+}
+
+// handleDeviceState updates the state of the device like it is reported through the event bus
+func handleDeviceState(deviceName, state string) error {
+	payload, err := CreateMessageStateUpdate(state)
+	if err != nil {
+		return err
+	}
+	sendToDeviceTwin(dtcommon.DeviceETPrefix+deviceName+dtcommon.DeviceETStateUpdateSuffix, payload)
+	return nil
+}
+
+func sendToDeviceTwin(topic string, payload []byte) {
 	target := modules.TwinGroup
 	resource := base64.URLEncoding.EncodeToString([]byte(topic))
 	// routing key will be $hw.<project_id>.events.user.bus.response.cluster.<cluster_id>.node.<node_id>.<base64_topic>
@@ -179,6 +342,15 @@ func CreateMessageTwinUpdate(name, valueType, value string) ([]byte, error) {
 	return msg, err
 }
 
+// CreateMessageStateUpdate create device state update message.
+func CreateMessageStateUpdate(state string) ([]byte, error) {
+	updateMsg := DeviceStateUpdate{State: state}
+	updateMsg.BaseMessage.Timestamp = getTimestamp()
+
+	msg, err := json.Marshal(updateMsg)
+	return msg, err
+}
+
 func initSock(sockPath string) error {
 	klog.Infof("init uds socket: %s", sockPath)
 	_, err := os.Stat(sockPath)
@@ -195,37 +367,77 @@ func initSock(sockPath string) error {
 	}
 }
 
+// StartDMIServer serves DMI on the unix domain socket for the mappers on the same host,
+// and over TCP with mutual TLS for the mappers on other hosts if it is enabled
 func StartDMIServer(cache *DMICache) {
-	err := initSock(SockPath)
+	sockPath := constants.DefaultDMISockPath
+	var tcp *edgecore.DeviceTwinDMITCP
+	if dmi := config.Get().DMI; dmi != nil {
+		if dmi.SocketPath != "" {
+			sockPath = dmi.SocketPath
+		}
+		tcp = dmi.TCP
+	}
+
+	limiter := rate.NewLimiter(rate.Every(Limit*time.Millisecond), Burst)
+	if tcp != nil && tcp.Enable {
+		go startTCPServer(tcp, cache, limiter)
+	}
+
+	err := initSock(sockPath)
 	if err != nil {
 		klog.Fatalf("failed to remove uds socket with err: %v", err)
 		return
 	}
 
-	lis, err := net.Listen(deviceconst.UnixNetworkType, SockPath)
+	lis, err := net.Listen(deviceconst.UnixNetworkType, sockPath)
 	if err != nil {
 		klog.Errorf("failed to start DMI Server with err: %v", err)
 		return
 	}
 
-	limiter := rate.NewLimiter(rate.Every(Limit*time.Millisecond), Burst)
+	s := newGRPCServer(cache, limiter)
+	klog.Infof("start DMI Server on %s", sockPath)
+	if err := s.Serve(lis); err != nil {
+		klog.Errorf("failed to start DMI Server with err: %v", err)
+		return
+	}
+}
+
+// startTCPServer serves DMI over TCP, the mappers must present certificates issued by the mapper CA
+func startTCPServer(tcp *edgecore.DeviceTwinDMITCP, cache *DMICache, limiter *rate.Limiter) {
+	tlsConfig, err := dtcommon.DMITLSConfig(tcp)
+	if err != nil {
+		klog.Errorf("failed to start DMI Server over TCP with err: %v", err)
+		return
+	}
+
+	lis, err := net.Listen(dtcommon.TCPNetworkType, tcp.Address)
+	if err != nil {
+		klog.Errorf("failed to start DMI Server over TCP with err: %v", err)
+		return
+	}
+
+	s := newGRPCServer(cache, limiter, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	klog.Infof("start DMI Server over TCP on %s", tcp.Address)
+	if err := s.Serve(lis); err != nil {
+		klog.Errorf("failed to start DMI Server over TCP with err: %v", err)
+	}
+}
 
-	s := grpc.NewServer()
+// newGRPCServer creates the DMI server, the servers of all listeners share the cache and the rate limit
+func newGRPCServer(cache *DMICache, limiter *rate.Limiter, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
 	pb.RegisterDeviceManagerServiceServer(s, &server{
 		limiter:  limiter,
 		dmiCache: cache,
 	})
 	reflection.Register(s)
-
-	if err := s.Serve(lis); err != nil {
-		klog.Errorf("failed to start DMI Server with err: %v", err)
-		return
-	}
-	klog.Infoln("success to start DMI Server")
+	return s
 }
 
-func saveMapper(mapper *pb.MapperInfo) error {
-	content, err := json.Marshal(mapper)
+func saveMapper(mapper *pb.MapperInfo, identity string) error {
+	content, err := json.Marshal(SavedMapper{MapperInfo: mapper, Identity: identity})
 	if err != nil {
 		klog.Errorf("marshal mapper info failed, %s: %v", mapper.Name, err)
 		return err
@@ -236,7 +448,7 @@ func saveMapper(mapper *pb.MapperInfo) error {
 		Key:   resource,
 		Type:  deviceconst.ResourceTypeDeviceMapper,
 		Value: string(content)}
-	err = dao.SaveMeta(meta)
+	err = dao.InsertOrUpdate(meta)
 	if err != nil {
 		klog.Errorf("save meta failed, %s: %v", mapper.Name, err)
 		return err
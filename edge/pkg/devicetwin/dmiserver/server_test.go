@@ -0,0 +1,144 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dmiserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	deviceconst "github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/constants"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	pb "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
+)
+
+// tcpContext returns the context of the call over TCP from the mapper presenting the certificate
+func tcpContext(commonName string, dnsNames ...string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}, DNSNames: dnsNames}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+}
+
+func TestMapperRegisterIdentity(t *testing.T) {
+	store, err := dbm.OpenBoltStore(filepath.Join(t.TempDir(), "edgecore.bolt"))
+	if err != nil {
+		t.Fatalf("failed to open bolt store: %v", err)
+	}
+	dbm.KVAccess = store
+	defer func() {
+		dbm.KVAccess = nil
+		store.Close()
+	}()
+	healthCheckFunc = func(protocol string, timeout time.Duration) error {
+		return nil
+	}
+
+	s := &server{
+		limiter: rate.NewLimiter(rate.Inf, Burst),
+		dmiCache: &DMICache{
+			MapperMu:         &sync.Mutex{},
+			DeviceMu:         &sync.Mutex{},
+			DeviceModelMu:    &sync.Mutex{},
+			MapperList:       map[string]*pb.MapperInfo{},
+			MapperIdentities: map[string]string{},
+			DeviceModelList:  map[string]*v1alpha2.DeviceModel{},
+			DeviceList:       map[string]*v1alpha2.Device{},
+		},
+	}
+	cases := []struct {
+		name    string
+		ctx     context.Context
+		mapper  *pb.MapperInfo
+		wantErr bool
+	}{
+		{
+			name:    "certificate not issued for the protocol",
+			ctx:     tcpContext("modbus"),
+			mapper:  &pb.MapperInfo{Name: "opcua-mapper", Protocol: "opcua", Address: []byte("tcp://10.0.0.1:7777")},
+			wantErr: true,
+		},
+		{
+			name:   "register over TCP",
+			ctx:    tcpContext("modbus"),
+			mapper: &pb.MapperInfo{Name: "modbus-mapper", Protocol: "modbus", Address: []byte("tcp://10.0.0.1:7777")},
+		},
+		{
+			name:    "change the address from another certificate",
+			ctx:     tcpContext("other", "modbus"),
+			mapper:  &pb.MapperInfo{Name: "modbus-mapper", Protocol: "modbus", Address: []byte("tcp://10.0.0.2:7777")},
+			wantErr: true,
+		},
+		{
+			name:    "change the address of the protocol from the unix socket",
+			ctx:     context.Background(),
+			mapper:  &pb.MapperInfo{Name: "local-mapper", Protocol: "modbus", Address: []byte("unix:///tmp/modbus.sock")},
+			wantErr: true,
+		},
+		{
+			name:   "change the address from the same certificate",
+			ctx:    tcpContext("modbus"),
+			mapper: &pb.MapperInfo{Name: "modbus-mapper", Protocol: "modbus", Address: []byte("tcp://10.0.0.3:7777")},
+		},
+		{
+			name:   "register over the unix socket",
+			ctx:    context.Background(),
+			mapper: &pb.MapperInfo{Name: "opcua-mapper", Protocol: "opcua", Address: []byte("unix:///tmp/opcua.sock")},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := s.MapperRegister(c.ctx, &pb.MapperRegisterRequest{Mapper: c.mapper})
+			if (err != nil) != c.wantErr {
+				t.Errorf("MapperRegister() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+
+	if address := string(s.dmiCache.MapperList["modbus-mapper"].Address); address != "tcp://10.0.0.3:7777" {
+		t.Errorf("got address %s of modbus-mapper, want tcp://10.0.0.3:7777", address)
+	}
+	// the identity is saved to be checked after edgecore restarts
+	metas, err := dao.QueryMeta("type", deviceconst.ResourceTypeDeviceMapper)
+	if err != nil {
+		t.Fatalf("failed to query the saved mappers: %v", err)
+	}
+	identities := map[string]string{}
+	for _, meta := range *metas {
+		saved := SavedMapper{MapperInfo: &pb.MapperInfo{}}
+		if err := json.Unmarshal([]byte(meta), &saved); err != nil {
+			t.Fatalf("failed to unmarshal the saved mapper: %v", err)
+		}
+		identities[saved.Name] = saved.Identity
+	}
+	if want := map[string]string{"modbus-mapper": "modbus", "opcua-mapper": ""}; !reflect.DeepEqual(identities, want) {
+		t.Errorf("got saved identities %v, want %v", identities, want)
+	}
+}
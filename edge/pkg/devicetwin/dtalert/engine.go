@@ -0,0 +1,220 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtalert
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+)
+
+// Alert is a transition of a rule, the alert either fires or resolves
+type Alert struct {
+	Rule    *v1alpha2.DeviceAlertRule
+	Value   string
+	Firing  bool
+	Message string
+	// Timestamp in unix milliseconds
+	Timestamp int64
+}
+
+type ruleState struct {
+	rule   *v1alpha2.DeviceAlertRule
+	firing bool
+	// lastSeen is the time when the last value is reported, or the rule is set
+	lastSeen time.Time
+	// lastValue is the last numeric value, used for RateOfChange
+	lastValue    float64
+	hasLastValue bool
+}
+
+// Engine evaluates the alert rules against the values reported for the device properties
+type Engine struct {
+	mu sync.Mutex
+	// rules, key is namespace/name of the rule
+	rules map[string]*ruleState
+	// now is replaced in tests
+	now func() time.Time
+}
+
+// NewEngine creates an Engine without rules
+func NewEngine() *Engine {
+	return &Engine{
+		rules: make(map[string]*ruleState),
+		now:   time.Now,
+	}
+}
+
+// SetRule adds or updates the rule, the state of the alert is kept if the rule
+// still evaluates the same device property
+func (e *Engine) SetRule(rule *v1alpha2.DeviceAlertRule) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	key := ruleKey(rule.Namespace, rule.Name)
+	state, ok := e.rules[key]
+	if ok && state.rule.Spec.DeviceName == rule.Spec.DeviceName && state.rule.Spec.PropertyName == rule.Spec.PropertyName {
+		state.rule = rule
+		return
+	}
+	e.rules[key] = &ruleState{rule: rule, lastSeen: e.now()}
+}
+
+// DeleteRule deletes the rule
+func (e *Engine) DeleteRule(namespace, name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.rules, ruleKey(namespace, name))
+}
+
+// Evaluate evaluates the rules of the device property against the reported value,
+// and returns the alerts which fire or resolve
+func (e *Engine) Evaluate(device, property, value string) []Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	var alerts []Alert
+	for _, state := range e.rules {
+		spec := state.rule.Spec
+		if spec.DeviceName != device || spec.PropertyName != property {
+			continue
+		}
+		holds, message := state.evaluate(value, now)
+		state.lastSeen = now
+		if holds == state.firing {
+			continue
+		}
+		state.firing = holds
+		alerts = append(alerts, Alert{
+			Rule:      state.rule,
+			Value:     value,
+			Firing:    holds,
+			Message:   message,
+			Timestamp: now.UnixNano() / 1e6,
+		})
+	}
+	return alerts
+}
+
+// CheckAbsent fires the Absent rules whose properties are not reported for the duration
+// of the rules, it is called periodically
+func (e *Engine) CheckAbsent() []Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	var alerts []Alert
+	for _, state := range e.rules {
+		condition := state.rule.Spec.Condition
+		if condition.Type != v1alpha2.AlertConditionAbsent || state.firing {
+			continue
+		}
+		absentFor := time.Duration(condition.AbsentForSeconds) * time.Second
+		if now.Sub(state.lastSeen) < absentFor {
+			continue
+		}
+		state.firing = true
+		alerts = append(alerts, Alert{
+			Rule:      state.rule,
+			Firing:    true,
+			Message:   fmt.Sprintf("no value of property %s of device %s is reported for %v", state.rule.Spec.PropertyName, state.rule.Spec.DeviceName, absentFor),
+			Timestamp: now.UnixNano() / 1e6,
+		})
+	}
+	return alerts
+}
+
+// evaluate returns whether the condition of the rule holds with the reported value, and the message of the result
+func (s *ruleState) evaluate(value string, now time.Time) (bool, string) {
+	spec := s.rule.Spec
+	condition := spec.Condition
+	switch condition.Type {
+	case v1alpha2.AlertConditionThreshold:
+		holds, err := compare(value, condition.Operator, condition.Value)
+		if err != nil {
+			klog.Warningf("fail to evaluate alert rule %s/%s with err: %v", s.rule.Namespace, s.rule.Name, err)
+			return s.firing, ""
+		}
+		return holds, fmt.Sprintf("value %s of property %s of device %s, condition %s %s", value, spec.PropertyName, spec.DeviceName, condition.Operator, condition.Value)
+
+	case v1alpha2.AlertConditionRateOfChange:
+		current, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			klog.Warningf("fail to evaluate alert rule %s/%s, value %s is not a number", s.rule.Namespace, s.rule.Name, value)
+			return s.firing, ""
+		}
+		last, hasLast, elapsed := s.lastValue, s.hasLastValue, now.Sub(s.lastSeen).Seconds()
+		s.lastValue, s.hasLastValue = current, true
+		if !hasLast || elapsed <= 0 {
+			return s.firing, ""
+		}
+		rate := strconv.FormatFloat((current-last)/elapsed, 'f', -1, 64)
+		holds, err := compare(rate, condition.Operator, condition.Value)
+		if err != nil {
+			klog.Warningf("fail to evaluate alert rule %s/%s with err: %v", s.rule.Namespace, s.rule.Name, err)
+			return s.firing, ""
+		}
+		return holds, fmt.Sprintf("rate %s/s of property %s of device %s, condition %s %s", rate, spec.PropertyName, spec.DeviceName, condition.Operator, condition.Value)
+
+	case v1alpha2.AlertConditionAbsent:
+		// any reported value resolves the alert
+		return false, fmt.Sprintf("value %s of property %s of device %s is reported", value, spec.PropertyName, spec.DeviceName)
+	}
+	klog.Warningf("fail to evaluate alert rule %s/%s, condition type %s unsupported", s.rule.Namespace, s.rule.Name, condition.Type)
+	return s.firing, ""
+}
+
+// compare compares the values as numbers if both are numbers, otherwise as strings
+func compare(observed string, operator v1alpha2.AlertOperator, expected string) (bool, error) {
+	o, oErr := strconv.ParseFloat(observed, 64)
+	e, eErr := strconv.ParseFloat(expected, 64)
+	if oErr == nil && eErr == nil {
+		switch operator {
+		case v1alpha2.AlertOperatorGreaterThan:
+			return o > e, nil
+		case v1alpha2.AlertOperatorGreaterThanEqual:
+			return o >= e, nil
+		case v1alpha2.AlertOperatorLessThan:
+			return o < e, nil
+		case v1alpha2.AlertOperatorLessThanEqual:
+			return o <= e, nil
+		case v1alpha2.AlertOperatorEqual:
+			return o == e, nil
+		case v1alpha2.AlertOperatorNotEqual:
+			return o != e, nil
+		}
+		return false, fmt.Errorf("operator %s unsupported", operator)
+	}
+	switch operator {
+	case v1alpha2.AlertOperatorEqual:
+		return observed == expected, nil
+	case v1alpha2.AlertOperatorNotEqual:
+		return observed != expected, nil
+	}
+	return false, fmt.Errorf("operator %s unsupported to compare %s with %s", operator, observed, expected)
+}
+
+func ruleKey(namespace, name string) string {
+	return namespace + "/" + name
+}
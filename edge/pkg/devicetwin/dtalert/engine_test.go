@@ -0,0 +1,184 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtalert
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+)
+
+func newRule(name string, condition v1alpha2.AlertCondition) *v1alpha2.DeviceAlertRule {
+	return &v1alpha2.DeviceAlertRule{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: v1alpha2.DeviceAlertRuleSpec{
+			DeviceName:   "sensor",
+			PropertyName: "temperature",
+			Condition:    condition,
+		},
+	}
+}
+
+// newTestEngine returns the engine and a function to move its fake clock forward
+func newTestEngine() (*Engine, func(time.Duration)) {
+	e := NewEngine()
+	now := time.Unix(1700000000, 0)
+	e.now = func() time.Time { return now }
+	return e, func(d time.Duration) { now = now.Add(d) }
+}
+
+// firings returns the firing flags of the alerts, the alerts in the tests belong to one rule
+func firings(alerts []Alert) []bool {
+	var result []bool
+	for _, a := range alerts {
+		result = append(result, a.Firing)
+	}
+	return result
+}
+
+func TestEvaluateThreshold(t *testing.T) {
+	e, _ := newTestEngine()
+	e.SetRule(newRule("high", v1alpha2.AlertCondition{
+		Type:     v1alpha2.AlertConditionThreshold,
+		Operator: v1alpha2.AlertOperatorGreaterThan,
+		Value:    "80",
+	}))
+
+	tests := []struct {
+		device, property, value string
+		want                    []bool
+	}{
+		{device: "sensor", property: "temperature", value: "75"},
+		{device: "sensor", property: "temperature", value: "85", want: []bool{true}},
+		{device: "sensor", property: "temperature", value: "90"},
+		{device: "sensor", property: "humidity", value: "10"},
+		{device: "other", property: "temperature", value: "10"},
+		{device: "sensor", property: "temperature", value: "not a number"},
+		{device: "sensor", property: "temperature", value: "80", want: []bool{false}},
+	}
+	for _, tt := range tests {
+		got := firings(e.Evaluate(tt.device, tt.property, tt.value))
+		if len(got) != len(tt.want) || (len(got) == 1 && got[0] != tt.want[0]) {
+			t.Errorf("Evaluate(%s, %s, %s) = %v, want %v", tt.device, tt.property, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateRateOfChange(t *testing.T) {
+	e, advance := newTestEngine()
+	e.SetRule(newRule("rising", v1alpha2.AlertCondition{
+		Type:     v1alpha2.AlertConditionRateOfChange,
+		Operator: v1alpha2.AlertOperatorGreaterThanEqual,
+		Value:    "2",
+	}))
+
+	if got := e.Evaluate("sensor", "temperature", "20"); len(got) != 0 {
+		t.Fatalf("Evaluate() of the first value = %v, want none", got)
+	}
+	advance(10 * time.Second)
+	if got := firings(e.Evaluate("sensor", "temperature", "30")); len(got) != 0 {
+		t.Fatalf("Evaluate() at 1/s = %v, want none", got)
+	}
+	advance(5 * time.Second)
+	if got := firings(e.Evaluate("sensor", "temperature", "40")); len(got) != 1 || !got[0] {
+		t.Fatalf("Evaluate() at 2/s = %v, want firing", got)
+	}
+	advance(5 * time.Second)
+	if got := firings(e.Evaluate("sensor", "temperature", "35")); len(got) != 1 || got[0] {
+		t.Fatalf("Evaluate() at -1/s = %v, want resolved", got)
+	}
+}
+
+func TestCheckAbsent(t *testing.T) {
+	e, advance := newTestEngine()
+	e.SetRule(newRule("silent", v1alpha2.AlertCondition{
+		Type:             v1alpha2.AlertConditionAbsent,
+		AbsentForSeconds: 60,
+	}))
+
+	advance(59 * time.Second)
+	if got := e.CheckAbsent(); len(got) != 0 {
+		t.Fatalf("CheckAbsent() before the duration = %v, want none", got)
+	}
+	advance(time.Second)
+	if got := firings(e.CheckAbsent()); len(got) != 1 || !got[0] {
+		t.Fatalf("CheckAbsent() after the duration = %v, want firing", got)
+	}
+	if got := e.CheckAbsent(); len(got) != 0 {
+		t.Fatalf("CheckAbsent() of a firing alert = %v, want none", got)
+	}
+	if got := firings(e.Evaluate("sensor", "temperature", "20")); len(got) != 1 || got[0] {
+		t.Fatalf("Evaluate() of a reported value = %v, want resolved", got)
+	}
+	advance(30 * time.Second)
+	if got := e.CheckAbsent(); len(got) != 0 {
+		t.Fatalf("CheckAbsent() after the value is reported = %v, want none", got)
+	}
+}
+
+func TestSetAndDeleteRule(t *testing.T) {
+	e, _ := newTestEngine()
+	rule := newRule("high", v1alpha2.AlertCondition{
+		Type:     v1alpha2.AlertConditionThreshold,
+		Operator: v1alpha2.AlertOperatorGreaterThan,
+		Value:    "80",
+	})
+	e.SetRule(rule)
+	if got := firings(e.Evaluate("sensor", "temperature", "85")); len(got) != 1 || !got[0] {
+		t.Fatalf("Evaluate() = %v, want firing", got)
+	}
+
+	// the firing alert is kept when the condition of the rule is updated
+	updated := rule.DeepCopy()
+	updated.Spec.Condition.Value = "100"
+	e.SetRule(updated)
+	if got := firings(e.Evaluate("sensor", "temperature", "85")); len(got) != 1 || got[0] {
+		t.Fatalf("Evaluate() after the rule is updated = %v, want resolved", got)
+	}
+
+	e.DeleteRule(rule.Namespace, rule.Name)
+	if got := e.Evaluate("sensor", "temperature", "120"); len(got) != 0 {
+		t.Fatalf("Evaluate() after the rule is deleted = %v, want none", got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		observed string
+		operator v1alpha2.AlertOperator
+		expected string
+		want     bool
+		wantErr  bool
+	}{
+		{observed: "10", operator: v1alpha2.AlertOperatorLessThan, expected: "9.5", want: false},
+		{observed: "10", operator: v1alpha2.AlertOperatorLessThanEqual, expected: "10.0", want: true},
+		{observed: "1e2", operator: v1alpha2.AlertOperatorEqual, expected: "100", want: true},
+		{observed: "on", operator: v1alpha2.AlertOperatorEqual, expected: "on", want: true},
+		{observed: "on", operator: v1alpha2.AlertOperatorNotEqual, expected: "off", want: true},
+		{observed: "on", operator: v1alpha2.AlertOperatorGreaterThan, expected: "off", wantErr: true},
+		{observed: "1", operator: "unknown", expected: "1", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := compare(tt.observed, tt.operator, tt.expected)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("compare(%s, %s, %s) = %v, %v, want %v, error %v", tt.observed, tt.operator, tt.expected, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -36,36 +36,30 @@ func DeleteDeviceByID(obm orm.Ormer, id string) error {
 
 // UpdateDeviceField update special field
 func UpdateDeviceField(deviceID string, col string, value interface{}) error {
-	num, err := dbm.DBAccess.QueryTable(DeviceTableName).Filter("id", deviceID).Update(map[string]interface{}{col: value})
-	klog.V(4).Infof("Update affected Num: %d, %s", num, err)
-	return err
+	return deviceStorage().UpdateDeviceFields(deviceID, map[string]interface{}{col: value})
 }
 
 // UpdateDeviceFields update special fields
 func UpdateDeviceFields(deviceID string, cols map[string]interface{}) error {
-	num, err := dbm.DBAccess.QueryTable(DeviceTableName).Filter("id", deviceID).Update(cols)
-	klog.V(4).Infof("Update affected Num: %d, %s", num, err)
-	return err
+	return deviceStorage().UpdateDeviceFields(deviceID, cols)
 }
 
 // QueryDevice query Device
 func QueryDevice(key string, condition string) (*[]Device, error) {
-	devices := new([]Device)
-	_, err := dbm.DBAccess.QueryTable(DeviceTableName).Filter(key, condition).All(devices)
+	devices, err := deviceStorage().QueryDevice(dbm.Cond{Expr: key, Value: condition})
 	if err != nil {
 		return nil, err
 	}
-	return devices, nil
+	return &devices, nil
 }
 
 // QueryDeviceAll query twin
 func QueryDeviceAll() (*[]Device, error) {
-	devices := new([]Device)
-	_, err := dbm.DBAccess.QueryTable(DeviceTableName).All(devices)
+	devices, err := deviceStorage().QueryDevice()
 	if err != nil {
 		return nil, err
 	}
-	return devices, nil
+	return &devices, nil
 }
 
 //DeviceUpdate the struct for updating device
@@ -88,6 +82,10 @@ func UpdateDeviceMulti(updates []DeviceUpdate) error {
 
 //AddDeviceTrans the transaction of add device
 func AddDeviceTrans(adds []Device, addAttrs []DeviceAttr, addTwins []DeviceTwin) error {
+	return deviceStorage().AddDeviceTrans(adds, addAttrs, addTwins)
+}
+
+func (sqliteDeviceStore) AddDeviceTrans(adds []Device, addAttrs []DeviceAttr, addTwins []DeviceTwin) error {
 	obm := dbm.DefaultOrmFunc()
 	err := obm.Begin()
 	if err != nil {
@@ -134,6 +132,10 @@ func AddDeviceTrans(adds []Device, addAttrs []DeviceAttr, addTwins []DeviceTwin)
 
 //DeleteDeviceTrans the transaction of delete device
 func DeleteDeviceTrans(deletes []string) error {
+	return deviceStorage().DeleteDeviceTrans(deletes)
+}
+
+func (sqliteDeviceStore) DeleteDeviceTrans(deletes []string) error {
 	obm := dbm.DefaultOrmFunc()
 	err := obm.Begin()
 	if err != nil {
@@ -50,9 +50,7 @@ func DeleteDeviceAttr(obm orm.Ormer, deviceID string, name string) error {
 
 // UpdateDeviceAttrField update special field
 func UpdateDeviceAttrField(deviceID string, name string, col string, value interface{}) error {
-	num, err := dbm.DBAccess.QueryTable(DeviceAttrTableName).Filter("deviceid", deviceID).Filter("name", name).Update(map[string]interface{}{col: value})
-	klog.V(4).Infof("Update affected Num: %d, %s", num, err)
-	return err
+	return deviceStorage().UpdateDeviceAttrFields(deviceID, name, map[string]interface{}{col: value})
 }
 
 // UpdateDeviceAttrFields update special fields
@@ -64,12 +62,11 @@ func UpdateDeviceAttrFields(obm orm.Ormer, deviceID string, name string, cols ma
 
 // QueryDeviceAttr query Device
 func QueryDeviceAttr(key string, condition string) (*[]DeviceAttr, error) {
-	attrs := new([]DeviceAttr)
-	_, err := dbm.DBAccess.QueryTable(DeviceAttrTableName).Filter(key, condition).All(attrs)
+	attrs, err := deviceStorage().QueryDeviceAttr(key, condition)
 	if err != nil {
 		return nil, err
 	}
-	return attrs, nil
+	return &attrs, nil
 }
 
 //DeviceDelete the struct for deleting device
@@ -89,7 +86,7 @@ type DeviceAttrUpdate struct {
 func UpdateDeviceAttrMulti(updates []DeviceAttrUpdate) error {
 	var err error
 	for _, update := range updates {
-		err = UpdateDeviceAttrFields(dbm.DBAccess, update.DeviceID, update.Name, update.Cols)
+		err = deviceStorage().UpdateDeviceAttrFields(update.DeviceID, update.Name, update.Cols)
 		if err != nil {
 			return err
 		}
@@ -99,6 +96,10 @@ func UpdateDeviceAttrMulti(updates []DeviceAttrUpdate) error {
 
 //DeviceAttrTrans transaction of device attr
 func DeviceAttrTrans(adds []DeviceAttr, deletes []DeviceDelete, updates []DeviceAttrUpdate) error {
+	return deviceStorage().DeviceAttrTrans(adds, deletes, updates)
+}
+
+func (sqliteDeviceStore) DeviceAttrTrans(adds []DeviceAttr, deletes []DeviceDelete, updates []DeviceAttrUpdate) error {
 	obm := dbm.DefaultOrmFunc()
 	err := obm.Begin()
 	if err != nil {
@@ -55,9 +55,7 @@ func DeleteDeviceTwin(obm orm.Ormer, deviceID string, name string) error {
 
 // UpdateDeviceTwinField update special field
 func UpdateDeviceTwinField(deviceID string, name string, col string, value interface{}) error {
-	num, err := dbm.DBAccess.QueryTable(DeviceTwinTableName).Filter("deviceid", deviceID).Filter("name", name).Update(map[string]interface{}{col: value})
-	klog.V(4).Infof("Update affected Num: %d, %s", num, err)
-	return err
+	return deviceStorage().UpdateDeviceTwinFields(deviceID, name, map[string]interface{}{col: value})
 }
 
 // UpdateDeviceTwinFields update special fields
@@ -69,12 +67,38 @@ func UpdateDeviceTwinFields(obm orm.Ormer, deviceID string, name string, cols ma
 
 // QueryDeviceTwin query Device
 func QueryDeviceTwin(key string, condition string) (*[]DeviceTwin, error) {
-	twin := new([]DeviceTwin)
-	_, err := dbm.DBAccess.QueryTable(DeviceTwinTableName).Filter(key, condition).All(twin)
+	twin, err := deviceStorage().QueryDeviceTwin(key, condition)
 	if err != nil {
 		return nil, err
 	}
-	return twin, nil
+	return &twin, nil
+}
+
+// QueryDeviceTwinAll returns all the device twins in the order of id
+func QueryDeviceTwinAll() ([]DeviceTwin, error) {
+	return deviceStorage().ListDeviceTwins()
+}
+
+// CheckDeviceTwinQuota checks the quota of device_twin before the twins are added,
+// the new twins are refused beyond the quota since they can not be rebuilt once deleted
+func CheckDeviceTwinQuota(adds []DeviceTwin) error {
+	if len(adds) == 0 {
+		return nil
+	}
+	return dbm.CheckQuota(DeviceTwinTableName, func() (int64, int64, error) {
+		twins, err := QueryDeviceTwinAll()
+		if err != nil {
+			return 0, 0, err
+		}
+		var size int64
+		for _, list := range [][]DeviceTwin{twins, adds} {
+			for i := range list {
+				twin := &list[i]
+				size += int64(len(twin.Expected) + len(twin.Actual) + len(twin.ExpectedMeta) + len(twin.ActualMeta) + len(twin.Metadata))
+			}
+		}
+		return int64(len(twins) + len(adds)), size, nil
+	})
 }
 
 //DeviceTwinUpdate the struct for updating device twin
@@ -88,7 +112,7 @@ type DeviceTwinUpdate struct {
 func UpdateDeviceTwinMulti(updates []DeviceTwinUpdate) error {
 	var err error
 	for _, update := range updates {
-		err = UpdateDeviceTwinFields(dbm.DBAccess, update.DeviceID, update.Name, update.Cols)
+		err = deviceStorage().UpdateDeviceTwinFields(update.DeviceID, update.Name, update.Cols)
 		if err != nil {
 			return err
 		}
@@ -98,6 +122,10 @@ func UpdateDeviceTwinMulti(updates []DeviceTwinUpdate) error {
 
 //DeviceTwinTrans transaction of device twin
 func DeviceTwinTrans(adds []DeviceTwin, deletes []DeviceDelete, updates []DeviceTwinUpdate) error {
+	return deviceStorage().DeviceTwinTrans(adds, deletes, updates)
+}
+
+func (sqliteDeviceStore) DeviceTwinTrans(adds []DeviceTwin, deletes []DeviceDelete, updates []DeviceTwinUpdate) error {
 	obm := dbm.DefaultOrmFunc()
 	err := obm.Begin()
 	if err != nil {
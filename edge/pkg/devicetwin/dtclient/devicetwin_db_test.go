@@ -17,6 +17,8 @@ limitations under the License.
 package dtclient
 
 import (
+	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/astaxie/beego/orm"
@@ -24,6 +26,7 @@ import (
 
 	"github.com/kubeedge/kubeedge/edge/mocks/beego"
 	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
 )
 
 // TestSaveDeviceTwin is function to test SaveDeviceTwin
@@ -579,3 +582,34 @@ func TestDeviceTwinTrans(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckDeviceTwinQuota(t *testing.T) {
+	store, err := dbm.OpenBoltStore(filepath.Join(t.TempDir(), "edgecore.bolt"))
+	if err != nil {
+		t.Fatalf("failed to open bolt store: %v", err)
+	}
+	dbm.KVAccess = store
+	defer func() {
+		dbm.KVAccess = nil
+		store.Close()
+	}()
+	dbm.SetQuotas([]v1alpha2.DataBaseQuota{{ResourceType: DeviceTwinTableName, MaxCount: 2}})
+	defer dbm.SetQuotas(nil)
+
+	twins := []DeviceTwin{{DeviceID: "device", Name: "temperature"}, {DeviceID: "device", Name: "humidity"}}
+	if err := CheckDeviceTwinQuota(twins); err != nil {
+		t.Fatalf("expected twins within the quota, but got %v", err)
+	}
+	if err := DeviceTwinTrans(twins, nil, nil); err != nil {
+		t.Fatalf("failed to save twins: %v", err)
+	}
+	if err := CheckDeviceTwinQuota(nil); err != nil {
+		t.Errorf("expected no check without new twins, but got %v", err)
+	}
+	if err := CheckDeviceTwinQuota([]DeviceTwin{{DeviceID: "device", Name: "pressure"}}); !errors.Is(err, dbm.ErrQuotaExceeded) {
+		t.Errorf("expected twin beyond the quota refused, but got %v", err)
+	}
+	if remaining, err := QueryDeviceTwinAll(); err != nil || len(remaining) != 2 {
+		t.Errorf("expected the existing twins kept, but got %v, %v", remaining, err)
+	}
+}
@@ -1,10 +1,10 @@
 package dtclient
 
 import (
-	"github.com/astaxie/beego/orm"
 	"k8s.io/klog/v2"
 
 	"github.com/kubeedge/beehive/pkg/core"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
 )
 
 const (
@@ -24,7 +24,7 @@ func InitDBTable(module core.Module) {
 		klog.Infof("Module %s is disabled, DB meta for it will not be registered", module.Name())
 		return
 	}
-	orm.RegisterModel(new(Device))
-	orm.RegisterModel(new(DeviceAttr))
-	orm.RegisterModel(new(DeviceTwin))
+	dbm.RegisterModel(DeviceTableName, new(Device))
+	dbm.RegisterModel(DeviceAttrTableName, new(DeviceAttr))
+	dbm.RegisterModel(DeviceTwinTableName, new(DeviceTwin))
 }
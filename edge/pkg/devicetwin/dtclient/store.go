@@ -0,0 +1,234 @@
+package dtclient
+
+import (
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+)
+
+// deviceStore is the storage of devices, device attributes and device twins
+type deviceStore interface {
+	UpdateDeviceFields(deviceID string, cols map[string]interface{}) error
+	QueryDevice(conds ...dbm.Cond) ([]Device, error)
+	AddDeviceTrans(adds []Device, addAttrs []DeviceAttr, addTwins []DeviceTwin) error
+	DeleteDeviceTrans(deletes []string) error
+
+	UpdateDeviceAttrFields(deviceID string, name string, cols map[string]interface{}) error
+	QueryDeviceAttr(key string, condition string) ([]DeviceAttr, error)
+	DeviceAttrTrans(adds []DeviceAttr, deletes []DeviceDelete, updates []DeviceAttrUpdate) error
+
+	UpdateDeviceTwinFields(deviceID string, name string, cols map[string]interface{}) error
+	QueryDeviceTwin(key string, condition string) ([]DeviceTwin, error)
+	ListDeviceTwins() ([]DeviceTwin, error)
+	DeviceTwinTrans(adds []DeviceTwin, deletes []DeviceDelete, updates []DeviceTwinUpdate) error
+}
+
+// deviceStorage returns the storage selected by DataBase.DriverName
+func deviceStorage() deviceStore {
+	if dbm.UseKV() {
+		return kvDeviceStore{}
+	}
+	return sqliteDeviceStore{}
+}
+
+type sqliteDeviceStore struct{}
+
+func (sqliteDeviceStore) UpdateDeviceFields(deviceID string, cols map[string]interface{}) error {
+	num, err := dbm.DBAccess.QueryTable(DeviceTableName).Filter("id", deviceID).Update(cols)
+	klog.V(4).Infof("Update affected Num: %d, %s", num, err)
+	return err
+}
+
+func (sqliteDeviceStore) QueryDevice(conds ...dbm.Cond) ([]Device, error) {
+	devices := new([]Device)
+	qs := dbm.DBAccess.QueryTable(DeviceTableName)
+	for _, cond := range conds {
+		qs = qs.Filter(cond.Expr, cond.Value)
+	}
+	if _, err := qs.All(devices); err != nil {
+		return nil, err
+	}
+	return *devices, nil
+}
+
+func (sqliteDeviceStore) UpdateDeviceAttrFields(deviceID string, name string, cols map[string]interface{}) error {
+	return UpdateDeviceAttrFields(dbm.DBAccess, deviceID, name, cols)
+}
+
+func (sqliteDeviceStore) QueryDeviceAttr(key string, condition string) ([]DeviceAttr, error) {
+	attrs := new([]DeviceAttr)
+	_, err := dbm.DBAccess.QueryTable(DeviceAttrTableName).Filter(key, condition).All(attrs)
+	if err != nil {
+		return nil, err
+	}
+	return *attrs, nil
+}
+
+func (sqliteDeviceStore) UpdateDeviceTwinFields(deviceID string, name string, cols map[string]interface{}) error {
+	return UpdateDeviceTwinFields(dbm.DBAccess, deviceID, name, cols)
+}
+
+func (sqliteDeviceStore) QueryDeviceTwin(key string, condition string) ([]DeviceTwin, error) {
+	twin := new([]DeviceTwin)
+	_, err := dbm.DBAccess.QueryTable(DeviceTwinTableName).Filter(key, condition).All(twin)
+	if err != nil {
+		return nil, err
+	}
+	return *twin, nil
+}
+
+func (sqliteDeviceStore) ListDeviceTwins() ([]DeviceTwin, error) {
+	twin := new([]DeviceTwin)
+	_, err := dbm.DBAccess.QueryTable(DeviceTwinTableName).OrderBy("id").All(twin)
+	if err != nil {
+		return nil, err
+	}
+	return *twin, nil
+}
+
+type kvDeviceStore struct{}
+
+func deviceCond(deviceID string) dbm.Cond {
+	return dbm.Cond{Expr: "deviceid", Value: deviceID}
+}
+
+func nameCond(name string) dbm.Cond {
+	return dbm.Cond{Expr: "name", Value: name}
+}
+
+func (kvDeviceStore) UpdateDeviceFields(deviceID string, cols map[string]interface{}) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		num, err := dbm.KVUpdate(tx, DeviceTableName, new(Device), cols, dbm.Cond{Expr: "id", Value: deviceID})
+		klog.V(4).Infof("Update affected Num: %d, %v", num, err)
+		return err
+	})
+}
+
+func (kvDeviceStore) QueryDevice(conds ...dbm.Cond) ([]Device, error) {
+	var devices []Device
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, DeviceTableName, &devices, conds...)
+	})
+	return devices, err
+}
+
+func (kvDeviceStore) AddDeviceTrans(adds []Device, addAttrs []DeviceAttr, addTwins []DeviceTwin) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		for i := range adds {
+			if err := dbm.KVInsert(tx, DeviceTableName, &adds[i]); err != nil {
+				klog.Errorf("save device failed: %v", err)
+				return err
+			}
+		}
+		for i := range addAttrs {
+			if err := dbm.KVInsert(tx, DeviceAttrTableName, &addAttrs[i]); err != nil {
+				return err
+			}
+		}
+		for i := range addTwins {
+			if err := dbm.KVInsert(tx, DeviceTwinTableName, &addTwins[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (kvDeviceStore) DeleteDeviceTrans(deletes []string) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		for _, id := range deletes {
+			if err := tx.Delete(DeviceTableName, id); err != nil {
+				return err
+			}
+			if _, err := dbm.KVDelete(tx, DeviceAttrTableName, new(DeviceAttr), deviceCond(id)); err != nil {
+				return err
+			}
+			if _, err := dbm.KVDelete(tx, DeviceTwinTableName, new(DeviceTwin), deviceCond(id)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (kvDeviceStore) UpdateDeviceAttrFields(deviceID string, name string, cols map[string]interface{}) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		num, err := dbm.KVUpdate(tx, DeviceAttrTableName, new(DeviceAttr), cols, deviceCond(deviceID), nameCond(name))
+		klog.V(4).Infof("Update affected Num: %d, %v", num, err)
+		return err
+	})
+}
+
+func (kvDeviceStore) QueryDeviceAttr(key string, condition string) ([]DeviceAttr, error) {
+	var attrs []DeviceAttr
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, DeviceAttrTableName, &attrs, dbm.Cond{Expr: key, Value: condition})
+	})
+	return attrs, err
+}
+
+func (kvDeviceStore) DeviceAttrTrans(adds []DeviceAttr, deletes []DeviceDelete, updates []DeviceAttrUpdate) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		for i := range adds {
+			if err := dbm.KVInsert(tx, DeviceAttrTableName, &adds[i]); err != nil {
+				return err
+			}
+		}
+		for _, d := range deletes {
+			if _, err := dbm.KVDelete(tx, DeviceAttrTableName, new(DeviceAttr), deviceCond(d.DeviceID), nameCond(d.Name)); err != nil {
+				return err
+			}
+		}
+		for _, u := range updates {
+			if _, err := dbm.KVUpdate(tx, DeviceAttrTableName, new(DeviceAttr), u.Cols, deviceCond(u.DeviceID), nameCond(u.Name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (kvDeviceStore) UpdateDeviceTwinFields(deviceID string, name string, cols map[string]interface{}) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		num, err := dbm.KVUpdate(tx, DeviceTwinTableName, new(DeviceTwin), cols, deviceCond(deviceID), nameCond(name))
+		klog.V(4).Infof("Update affected Num: %d, %v", num, err)
+		return err
+	})
+}
+
+func (kvDeviceStore) QueryDeviceTwin(key string, condition string) ([]DeviceTwin, error) {
+	var twins []DeviceTwin
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, DeviceTwinTableName, &twins, dbm.Cond{Expr: key, Value: condition})
+	})
+	return twins, err
+}
+
+func (kvDeviceStore) ListDeviceTwins() ([]DeviceTwin, error) {
+	var twins []DeviceTwin
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, DeviceTwinTableName, &twins)
+	})
+	return twins, err
+}
+
+func (kvDeviceStore) DeviceTwinTrans(adds []DeviceTwin, deletes []DeviceDelete, updates []DeviceTwinUpdate) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		for i := range adds {
+			if err := dbm.KVInsert(tx, DeviceTwinTableName, &adds[i]); err != nil {
+				return err
+			}
+		}
+		for _, d := range deletes {
+			if _, err := dbm.KVDelete(tx, DeviceTwinTableName, new(DeviceTwin), deviceCond(d.DeviceID), nameCond(d.Name)); err != nil {
+				return err
+			}
+		}
+		for _, u := range updates {
+			if _, err := dbm.KVUpdate(tx, DeviceTwinTableName, new(DeviceTwin), u.Cols, deviceCond(u.DeviceID), nameCond(u.Name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
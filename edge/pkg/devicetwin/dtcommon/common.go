@@ -44,6 +44,8 @@ const (
 	TwinETDeltaSuffix = "/twin/update/delta"
 	// TwinETDocumentSuffix the topic suffix for twin document event
 	TwinETDocumentSuffix = "/twin/update/document"
+	// TwinETConflictSuffix the topic suffix for twin conflict event
+	TwinETConflictSuffix = "/twin/conflict"
 
 	// DeviceETUpdatedSuffix the topic suffix for device updated event
 	DeviceETUpdatedSuffix = "/updated"
@@ -53,6 +55,14 @@ const (
 	DeviceETStateUpdateResultSuffix = "/state/update/result"
 	// DeviceETStateGetSuffix the topic suffix for device state get event
 	DeviceETStateGetSuffix = "/state/get"
+	// DeviceETCommandInvokeSuffix the resource suffix for device command invocation from cloud
+	DeviceETCommandInvokeSuffix = "/command/invoke"
+	// DeviceETCommandResultSuffix the resource suffix for device command result to cloud
+	DeviceETCommandResultSuffix = "/command/result"
+	// DeviceETAlertSuffix the resource suffix for device alert to cloud
+	DeviceETAlertSuffix = "/alert"
+	// DeviceETHistoryResource the resource for device history to cloud
+	DeviceETHistoryResource = "device/history"
 
 	// MemDetailResult membership detail result
 	MemDetailResult = "MemDetailResult"
@@ -93,6 +103,10 @@ const (
 	Disconnected = "disconnected"
 	// MetaDeviceOperation event
 	MetaDeviceOperation = "MetaDeviceOperation"
+	// DeviceCommandInvoke device command invocation
+	DeviceCommandInvoke = "DeviceCommandInvoke"
+	// DeviceDesiredWrite desired values written by device alert rules
+	DeviceDesiredWrite = "DeviceDesiredWrite"
 
 	// CommModule communicate module
 	CommModule = "CommModule"
@@ -121,4 +135,18 @@ const (
 	InternalErrorCode = 500
 
 	TypeDeleted = "deleted"
+
+	// TwinConflictSourceCloud the rejected desired value is synced from cloud
+	TwinConflictSourceCloud = "cloud"
+	// TwinConflictSourceEdge the rejected desired value is updated through the eventbus
+	TwinConflictSourceEdge = "edge"
+
+	// MapperStateOnline the mapper responds to health checks
+	MapperStateOnline = "Online"
+	// MapperStateOffline the mapper does not respond to health checks
+	MapperStateOffline = "Offline"
+	// DeviceStateOffline the device is offline, e.g. its mapper is gone
+	DeviceStateOffline = "offline"
+	// DeviceStateUnknown the state of the device is not reported yet
+	DeviceStateUnknown = "unknown"
 )
@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtcommon
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/constants"
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+)
+
+const (
+	// DMIAddressTCPPrefix is the prefix of the mapper addresses served over TCP with mutual TLS
+	DMIAddressTCPPrefix = "tcp://"
+	// DMIAddressUnixPrefix is the optional prefix of the mapper addresses of unix domain sockets
+	DMIAddressUnixPrefix = "unix://"
+
+	// TCPNetworkType is the network of the mapper addresses served over TCP
+	TCPNetworkType = "tcp"
+)
+
+// ParseDMIAddress returns the network and the address to dial of a mapper address,
+// the addresses without the prefix of tcp:// are unix domain sockets
+func ParseDMIAddress(address string) (string, string) {
+	if strings.HasPrefix(address, DMIAddressTCPPrefix) {
+		return TCPNetworkType, strings.TrimPrefix(address, DMIAddressTCPPrefix)
+	}
+	return constants.UnixNetworkType, strings.TrimPrefix(address, DMIAddressUnixPrefix)
+}
+
+// DMITLSConfig returns the mutual TLS config of DMI over TCP, it is used by both the DMI server
+// and the clients of mappers. The DMI certificate of the edge node is loaded on each handshake,
+// so the rotated certificate is used without restarting edgecore
+func DMITLSConfig(tcp *v1alpha2.DeviceTwinDMITCP) (*tls.Config, error) {
+	ca, err := os.ReadFile(tcp.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca file %s: %v", tcp.TLSCAFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("failed to parse ca file %s", tcp.TLSCAFile)
+	}
+	// fail early if the certificate can not be loaded
+	if _, err := tls.LoadX509KeyPair(tcp.TLSCertFile, tcp.TLSPrivateKeyFile); err != nil {
+		return nil, fmt.Errorf("failed to load certificate %s: %v", tcp.TLSCertFile, err)
+	}
+	loadCertificate := func() (*tls.Certificate, error) {
+		cert, err := tls.LoadX509KeyPair(tcp.TLSCertFile, tcp.TLSPrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &cert, nil
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// the server verifies the mappers
+		ClientCAs:  pool,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return loadCertificate()
+		},
+		// the clients verify the mappers
+		RootCAs: pool,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return loadCertificate()
+		},
+	}, nil
+}
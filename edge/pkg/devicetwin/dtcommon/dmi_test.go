@@ -0,0 +1,218 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtcommon
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+)
+
+func TestParseDMIAddress(t *testing.T) {
+	tests := []struct {
+		address     string
+		wantNetwork string
+		wantAddress string
+	}{
+		{address: "/etc/kubeedge/modbus.sock", wantNetwork: "unix", wantAddress: "/etc/kubeedge/modbus.sock"},
+		{address: "unix:///etc/kubeedge/modbus.sock", wantNetwork: "unix", wantAddress: "/etc/kubeedge/modbus.sock"},
+		{address: "tcp://192.168.1.10:10353", wantNetwork: "tcp", wantAddress: "192.168.1.10:10353"},
+	}
+	for _, tt := range tests {
+		network, address := ParseDMIAddress(tt.address)
+		if network != tt.wantNetwork || address != tt.wantAddress {
+			t.Errorf("ParseDMIAddress(%s) = %s, %s, want %s, %s", tt.address, network, address, tt.wantNetwork, tt.wantAddress)
+		}
+	}
+}
+
+// testCert is a certificate issued for the tests and its key
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+// issueCert issues a certificate for 127.0.0.1 by the parent, the certificate is self-signed if parent is nil
+func issueCert(t *testing.T, parent *testCert, name string, isCA bool) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if isCA {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+	issuer, issuerKey := template, key
+	if parent != nil {
+		issuer, issuerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+// writeFiles writes the certificate and its key to the dir, and returns their paths
+func (c *testCert) writeFiles(t *testing.T, dir, name string) (string, string) {
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+// handshake runs the TLS handshake between the server and the client config, and returns the error of both sides
+func handshake(t *testing.T, serverConfig, clientConfig *tls.Config) (error, error) {
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer lis.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		err = conn.(*tls.Conn).Handshake()
+		if err == nil {
+			_, err = conn.Write([]byte{0})
+		}
+		serverErr <- err
+	}()
+
+	conn, err := tls.Dial("tcp", lis.Addr().String(), clientConfig)
+	if err == nil {
+		// the server verifies the client certificate after the client finishes the handshake in TLS 1.3,
+		// the rejection is only seen by reading from the connection
+		_, err = conn.Read(make([]byte, 1))
+		conn.Close()
+	}
+	return <-serverErr, err
+}
+
+func TestDMITLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	// the CA which issues the DMI certificate of the edge node, and the CA dedicated to mappers
+	edgeCA := issueCert(t, nil, "edge-ca", true)
+	mapperCA := issueCert(t, nil, "mapper-ca", true)
+	mapperCAFile, _ := mapperCA.writeFiles(t, dir, "mapper-ca")
+	certFile, keyFile := issueCert(t, edgeCA, "edge-node", false).writeFiles(t, dir, "dmi")
+	tcp := &v1alpha2.DeviceTwinDMITCP{
+		Enable:            true,
+		TLSCAFile:         mapperCAFile,
+		TLSCertFile:       certFile,
+		TLSPrivateKeyFile: keyFile,
+	}
+	tlsConfig, err := DMITLSConfig(tcp)
+	if err != nil {
+		t.Fatalf("DMITLSConfig() error = %v", err)
+	}
+
+	edgeCAPool := x509.NewCertPool()
+	edgeCAPool.AddCert(edgeCA.cert)
+	mapperCAPool := x509.NewCertPool()
+	mapperCAPool.AddCert(mapperCA.cert)
+	mapperCert := issueCert(t, mapperCA, "mapper", false).tlsCertificate()
+	otherCert := issueCert(t, edgeCA, "other", false).tlsCertificate()
+
+	tests := []struct {
+		name    string
+		client  *tls.Config
+		wantErr bool
+	}{
+		{
+			name:   "mapper with the certificate of the mapper CA",
+			client: &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: edgeCAPool, Certificates: []tls.Certificate{mapperCert}},
+		},
+		{
+			name:    "mapper without certificate",
+			client:  &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: edgeCAPool},
+			wantErr: true,
+		},
+		{
+			name:    "mapper with the certificate of another CA",
+			client:  &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: edgeCAPool, Certificates: []tls.Certificate{otherCert}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		serverErr, clientErr := handshake(t, tlsConfig, tt.client)
+		if (serverErr != nil) != tt.wantErr || (clientErr != nil) != tt.wantErr {
+			t.Errorf("%s: handshake error of server = %v, client = %v, want error %v", tt.name, serverErr, clientErr, tt.wantErr)
+		}
+	}
+
+	// the edge node calls the mapper on another host with the same certificate
+	mapperServer := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{mapperCert},
+		ClientCAs:    edgeCAPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	if serverErr, clientErr := handshake(t, mapperServer, tlsConfig); serverErr != nil || clientErr != nil {
+		t.Errorf("calling mapper: handshake error of server = %v, client = %v", serverErr, clientErr)
+	}
+
+	tcp.TLSCAFile = filepath.Join(t.TempDir(), "missing.crt")
+	if _, err := DMITLSConfig(tcp); err == nil {
+		t.Errorf("DMITLSConfig() with missing ca file, want error")
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtcontext
+
+import (
+	"sync"
+
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+)
+
+// DeviceSpecs keeps the specs of the devices and device models synced from cloud,
+// the desired values updated on the edge node are validated against them
+type DeviceSpecs struct {
+	mu sync.RWMutex
+	// devices, key is the name of device
+	devices map[string]*v1alpha2.Device
+	// models, key is namespace/name of device model
+	models map[string]*v1alpha2.DeviceModel
+}
+
+// NewDeviceSpecs creates an empty DeviceSpecs
+func NewDeviceSpecs() *DeviceSpecs {
+	return &DeviceSpecs{
+		devices: make(map[string]*v1alpha2.Device),
+		models:  make(map[string]*v1alpha2.DeviceModel),
+	}
+}
+
+// SetDevice adds or updates the device
+func (s *DeviceSpecs) SetDevice(device *v1alpha2.Device) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.devices[device.Name] = device
+}
+
+// DeleteDevice deletes the device
+func (s *DeviceSpecs) DeleteDevice(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.devices, name)
+}
+
+// SetDeviceModel adds or updates the device model
+func (s *DeviceSpecs) SetDeviceModel(model *v1alpha2.DeviceModel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.models[model.Namespace+"/"+model.Name] = model
+}
+
+// DeleteDeviceModel deletes the device model
+func (s *DeviceSpecs) DeleteDeviceModel(namespace, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.models, namespace+"/"+name)
+}
+
+// GetDeviceModel returns the device model referenced by the device,
+// it returns false if the device or the device model is not synced
+func (s *DeviceSpecs) GetDeviceModel(deviceID string) (*v1alpha2.DeviceModel, bool) {
+	if s == nil {
+		return nil, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	device, ok := s.devices[deviceID]
+	if !ok || device.Spec.DeviceModelRef == nil {
+		return nil, false
+	}
+	model, ok := s.models[device.Namespace+"/"+device.Spec.DeviceModelRef.Name]
+	return model, ok
+}
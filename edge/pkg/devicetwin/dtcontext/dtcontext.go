@@ -12,6 +12,7 @@ import (
 	"github.com/kubeedge/beehive/pkg/core/model"
 	"github.com/kubeedge/kubeedge/edge/pkg/common/modules"
 	deviceconfig "github.com/kubeedge/kubeedge/edge/pkg/devicetwin/config"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtalert"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dttype"
 )
@@ -28,6 +29,10 @@ type DTContext struct {
 	DeviceList     *sync.Map
 	DeviceMutex    *sync.Map
 	Mutex          *sync.RWMutex
+	// AlertEngine evaluates the device alert rules synced to the node
+	AlertEngine *dtalert.Engine
+	// DeviceSpecs keeps the devices and device models to validate the desired values
+	DeviceSpecs *DeviceSpecs
 	// DBConn *dtclient.Conn
 	State string
 }
@@ -44,6 +49,8 @@ func InitDTContext() (*DTContext, error) {
 		DeviceList:    &sync.Map{},
 		DeviceMutex:   &sync.Map{},
 		Mutex:         &sync.RWMutex{},
+		AlertEngine:   dtalert.NewEngine(),
+		DeviceSpecs:   NewDeviceSpecs(),
 		State:         dtcommon.Disconnected,
 	}, nil
 }
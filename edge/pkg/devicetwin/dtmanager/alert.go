@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtmanager
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"k8s.io/klog/v2"
+
+	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
+	"github.com/kubeedge/beehive/pkg/core/model"
+	messagepkg "github.com/kubeedge/kubeedge/edge/pkg/common/message"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/modules"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtalert"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcontext"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dttype"
+)
+
+// evaluateAlertRules evaluates the alert rules against the actual values of the twin update,
+// and takes the actions of the alerts which fire or resolve
+func evaluateAlertRules(context *dtcontext.DTContext, deviceID string, msgTwin map[string]*dttype.MsgTwin) {
+	if context.AlertEngine == nil {
+		return
+	}
+	var alerts []dtalert.Alert
+	for key, twin := range msgTwin {
+		if twin == nil || twin.Actual == nil || twin.Actual.Value == nil {
+			continue
+		}
+		alerts = append(alerts, context.AlertEngine.Evaluate(deviceID, key, *twin.Actual.Value)...)
+	}
+	dealAlerts(context, alerts)
+}
+
+// CheckAbsentAlerts fires the alerts of the properties which are not reported for the duration of the rules,
+// it is called periodically
+func CheckAbsentAlerts(context *dtcontext.DTContext) {
+	if context.AlertEngine == nil {
+		return
+	}
+	dealAlerts(context, context.AlertEngine.CheckAbsent())
+}
+
+func dealAlerts(context *dtcontext.DTContext, alerts []dtalert.Alert) {
+	for _, alert := range alerts {
+		rule := alert.Rule
+		klog.Infof("Alert %s/%s of device %s firing: %v, %s", rule.Namespace, rule.Name, rule.Spec.DeviceName, alert.Firing, alert.Message)
+		deviceAlert := dttype.DeviceAlert{
+			BaseMessage: dttype.BuildBaseMessage(),
+			Namespace:   rule.Namespace,
+			Name:        rule.Name,
+			Device:      rule.Spec.DeviceName,
+			Property:    rule.Spec.PropertyName,
+			Value:       alert.Value,
+			Firing:      alert.Firing,
+			Message:     alert.Message,
+		}
+		deviceAlert.Timestamp = alert.Timestamp
+
+		// the desired values are written first, they must not wait for the other actions
+		if alert.Firing {
+			for _, write := range rule.Spec.Actions.DesiredWrites {
+				writeDesiredValue(context, write.DeviceName, write.PropertyName, write.Value)
+			}
+		}
+		if rule.Spec.Actions.Topic != "" {
+			publishAlert(context, rule.Spec.Actions.Topic, &deviceAlert)
+		}
+		reportAlert(context, &deviceAlert)
+	}
+}
+
+// writeDesiredValue updates the desired value of the device twin through the twin module,
+// and sends it to the mapper if the device is managed through DMI. The value is validated
+// against the device model first, like the desired values updated by the users
+func writeDesiredValue(context *dtcontext.DTContext, deviceID, property, value string) {
+	update := dttype.DeviceTwinUpdate{
+		BaseMessage: dttype.BuildBaseMessage(),
+		Twin: map[string]*dttype.MsgTwin{
+			property: {Expected: &dttype.TwinValue{Value: &value}},
+		},
+	}
+	if err := validateDesiredTwins(context, deviceID, update.Twin); err != nil {
+		klog.Errorf("write desired value of device %s rejected, err: %v", deviceID, err)
+		return
+	}
+	payload, err := json.Marshal(update)
+	if err != nil {
+		klog.Errorf("marshal desired value of device %s failed with err: %v", deviceID, err)
+		return
+	}
+	topic := dtcommon.DeviceETPrefix + deviceID + dtcommon.TwinETUpdateSuffix
+	resource := base64.URLEncoding.EncodeToString([]byte(topic))
+	// send through beehive instead of the channel of twin module, the rules are evaluated in twin module
+	message := model.NewMessage("").BuildRouter(modules.BusGroup, modules.UserGroup,
+		resource, messagepkg.OperationResponse).FillBody(string(payload))
+	beehiveContext.SendToGroup(modules.TwinGroup, *message)
+
+	if err := context.CommTo(dtcommon.DMIModule, &dttype.DTMessage{
+		Action:   dtcommon.DeviceDesiredWrite,
+		Identity: deviceID,
+		Msg:      model.NewMessage("").FillBody(map[string]string{property: value}),
+	}); err != nil {
+		klog.Errorf("send desired value of device %s to DMI failed with err: %v", deviceID, err)
+	}
+}
+
+func publishAlert(context *dtcontext.DTContext, topic string, alert *dttype.DeviceAlert) {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		klog.Errorf("marshal alert %s/%s failed with err: %v", alert.Namespace, alert.Name, err)
+		return
+	}
+	err = context.Send("",
+		dtcommon.SendToEdge,
+		dtcommon.CommModule,
+		context.BuildModelMessage(modules.BusGroup, "", topic, messagepkg.OperationPublish, payload))
+	if err != nil {
+		klog.Errorf("publish alert %s/%s failed with err: %v", alert.Namespace, alert.Name, err)
+	}
+}
+
+// reportAlert reports the alert to cloud, the alert is kept to be resent until cloud confirms it,
+// so the alerts raised while the node is disconnected are reported after it reconnects
+func reportAlert(context *dtcontext.DTContext, alert *dttype.DeviceAlert) {
+	resource := "device/" + alert.Device + dtcommon.DeviceETAlertSuffix
+	msg := context.BuildModelMessage("resource", "", resource, model.UpdateOperation, alert)
+	context.ConfirmMap.Store(msg.GetID(), &dttype.DTMessage{Msg: msg, Action: dtcommon.SendToCloud, Type: dtcommon.CommModule})
+	if err := context.Send("", dtcommon.SendToCloud, dtcommon.CommModule, msg); err != nil {
+		klog.Errorf("report alert %s/%s failed with err: %v", alert.Namespace, alert.Name, err)
+	}
+}
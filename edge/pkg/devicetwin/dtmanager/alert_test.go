@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtmanager
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubeedge/beehive/pkg/common"
+	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/modules"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcontext"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dttype"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+)
+
+// TestWriteDesiredValue is function to test writeDesiredValue
+func TestWriteDesiredValue(t *testing.T) {
+	beehiveContext.InitContext([]string{common.MsgCtxTypeChannel})
+	beehiveContext.AddModule(&common.ModuleInfo{ModuleName: modules.TwinGroup, ModuleType: common.MsgCtxTypeChannel})
+	beehiveContext.AddModuleGroup(modules.TwinGroup, modules.TwinGroup)
+
+	specs := dtcontext.NewDeviceSpecs()
+	deviceModel := &v1alpha2.DeviceModel{}
+	deviceModel.Namespace, deviceModel.Name = "default", "model"
+	deviceModel.Spec.Properties = []v1alpha2.DeviceProperty{
+		{
+			Name: key1,
+			Type: v1alpha2.PropertyType{Int: &v1alpha2.PropertyTypeInt64{AccessMode: v1alpha2.ReadWrite, Maximum: 10}},
+		},
+		{
+			Name: "temperature",
+			Type: v1alpha2.PropertyType{Int: &v1alpha2.PropertyTypeInt64{AccessMode: v1alpha2.ReadOnly}},
+		},
+	}
+	device := &v1alpha2.Device{}
+	device.Namespace, device.Name = "default", deviceA
+	device.Spec.DeviceModelRef = &v1.LocalObjectReference{Name: "model"}
+	specs.SetDeviceModel(deviceModel)
+	specs.SetDevice(device)
+	dmiChan := make(chan interface{}, 1)
+	context := &dtcontext.DTContext{
+		DeviceSpecs: specs,
+		CommChan:    map[string]chan interface{}{dtcommon.DMIModule: dmiChan},
+	}
+
+	tests := []struct {
+		name     string
+		property string
+		value    string
+		written  bool
+	}{
+		{
+			name:     "TestWriteDesiredValue(): Case 1: valid desired value",
+			property: key1,
+			value:    "5",
+			written:  true,
+		},
+		{
+			name:     "TestWriteDesiredValue(): Case 2: desired value out of range",
+			property: key1,
+			value:    "11",
+		},
+		{
+			name:     "TestWriteDesiredValue(): Case 3: ReadOnly property",
+			property: "temperature",
+			value:    "5",
+		},
+		{
+			name:     "TestWriteDesiredValue(): Case 4: property not defined by device model",
+			property: "undefined",
+			value:    "5",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writeDesiredValue(context, deviceA, test.property, test.value)
+			select {
+			case msg := <-dmiChan:
+				if !test.written {
+					t.Errorf("DTManager.TestWriteDesiredValue() case failed: got %+v sent to DMI, want none", msg)
+				} else if dtMsg := msg.(*dttype.DTMessage); dtMsg.Identity != deviceA || dtMsg.Action != dtcommon.DeviceDesiredWrite {
+					t.Errorf("DTManager.TestWriteDesiredValue() case failed: got %+v sent to DMI", dtMsg)
+				}
+				if _, err := beehiveContext.Receive(modules.TwinGroup); err != nil {
+					t.Errorf("DTManager.TestWriteDesiredValue() case failed: twin update not sent, err: %v", err)
+				}
+			default:
+				if test.written {
+					t.Errorf("DTManager.TestWriteDesiredValue() case failed: desired value not sent to DMI")
+				}
+			}
+		})
+	}
+}
@@ -22,21 +22,33 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 
+	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
 	"github.com/kubeedge/beehive/pkg/core/model"
 	"github.com/kubeedge/kubeedge/cloud/pkg/devicecontroller/constants"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/config"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dmiclient"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dmiserver"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcontext"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtseries"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dttype"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
 	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
 	pb "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
 )
 
+const (
+	// defaultDeviceCommandTimeout is the timeout of a device command invocation without the timeout specified
+	defaultDeviceCommandTimeout = 30 * time.Second
+	// timeSeriesPruneInterval is the interval to drop the expired values from the time-series buffer
+	timeSeriesPruneInterval = time.Minute
+)
+
 //TwinWorker deal twin event
 type DMIWorker struct {
 	Worker
@@ -48,18 +60,38 @@ type DMIWorker struct {
 
 func (dw *DMIWorker) init() {
 	dw.dmiCache = &dmiserver.DMICache{
-		MapperMu:        &sync.Mutex{},
-		DeviceMu:        &sync.Mutex{},
-		DeviceModelMu:   &sync.Mutex{},
-		MapperList:      make(map[string]*pb.MapperInfo),
-		DeviceList:      make(map[string]*v1alpha2.Device),
-		DeviceModelList: make(map[string]*v1alpha2.DeviceModel),
+		MapperMu:         &sync.Mutex{},
+		DeviceMu:         &sync.Mutex{},
+		DeviceModelMu:    &sync.Mutex{},
+		MapperList:       make(map[string]*pb.MapperInfo),
+		MapperIdentities: make(map[string]string),
+		DeviceList:       make(map[string]*v1alpha2.Device),
+		DeviceModelList:  make(map[string]*v1alpha2.DeviceModel),
 	}
 
 	dw.initDMIActionCallBack()
 	dw.initDeviceModelInfoFromDB()
 	dw.initDeviceInfoFromDB()
 	dw.initDeviceMapperInfoFromDB()
+	dw.initDeviceAlertRulesFromDB()
+	dw.initTimeSeries()
+}
+
+// initTimeSeries creates the time-series buffer of the reported values if it is enabled,
+// and starts to upload the history to the cloud if the upload topic is configured
+func (dw *DMIWorker) initTimeSeries() {
+	timeSeries := config.Get().TimeSeries
+	if timeSeries == nil || !timeSeries.Enable {
+		return
+	}
+	dw.dmiCache.Series = dtseries.NewStore(time.Duration(timeSeries.MaxAge)*time.Second, int(timeSeries.MaxPointsPerProperty))
+	go wait.Until(dw.dmiCache.Series.Prune, timeSeriesPruneInterval, beehiveContext.Done())
+
+	if timeSeries.UploadTopic == "" {
+		return
+	}
+	uploader := dtseries.NewUploader(dw.dmiCache.Series, dw.DTContexts, timeSeries.UploadTopic, int(timeSeries.UploadBatchSize))
+	go wait.Until(uploader.Upload, time.Duration(timeSeries.UploadPeriod)*time.Second, beehiveContext.Done())
 }
 
 //Start worker
@@ -68,6 +100,7 @@ func (dw DMIWorker) Start() {
 	dw.init()
 
 	go dmiserver.StartDMIServer(dw.dmiCache)
+	go dmiserver.StartMapperHealthCheck(dw.dmiCache)
 
 	for {
 		select {
@@ -101,6 +134,151 @@ func (dw DMIWorker) Start() {
 func (dw *DMIWorker) initDMIActionCallBack() {
 	dw.dmiActionCallBack = make(map[string]CallBack)
 	dw.dmiActionCallBack[dtcommon.MetaDeviceOperation] = dw.dealMetaDeviceOperation
+	dw.dmiActionCallBack[dtcommon.DeviceCommandInvoke] = dw.dealDeviceCommandInvoke
+	dw.dmiActionCallBack[dtcommon.DeviceDesiredWrite] = dw.dealDeviceDesiredWrite
+}
+
+// dealDeviceDesiredWrite sends the desired values to the mapper if the device is managed through DMI,
+// the other mappers get them from the twin delta on eventbus
+func (dw *DMIWorker) dealDeviceDesiredWrite(context *dtcontext.DTContext, deviceID string, msg interface{}) error {
+	message, ok := msg.(*model.Message)
+	if !ok {
+		return errors.New("msg not Message type")
+	}
+	desired, ok := message.Content.(map[string]string)
+	if !ok {
+		return errors.New("invalid message content")
+	}
+	dw.dmiCache.DeviceMu.Lock()
+	device, ok := dw.dmiCache.DeviceList[deviceID]
+	dw.dmiCache.DeviceMu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	versions := desiredVersions(context, deviceID)
+	// do not block the other device operations on a slow mapper
+	go func() {
+		if err := dmiclient.DMIClientsImp.UpdateDeviceStatus(device, desired, versions); err != nil {
+			klog.Errorf("update desired values of device %s failed with err: %v", deviceID, err)
+		}
+	}()
+	return nil
+}
+
+// desiredVersions returns the versions of the desired values of the device twins merged by edgecore,
+// key is the property name
+func desiredVersions(context *dtcontext.DTContext, deviceID string) map[string]*v1alpha2.TwinVersion {
+	if !context.Lock(deviceID) {
+		return nil
+	}
+	defer context.Unlock(deviceID)
+	device, ok := context.GetDevice(deviceID)
+	if !ok {
+		return nil
+	}
+	versions := make(map[string]*v1alpha2.TwinVersion, len(device.Twin))
+	for property, twin := range device.Twin {
+		if twin != nil && twin.ExpectedVersion != nil {
+			versions[property] = &v1alpha2.TwinVersion{
+				CloudVersion: twin.ExpectedVersion.CloudVersion,
+				EdgeVersion:  twin.ExpectedVersion.EdgeVersion,
+			}
+		}
+	}
+	return versions
+}
+
+// setDesiredVersions sets the desired versions of the device twins sent to the mapper
+func setDesiredVersions(device *v1alpha2.Device, versions map[string]*v1alpha2.TwinVersion) {
+	for i := range device.Status.Twins {
+		if version, ok := versions[device.Status.Twins[i].PropertyName]; ok {
+			device.Status.Twins[i].DesiredVersion = version
+		}
+	}
+}
+
+func (dw *DMIWorker) dealDeviceCommandInvoke(context *dtcontext.DTContext, deviceID string, msg interface{}) error {
+	message, ok := msg.(*model.Message)
+	if !ok {
+		return errors.New("msg not Message type")
+	}
+	content, ok := message.Content.([]byte)
+	if !ok {
+		return errors.New("invalid message content")
+	}
+	var invocation dttype.DeviceCommandInvocation
+	if err := json.Unmarshal(content, &invocation); err != nil {
+		return fmt.Errorf("invalid message content with err: %+v", err)
+	}
+
+	// the command may take a while on the device, do not block the other device operations
+	go dw.invokeDeviceCommand(context, deviceID, &invocation)
+	return nil
+}
+
+// invokeDeviceCommand invokes the command through the mapper and reports the result to cloud
+func (dw *DMIWorker) invokeDeviceCommand(context *dtcontext.DTContext, deviceID string, invocation *dttype.DeviceCommandInvocation) {
+	result := dttype.DeviceCommandResult{
+		BaseMessage: dttype.BuildBaseMessage(),
+		Namespace:   invocation.Namespace,
+		Name:        invocation.Name,
+	}
+	resp, err := dw.callDeviceCommand(deviceID, invocation)
+	if err != nil {
+		klog.Errorf("invoke command %s of device %s failed with err: %v", invocation.Command, deviceID, err)
+		result.Message = err.Error()
+	} else {
+		result.Succeeded = true
+		result.StatusCode = resp.GetStatusCode()
+		result.Result = string(resp.GetResult())
+	}
+
+	resource := "device/" + deviceID + dtcommon.DeviceETCommandResultSuffix
+	err = context.Send("",
+		dtcommon.SendToCloud,
+		dtcommon.CommModule,
+		context.BuildModelMessage("resource", "", resource, model.UpdateOperation, result))
+	if err != nil {
+		klog.Errorf("send result of command %s of device %s failed with err: %v", invocation.Command, deviceID, err)
+	}
+}
+
+func (dw *DMIWorker) callDeviceCommand(deviceID string, invocation *dttype.DeviceCommandInvocation) (*pb.InvokeDeviceCommandResponse, error) {
+	dw.dmiCache.DeviceMu.Lock()
+	device, ok := dw.dmiCache.DeviceList[deviceID]
+	dw.dmiCache.DeviceMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("device %s not found on edge node", deviceID)
+	}
+	if device.Spec.DeviceModelRef == nil {
+		return nil, fmt.Errorf("device %s has no device model", deviceID)
+	}
+
+	dw.dmiCache.DeviceModelMu.Lock()
+	dm, ok := dw.dmiCache.DeviceModelList[device.Spec.DeviceModelRef.Name]
+	dw.dmiCache.DeviceModelMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("device model %s not found on edge node", device.Spec.DeviceModelRef.Name)
+	}
+	if !hasDeviceCommand(dm, invocation.Command) {
+		return nil, fmt.Errorf("command %s is not declared by device model %s", invocation.Command, dm.Name)
+	}
+
+	timeout := time.Duration(invocation.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultDeviceCommandTimeout
+	}
+	return dmiclient.DMIClientsImp.InvokeDeviceCommand(device, invocation.Command, invocation.Parameters, timeout)
+}
+
+func hasDeviceCommand(dm *v1alpha2.DeviceModel, command string) bool {
+	for _, c := range dm.Spec.Commands {
+		if c.Name == command {
+			return true
+		}
+	}
+	return false
 }
 
 func (dw *DMIWorker) dealMetaDeviceOperation(context *dtcontext.DTContext, resource string, msg interface{}) error {
@@ -120,8 +298,14 @@ func (dw *DMIWorker) dealMetaDeviceOperation(context *dtcontext.DTContext, resou
 		if err != nil {
 			return fmt.Errorf("invalid message content with err: %+v", err)
 		}
+		if message.GetOperation() == model.DeleteOperation {
+			context.DeviceSpecs.DeleteDevice(device.Name)
+		} else {
+			context.DeviceSpecs.SetDevice(&device)
+		}
 		switch message.GetOperation() {
 		case model.InsertOperation:
+			setDesiredVersions(&device, desiredVersions(context, device.Name))
 			err = dmiclient.DMIClientsImp.RegisterDevice(&device)
 			if err != nil {
 				klog.Errorf("add device %s failed with err: %v", device.Name, err)
@@ -139,7 +323,11 @@ func (dw *DMIWorker) dealMetaDeviceOperation(context *dtcontext.DTContext, resou
 			dw.dmiCache.DeviceMu.Lock()
 			delete(dw.dmiCache.DeviceList, device.Name)
 			dw.dmiCache.DeviceMu.Unlock()
+			if dw.dmiCache.Series != nil {
+				dw.dmiCache.Series.DeleteDevice(device.Name)
+			}
 		case model.UpdateOperation:
+			setDesiredVersions(&device, desiredVersions(context, device.Name))
 			err = dmiclient.DMIClientsImp.UpdateDevice(&device)
 			if err != nil {
 				klog.Errorf("udpate device %s failed with err: %v", device.Name, err)
@@ -156,6 +344,11 @@ func (dw *DMIWorker) dealMetaDeviceOperation(context *dtcontext.DTContext, resou
 		if err != nil {
 			return fmt.Errorf("invalid message content with err: %+v", err)
 		}
+		if message.GetOperation() == model.DeleteOperation {
+			context.DeviceSpecs.DeleteDeviceModel(dm.Namespace, dm.Name)
+		} else {
+			context.DeviceSpecs.SetDeviceModel(&dm)
+		}
 		switch message.GetOperation() {
 		case model.InsertOperation:
 			err = dmiclient.DMIClientsImp.CreateDeviceModel(&dm)
@@ -188,8 +381,23 @@ func (dw *DMIWorker) dealMetaDeviceOperation(context *dtcontext.DTContext, resou
 			klog.Warningf("unsupported operation %s", message.GetOperation())
 		}
 
+	case constants.ResourceTypeDeviceAlertRule:
+		var rule v1alpha2.DeviceAlertRule
+		err := json.Unmarshal(message.Content.([]byte), &rule)
+		if err != nil {
+			return fmt.Errorf("invalid message content with err: %+v", err)
+		}
+		switch message.GetOperation() {
+		case model.InsertOperation, model.UpdateOperation:
+			context.AlertEngine.SetRule(&rule)
+		case model.DeleteOperation:
+			context.AlertEngine.DeleteRule(rule.Namespace, rule.Name)
+		default:
+			klog.Warningf("unsupported operation %s", message.GetOperation())
+		}
+
 	default:
-		klog.Warningf("unsupported resource type %s", resources[3])
+		klog.Warningf("unsupported resource type %s", resources[1])
 	}
 
 	return nil
@@ -211,10 +419,29 @@ func (dw *DMIWorker) initDeviceModelInfoFromDB() {
 		dw.dmiCache.DeviceModelMu.Lock()
 		dw.dmiCache.DeviceModelList[deviceModel.Name] = &deviceModel
 		dw.dmiCache.DeviceModelMu.Unlock()
+		dw.DTContexts.DeviceSpecs.SetDeviceModel(&deviceModel)
 	}
 	klog.Infoln("success to init device model info from db")
 }
 
+func (dw *DMIWorker) initDeviceAlertRulesFromDB() {
+	metas, err := dao.QueryMeta("type", constants.ResourceTypeDeviceAlertRule)
+	if err != nil {
+		klog.Errorf("fail to init device alert rules from db with err: %v", err)
+		return
+	}
+
+	for _, meta := range *metas {
+		rule := v1alpha2.DeviceAlertRule{}
+		if err := json.Unmarshal([]byte(meta), &rule); err != nil {
+			klog.Errorf("fail to unmarshal device alert rule from db with err: %v", err)
+			return
+		}
+		dw.DTContexts.AlertEngine.SetRule(&rule)
+	}
+	klog.Infoln("success to init device alert rules from db")
+}
+
 func (dw *DMIWorker) initDeviceInfoFromDB() {
 	metas, err := dao.QueryMeta("type", constants.ResourceTypeDevice)
 	if err != nil {
@@ -231,6 +458,7 @@ func (dw *DMIWorker) initDeviceInfoFromDB() {
 		dw.dmiCache.DeviceMu.Lock()
 		dw.dmiCache.DeviceList[device.Name] = &device
 		dw.dmiCache.DeviceMu.Unlock()
+		dw.DTContexts.DeviceSpecs.SetDevice(&device)
 	}
 	klog.Infoln("success to init device info from db")
 }
@@ -244,13 +472,17 @@ func (dw *DMIWorker) initDeviceMapperInfoFromDB() {
 
 	for _, meta := range *metas {
 		deviceMapper := pb.MapperInfo{}
-		if err := json.Unmarshal([]byte(meta), &deviceMapper); err != nil {
+		saved := dmiserver.SavedMapper{MapperInfo: &deviceMapper}
+		if err := json.Unmarshal([]byte(meta), &saved); err != nil {
 			klog.Errorf("fail to unmarshal device mapper info from db with err: %v", err)
 			return
 		}
 		dw.dmiCache.MapperMu.Lock()
 		dw.dmiCache.MapperList[deviceMapper.Name] = &deviceMapper
+		dw.dmiCache.MapperIdentities[deviceMapper.Name] = saved.Identity
 		dw.dmiCache.MapperMu.Unlock()
+		// the mapper does not register again after edgecore restarts, reconnect it with the saved address
+		dmiclient.DMIClientsImp.CreateDMIClient(deviceMapper.Protocol, string(deviceMapper.Address))
 	}
 	klog.Infoln("success to init device mapper info from db")
 }
@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtmanager
+
+import (
+	"testing"
+
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dttype"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+)
+
+func TestDesiredVersions(t *testing.T) {
+	context := contextFunc("sensor")
+	device, _ := context.GetDevice("sensor")
+	device.Twin = map[string]*dttype.MsgTwin{
+		"temperature": {ExpectedVersion: &dttype.TwinVersion{CloudVersion: 3, EdgeVersion: 4}},
+		"humidity":    {},
+	}
+
+	versions := desiredVersions(&context, "sensor")
+	if len(versions) != 1 || *versions["temperature"] != (v1alpha2.TwinVersion{CloudVersion: 3, EdgeVersion: 4}) {
+		t.Errorf("desiredVersions() = %v, want the version of temperature only", versions)
+	}
+	if versions := desiredVersions(&context, "missing"); len(versions) != 0 {
+		t.Errorf("desiredVersions() of a missing device = %v, want empty", versions)
+	}
+
+	instance := &v1alpha2.Device{Status: v1alpha2.DeviceStatus{Twins: []v1alpha2.Twin{
+		{PropertyName: "temperature"},
+		{PropertyName: "humidity"},
+	}}}
+	setDesiredVersions(instance, versions)
+	if version := instance.Status.Twins[0].DesiredVersion; version == nil || version.EdgeVersion != 4 {
+		t.Errorf("desired version of temperature = %v, want edge version 4", version)
+	}
+	if version := instance.Status.Twins[1].DesiredVersion; version != nil {
+		t.Errorf("desired version of humidity = %v, want nil", version)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcontext"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dttype"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2/validation"
 )
 
 const (
@@ -161,7 +162,37 @@ func Updated(context *dtcontext.DTContext, deviceID string, payload []byte) {
 	}
 	klog.Infof("Begin to update twin of the device %s", deviceID)
 	eventID := msg.EventID
-	DealDeviceTwin(context, deviceID, eventID, msg.Twin, RestDealType)
+	if err := validateDesiredTwins(context, deviceID, msg.Twin); err != nil {
+		klog.Errorf("Update twin of device %s rejected, err: %v", deviceID, err)
+		dealUpdateResult(context, deviceID, eventID, dtcommon.BadRequestCode, err, result)
+		return
+	}
+	if err := DealDeviceTwin(context, deviceID, eventID, msg.Twin, RestDealType); err != nil {
+		return
+	}
+	evaluateAlertRules(context, deviceID, msg.Twin)
+}
+
+// validateDesiredTwins validates the desired values against the device model of the device,
+// the same as the admission of devices in cloud. The devices without synced device model are not validated
+func validateDesiredTwins(context *dtcontext.DTContext, deviceID string, msgTwin map[string]*dttype.MsgTwin) error {
+	deviceModel, ok := context.DeviceSpecs.GetDeviceModel(deviceID)
+	if !ok {
+		return nil
+	}
+	for key, twin := range msgTwin {
+		if twin == nil || twin.Expected == nil || twin.Expected.Value == nil {
+			continue
+		}
+		property := validation.FindProperty(deviceModel, key)
+		if property == nil {
+			return fmt.Errorf("property %s is not defined by device model %s", key, deviceModel.Name)
+		}
+		if err := validation.ValidateDesiredValue(property, *twin.Expected.Value); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 //DealDeviceTwin deal device twin
@@ -186,11 +217,27 @@ func DealDeviceTwin(context *dtcontext.DTContext, deviceID string, eventID strin
 	dealTwinResult := DealMsgTwin(context, deviceID, content, dealType)
 
 	add, deletes, update := dealTwinResult.Add, dealTwinResult.Delete, dealTwinResult.Update
+	if len(dealTwinResult.Conflicts) > 0 {
+		dealConflict(context, deviceID, dttype.BaseMessage{EventID: eventID, Timestamp: now}, dealTwinResult.Conflicts)
+	}
 	if dealType == RestDealType && dealTwinResult.Err != nil {
 		SyncDeviceFromSqlite(context, deviceID)
 		err = dealTwinResult.Err
+		code := dtcommon.BadRequestCode
+		if errors.Is(err, dttype.ErrVersionConflict) {
+			code = dtcommon.ConflictCode
+		}
 		updateResult, _ := dttype.BuildDeviceTwinResult(dttype.BaseMessage{EventID: eventID, Timestamp: now}, dealTwinResult.Result, 0)
-		dealUpdateResult(context, deviceID, eventID, dtcommon.BadRequestCode, err, updateResult)
+		dealUpdateResult(context, deviceID, eventID, code, err, updateResult)
+		return err
+	}
+	if err = dtclient.CheckDeviceTwinQuota(add); err != nil {
+		klog.Errorf("Update device twin of device %s refused: %v", deviceID, err)
+		SyncDeviceFromSqlite(context, deviceID)
+		if dealType == RestDealType {
+			updateResult, _ := dttype.BuildDeviceTwinResult(dttype.BaseMessage{EventID: eventID, Timestamp: now}, dealTwinResult.Result, 0)
+			dealUpdateResult(context, deviceID, eventID, dtcommon.BadRequestCode, err, updateResult)
+		}
 		return err
 	}
 	if len(add) != 0 || len(deletes) != 0 || len(update) != 0 {
@@ -278,6 +325,39 @@ func dealSyncResult(context *dtcontext.DTContext, deviceID string, baseMessage d
 		context.BuildModelMessage("resource", "", resource, model.UpdateOperation, dttype.DeviceTwinResult{BaseMessage: baseMessage, Twin: twin}))
 }
 
+// dealConflict publishes the desired updates rejected due to version conflict, and reports the
+// rejected cloud updates to cloud. The report is kept to be resent until cloud confirms it
+func dealConflict(context *dtcontext.DTContext, deviceID string, baseMessage dttype.BaseMessage, conflicts map[string]*dttype.TwinConflict) error {
+	klog.Warningf("Desired updates of %d twins of device %s are rejected due to version conflict", len(conflicts), deviceID)
+	payload, err := json.Marshal(dttype.DeviceTwinConflict{BaseMessage: baseMessage, Twin: conflicts})
+	if err != nil {
+		klog.Errorf("Marshal twin conflict of device %s failed, err: %v", deviceID, err)
+		return err
+	}
+	topic := dtcommon.DeviceETPrefix + deviceID + dtcommon.TwinETConflictSuffix
+	err = context.Send("",
+		dtcommon.SendToEdge,
+		dtcommon.CommModule,
+		context.BuildModelMessage(modules.BusGroup, "", topic, messagepkg.OperationPublish, payload))
+	if err != nil {
+		klog.Errorf("Publish twin conflict of device %s failed, err: %v", deviceID, err)
+	}
+
+	cloudConflicts := make(map[string]*dttype.TwinConflict)
+	for key, conflict := range conflicts {
+		if conflict.Source == dtcommon.TwinConflictSourceCloud {
+			cloudConflicts[key] = conflict
+		}
+	}
+	if len(cloudConflicts) == 0 {
+		return err
+	}
+	resource := "device/" + deviceID + dtcommon.TwinETConflictSuffix
+	msg := context.BuildModelMessage("resource", "", resource, model.UpdateOperation, dttype.DeviceTwinConflict{BaseMessage: baseMessage, Twin: cloudConflicts})
+	context.ConfirmMap.Store(msg.GetID(), &dttype.DTMessage{Msg: msg, Action: dtcommon.SendToCloud, Type: dtcommon.CommModule})
+	return context.Send("", dtcommon.SendToCloud, dtcommon.CommModule, msg)
+}
+
 //dealDocument build document and save current state as last state, update sqlite
 func dealDocument(context *dtcontext.DTContext, deviceID string, baseMessage dttype.BaseMessage, twinDocument map[string]*dttype.TwinDoc) error {
 	klog.Infof("Deal document of device %s: build and send document", deviceID)
@@ -345,8 +425,13 @@ func DealGetTwin(context *dtcontext.DTContext, deviceID string, payload []byte)
 }
 
 //dealtype 0:update ,2:cloud_update,1:detail result,3:deleted
+//the update is compare-and-set if the reqVersion of update is set, it is rejected unless reqVersion is the current version
 func dealVersion(version *dttype.TwinVersion, reqVersion *dttype.TwinVersion, dealType int) (bool, error) {
 	if dealType == RestDealType {
+		if reqVersion != nil && *reqVersion != *version {
+			return false, fmt.Errorf("%w: the update is based on version cloud %d edge %d, but the current version is cloud %d edge %d",
+				dttype.ErrVersionConflict, reqVersion.CloudVersion, reqVersion.EdgeVersion, version.CloudVersion, version.EdgeVersion)
+		}
 		version.EdgeVersion = version.EdgeVersion + 1
 	} else if dealType >= SyncDealType {
 		if reqVersion == nil {
@@ -359,7 +444,7 @@ func dealVersion(version *dttype.TwinVersion, reqVersion *dttype.TwinVersion, de
 			return false, errors.New("version not allowed")
 		}
 		if version.EdgeVersion > reqVersion.EdgeVersion {
-			return false, errors.New("not allowed to sync due to version conflict")
+			return false, dttype.ErrVersionConflict
 		}
 		version.CloudVersion = reqVersion.CloudVersion
 		version.EdgeVersion = reqVersion.EdgeVersion
@@ -487,6 +572,29 @@ func dealTwinDelete(returnResult *dttype.DealTwinResult, deviceID string, key st
 	}
 }
 
+// isTwinValueEqual returns whether the values of the twins are the same
+func isTwinValueEqual(value *dttype.TwinValue, other *dttype.TwinValue) bool {
+	if value == nil || value.Value == nil || other == nil || other.Value == nil {
+		return false
+	}
+	return *value.Value == *other.Value
+}
+
+// addTwinConflict records the desired value of msgTwin rejected due to version conflict with the twin
+func addTwinConflict(returnResult *dttype.DealTwinResult, key string, twin *dttype.MsgTwin, msgTwin *dttype.MsgTwin, source string) {
+	if returnResult.Conflicts == nil {
+		returnResult.Conflicts = make(map[string]*dttype.TwinConflict)
+	}
+	conflict := &dttype.TwinConflict{Source: source, Rejected: msgTwin.Expected, RejectedVersion: msgTwin.ExpectedVersion}
+	if twin.Expected != nil {
+		conflict.Current = &dttype.TwinValue{Value: twin.Expected.Value, Metadata: twin.Expected.Metadata}
+	}
+	if twin.ExpectedVersion != nil {
+		conflict.CurrentVersion = &dttype.TwinVersion{CloudVersion: twin.ExpectedVersion.CloudVersion, EdgeVersion: twin.ExpectedVersion.EdgeVersion}
+	}
+	returnResult.Conflicts[key] = conflict
+}
+
 //0:expected ,1 :actual
 func isTwinValueDiff(twin *dttype.MsgTwin, msgTwin *dttype.MsgTwin, dealType int) (bool, error) {
 	hasTwin := false
@@ -557,12 +665,16 @@ func dealTwinCompare(returnResult *dttype.DealTwinResult, deviceID string, key s
 			twin.ExpectedVersion = &dttype.TwinVersion{}
 		}
 		version := twin.ExpectedVersion
-		var msgTwinExpectedVersion *dttype.TwinVersion
-		if dealType != RestDealType {
-			msgTwinExpectedVersion = msgTwin.ExpectedVersion
-		}
-		ok, err := dealVersion(version, msgTwinExpectedVersion, dealType)
+		ok, err := dealVersion(version, msgTwin.ExpectedVersion, dealType)
 		if !ok {
+			// the desired value changed by edge is not overwritten by cloud silently, but reported as conflict
+			if errors.Is(err, dttype.ErrVersionConflict) && !isTwinValueEqual(twin.Expected, msgTwin.Expected) {
+				source := dtcommon.TwinConflictSourceCloud
+				if dealType == RestDealType {
+					source = dtcommon.TwinConflictSourceEdge
+				}
+				addTwinConflict(returnResult, key, twin, msgTwin, source)
+			}
 			// if reject the sync,  set the syncResult and then send the edge_updated msg
 			if dealType != RestDealType {
 				syncResult[key].Expected = &dttype.TwinValue{Value: twin.Expected.Value, Metadata: twin.Expected.Metadata}
@@ -717,7 +829,8 @@ func dealTwinCompare(returnResult *dttype.DealTwinResult, deviceID string, key s
 		returnResult.Document = document
 
 		if dealType == RestDealType {
-			copyResult := dttype.CopyMsgTwin(syncResult[key], true)
+			// the result keeps the version for the following compare-and-set updates
+			copyResult := dttype.CopyMsgTwin(syncResult[key], false)
 			returnResult.Result[key] = &copyResult
 			returnResult.SyncResult = syncResult
 		} else {
@@ -770,14 +883,13 @@ func dealTwinAdd(returnResult *dttype.DealTwinResult, deviceID string, key strin
 
 	if msgTwin.Expected != nil {
 		version := &dttype.TwinVersion{}
-		var msgTwinExpectedVersion *dttype.TwinVersion
-		if dealType != RestDealType {
-			msgTwinExpectedVersion = msgTwin.ExpectedVersion
-		}
-		ok, err := dealVersion(version, msgTwinExpectedVersion, dealType)
+		ok, err := dealVersion(version, msgTwin.ExpectedVersion, dealType)
 		if !ok {
 			// not match
 			if dealType == RestDealType {
+				if errors.Is(err, dttype.ErrVersionConflict) {
+					addTwinConflict(returnResult, key, &dttype.MsgTwin{ExpectedVersion: version}, msgTwin, dtcommon.TwinConflictSourceEdge)
+				}
 				returnResult.Err = err
 				return err
 			}
@@ -890,7 +1002,8 @@ func dealTwinAdd(returnResult *dttype.DealTwinResult, deviceID string, key strin
 		copySync := dttype.CopyMsgTwin(twins[key], false)
 		syncResult[key] = &copySync
 		if dealType == RestDealType {
-			copyResult := dttype.CopyMsgTwin(syncResult[key], true)
+			// the result keeps the version for the following compare-and-set updates
+			copyResult := dttype.CopyMsgTwin(syncResult[key], false)
 			returnResult.Result[key] = &copyResult
 			returnResult.SyncResult = syncResult
 		} else {
@@ -26,6 +26,7 @@ import (
 
 	"github.com/astaxie/beego/orm"
 	"github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	"github.com/kubeedge/beehive/pkg/core/model"
@@ -35,6 +36,7 @@ import (
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcontext"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dttype"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
 )
 
 var (
@@ -753,6 +755,14 @@ func TestDealVersion(t *testing.T) {
 			errorWant:  false,
 			err:        errors.New("not allowed to sync due to version conflict"),
 		},
+		{
+			name:       "TestDealVersion(): Case 3: dealType=0 && reqVersion is the current version",
+			version:    &dttype.TwinVersion{CloudVersion: 1, EdgeVersion: 1},
+			reqVersion: &dttype.TwinVersion{CloudVersion: 1, EdgeVersion: 1},
+			dealType:   RestDealType,
+			errorWant:  true,
+			err:        nil,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -768,6 +778,102 @@ func TestDealVersion(t *testing.T) {
 	}
 }
 
+// TestDealTwinCompareConflict is function to test the version conflicts of desired updates in dealTwinCompare
+func TestDealTwinCompareConflict(t *testing.T) {
+	current, rejected := "edge-value", "cloud-value"
+	tests := []struct {
+		name         string
+		value        string
+		version      *dttype.TwinVersion
+		dealType     int
+		wantErr      bool
+		wantConflict string
+		wantVersion  dttype.TwinVersion
+	}{
+		{
+			name:        "TestDealTwinCompareConflict(): Case 1: compare-and-set update with the current version",
+			value:       rejected,
+			version:     &dttype.TwinVersion{CloudVersion: 1, EdgeVersion: 2},
+			dealType:    RestDealType,
+			wantVersion: dttype.TwinVersion{CloudVersion: 1, EdgeVersion: 3},
+		},
+		{
+			name:         "TestDealTwinCompareConflict(): Case 2: compare-and-set update with an outdated version",
+			value:        rejected,
+			version:      &dttype.TwinVersion{CloudVersion: 1, EdgeVersion: 1},
+			dealType:     RestDealType,
+			wantErr:      true,
+			wantConflict: dtcommon.TwinConflictSourceEdge,
+			wantVersion:  dttype.TwinVersion{CloudVersion: 1, EdgeVersion: 2},
+		},
+		{
+			name:         "TestDealTwinCompareConflict(): Case 3: cloud sync not based on the edge update",
+			value:        rejected,
+			version:      &dttype.TwinVersion{CloudVersion: 5, EdgeVersion: 0},
+			dealType:     SyncDealType,
+			wantConflict: dtcommon.TwinConflictSourceCloud,
+			wantVersion:  dttype.TwinVersion{CloudVersion: 1, EdgeVersion: 2},
+		},
+		{
+			name:        "TestDealTwinCompareConflict(): Case 4: cloud sync of the same value is not a conflict",
+			value:       current,
+			version:     &dttype.TwinVersion{CloudVersion: 5, EdgeVersion: 0},
+			dealType:    SyncDealType,
+			wantVersion: dttype.TwinVersion{CloudVersion: 1, EdgeVersion: 2},
+		},
+		{
+			name:        "TestDealTwinCompareConflict(): Case 5: cloud sync based on the edge update",
+			value:       rejected,
+			version:     &dttype.TwinVersion{CloudVersion: 5, EdgeVersion: 2},
+			dealType:    SyncDealType,
+			wantVersion: dttype.TwinVersion{CloudVersion: 5, EdgeVersion: 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			optional := true
+			value, currentValue := test.value, current
+			twin := &dttype.MsgTwin{
+				Expected:        &dttype.TwinValue{Value: &currentValue},
+				ExpectedVersion: &dttype.TwinVersion{CloudVersion: 1, EdgeVersion: 2},
+				Optional:        &optional,
+				Metadata:        &dttype.TypeMetadata{Type: typeString},
+			}
+			msgTwin := &dttype.MsgTwin{
+				Expected:        &dttype.TwinValue{Value: &value},
+				ExpectedVersion: test.version,
+				Metadata:        &dttype.TypeMetadata{Type: typeString},
+			}
+			returnResult := &dttype.DealTwinResult{
+				Document:   make(map[string]*dttype.TwinDoc),
+				SyncResult: make(map[string]*dttype.MsgTwin),
+				Result:     make(map[string]*dttype.MsgTwin),
+			}
+			err := dealTwinCompare(returnResult, deviceA, key1, twin, msgTwin, test.dealType)
+			if (err != nil) != test.wantErr || err != nil && !errors.Is(err, dttype.ErrVersionConflict) {
+				t.Fatalf("DTManager.TestDealTwinCompareConflict() case failed: got err = %v, wantErr = %v", err, test.wantErr)
+			}
+			if *twin.ExpectedVersion != test.wantVersion {
+				t.Errorf("DTManager.TestDealTwinCompareConflict() case failed: got version = %+v, want %+v", *twin.ExpectedVersion, test.wantVersion)
+			}
+			conflict := returnResult.Conflicts[key1]
+			if test.wantConflict == "" {
+				if conflict != nil {
+					t.Errorf("DTManager.TestDealTwinCompareConflict() case failed: unexpected conflict %+v", conflict)
+				}
+				return
+			}
+			if conflict == nil {
+				t.Fatalf("DTManager.TestDealTwinCompareConflict() case failed: conflict is not recorded")
+			}
+			if conflict.Source != test.wantConflict || *conflict.Rejected.Value != rejected || *conflict.Current.Value != current ||
+				*conflict.RejectedVersion != *test.version || *conflict.CurrentVersion != test.wantVersion {
+				t.Errorf("DTManager.TestDealTwinCompareConflict() case failed: got conflict = %+v", conflict)
+			}
+		})
+	}
+}
+
 // TestDealTwinDelete is function to test dealTwinDelete
 func TestDealTwinDelete(t *testing.T) {
 	optionTrue := true
@@ -1567,3 +1673,60 @@ func TestDealMsgTwin(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateDesiredTwins is function to test validateDesiredTwins
+func TestValidateDesiredTwins(t *testing.T) {
+	specs := dtcontext.NewDeviceSpecs()
+	deviceModel := &v1alpha2.DeviceModel{}
+	deviceModel.Namespace, deviceModel.Name = "default", "model"
+	deviceModel.Spec.Properties = []v1alpha2.DeviceProperty{{
+		Name: key1,
+		Type: v1alpha2.PropertyType{Int: &v1alpha2.PropertyTypeInt64{AccessMode: v1alpha2.ReadWrite, Maximum: 10}},
+	}}
+	device := &v1alpha2.Device{}
+	device.Namespace, device.Name = "default", deviceA
+	device.Spec.DeviceModelRef = &v1.LocalObjectReference{Name: "model"}
+	specs.SetDeviceModel(deviceModel)
+	specs.SetDevice(device)
+	context := &dtcontext.DTContext{DeviceSpecs: specs}
+
+	desired := func(key, value string) map[string]*dttype.MsgTwin {
+		return map[string]*dttype.MsgTwin{key: {Expected: &dttype.TwinValue{Value: &value}}}
+	}
+	tests := []struct {
+		name     string
+		deviceID string
+		msgTwin  map[string]*dttype.MsgTwin
+		wantErr  bool
+	}{
+		{
+			name:     "TestValidateDesiredTwins(): Case 1: valid desired value",
+			deviceID: deviceA,
+			msgTwin:  desired(key1, "5"),
+		},
+		{
+			name:     "TestValidateDesiredTwins(): Case 2: desired value out of range",
+			deviceID: deviceA,
+			msgTwin:  desired(key1, "11"),
+			wantErr:  true,
+		},
+		{
+			name:     "TestValidateDesiredTwins(): Case 3: property not defined by device model",
+			deviceID: deviceA,
+			msgTwin:  desired("undefined", "1"),
+			wantErr:  true,
+		},
+		{
+			name:     "TestValidateDesiredTwins(): Case 4: device without synced device model",
+			deviceID: deviceB,
+			msgTwin:  desired("undefined", "1"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := validateDesiredTwins(context, test.deviceID, test.msgTwin); (err != nil) != test.wantErr {
+				t.Errorf("DTManager.TestValidateDesiredTwins() case failed: got = %v, wantErr = %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtseries
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Point is a value of a device property reported at the timestamp
+type Point struct {
+	// Timestamp in unix milliseconds
+	Timestamp int64  `json:"timestamp"`
+	Value     string `json:"value"`
+	// Seq is the order in which the value is appended to the store
+	Seq uint64 `json:"-"`
+}
+
+// Aggregate summarizes the numeric values of a device property in the step starting at the timestamp
+type Aggregate struct {
+	// Timestamp in unix milliseconds
+	Timestamp int64   `json:"timestamp"`
+	Count     int64   `json:"count"`
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	Avg       float64 `json:"avg"`
+}
+
+type seriesKey struct {
+	device   string
+	property string
+}
+
+// Store keeps the recent values of the device properties in memory, the values of a property
+// are bounded by the age and the number of points
+type Store struct {
+	mu        sync.RWMutex
+	maxAge    time.Duration
+	maxPoints int
+	series    map[seriesKey][]Point
+	// seq is the sequence number of the last appended value
+	seq uint64
+	// now is replaced in tests
+	now func() time.Time
+}
+
+// NewStore creates a Store which keeps at most maxPoints values of each property for maxAge
+func NewStore(maxAge time.Duration, maxPoints int) *Store {
+	return &Store{
+		maxAge:    maxAge,
+		maxPoints: maxPoints,
+		series:    make(map[seriesKey][]Point),
+		now:       time.Now,
+	}
+}
+
+// Append adds the value of the device property, the values are expected to come in order
+// of the timestamp, a value older than the last one is inserted at its position
+func (s *Store) Append(device, property string, p Point) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	p.Seq = s.seq
+	key := seriesKey{device: device, property: property}
+	points := s.series[key]
+	if n := len(points); n == 0 || points[n-1].Timestamp <= p.Timestamp {
+		points = append(points, p)
+	} else {
+		i := sort.Search(n, func(i int) bool { return points[i].Timestamp > p.Timestamp })
+		points = append(points, Point{})
+		copy(points[i+1:], points[i:])
+		points[i] = p
+	}
+	s.series[key] = s.trim(points)
+}
+
+// trim drops the values which are too old or exceed the maximum number
+func (s *Store) trim(points []Point) []Point {
+	expired := s.now().Add(-s.maxAge).UnixNano() / 1e6
+	start := sort.Search(len(points), func(i int) bool { return points[i].Timestamp >= expired })
+	if len(points)-start > s.maxPoints {
+		start = len(points) - s.maxPoints
+	}
+	if start == 0 {
+		return points
+	}
+	// copy to release the memory of the dropped points
+	return append([]Point(nil), points[start:]...)
+}
+
+// Query returns the values of the device property in the range [start, end],
+// start or end 0 means the range is unbounded at that side
+func (s *Store) Query(device, property string, start, end int64) []Point {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	expired := s.now().Add(-s.maxAge).UnixNano() / 1e6
+	if start < expired {
+		start = expired
+	}
+	return pointsInRange(s.series[seriesKey{device: device, property: property}], start, end)
+}
+
+// After returns at most limit values of the device property appended after the sequence number,
+// in the order they are appended, so the values appended late with older timestamps are included
+func (s *Store) After(device, property string, after uint64, limit int) []Point {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var points []Point
+	for _, p := range s.series[seriesKey{device: device, property: property}] {
+		if p.Seq > after {
+			points = append(points, p)
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Seq < points[j].Seq })
+	if len(points) > limit {
+		points = points[:limit]
+	}
+	return points
+}
+
+// Properties returns the device properties which have values, the map key is device name
+func (s *Store) Properties() map[string][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	properties := make(map[string][]string)
+	for key := range s.series {
+		properties[key.device] = append(properties[key.device], key.property)
+	}
+	for _, names := range properties {
+		sort.Strings(names)
+	}
+	return properties
+}
+
+// Prune drops the expired values of all properties, it is called periodically so that
+// the properties which are not reported anymore do not keep their values
+func (s *Store) Prune() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, points := range s.series {
+		points = s.trim(points)
+		if len(points) == 0 {
+			delete(s.series, key)
+			continue
+		}
+		s.series[key] = points
+	}
+}
+
+// DeleteDevice drops the values of all properties of the device
+func (s *Store) DeleteDevice(device string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.series {
+		if key.device == device {
+			delete(s.series, key)
+		}
+	}
+}
+
+func pointsInRange(points []Point, start, end int64) []Point {
+	from := sort.Search(len(points), func(i int) bool { return points[i].Timestamp >= start })
+	to := len(points)
+	if end > 0 {
+		to = sort.Search(len(points), func(i int) bool { return points[i].Timestamp > end })
+	}
+	if from >= to {
+		return nil
+	}
+	return append([]Point(nil), points[from:to]...)
+}
+
+// Downsample aggregates the numeric values into steps of the duration in milliseconds,
+// the values which are not numbers are skipped, and the steps without values are omitted
+func Downsample(points []Point, step int64) []Aggregate {
+	var aggregates []Aggregate
+	var sum float64
+	for _, p := range points {
+		value, err := strconv.ParseFloat(p.Value, 64)
+		if err != nil || math.IsNaN(value) {
+			continue
+		}
+		timestamp := p.Timestamp - p.Timestamp%step
+		n := len(aggregates)
+		if n == 0 || aggregates[n-1].Timestamp != timestamp {
+			if n > 0 {
+				aggregates[n-1].Avg = sum / float64(aggregates[n-1].Count)
+			}
+			aggregates = append(aggregates, Aggregate{Timestamp: timestamp, Min: value, Max: value})
+			sum = 0
+			n++
+		}
+		a := &aggregates[n-1]
+		a.Count++
+		a.Min = math.Min(a.Min, value)
+		a.Max = math.Max(a.Max, value)
+		sum += value
+	}
+	if n := len(aggregates); n > 0 {
+		aggregates[n-1].Avg = sum / float64(aggregates[n-1].Count)
+	}
+	return aggregates
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtseries
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// base is the fake now of the tests in unix milliseconds
+const base = int64(1700000000000)
+
+func newTestStore(maxAge time.Duration, maxPoints int) *Store {
+	s := NewStore(maxAge, maxPoints)
+	s.now = func() time.Time { return time.Unix(0, base*1e6) }
+	return s
+}
+
+func timestamps(points []Point) []int64 {
+	var result []int64
+	for _, p := range points {
+		result = append(result, p.Timestamp)
+	}
+	return result
+}
+
+func TestAppend(t *testing.T) {
+	s := newTestStore(time.Hour, 3)
+	for _, ts := range []int64{base - 4000, base - 1000, base - 3000, base - 2000} {
+		s.Append("device", "temperature", Point{Timestamp: ts, Value: "1"})
+	}
+	got := timestamps(s.Query("device", "temperature", 0, 0))
+	want := []int64{base - 3000, base - 2000, base - 1000}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Query() after appending more than max points = %v, want %v", got, want)
+	}
+
+	s.Append("device", "humidity", Point{Timestamp: base - 2*time.Hour.Milliseconds(), Value: "1"})
+	if got := s.Query("device", "humidity", 0, 0); len(got) != 0 {
+		t.Errorf("Query() after appending expired value = %v, want empty", got)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	s := newTestStore(time.Minute, 100)
+	for i := int64(0); i < 10; i++ {
+		s.Append("device", "temperature", Point{Timestamp: base - i*10000, Value: "1"})
+	}
+	tests := []struct {
+		name       string
+		start, end int64
+		want       []int64
+	}{
+		{
+			name: "unbounded range excludes expired values",
+			want: []int64{base - 60000, base - 50000, base - 40000, base - 30000, base - 20000, base - 10000, base},
+		},
+		{
+			name:  "bounded range is inclusive",
+			start: base - 30000,
+			end:   base - 10000,
+			want:  []int64{base - 30000, base - 20000, base - 10000},
+		},
+		{
+			name:  "empty range",
+			start: base + 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timestamps(s.Query("device", "temperature", tt.start, tt.end))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruneAndDeleteDevice(t *testing.T) {
+	s := newTestStore(time.Minute, 100)
+	s.Append("device", "temperature", Point{Timestamp: base, Value: "1"})
+	s.Append("device", "humidity", Point{Timestamp: base, Value: "1"})
+	s.Append("other", "temperature", Point{Timestamp: base, Value: "1"})
+
+	s.now = func() time.Time { return time.Unix(0, base*1e6).Add(2 * time.Minute) }
+	s.Append("other", "humidity", Point{Timestamp: base + 2*time.Minute.Milliseconds(), Value: "1"})
+	s.Prune()
+	want := map[string][]string{"other": {"humidity"}}
+	if got := s.Properties(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Properties() after Prune() = %v, want %v", got, want)
+	}
+
+	s.DeleteDevice("other")
+	if got := s.Properties(); len(got) != 0 {
+		t.Errorf("Properties() after DeleteDevice() = %v, want empty", got)
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	points := []Point{
+		{Timestamp: 1000, Value: "1"},
+		{Timestamp: 1500, Value: "3"},
+		{Timestamp: 1800, Value: "on"},
+		{Timestamp: 3200, Value: "-2"},
+		{Timestamp: 3900, Value: "4"},
+	}
+	want := []Aggregate{
+		{Timestamp: 1000, Count: 2, Min: 1, Max: 3, Avg: 2},
+		{Timestamp: 3000, Count: 2, Min: -2, Max: 4, Avg: 1},
+	}
+	if got := Downsample(points, 1000); !reflect.DeepEqual(got, want) {
+		t.Errorf("Downsample() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtseries
+
+import (
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/cloudconnection"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcontext"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dttype"
+)
+
+// Series is the values of a device property in an uploaded batch
+type Series struct {
+	Device   string  `json:"device"`
+	Property string  `json:"property"`
+	Points   []Point `json:"points"`
+}
+
+// Batch is the history of the device properties uploaded to the cloud
+type Batch struct {
+	NodeName string   `json:"nodeName"`
+	Series   []Series `json:"series"`
+}
+
+// Upload is the message of a batch reported to the cloud, the cloud forwards the batch
+// to the topic of the router eventbus source
+type Upload struct {
+	Topic string `json:"topic"`
+	Batch Batch  `json:"batch"`
+}
+
+// inflight is the batch reported and not confirmed by the cloud yet
+type inflight struct {
+	msgID   string
+	cursors map[seriesKey]uint64
+}
+
+// Uploader uploads the history in the store to the cloud in batches, a batch is resent until the
+// cloud confirms it and the next one is uploaded after that, so the values which are kept in the
+// store while the edge node is disconnected are uploaded after it connects again
+type Uploader struct {
+	store     *Store
+	context   *dtcontext.DTContext
+	topic     string
+	batchSize int
+	// uploaded is the sequence number of the last value confirmed by the cloud of each property
+	uploaded map[seriesKey]uint64
+	inflight *inflight
+	// send is replaced in tests
+	send func(msg *model.Message)
+}
+
+// NewUploader creates an Uploader which uploads at most batchSize values in a batch
+func NewUploader(store *Store, context *dtcontext.DTContext, topic string, batchSize int) *Uploader {
+	u := &Uploader{
+		store:     store,
+		context:   context,
+		topic:     topic,
+		batchSize: batchSize,
+		uploaded:  make(map[seriesKey]uint64),
+	}
+	u.send = u.sendToCloud
+	return u
+}
+
+// Upload uploads the next batch once the last one is confirmed by the cloud, it is called periodically
+func (u *Uploader) Upload() {
+	if u.inflight != nil {
+		if _, pending := u.context.ConfirmMap.Load(u.inflight.msgID); pending {
+			klog.V(4).Infof("Device history %s is not confirmed by cloud yet", u.inflight.msgID)
+			return
+		}
+		for key, seq := range u.inflight.cursors {
+			u.uploaded[key] = seq
+		}
+		u.inflight = nil
+	}
+	if !cloudconnection.IsConnected() {
+		klog.V(4).Infof("Disconnected with cloud, not upload device history")
+		return
+	}
+	batch, cursors := u.nextBatch()
+	if len(batch.Series) == 0 {
+		return
+	}
+	msg := u.context.BuildModelMessage("resource", "", dtcommon.DeviceETHistoryResource, model.UpdateOperation,
+		Upload{Topic: u.topic, Batch: batch})
+	u.inflight = &inflight{msgID: msg.GetID(), cursors: cursors}
+	u.send(msg)
+}
+
+func (u *Uploader) nextBatch() (Batch, map[seriesKey]uint64) {
+	batch := Batch{NodeName: u.context.NodeName}
+	cursors := make(map[seriesKey]uint64)
+	remaining := u.batchSize
+	for device, properties := range u.store.Properties() {
+		for _, property := range properties {
+			if remaining == 0 {
+				return batch, cursors
+			}
+			key := seriesKey{device: device, property: property}
+			points := u.store.After(device, property, u.uploaded[key], remaining)
+			if len(points) == 0 {
+				continue
+			}
+			batch.Series = append(batch.Series, Series{Device: device, Property: property, Points: points})
+			cursors[key] = points[len(points)-1].Seq
+			remaining -= len(points)
+		}
+	}
+	return batch, cursors
+}
+
+// sendToCloud reports the batch like the other device reports, the message is kept to be
+// resent until the cloud confirms it
+func (u *Uploader) sendToCloud(msg *model.Message) {
+	u.context.ConfirmMap.Store(msg.GetID(), &dttype.DTMessage{Msg: msg, Action: dtcommon.SendToCloud, Type: dtcommon.CommModule})
+	if err := u.context.Send("", dtcommon.SendToCloud, dtcommon.CommModule, msg); err != nil {
+		klog.Errorf("upload device history failed with err: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dtseries
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/cloudconnection"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcontext"
+)
+
+func TestUpload(t *testing.T) {
+	s := newTestStore(time.Hour, 100)
+	for i := int64(1); i <= 3; i++ {
+		s.Append("device", "temperature", Point{Timestamp: base - i, Value: "1"})
+		s.Append("device", "humidity", Point{Timestamp: base - i, Value: "1"})
+	}
+
+	context := &dtcontext.DTContext{NodeName: "edge-node", ConfirmMap: &sync.Map{}}
+	var sent []*model.Message
+	u := NewUploader(s, context, "history", 4)
+	u.send = func(msg *model.Message) {
+		context.ConfirmMap.Store(msg.GetID(), msg)
+		sent = append(sent, msg)
+	}
+	batch := func(i int) Batch {
+		upload, ok := sent[i].GetContent().(Upload)
+		if !ok || upload.Topic != "history" {
+			t.Fatalf("unexpected content of upload message %v", sent[i].GetContent())
+		}
+		return upload.Batch
+	}
+
+	cloudconnection.SetConnected(false)
+	u.Upload()
+	if len(sent) != 0 {
+		t.Fatalf("Upload() while disconnected sent %d batches, want 0", len(sent))
+	}
+
+	cloudconnection.SetConnected(true)
+	defer cloudconnection.SetConnected(false)
+	u.Upload()
+	u.Upload()
+	if len(sent) != 1 || batchLen(batch(0)) != 4 {
+		t.Fatalf("Upload() sent %d batches, want one batch of 4 values before it is confirmed", len(sent))
+	}
+
+	context.ConfirmMap.Delete(sent[0].GetID())
+	u.Upload()
+	if len(sent) != 2 || batchLen(batch(1)) != 2 {
+		t.Fatalf("Upload() after confirm sent %d batches, want the next batch of 2 values", len(sent))
+	}
+
+	// the late value with the same timestamp as an uploaded one is uploaded as well
+	context.ConfirmMap.Delete(sent[1].GetID())
+	s.Append("device", "temperature", Point{Timestamp: base - 3, Value: "2"})
+	u.Upload()
+	want := Batch{NodeName: "edge-node", Series: []Series{{Device: "device", Property: "temperature", Points: []Point{{Timestamp: base - 3, Value: "2", Seq: 7}}}}}
+	if len(sent) != 3 || !reflect.DeepEqual(batch(2), want) {
+		t.Errorf("Upload() after late value sent %v, want %v", sent[2:], want)
+	}
+}
+
+func batchLen(batch Batch) int {
+	n := 0
+	for _, series := range batch.Series {
+		n += len(series.Points)
+	}
+	return n
+}
@@ -34,6 +34,9 @@ var ErrorUpdate = errors.New("Update twin error, key:twin does not exist")
 var ErrorKey = errors.New("The key of twin must only include upper or lowercase letters, number, english, and special letter - _ . , : / @ # and the length of key should be less than 128 bytes")
 var ErrorValue = errors.New("The value of twin must only include upper or lowercase letters, number, english, and special letter - _ . , : / @ # and the length of value should be less than 512 bytes")
 
+// ErrVersionConflict the update of the twin is not based on the current version of the twin
+var ErrVersionConflict = errors.New("not allowed to sync due to version conflict")
+
 //SetEventID set event id
 func (bs *BaseMessage) SetEventID(eventID string) {
 	bs.EventID = eventID
@@ -286,6 +289,59 @@ func UnmarshalDeviceTwinUpdate(payload []byte) (*DeviceTwinUpdate, error) {
 	return &deviceTwinUpdate, nil
 }
 
+// DeviceCommandInvocation the struct of device command invocation from cloud
+type DeviceCommandInvocation struct {
+	BaseMessage
+	// Namespace and Name of the DeviceCommandRequest, used to match the result
+	Namespace      string            `json:"namespace"`
+	Name           string            `json:"name"`
+	Command        string            `json:"command"`
+	Parameters     map[string]string `json:"parameters,omitempty"`
+	TimeoutSeconds int32             `json:"timeout_seconds"`
+}
+
+// DeviceCommandResult the struct of device command result reported to cloud
+type DeviceCommandResult struct {
+	BaseMessage
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Succeeded  bool   `json:"succeeded"`
+	StatusCode string `json:"status_code,omitempty"`
+	Result     string `json:"result,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// DeviceAlert the struct of device alert fired or resolved, it is published to
+// the eventbus topic of the rule and reported to cloud
+type DeviceAlert struct {
+	BaseMessage
+	// Namespace and Name of the DeviceAlertRule
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Device    string `json:"device"`
+	Property  string `json:"property"`
+	Value     string `json:"value,omitempty"`
+	Firing    bool   `json:"firing"`
+	Message   string `json:"message,omitempty"`
+}
+
+// TwinConflict the desired value rejected due to version conflict, and the current desired value kept by edge
+type TwinConflict struct {
+	// Source is where the rejected update comes from, cloud or edge
+	Source          string       `json:"source"`
+	Rejected        *TwinValue   `json:"rejected,omitempty"`
+	RejectedVersion *TwinVersion `json:"rejected_version,omitempty"`
+	Current         *TwinValue   `json:"current,omitempty"`
+	CurrentVersion  *TwinVersion `json:"current_version,omitempty"`
+}
+
+// DeviceTwinConflict the struct of twin conflict event, it is published to the eventbus
+// and the conflicts of cloud updates are reported to cloud
+type DeviceTwinConflict struct {
+	BaseMessage
+	Twin map[string]*TwinConflict `json:"twin"`
+}
+
 //DealTwinResult the result of dealing twin
 type DealTwinResult struct {
 	Add        []dtclient.DeviceTwin
@@ -294,7 +350,9 @@ type DealTwinResult struct {
 	Result     map[string]*MsgTwin
 	SyncResult map[string]*MsgTwin
 	Document   map[string]*TwinDoc
-	Err        error
+	// Conflicts the desired updates rejected due to version conflict
+	Conflicts map[string]*TwinConflict
+	Err       error
 }
 
 //DealAttrResult the result of dealing attr
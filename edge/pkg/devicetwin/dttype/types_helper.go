@@ -232,8 +232,8 @@ func BuildDeviceTwinResult(baseMessage BaseMessage, twins map[string]*MsgTwin, d
 			}
 			twin := *v
 
+			// the expected version is kept for the compare-and-set updates of the desired value
 			twin.ActualVersion = nil
-			twin.ExpectedVersion = nil
 			result[k] = &twin
 		}
 	} else {
@@ -653,6 +653,8 @@ func createMessageTwin() map[string]*MsgTwin {
 		Metadata: &TypeMetadata{
 			Type: "updated",
 		},
+		ExpectedVersion: &TwinVersion{CloudVersion: 1, EdgeVersion: 2},
+		ActualVersion:   &TwinVersion{CloudVersion: 0, EdgeVersion: 3},
 	}
 	msgTwins["empty"] = nil
 	msgTwins[dtcommon.TypeDeleted] = &twinMetadataDeleted
@@ -664,10 +666,12 @@ func createMessageTwin() map[string]*MsgTwin {
 func createDeviceTwinResultDealTypeGet(baseMessage BaseMessage) DeviceTwinResult {
 	resultDealType0Twin := make(map[string]*MsgTwin)
 	resultDealType0Twin["empty"] = nil
+	// the get result keeps the expected version for compare-and-set updates
 	twinMetadataUpdated := MsgTwin{
 		Metadata: &TypeMetadata{
 			Type: "updated",
 		},
+		ExpectedVersion: &TwinVersion{CloudVersion: 1, EdgeVersion: 2},
 	}
 	resultDealType0Twin["updated"] = &twinMetadataUpdated
 	devTwinResult := DeviceTwinResult{
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 
 	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
@@ -16,10 +17,14 @@ import (
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtclient"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcommon"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtcontext"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtmanager"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtmodule"
 	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dttype"
 )
 
+// alertCheckInterval is the interval to check the device alert rules of absent values
+const alertCheckInterval = time.Second
+
 var (
 	//EventActionMap map for event to action
 	EventActionMap map[string]map[string]string
@@ -110,6 +115,7 @@ func initActionModuleMap() {
 	ActionModuleMap[dtcommon.LifeCycle] = dtcommon.CommModule
 	ActionModuleMap[dtcommon.Confirm] = dtcommon.CommModule
 	ActionModuleMap[dtcommon.MetaDeviceOperation] = dtcommon.DMIModule
+	ActionModuleMap[dtcommon.DeviceCommandInvoke] = dtcommon.DMIModule
 }
 
 // SyncSqlite sync sqlite
@@ -244,6 +250,11 @@ func classifyMsg(message *dttype.DTMessage) bool {
 		} else if strings.Contains(message.Msg.Router.Resource, "membership") {
 			message.Action = dtcommon.MemUpdated
 			return true
+		} else if strings.HasSuffix(message.Msg.Router.Resource, dtcommon.DeviceETCommandInvokeSuffix) {
+			message.Action = dtcommon.DeviceCommandInvoke
+			resources := strings.Split(message.Msg.Router.Resource, "/")
+			message.Identity = resources[1]
+			return true
 		} else if strings.Contains(message.Msg.Router.Resource, "twin/cloud_updated") {
 			message.Action = dtcommon.TwinCloudSync
 			resources := strings.Split(message.Msg.Router.Resource, "/")
@@ -287,6 +298,9 @@ func (dt *DeviceTwin) runDeviceTwin() {
 		dt.RegisterDTModule(v)
 		go dt.DTModules[v].Start()
 	}
+	go wait.Until(func() {
+		dtmanager.CheckAbsentAlerts(dt.DTContexts)
+	}, alertCheckInterval, beehiveContext.Done())
 	go func() {
 		for {
 			select {
@@ -565,6 +565,20 @@ func Test_classifyMsg(t *testing.T) {
 			},
 			wantBool: true,
 		},
+		{
+			//Success Case
+			name: "classifyMessageTest-Source:devicecontroller-Resource:device/command/invoke",
+			message: &dttype.DTMessage{
+				Msg: &model.Message{
+					Router: model.MessageRoute{
+						Source:   "devicecontroller",
+						Resource: "device/sensor/command/invoke",
+					},
+					Content: string(content),
+				},
+			},
+			wantBool: true,
+		},
 		{
 			//Success Case
 			name: "classifyMessageTest-Source:edgemgr-Resource:device/updated-Operation:updated",
@@ -2,8 +2,7 @@ package certificate
 
 import (
 	"bytes"
-	"crypto/ecdsa"
-	"crypto/elliptic"
+	"crypto"
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/tls"
@@ -27,20 +26,26 @@ import (
 	"github.com/kubeedge/kubeedge/edge/pkg/edgehub/common/certutil"
 	"github.com/kubeedge/kubeedge/edge/pkg/edgehub/common/http"
 	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+	"github.com/kubeedge/kubeedge/pkg/util/pki"
 )
 
 // jitteryDuration uses some jitter to set the rotation threshold so each node
-// will rotate at approximately 70-90% of the total lifetime of the
-// certificate.  With jitter, if a number of nodes are added to a cluster at
+// will rotate at approximately threshold±10% (70-90% by default) of the total
+// lifetime of the certificate.  With jitter, if a number of nodes are added to a cluster at
 // approximately the same time (such as cluster creation time), they won't all
 // try to rotate certificates at the same time for the rest of the life of the
 // cluster.
 //
 // This function is represented as a variable to allow replacement during testing.
-var jitteryDuration = func(totalDuration float64) time.Duration {
-	return wait.Jitter(time.Duration(totalDuration), 0.2) - time.Duration(totalDuration*0.3)
+var jitteryDuration = func(totalDuration, threshold float64) time.Duration {
+	return wait.Jitter(time.Duration(totalDuration), 0.2) - time.Duration(totalDuration*(1.1-threshold))
 }
 
+const (
+	defaultKeyAlgorithm      = pki.KeyAlgorithmECDSAP256
+	defaultRotationThreshold = 0.8
+)
+
 var CleanupTokenChan = make(chan struct{}, 1)
 
 type CertManager struct {
@@ -52,6 +57,13 @@ type CertManager struct {
 	certFile string
 	keyFile  string
 
+	// keyAlgorithm is the algorithm of the private key of edge certificate
+	keyAlgorithm string
+	// rotateKey indicates whether a new private key is generated on certificate rotation
+	rotateKey bool
+	// rotationThreshold is the fraction of the certificate lifetime to rotate the certificate
+	rotationThreshold float64
+
 	token string
 	// Set to time.Now but can be stubbed out for testing
 	now func() time.Time
@@ -69,11 +81,24 @@ func NewCertManager(edgehub v1alpha2.EdgeHub, nodename string) CertManager {
 			Organization: []string{"kubeEdge"},
 			Locality:     []string{"Hangzhou"},
 			Province:     []string{"Zhejiang"},
-			CommonName:   "kubeedge.io",
+			// the certificate is issued to the node, cloudcore rejects the
+			// connection whose certificate does not match the node name
+			CommonName: nodename,
 		},
 	}
+	keyAlgorithm := edgehub.KeyAlgorithm
+	if keyAlgorithm == "" {
+		keyAlgorithm = defaultKeyAlgorithm
+	}
+	rotationThreshold := defaultRotationThreshold
+	if edgehub.CertRotationThreshold != 0 {
+		rotationThreshold = float64(edgehub.CertRotationThreshold) / 100
+	}
 	return CertManager{
 		RotateCertificates: edgehub.RotateCertificates,
+		keyAlgorithm:       keyAlgorithm,
+		rotateKey:          edgehub.RotateKey,
+		rotationThreshold:  rotationThreshold,
 		NodeName:           nodename,
 		token:              edgehub.Token,
 		CR:                 certReq,
@@ -89,7 +114,7 @@ func NewCertManager(edgehub v1alpha2.EdgeHub, nodename string) CertManager {
 
 // Start starts the CertManager
 func (cm *CertManager) Start() {
-	_, err := cm.getCurrent()
+	cert, err := cm.getCurrent()
 	if err != nil {
 		err = cm.applyCerts()
 		if err != nil {
@@ -97,6 +122,13 @@ func (cm *CertManager) Start() {
 		}
 		// inform to cleanup token in configuration edgecore.yaml
 		CleanupTokenChan <- struct{}{}
+	} else if cert.Leaf.Subject.CommonName != cm.NodeName {
+		// the certificate issued before it is bound to the node name is still
+		// accepted by cloudcore, but it can only be renewed with the token
+		klog.Infof("certificate is not issued to node %s, renew it", cm.NodeName)
+		if ok, _ := cm.renewCert(); !ok {
+			klog.Warningf("failed to renew the legacy certificate, configure a valid token to renew it")
+		}
 	}
 	if cm.RotateCertificates {
 		cm.rotate()
@@ -198,7 +230,7 @@ func (cm *CertManager) nextRotationDeadline() (time.Time, error) {
 	}
 	notAfter := cert.Leaf.NotAfter
 	totalDuration := float64(notAfter.Sub(cert.Leaf.NotBefore))
-	deadline := cert.Leaf.NotBefore.Add(jitteryDuration(totalDuration))
+	deadline := cert.Leaf.NotBefore.Add(jitteryDuration(totalDuration, cm.rotationThreshold))
 	klog.V(2).Infof("Certificate expiration is %v, rotation deadline is %v", notAfter, deadline)
 
 	return deadline, nil
@@ -208,6 +240,19 @@ func (cm *CertManager) nextRotationDeadline() (time.Time, error) {
 func (cm *CertManager) rotateCert() (bool, error) {
 	klog.Infof("Rotating certificates")
 
+	if ok, err := cm.renewCert(); !ok {
+		return ok, err
+	}
+
+	klog.Info("succeeded to rotate certificate")
+
+	cm.Done <- struct{}{}
+
+	return true, nil
+}
+
+// renewCert applies for a new edge certificate with the current one
+func (cm *CertManager) renewCert() (bool, error) {
 	tlsCert, err := cm.getCurrent()
 	if err != nil {
 		klog.Errorf("failed to get current certificate:%v", err)
@@ -218,7 +263,14 @@ func (cm *CertManager) rotateCert() (bool, error) {
 		klog.Errorf("failed to get CA certificate locally:%v", err)
 		return false, nil
 	}
-	pk, edgecert, err := cm.GetEdgeCert(cm.certURL, caPem, *tlsCert, "")
+	// the legacy certificate is not bound to the node, cloudcore requires the token to renew it
+	var token string
+	if tlsCert.Leaf.Subject.CommonName != cm.NodeName {
+		if tokenParts := strings.Split(cm.token, "."); len(tokenParts) == 4 {
+			token = strings.Join(tokenParts[1:], ".")
+		}
+	}
+	pk, edgecert, err := cm.GetEdgeCert(cm.certURL, caPem, *tlsCert, token)
 	if err != nil {
 		klog.Errorf("failed to get edge certificate from CloudCore:%v", err)
 		return false, nil
@@ -233,11 +285,6 @@ func (cm *CertManager) rotateCert() (bool, error) {
 		klog.Errorf("failed to save edge key and certificate:%v", err)
 		return false, nil
 	}
-
-	klog.Info("succeeded to rotate certificate")
-
-	cm.Done <- struct{}{}
-
 	return true, nil
 }
 
@@ -267,8 +314,12 @@ func GetCACert(url string) ([]byte, error) {
 }
 
 // GetEdgeCert applies for the certificate from cloudcore
-func (cm *CertManager) GetEdgeCert(url string, capem []byte, cert tls.Certificate, token string) (*ecdsa.PrivateKey, []byte, error) {
-	pk, csr, err := cm.getCSR()
+func (cm *CertManager) GetEdgeCert(url string, capem []byte, cert tls.Certificate, token string) (crypto.Signer, []byte, error) {
+	pk, err := cm.getKey(cert)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate private key: %v", err)
+	}
+	csr, err := cm.getCSR(pk)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create CSR: %v", err)
 	}
@@ -300,17 +351,21 @@ func (cm *CertManager) GetEdgeCert(url string, capem []byte, cert tls.Certificat
 	return pk, content, nil
 }
 
-func (cm *CertManager) getCSR() (*ecdsa.PrivateKey, []byte, error) {
-	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	if err != nil {
-		return nil, nil, err
-	}
-	csr, err := x509.CreateCertificateRequest(rand.Reader, cm.CR, pk)
-	if err != nil {
-		return nil, nil, err
+// getKey returns the private key for the new edge certificate. The private key of the
+// current certificate is reused if key rotation is disabled and the key algorithm is unchanged.
+func (cm *CertManager) getKey(cert tls.Certificate) (crypto.Signer, error) {
+	if !cm.rotateKey {
+		if key, ok := cert.PrivateKey.(crypto.Signer); ok {
+			if algorithm, err := pki.KeyAlgorithmOf(key.Public()); err == nil && algorithm == cm.keyAlgorithm {
+				return key, nil
+			}
+		}
 	}
+	return pki.GenerateKey(cm.keyAlgorithm)
+}
 
-	return pk, csr, nil
+func (cm *CertManager) getCSR(pk crypto.Signer) ([]byte, error) {
+	return x509.CreateCertificateRequest(rand.Reader, cm.CR, pk)
 }
 
 // ValidateCACerts validates the CA certificate by hash code
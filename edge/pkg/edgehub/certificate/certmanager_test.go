@@ -18,12 +18,15 @@ package certificate
 
 import (
 	"crypto/sha256"
+	"crypto/tls"
 	"encoding/hex"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/kubeedge/kubeedge/edge/pkg/common/util"
+	"github.com/kubeedge/kubeedge/pkg/util/pki"
 )
 
 func init() {
@@ -79,3 +82,57 @@ func TestValidateCACerts(t *testing.T) {
 		})
 	}
 }
+
+func TestGetKey(t *testing.T) {
+	current, err := pki.GenerateKey(pki.KeyAlgorithmECDSAP256)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	cert := tls.Certificate{PrivateKey: current}
+
+	tests := []struct {
+		name         string
+		keyAlgorithm string
+		rotateKey    bool
+		wantReuse    bool
+	}{
+		{
+			name:         "rotate key",
+			keyAlgorithm: pki.KeyAlgorithmECDSAP256,
+			rotateKey:    true,
+		},
+		{
+			name:         "reuse key",
+			keyAlgorithm: pki.KeyAlgorithmECDSAP256,
+			wantReuse:    true,
+		},
+		{
+			name:         "key algorithm changed",
+			keyAlgorithm: pki.KeyAlgorithmEd25519,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := &CertManager{keyAlgorithm: tt.keyAlgorithm, rotateKey: tt.rotateKey}
+			key, err := cm.getKey(cert)
+			if err != nil {
+				t.Fatalf("failed to get key: %v", err)
+			}
+			if reused := key == current; reused != tt.wantReuse {
+				t.Errorf("key reused = %v, want %v", reused, tt.wantReuse)
+			}
+			if algorithm, _ := pki.KeyAlgorithmOf(key.Public()); algorithm != tt.keyAlgorithm {
+				t.Errorf("key algorithm = %s, want %s", algorithm, tt.keyAlgorithm)
+			}
+		})
+	}
+}
+
+func TestJitteryDuration(t *testing.T) {
+	total := float64(100 * time.Hour)
+	for i := 0; i < 100; i++ {
+		if d := jitteryDuration(total, 0.5); d < 40*time.Hour || d > 60*time.Hour {
+			t.Fatalf("jitteryDuration = %v, want within [40h, 60h]", d)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/edge/pkg/edgehub/common/session"
 	"github.com/kubeedge/viaduct/pkg/api"
 	qclient "github.com/kubeedge/viaduct/pkg/client"
 	"github.com/kubeedge/viaduct/pkg/conn"
@@ -71,6 +72,9 @@ func (qcc *QuicClient) Init() error {
 	exOpts := api.QuicClientOption{Header: make(http.Header)}
 	exOpts.Header.Set("node_id", qcc.config.NodeID)
 	exOpts.Header.Set("project_id", qcc.config.ProjectID)
+	if token := session.GetToken(); token != "" {
+		exOpts.Header.Set(session.HeaderSessionToken, token)
+	}
 	client := qclient.NewQuicClient(option, exOpts)
 	connection, err := client.Connect()
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/edge/pkg/edgehub/common/session"
 	"github.com/kubeedge/kubeedge/edge/pkg/edgehub/config"
 	"github.com/kubeedge/viaduct/pkg/api"
 	wsclient "github.com/kubeedge/viaduct/pkg/client"
@@ -82,6 +83,9 @@ func (wsc *WebSocketClient) Init() error {
 	exOpts := api.WSClientOption{Header: make(http.Header)}
 	exOpts.Header.Set("node_id", wsc.config.NodeID)
 	exOpts.Header.Set("project_id", wsc.config.ProjectID)
+	if token := session.GetToken(); token != "" {
+		exOpts.Header.Set(session.HeaderSessionToken, token)
+	}
 	client := &wsclient.Client{Options: option, ExOpts: exOpts}
 
 	for i := 0; i < retryCount; i++ {
@@ -2,6 +2,7 @@ package certutil
 
 import (
 	"crypto"
+	"crypto/ed25519"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
@@ -28,7 +29,7 @@ func WriteKey(pkiPath string, key crypto.Signer) error {
 		return fmt.Errorf("private key cannot be nil when writing to file")
 	}
 
-	encoded, err := keyutil.MarshalPrivateKeyToPEM(key)
+	encoded, err := marshalPrivateKeyToPEM(key)
 	if err != nil {
 		return fmt.Errorf("unable to marshal private key to PEM: %w", err)
 	}
@@ -39,6 +40,19 @@ func WriteKey(pkiPath string, key crypto.Signer) error {
 	return nil
 }
 
+// marshalPrivateKeyToPEM converts the private key to PEM format, the Ed25519 key
+// which is not supported by keyutil is converted to PKCS #8 form
+func marshalPrivateKeyToPEM(key crypto.Signer) ([]byte, error) {
+	if _, ok := key.(ed25519.PrivateKey); !ok {
+		return keyutil.MarshalPrivateKeyToPEM(key)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: keyutil.PrivateKeyBlockType, Bytes: der}), nil
+}
+
 // WriteCert stores the given certificate at the given location
 func WriteCert(certPath string, cert *x509.Certificate) error {
 	if cert == nil {
@@ -0,0 +1,28 @@
+package session
+
+import (
+	"sync"
+)
+
+// HeaderSessionToken is the connection header carrying the session token
+const HeaderSessionToken = "session_token"
+
+var (
+	token string
+	lock  sync.RWMutex
+)
+
+// SetToken saves the session token issued by cloudhub
+func SetToken(t string) {
+	lock.Lock()
+	defer lock.Unlock()
+	token = t
+}
+
+// GetToken returns the session token used to resume the session when reconnecting,
+// it is empty if cloudhub has not issued one
+func GetToken() string {
+	lock.RLock()
+	defer lock.RUnlock()
+	return token
+}
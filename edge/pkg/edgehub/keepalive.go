@@ -0,0 +1,154 @@
+package edgehub
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	commontypes "github.com/kubeedge/kubeedge/common/types"
+	messagepkg "github.com/kubeedge/kubeedge/edge/pkg/common/message"
+	"github.com/kubeedge/kubeedge/edge/pkg/edgehub/clients"
+	"github.com/kubeedge/kubeedge/edge/pkg/edgehub/common/msghandler"
+)
+
+const (
+	// the number of recent keepalive messages used to compute the loss ratio
+	keepaliveWindowSize = 20
+	// the weight of the latest rtt sample in the smoothed rtt
+	rttSmoothingFactor = 0.125
+	// heart beats are sent more frequently if the loss ratio exceeds the threshold
+	lossRatioThreshold = 0.1
+)
+
+var keepaliveMonitor = newLinkMonitor()
+
+func init() {
+	handler := &keepaliveHandler{monitor: keepaliveMonitor}
+	msghandler.RegisterHandler(handler)
+}
+
+// keepaliveHandler receives the replies of keepalive messages from cloudhub
+type keepaliveHandler struct {
+	monitor *linkMonitor
+}
+
+func (*keepaliveHandler) Filter(message *model.Message) bool {
+	return message.GetGroup() == messagepkg.HubGroupName &&
+		message.GetOperation() == messagepkg.OperationKeepalive
+}
+
+func (h *keepaliveHandler) Process(message *model.Message, clientHub clients.Adapter) error {
+	h.monitor.receive(message.GetParentID(), time.Now())
+	return nil
+}
+
+// linkMonitor measures the rtt and loss ratio of the link to cloudhub
+// from the round trips of keepalive messages
+type linkMonitor struct {
+	lock sync.Mutex
+	// sent time of the keepalive messages waiting for reply
+	pending map[string]time.Time
+	// results of recent keepalive messages, true means lost
+	results []bool
+	// smoothed rtt
+	rtt time.Duration
+}
+
+func newLinkMonitor() *linkMonitor {
+	return &linkMonitor{
+		pending: make(map[string]time.Time),
+	}
+}
+
+// reset clears the measurement of the broken connection
+func (m *linkMonitor) reset() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.pending = make(map[string]time.Time)
+	m.results = nil
+}
+
+func (m *linkMonitor) record(lost bool) {
+	m.results = append(m.results, lost)
+	if len(m.results) > keepaliveWindowSize {
+		m.results = m.results[len(m.results)-keepaliveWindowSize:]
+	}
+}
+
+// send records the keepalive message, and regards the keepalive messages
+// waiting for reply longer than timeout as lost
+func (m *linkMonitor) send(msgID string, now time.Time, timeout time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for id, sent := range m.pending {
+		if now.Sub(sent) > timeout {
+			delete(m.pending, id)
+			m.record(true)
+		}
+	}
+	m.pending[msgID] = now
+}
+
+func (m *linkMonitor) receive(msgID string, now time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	sent, ok := m.pending[msgID]
+	if !ok {
+		klog.V(4).Infof("reply of keepalive message %s is too late", msgID)
+		return
+	}
+	delete(m.pending, msgID)
+	m.record(false)
+
+	sample := now.Sub(sent)
+	if m.rtt == 0 {
+		m.rtt = sample
+	} else {
+		m.rtt += time.Duration(rttSmoothingFactor * float64(sample-m.rtt))
+	}
+}
+
+// quality returns the smoothed rtt and the loss ratio of recent keepalive messages
+func (m *linkMonitor) quality() (time.Duration, float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if len(m.results) == 0 {
+		return m.rtt, 0
+	}
+	lost := 0
+	for _, l := range m.results {
+		if l {
+			lost++
+		}
+	}
+	return m.rtt, float64(lost) / float64(len(m.results))
+}
+
+// keepaliveRequest builds the content of keepalive message
+func (m *linkMonitor) keepaliveRequest(heartbeat time.Duration) *commontypes.KeepaliveRequest {
+	rtt, lossRatio := m.quality()
+	return &commontypes.KeepaliveRequest{
+		Heartbeat:  int32(heartbeat / time.Second),
+		RTT:        rtt.Milliseconds(),
+		LossRatio:  lossRatio,
+		ExpectPong: true,
+	}
+}
+
+// nextHeartbeat halves the heart beat interval when keepalive messages are lost,
+// and increases it by one second when the link recovers, within [min, max]
+func nextHeartbeat(current, min, max time.Duration, lossRatio float64) time.Duration {
+	next := current + time.Second
+	if lossRatio > lossRatioThreshold {
+		next = current / 2
+	}
+	if next < min {
+		return min
+	}
+	if next > max {
+		return max
+	}
+	return next
+}
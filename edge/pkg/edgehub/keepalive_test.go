@@ -0,0 +1,72 @@
+package edgehub
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLinkMonitor(t *testing.T) {
+	m := newLinkMonitor()
+	now := time.Now()
+
+	m.send("1", now, time.Second)
+	m.receive("1", now.Add(100*time.Millisecond))
+	m.send("2", now.Add(time.Second), time.Second)
+	m.send("3", now.Add(3*time.Second), time.Second)
+	// reply of the lost keepalive message is ignored
+	m.receive("2", now.Add(4*time.Second))
+
+	rtt, lossRatio := m.quality()
+	if rtt != 100*time.Millisecond {
+		t.Errorf("expected rtt 100ms, got %v", rtt)
+	}
+	if lossRatio != 0.5 {
+		t.Errorf("expected loss ratio 0.5, got %v", lossRatio)
+	}
+
+	m.reset()
+	if _, lossRatio := m.quality(); lossRatio != 0 {
+		t.Errorf("expected loss ratio 0 after reset, got %v", lossRatio)
+	}
+}
+
+func TestNextHeartbeat(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   time.Duration
+		lossRatio float64
+		want      time.Duration
+	}{
+		{
+			name:      "halve on loss",
+			current:   12 * time.Second,
+			lossRatio: 0.2,
+			want:      6 * time.Second,
+		},
+		{
+			name:      "not less than min",
+			current:   6 * time.Second,
+			lossRatio: 0.5,
+			want:      5 * time.Second,
+		},
+		{
+			name:      "increase when recovered",
+			current:   6 * time.Second,
+			lossRatio: 0,
+			want:      7 * time.Second,
+		},
+		{
+			name:      "not greater than max",
+			current:   15 * time.Second,
+			lossRatio: 0,
+			want:      15 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextHeartbeat(tt.current, 5*time.Second, 15*time.Second, tt.lossRatio); got != tt.want {
+				t.Errorf("nextHeartbeat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
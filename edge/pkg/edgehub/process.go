@@ -165,6 +165,11 @@ func (eh *EdgeHub) routeToCloud() {
 }
 
 func (eh *EdgeHub) keepalive() {
+	maxHeartbeat := time.Duration(config.Config.Heartbeat) * time.Second
+	minHeartbeat := time.Duration(config.Config.MinHeartbeat) * time.Second
+	heartbeat := maxHeartbeat
+	keepaliveMonitor.reset()
+
 	for {
 		select {
 		case <-beehiveContext.Done():
@@ -174,7 +179,8 @@ func (eh *EdgeHub) keepalive() {
 		}
 		msg := model.NewMessage("").
 			BuildRouter(modules.EdgeHubModuleName, "resource", "node", messagepkg.OperationKeepalive).
-			FillBody("ping")
+			FillBody(keepaliveMonitor.keepaliveRequest(heartbeat))
+		keepaliveMonitor.send(msg.GetID(), time.Now(), maxHeartbeat)
 
 		// post message to cloud hub
 		err := eh.sendToCloud(*msg)
@@ -184,7 +190,11 @@ func (eh *EdgeHub) keepalive() {
 			return
 		}
 
-		time.Sleep(time.Duration(config.Config.Heartbeat) * time.Second)
+		if config.Config.AdaptiveHeartbeat {
+			_, lossRatio := keepaliveMonitor.quality()
+			heartbeat = nextHeartbeat(heartbeat, minHeartbeat, maxHeartbeat, lossRatio)
+		}
+		time.Sleep(heartbeat)
 	}
 }
 
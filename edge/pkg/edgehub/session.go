@@ -0,0 +1,42 @@
+package edgehub
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	messagepkg "github.com/kubeedge/kubeedge/edge/pkg/common/message"
+	"github.com/kubeedge/kubeedge/edge/pkg/edgehub/clients"
+	"github.com/kubeedge/kubeedge/edge/pkg/edgehub/common/msghandler"
+	"github.com/kubeedge/kubeedge/edge/pkg/edgehub/common/session"
+)
+
+func init() {
+	handler := &sessionHandler{}
+	msghandler.RegisterHandler(handler)
+}
+
+// sessionHandler saves the session token issued by cloudhub,
+// which is sent in the header when reconnecting to resume the session
+type sessionHandler struct {
+}
+
+func (*sessionHandler) Filter(message *model.Message) bool {
+	return message.GetGroup() == messagepkg.HubGroupName &&
+		message.GetOperation() == messagepkg.OperationSessionToken
+}
+
+func (*sessionHandler) Process(message *model.Message, clientHub clients.Adapter) error {
+	token, err := message.GetContentData()
+	if err != nil {
+		return fmt.Errorf("failed to get session token: %v", err)
+	}
+	if len(token) == 0 {
+		return fmt.Errorf("session token is empty")
+	}
+
+	session.SetToken(string(token))
+	klog.Infof("session token is updated")
+	return nil
+}
@@ -32,28 +32,100 @@ type SubTopics struct {
 
 // InsertTopics insert sub_topics
 func InsertTopics(topic string) error {
-	_, err := dbm.DBAccess.Raw("INSERT OR REPLACE INTO sub_topics (topic) VALUES (?)", topic).Exec()
-	klog.V(4).Infof("INSERT result %v", err)
-	return err
+	return topicStorage().Insert(topic)
 }
 
 // DeleteTopicsByKey delete sub_topics by key
 func DeleteTopicsByKey(key string) error {
-	num, err := dbm.DBAccess.QueryTable(SubTopicsName).Filter("topic", key).Delete()
-	klog.V(4).Infof("Delete affected Num: %d, %v", num, err)
-	return err
+	return topicStorage().DeleteByKey(key)
 }
 
 // QueryAllTopics return all sub_topics, if no error, SubTopics not null
 func QueryAllTopics() (*[]string, error) {
-	event := new([]SubTopics)
-	_, err := dbm.DBAccess.QueryTable(SubTopicsName).All(event)
+	event, err := topicStorage().List()
 	if err != nil {
 		return nil, err
 	}
 	var result []string
-	for _, v := range *event {
+	for _, v := range event {
 		result = append(result, v.Topic)
 	}
 	return &result, nil
 }
+
+// CheckTopicQuota checks the quota of sub_topics before the topic is subscribed, the new
+// topics are refused beyond the quota since the subscriptions can not be rebuilt once deleted
+func CheckTopicQuota(topic string) error {
+	return dbm.CheckQuota(SubTopicsName, func() (int64, int64, error) {
+		topics, err := topicStorage().List()
+		if err != nil {
+			return 0, 0, err
+		}
+		size := int64(len(topic))
+		for _, t := range topics {
+			if t.Topic == topic {
+				// the topic subscribed again is not a new record
+				return 0, 0, nil
+			}
+			size += int64(len(t.Topic))
+		}
+		return int64(len(topics) + 1), size, nil
+	})
+}
+
+// topicStore is the storage of the topics subscribed
+type topicStore interface {
+	Insert(topic string) error
+	DeleteByKey(key string) error
+	List() ([]SubTopics, error)
+}
+
+// topicStorage returns the storage selected by DataBase.DriverName
+func topicStorage() topicStore {
+	if dbm.UseKV() {
+		return kvTopicStore{}
+	}
+	return sqliteTopicStore{}
+}
+
+type sqliteTopicStore struct{}
+
+func (sqliteTopicStore) Insert(topic string) error {
+	_, err := dbm.DBAccess.Raw("INSERT OR REPLACE INTO sub_topics (topic) VALUES (?)", topic).Exec()
+	klog.V(4).Infof("INSERT result %v", err)
+	return err
+}
+
+func (sqliteTopicStore) DeleteByKey(key string) error {
+	num, err := dbm.DBAccess.QueryTable(SubTopicsName).Filter("topic", key).Delete()
+	klog.V(4).Infof("Delete affected Num: %d, %v", num, err)
+	return err
+}
+
+func (sqliteTopicStore) List() ([]SubTopics, error) {
+	event := new([]SubTopics)
+	_, err := dbm.DBAccess.QueryTable(SubTopicsName).All(event)
+	return *event, err
+}
+
+type kvTopicStore struct{}
+
+func (kvTopicStore) Insert(topic string) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		return tx.Put(SubTopicsName, topic, &SubTopics{Topic: topic})
+	})
+}
+
+func (kvTopicStore) DeleteByKey(key string) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		return tx.Delete(SubTopicsName, key)
+	})
+}
+
+func (kvTopicStore) List() ([]SubTopics, error) {
+	var event []SubTopics
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, SubTopicsName, &event)
+	})
+	return event, err
+}
@@ -19,6 +19,7 @@ package dao
 import (
 	"database/sql"
 	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/astaxie/beego/orm"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/kubeedge/kubeedge/edge/mocks/beego"
 	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
 )
 
 const (
@@ -194,3 +196,30 @@ func TestQueryAllTopics(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckTopicQuota(t *testing.T) {
+	store, err := dbm.OpenBoltStore(filepath.Join(t.TempDir(), "edgecore.bolt"))
+	if err != nil {
+		t.Fatalf("failed to open bolt store: %v", err)
+	}
+	dbm.KVAccess = store
+	defer func() {
+		dbm.KVAccess = nil
+		store.Close()
+	}()
+	dbm.SetQuotas([]v1alpha2.DataBaseQuota{{ResourceType: SubTopicsName, MaxCount: 1}})
+	defer dbm.SetQuotas(nil)
+
+	if err := CheckTopicQuota("topic-1"); err != nil {
+		t.Fatalf("expected topic within the quota, but got %v", err)
+	}
+	if err := InsertTopics("topic-1"); err != nil {
+		t.Fatalf("failed to insert topic: %v", err)
+	}
+	if err := CheckTopicQuota("topic-1"); err != nil {
+		t.Errorf("expected subscribed topic allowed again, but got %v", err)
+	}
+	if err := CheckTopicQuota("topic-2"); !errors.Is(err, dbm.ErrQuotaExceeded) {
+		t.Errorf("expected topic beyond the quota refused, but got %v", err)
+	}
+}
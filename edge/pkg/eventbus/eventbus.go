@@ -5,11 +5,11 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/astaxie/beego/orm"
 	"k8s.io/klog/v2"
 
 	"github.com/kubeedge/beehive/pkg/core"
 	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
 	messagepkg "github.com/kubeedge/kubeedge/edge/pkg/common/message"
 	"github.com/kubeedge/kubeedge/edge/pkg/common/modules"
 	"github.com/kubeedge/kubeedge/edge/pkg/eventbus/common/util"
@@ -38,7 +38,7 @@ func newEventbus(enable bool) *eventbus {
 func Register(eventbus *v1alpha2.EventBus, nodeName string) {
 	eventconfig.InitConfigure(eventbus, nodeName)
 	core.Register(newEventbus(eventbus.Enable))
-	orm.RegisterModel(new(dao.SubTopics))
+	dbm.RegisterModel(dao.SubTopicsName, new(dao.SubTopics))
 }
 
 func (*eventbus) Name() string {
@@ -183,6 +183,11 @@ func (eb *eventbus) publish(topic string, payload []byte) {
 }
 
 func (eb *eventbus) subscribe(topic string) {
+	if err := dao.CheckTopicQuota(topic); err != nil {
+		klog.Errorf("Subscribe topic %s refused: %v", topic, err)
+		return
+	}
+
 	if eventconfig.Config.MqttMode <= v1alpha2.MqttModeBoth {
 		// set topic to internal mqtt broker.
 		mqttServer.SetTopic(topic)
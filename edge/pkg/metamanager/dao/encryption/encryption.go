@@ -0,0 +1,131 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// aesGCMPrefix marks the values encrypted by the AES-GCM provider, the values
+// without any known prefix are plain values saved before encryption is enabled
+const aesGCMPrefix = "enc:aesgcm:v1:"
+
+// Provider encrypts and decrypts the values saved in database
+type Provider interface {
+	// Encrypt returns the encrypted value of the plain value
+	Encrypt(plain string) (string, error)
+	// Decrypt returns the plain value of the value encrypted by Encrypt
+	Decrypt(value string) (string, error)
+	// IsEncrypted checks whether the value is encrypted by the provider
+	IsEncrypted(value string) bool
+}
+
+var (
+	provider Provider
+	lock     sync.RWMutex
+)
+
+// SetProvider sets the provider used to encrypt the sensitive values, nil disables encryption
+func SetProvider(p Provider) {
+	lock.Lock()
+	defer lock.Unlock()
+	provider = p
+}
+
+func getProvider() Provider {
+	lock.RLock()
+	defer lock.RUnlock()
+	return provider
+}
+
+// Enabled checks whether the sensitive values are encrypted
+func Enabled() bool {
+	return getProvider() != nil
+}
+
+// Encrypt encrypts the value by the provider, the value is returned as it is if encryption is disabled
+func Encrypt(value string) (string, error) {
+	p := getProvider()
+	if p == nil || p.IsEncrypted(value) {
+		return value, nil
+	}
+	return p.Encrypt(value)
+}
+
+// Decrypt decrypts the value by the provider, the plain value is returned as it is
+func Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	p := getProvider()
+	if p == nil {
+		return "", fmt.Errorf("the value is encrypted but encryption is not enabled")
+	}
+	return p.Decrypt(value)
+}
+
+// IsEncrypted checks whether the value is encrypted
+func IsEncrypted(value string) bool {
+	if p := getProvider(); p != nil {
+		return p.IsEncrypted(value)
+	}
+	return strings.HasPrefix(value, aesGCMPrefix)
+}
+
+type aesGCM struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMProvider returns the provider encrypting values by AES-GCM with the key from the source
+func NewAESGCMProvider(source KeySource) (Provider, error) {
+	key, err := source.Key()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %v", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesGCM{aead: aead}, nil
+}
+
+func (p *aesGCM) Encrypt(plain string) (string, error) {
+	nonce := make([]byte, p.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %v", err)
+	}
+	sealed := p.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return aesGCMPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (p *aesGCM) Decrypt(value string) (string, error) {
+	if !p.IsEncrypted(value) {
+		return "", fmt.Errorf("the value is not encrypted by aes-gcm")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, aesGCMPrefix))
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %v", err)
+	}
+	nonceSize := p.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", fmt.Errorf("invalid encrypted value: the data is too short")
+	}
+	plain, err := p.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %v", err)
+	}
+	return string(plain), nil
+}
+
+func (p *aesGCM) IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, aesGCMPrefix)
+}
@@ -0,0 +1,105 @@
+package encryption
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAESGCMProvider(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "keys", "meta.key")
+	p, err := NewAESGCMProvider(&FileKeySource{Path: keyFile, Generate: true})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	if _, err := os.Stat(keyFile); err != nil {
+		t.Fatalf("expected key file to be generated: %v", err)
+	}
+
+	plain := `{"data":{"password":"cGFzc3dvcmQ="}}`
+	encrypted, err := p.Encrypt(plain)
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+	if encrypted == plain || !p.IsEncrypted(encrypted) {
+		t.Fatalf("expected value to be encrypted, but got %s", encrypted)
+	}
+
+	// the provider loading the same key file decrypts the value
+	p, err = NewAESGCMProvider(&FileKeySource{Path: keyFile})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	decrypted, err := p.Decrypt(encrypted)
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+	if decrypted != plain {
+		t.Errorf("expected %s, but got %s", plain, decrypted)
+	}
+
+	// the provider with another key fails to decrypt the value
+	other, err := NewAESGCMProvider(&FileKeySource{Path: filepath.Join(t.TempDir(), "other.key"), Generate: true})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	if _, err := other.Decrypt(encrypted); err == nil {
+		t.Errorf("expected error decrypting with another key")
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	defer SetProvider(nil)
+	plain := "plain"
+
+	SetProvider(nil)
+	if value, _ := Encrypt(plain); value != plain {
+		t.Errorf("expected value not encrypted when encryption is disabled, but got %s", value)
+	}
+
+	p, err := NewAESGCMProvider(&FileKeySource{Path: filepath.Join(t.TempDir(), "meta.key"), Generate: true})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	SetProvider(p)
+	encrypted, err := Encrypt(plain)
+	if err != nil || !IsEncrypted(encrypted) {
+		t.Fatalf("expected value encrypted, but got %s, %v", encrypted, err)
+	}
+	// the value encrypted already is not encrypted twice
+	if value, _ := Encrypt(encrypted); value != encrypted {
+		t.Errorf("expected encrypted value unchanged, but got %s", value)
+	}
+	// the plain value saved before encryption is enabled is readable
+	if value, err := Decrypt(plain); err != nil || value != plain {
+		t.Errorf("expected %s, but got %s, %v", plain, value, err)
+	}
+	if value, err := Decrypt(encrypted); err != nil || value != plain {
+		t.Errorf("expected %s, but got %s, %v", plain, value, err)
+	}
+
+	SetProvider(nil)
+	if _, err := Decrypt(encrypted); err == nil {
+		t.Errorf("expected error decrypting when encryption is disabled")
+	}
+}
+
+func TestFileKeySourceInvalidKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "meta.key")
+	if err := os.WriteFile(keyFile, []byte("c2hvcnQ="), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewAESGCMProvider(&FileKeySource{Path: keyFile}); err == nil {
+		t.Errorf("expected error for the key with invalid size")
+	}
+}
+
+func TestFileKeySourceMissingKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "meta.key")
+	if _, err := NewAESGCMProvider(&FileKeySource{Path: keyFile}); err == nil {
+		t.Errorf("expected error for the missing key file which is not allowed to be generated")
+	}
+	if _, err := os.Stat(keyFile); !os.IsNotExist(err) {
+		t.Errorf("expected no key file generated, but got %v", err)
+	}
+}
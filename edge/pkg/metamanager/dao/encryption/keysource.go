@@ -0,0 +1,67 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// keySize is the size of the generated key, which selects AES-256
+const keySize = 32
+
+// KeySource provides the key to encrypt the values, the key can be
+// loaded from a local file, a TPM or a remote key management service
+type KeySource interface {
+	Key() ([]byte, error)
+}
+
+// FileKeySource loads the key encoded in base64 from a local file
+type FileKeySource struct {
+	Path string
+	// Generate indicates whether the key is generated and saved to the file if it does not exist,
+	// it must not be set if any value is encrypted already, which is unreadable with a new key
+	Generate bool
+}
+
+// Key returns the key in the file
+func (s *FileKeySource) Key() ([]byte, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		if !s.Generate {
+			return nil, fmt.Errorf("key file %s does not exist", s.Path)
+		}
+		return s.generate()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file %s: %v", s.Path, err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key file %s: %v", s.Path, err)
+	}
+	return key, nil
+}
+
+func (s *FileKeySource) generate() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create dir for key file %s: %v", s.Path, err)
+	}
+	// O_EXCL avoids overwriting the key created by others in the meantime
+	f, err := os.OpenFile(s.Path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key file %s: %v", s.Path, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(base64.StdEncoding.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("failed to write key file %s: %v", s.Path, err)
+	}
+	return key, nil
+}
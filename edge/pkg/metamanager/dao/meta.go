@@ -2,19 +2,21 @@ package dao
 
 import (
 	"strings"
+	"time"
 
-	"github.com/astaxie/beego/orm"
-	"k8s.io/klog/v2"
-
-	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
 )
 
 //constant metatable name reference
 const (
 	MetaTableName = "meta"
+
+	// updateTimeColumn is the column of the last write time in meta_v2
+	updateTimeColumn = "updatetime"
 )
 
-// Meta metadata object
+// Meta metadata object, it is the legacy resource format of the records in meta_v2.
+// The legacy meta table is only kept to migrate the records saved by the old versions
 type Meta struct {
 	Key     string `orm:"column(key); size(256); pk"`
 	Type    string `orm:"column(type); size(32)"`
@@ -25,8 +27,11 @@ type Meta struct {
 
 // SaveMeta save meta to db
 func SaveMeta(meta *Meta) error {
-	num, err := dbm.DBAccess.Insert(meta)
-	klog.V(4).Infof("Insert affected Num: %d, %v", num, err)
+	obj, err := toMetaV2(meta)
+	if err != nil {
+		return err
+	}
+	err = metaStorage().Insert(obj)
 	if err == nil || IsNonUniqueNameError(err) {
 		return nil
 	}
@@ -45,49 +50,61 @@ func IsNonUniqueNameError(err error) bool {
 
 // DeleteMetaByKey delete meta by key
 func DeleteMetaByKey(key string) error {
-	num, err := dbm.DBAccess.QueryTable(MetaTableName).Filter("key", key).Delete()
-	klog.V(4).Infof("Delete affected Num: %d, %v", num, err)
-	return err
+	return metaStorage().DeleteByKey(v2.LegacyKeyToKey(key))
 }
 
 // UpdateMeta update meta
 func UpdateMeta(meta *Meta) error {
-	num, err := dbm.DBAccess.Update(meta) // will update all field
-	klog.V(4).Infof("Update affected Num: %d, %v", num, err)
-	return err
+	obj, err := toMetaV2(meta)
+	if err != nil {
+		return err
+	}
+	return metaStorage().Update(obj)
 }
 
 // InsertOrUpdate insert or update meta
 func InsertOrUpdate(meta *Meta) error {
-	 _, err := dbm.DBAccess.Raw("INSERT OR REPLACE INTO meta (key, type, appname, domain, value) VALUES (?,?,?,?,?)", meta.Key, meta.Type, meta.AppName, meta.Domain, meta.Value).Exec() // will update all field
-	klog.V(4).Infof("Update result %v", err)
-	return err
+	obj, err := toMetaV2(meta)
+	if err != nil {
+		return err
+	}
+	return metaStorage().InsertOrUpdate(obj)
 }
 
 // UpdateMetaField update special field
 func UpdateMetaField(key string, col string, value interface{}) error {
-	num, err := dbm.DBAccess.QueryTable(MetaTableName).Filter("key", key).Update(map[string]interface{}{col: value})
-	klog.V(4).Infof("Update affected Num: %d, %v", num, err)
+	value, err := encryptField(key, col, value)
+	if err != nil {
+		return err
+	}
+	_, err = metaStorage().UpdateFields(v2.LegacyKeyToKey(key), map[string]interface{}{col: value, updateTimeColumn: time.Now().Unix()})
 	return err
 }
 
 // UpdateMetaFields update special fields
 func UpdateMetaFields(key string, cols map[string]interface{}) error {
-	num, err := dbm.DBAccess.QueryTable(MetaTableName).Filter("key", key).Update(cols)
-	klog.V(4).Infof("Update affected Num: %d, %v", num, err)
+	encrypted := make(map[string]interface{}, len(cols)+1)
+	encrypted[updateTimeColumn] = time.Now().Unix()
+	for col, value := range cols {
+		value, err := encryptField(key, col, value)
+		if err != nil {
+			return err
+		}
+		encrypted[col] = value
+	}
+	_, err := metaStorage().UpdateFields(v2.LegacyKeyToKey(key), encrypted)
 	return err
 }
 
 // QueryMeta return only meta's value, if no error, Meta not null
 func QueryMeta(key string, condition string) (*[]string, error) {
-	meta := new([]Meta)
-	_, err := dbm.DBAccess.QueryTable(MetaTableName).Filter(key, condition).All(meta)
+	meta, err := queryMeta(key, condition)
 	if err != nil {
 		return nil, err
 	}
 
 	var result []string
-	for _, v := range *meta {
+	for _, v := range meta {
 		result = append(result, v.Value)
 	}
 	return &result, nil
@@ -95,18 +112,16 @@ func QueryMeta(key string, condition string) (*[]string, error) {
 
 //QueryMeta return only meta's value by many conditions, if no error, Meta not null
 func QueryMetasByGroupCond(conditions map[string]string) (*[]string, error) {
-	meta := new([]Meta)
-	conds := orm.NewCondition()
-
-	for key, conditon := range conditions {
-		conds = conds.And(key, conditon)
+	objs, err := metaStorage().QueryByGroupCond(conditions)
+	if err != nil {
+		return nil, err
 	}
-	_, err := dbm.DBAccess.QueryTable(MetaTableName).SetCond(conds).All(meta)
+	meta, err := fromMetaV2s(objs)
 	if err != nil {
 		return nil, err
 	}
 	var result []string
-	for _, v := range *meta {
+	for _, v := range meta {
 		result = append(result, v.Value)
 	}
 	return &result, nil
@@ -114,11 +129,54 @@ func QueryMetasByGroupCond(conditions map[string]string) (*[]string, error) {
 
 // QueryAllMeta return all meta, if no error, Meta not null
 func QueryAllMeta(key string, condition string) (*[]Meta, error) {
-	meta := new([]Meta)
-	_, err := dbm.DBAccess.QueryTable(MetaTableName).Filter(key, condition).All(meta)
+	meta, err := queryMeta(key, condition)
 	if err != nil {
 		return nil, err
 	}
 
-	return meta, nil
+	return &meta, nil
+}
+
+// queryMeta queries the records in meta_v2 by the column of the legacy resource format
+func queryMeta(key string, condition string) ([]Meta, error) {
+	if strings.EqualFold(key, "key") {
+		condition = v2.LegacyKeyToKey(condition)
+	}
+	objs, err := metaStorage().Query(key, condition)
+	if err != nil {
+		return nil, err
+	}
+	return fromMetaV2s(objs)
+}
+
+// toMetaV2 converts the meta to the record in meta_v2, the value of the sensitive meta is encrypted
+func toMetaV2(meta *Meta) (*v2.MetaV2, error) {
+	obj := v2.FromLegacy(meta.Key, meta.Type, meta.AppName, meta.Domain, meta.Value)
+	obj.UpdateTime = time.Now().Unix()
+	plain := *meta
+	plain.Value = obj.Value
+	encrypted, err := encryptMeta(&plain)
+	if err != nil {
+		return nil, err
+	}
+	obj.Value = encrypted.Value
+	return obj, nil
+}
+
+// fromMetaV2s converts the records in meta_v2 to the metas with the values decrypted
+func fromMetaV2s(objs []v2.MetaV2) ([]Meta, error) {
+	metas := make([]Meta, 0, len(objs))
+	for i := range objs {
+		metas = append(metas, Meta{
+			Key:     objs[i].LegacyKey(),
+			Type:    objs[i].Type,
+			AppName: objs[i].AppName,
+			Domain:  objs[i].Domain,
+			Value:   objs[i].Value,
+		})
+	}
+	if err := decryptMetas(metas); err != nil {
+		return nil, err
+	}
+	return metas, nil
 }
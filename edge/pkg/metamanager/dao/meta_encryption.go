@@ -0,0 +1,124 @@
+package dao
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/encryption"
+)
+
+// sensitiveTypes are the types of metas whose values are encrypted at rest
+var (
+	sensitiveTypes = map[string]bool{
+		model.ResourceTypeSecret:              true,
+		model.ResourceTypeConfigmap:           true,
+		model.ResourceTypeServiceAccountToken: true,
+	}
+	sensitiveLock sync.RWMutex
+)
+
+// SetSensitiveResources sets the metas encrypted at rest by the resources of the core group,
+// the legacy type of meta is the resource name in singular, like secret for secrets
+func SetSensitiveResources(resources []string) {
+	types := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		if strings.Contains(resource, ".") {
+			// the legacy metas are all in the core group
+			continue
+		}
+		types[resource] = true
+		types[strings.TrimSuffix(resource, "s")] = true
+	}
+	sensitiveLock.Lock()
+	defer sensitiveLock.Unlock()
+	sensitiveTypes = types
+}
+
+// IsSensitiveType checks whether the value of the meta type is encrypted at rest
+func IsSensitiveType(resType string) bool {
+	sensitiveLock.RLock()
+	defer sensitiveLock.RUnlock()
+	return sensitiveTypes[resType]
+}
+
+// isSensitiveKey checks the type in the meta key, which is like {namespace}/{type}/{name}
+func isSensitiveKey(key string) bool {
+	parts := strings.Split(key, "/")
+	return len(parts) > 1 && IsSensitiveType(parts[1])
+}
+
+// encryptMeta returns a copy of the meta with the value encrypted if the meta is sensitive
+func encryptMeta(meta *Meta) (*Meta, error) {
+	if !encryption.Enabled() || !IsSensitiveType(meta.Type) {
+		return meta, nil
+	}
+	value, err := encryption.Encrypt(meta.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt meta %s: %v", meta.Key, err)
+	}
+	encrypted := *meta
+	encrypted.Value = value
+	return &encrypted, nil
+}
+
+// encryptField encrypts the value column updated by key if the meta is sensitive
+func encryptField(key string, col string, value interface{}) (interface{}, error) {
+	str, ok := value.(string)
+	if !ok || col != "value" || !encryption.Enabled() || !isSensitiveKey(key) {
+		return value, nil
+	}
+	encrypted, err := encryption.Encrypt(str)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt meta %s: %v", key, err)
+	}
+	return encrypted, nil
+}
+
+// decryptMetas decrypts the values of the metas in place
+func decryptMetas(metas []Meta) error {
+	for i := range metas {
+		value, err := encryption.Decrypt(metas[i].Value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt meta %s: %v", metas[i].Key, err)
+		}
+		metas[i].Value = value
+	}
+	return nil
+}
+
+// EncryptSensitiveMetas encrypts the sensitive values saved in plain before encryption is enabled
+func EncryptSensitiveMetas() error {
+	if !encryption.Enabled() {
+		return nil
+	}
+	sensitiveLock.RLock()
+	types := make([]string, 0, len(sensitiveTypes))
+	for resType := range sensitiveTypes {
+		types = append(types, resType)
+	}
+	sensitiveLock.RUnlock()
+	for _, resType := range types {
+		objs, err := metaStorage().Query("type", resType)
+		if err != nil {
+			return err
+		}
+		for _, obj := range objs {
+			if encryption.IsEncrypted(obj.Value) {
+				continue
+			}
+			value, err := encryption.Encrypt(obj.Value)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt meta %s: %v", obj.Key, err)
+			}
+			if _, err := metaStorage().UpdateFields(obj.Key, map[string]interface{}{"value": value}); err != nil {
+				return err
+			}
+			klog.V(4).Infof("encrypted meta %s", obj.Key)
+		}
+	}
+	return nil
+}
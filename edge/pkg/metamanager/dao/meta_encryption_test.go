@@ -0,0 +1,60 @@
+package dao
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/encryption"
+)
+
+func TestEncryptMeta(t *testing.T) {
+	provider, err := encryption.NewAESGCMProvider(&encryption.FileKeySource{Path: filepath.Join(t.TempDir(), "meta.key"), Generate: true})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	encryption.SetProvider(provider)
+	defer encryption.SetProvider(nil)
+
+	secret := &Meta{Key: "default/secret/token", Type: model.ResourceTypeSecret, Value: "secret-value"}
+	encrypted, err := encryptMeta(secret)
+	if err != nil {
+		t.Fatalf("failed to encrypt meta: %v", err)
+	}
+	if !encryption.IsEncrypted(encrypted.Value) || secret.Value != "secret-value" {
+		t.Errorf("expected the copy of meta encrypted, but got %s and %s", encrypted.Value, secret.Value)
+	}
+	metas := []Meta{*encrypted}
+	if err := decryptMetas(metas); err != nil || metas[0].Value != "secret-value" {
+		t.Errorf("expected secret-value, but got %s, %v", metas[0].Value, err)
+	}
+
+	pod := &Meta{Key: "default/pod/nginx", Type: model.ResourceTypePod, Value: "pod-value"}
+	if encrypted, _ := encryptMeta(pod); encrypted.Value != "pod-value" {
+		t.Errorf("expected pod not encrypted, but got %s", encrypted.Value)
+	}
+
+	value, err := encryptField("default/serviceaccounttoken/sa", "value", "token")
+	if err != nil || !encryption.IsEncrypted(value.(string)) {
+		t.Errorf("expected token encrypted, but got %v, %v", value, err)
+	}
+	if value, _ := encryptField("default/serviceaccounttoken/sa", "type", "token"); value != "token" {
+		t.Errorf("expected type column not encrypted, but got %v", value)
+	}
+}
+
+func TestSetSensitiveResources(t *testing.T) {
+	defer SetSensitiveResources([]string{"secrets", "configmaps", "serviceaccounttokens"})
+	SetSensitiveResources([]string{"secrets", "endpoints", "widgets.example.com"})
+
+	for resType, want := range map[string]bool{
+		model.ResourceTypeSecret:    true,
+		"endpoints":                 true,
+		model.ResourceTypeConfigmap: false,
+		"widget":                    false,
+	} {
+		if got := IsSensitiveType(resType); got != want {
+			t.Errorf("%s: expected %v, but got %v", resType, want, got)
+		}
+	}
+}
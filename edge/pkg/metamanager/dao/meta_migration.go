@@ -0,0 +1,42 @@
+package dao
+
+import (
+	"k8s.io/klog/v2"
+
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+)
+
+// MigrateLegacyMetas moves the metas saved in the legacy meta table by the old versions to meta_v2.
+// It is called at startup and the migrated metas are deleted from the legacy table, so it only
+// takes effect once. If the object has been saved in meta_v2 by metaserver, the stored object
+// is kept and only the legacy columns are set.
+func MigrateLegacyMetas() error {
+	store := metaStorage()
+	metas, err := store.ListLegacy()
+	if err != nil || len(metas) == 0 {
+		return err
+	}
+	if err := decryptMetas(metas); err != nil {
+		return err
+	}
+	for i := range metas {
+		obj, err := toMetaV2(&metas[i])
+		if err != nil {
+			return err
+		}
+		num, err := store.UpdateFields(obj.Key, map[string]interface{}{"type": obj.Type, "appname": obj.AppName, "domain": obj.Domain})
+		if err != nil {
+			return err
+		}
+		if num == 0 {
+			if err := store.Insert(obj); err != nil && !IsNonUniqueNameError(err) {
+				return err
+			}
+		}
+		if err := store.DeleteLegacy(metas[i].Key); err != nil {
+			return err
+		}
+	}
+	klog.Infof("migrated %d metas from table %s to table %s", len(metas), MetaTableName, v2.NewMetaTableName)
+	return nil
+}
@@ -0,0 +1,176 @@
+package dao
+
+import (
+	"github.com/astaxie/beego/orm"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+)
+
+// metaStore is the storage of metas in meta_v2, the values are saved as they are given
+type metaStore interface {
+	Insert(obj *v2.MetaV2) error
+	Update(obj *v2.MetaV2) error
+	InsertOrUpdate(obj *v2.MetaV2) error
+	UpdateFields(key string, cols map[string]interface{}) (int64, error)
+	DeleteByKey(key string) error
+	Query(key string, condition string) ([]v2.MetaV2, error)
+	QueryByGroupCond(conditions map[string]string) ([]v2.MetaV2, error)
+
+	// ListLegacy lists the metas left in the legacy meta table
+	ListLegacy() ([]Meta, error)
+	// DeleteLegacy deletes the meta in the legacy meta table
+	DeleteLegacy(key string) error
+}
+
+// metaStorage returns the storage selected by DataBase.DriverName
+func metaStorage() metaStore {
+	if dbm.UseKV() {
+		return kvMetaStore{}
+	}
+	return sqliteMetaStore{}
+}
+
+type sqliteMetaStore struct{}
+
+func (sqliteMetaStore) Insert(obj *v2.MetaV2) error {
+	num, err := dbm.DBAccess.Insert(obj)
+	klog.V(4).Infof("Insert affected Num: %d, %v", num, err)
+	return err
+}
+
+func (sqliteMetaStore) Update(obj *v2.MetaV2) error {
+	num, err := dbm.DBAccess.Update(obj) // will update all field
+	klog.V(4).Infof("Update affected Num: %d, %v", num, err)
+	return err
+}
+
+func (sqliteMetaStore) InsertOrUpdate(obj *v2.MetaV2) error {
+	_, err := dbm.DBAccess.Raw("INSERT OR REPLACE INTO meta_v2 (key, groupversionresource, namespace, name, resourceversion, value, type, appname, domain, updatetime) VALUES (?,?,?,?,?,?,?,?,?,?)",
+		obj.Key, obj.GroupVersionResource, obj.Namespace, obj.Name, obj.ResourceVersion, obj.Value, obj.Type, obj.AppName, obj.Domain, obj.UpdateTime).Exec() // will update all field
+	klog.V(4).Infof("Update result %v", err)
+	return err
+}
+
+func (sqliteMetaStore) UpdateFields(key string, cols map[string]interface{}) (int64, error) {
+	num, err := dbm.DBAccess.QueryTable(v2.NewMetaTableName).Filter("key", key).Update(cols)
+	klog.V(4).Infof("Update affected Num: %d, %v", num, err)
+	return num, err
+}
+
+func (sqliteMetaStore) DeleteByKey(key string) error {
+	num, err := dbm.DBAccess.QueryTable(v2.NewMetaTableName).Filter("key", key).Delete()
+	klog.V(4).Infof("Delete affected Num: %d, %v", num, err)
+	return err
+}
+
+func (sqliteMetaStore) Query(key string, condition string) ([]v2.MetaV2, error) {
+	objs := new([]v2.MetaV2)
+	_, err := dbm.DBAccess.QueryTable(v2.NewMetaTableName).Filter(key, condition).All(objs)
+	if err != nil {
+		return nil, err
+	}
+	return *objs, nil
+}
+
+func (sqliteMetaStore) QueryByGroupCond(conditions map[string]string) ([]v2.MetaV2, error) {
+	objs := new([]v2.MetaV2)
+	conds := orm.NewCondition()
+
+	for key, conditon := range conditions {
+		conds = conds.And(key, conditon)
+	}
+	_, err := dbm.DBAccess.QueryTable(v2.NewMetaTableName).SetCond(conds).All(objs)
+	if err != nil {
+		return nil, err
+	}
+	return *objs, nil
+}
+
+func (sqliteMetaStore) ListLegacy() ([]Meta, error) {
+	var metas []Meta
+	_, err := dbm.DBAccess.QueryTable(MetaTableName).Limit(-1).All(&metas)
+	return metas, err
+}
+
+func (sqliteMetaStore) DeleteLegacy(key string) error {
+	_, err := dbm.DBAccess.QueryTable(MetaTableName).Filter("key", key).Delete()
+	return err
+}
+
+type kvMetaStore struct{}
+
+func (kvMetaStore) Insert(obj *v2.MetaV2) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		return dbm.KVInsert(tx, v2.NewMetaTableName, obj)
+	})
+}
+
+func (kvMetaStore) Update(obj *v2.MetaV2) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		// update does nothing if the record does not exist
+		exists, err := tx.Get(v2.NewMetaTableName, obj.Key, new(v2.MetaV2))
+		if err != nil || !exists {
+			return err
+		}
+		return tx.Put(v2.NewMetaTableName, obj.Key, obj)
+	})
+}
+
+func (kvMetaStore) InsertOrUpdate(obj *v2.MetaV2) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		return tx.Put(v2.NewMetaTableName, obj.Key, obj)
+	})
+}
+
+func (kvMetaStore) UpdateFields(key string, cols map[string]interface{}) (int64, error) {
+	var num int64
+	err := dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		var err error
+		num, err = dbm.KVUpdate(tx, v2.NewMetaTableName, new(v2.MetaV2), cols, dbm.Cond{Expr: "key", Value: key})
+		klog.V(4).Infof("Update affected Num: %d, %v", num, err)
+		return err
+	})
+	return num, err
+}
+
+func (kvMetaStore) DeleteByKey(key string) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		return tx.Delete(v2.NewMetaTableName, key)
+	})
+}
+
+func (kvMetaStore) Query(key string, condition string) ([]v2.MetaV2, error) {
+	var objs []v2.MetaV2
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, v2.NewMetaTableName, &objs, dbm.Cond{Expr: key, Value: condition})
+	})
+	return objs, err
+}
+
+func (kvMetaStore) QueryByGroupCond(conditions map[string]string) ([]v2.MetaV2, error) {
+	conds := make([]dbm.Cond, 0, len(conditions))
+	for key, condition := range conditions {
+		conds = append(conds, dbm.Cond{Expr: key, Value: condition})
+	}
+	var objs []v2.MetaV2
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, v2.NewMetaTableName, &objs, conds...)
+	})
+	return objs, err
+}
+
+func (kvMetaStore) ListLegacy() ([]Meta, error) {
+	var metas []Meta
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, MetaTableName, &metas)
+	})
+	return metas, err
+}
+
+func (kvMetaStore) DeleteLegacy(key string) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		return tx.Delete(MetaTableName, key)
+	})
+}
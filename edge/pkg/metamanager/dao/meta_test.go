@@ -26,6 +26,7 @@ import (
 
 	"github.com/kubeedge/kubeedge/edge/mocks/beego"
 	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
 )
 
 // errFailedDBOperation is common DB operation fail error
@@ -356,9 +357,9 @@ func TestQueryMeta(t *testing.T) {
 	}
 
 	// fakeDao is used to set the argument of All function
-	fakeDao := new([]Meta)
-	fakeDaoArray := make([]Meta, 1)
-	fakeDaoArray[0] = Meta{Key: "Test"}
+	fakeDao := new([]v2.MetaV2)
+	fakeDaoArray := make([]v2.MetaV2, 1)
+	fakeDaoArray[0] = v2.MetaV2{Key: "Test"}
 	fakeDao = &fakeDaoArray
 
 	// run the test cases
@@ -422,9 +423,9 @@ func TestQueryAllMeta(t *testing.T) {
 	}
 
 	// fakeDao is used to set the argument of All function
-	fakeDao := new([]Meta)
-	fakeDaoArray := make([]Meta, 1)
-	fakeDaoArray[0] = Meta{Key: "Test", Value: "Test"}
+	fakeDao := new([]v2.MetaV2)
+	fakeDaoArray := make([]v2.MetaV2, 1)
+	fakeDaoArray[0] = v2.MetaV2{Key: "Test", Value: "Test"}
 	fakeDao = &fakeDaoArray
 
 	// run the test cases
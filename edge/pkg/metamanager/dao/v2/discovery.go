@@ -0,0 +1,32 @@
+package v2
+
+import (
+	"github.com/kubeedge/kubeedge/common/constants"
+)
+
+// discoveryKeyPrefix is the key prefix of the discovery documents, they are saved with
+// an empty gvr so that they are never listed as api objects
+const discoveryKeyPrefix = "/discovery"
+
+// SaveDiscovery saves the discovery document of the path, like /apis/{group}/{version}
+func SaveDiscovery(path string, doc string) error {
+	return InsertOrUpdate(&MetaV2{
+		Key:   discoveryKeyPrefix + path,
+		Type:  constants.ResourceTypeDiscovery,
+		Value: doc,
+	})
+}
+
+// GetDiscovery returns the discovery document of the path, it is false if the document is not saved
+func GetDiscovery(path string) (string, bool, error) {
+	obj, exists, err := objectStorage().Get(discoveryKeyPrefix + path)
+	if err != nil || !exists {
+		return "", false, err
+	}
+	return obj.Value, true, nil
+}
+
+// DeleteDiscovery deletes the discovery document of the path
+func DeleteDiscovery(path string) error {
+	return DeleteByKey(discoveryKeyPrefix + path)
+}
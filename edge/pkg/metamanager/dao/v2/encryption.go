@@ -0,0 +1,146 @@
+package v2
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/encryption"
+)
+
+var (
+	// sensitiveResources are the resources whose values are encrypted at rest, which are
+	// in the format of {resource} for the core group or {resource}.{group} for the others
+	sensitiveResources = sets.NewString("secrets", "configmaps", "serviceaccounttokens")
+	sensitiveLock      sync.RWMutex
+)
+
+// SetSensitiveResources sets the resources whose values are encrypted at rest
+func SetSensitiveResources(resources []string) {
+	sensitiveLock.Lock()
+	defer sensitiveLock.Unlock()
+	sensitiveResources = sets.NewString(resources...)
+}
+
+// IsSensitiveKey checks whether the value of the object with the key is encrypted at rest,
+// the key is like /{group}/{version}/{resource}/{namespace}/{name}
+func IsSensitiveKey(key string) bool {
+	parts := strings.Split(key, "/")
+	if len(parts) < 4 || parts[0] != "" {
+		return false
+	}
+	resource := parts[3]
+	if parts[1] != GroupCore {
+		resource += "." + parts[1]
+	}
+	sensitiveLock.RLock()
+	defer sensitiveLock.RUnlock()
+	return sensitiveResources.Has(resource)
+}
+
+// EncryptValue returns the encrypted value if the object with the key is sensitive
+func EncryptValue(key, value string) (string, error) {
+	if !encryption.Enabled() || !IsSensitiveKey(key) {
+		return value, nil
+	}
+	encrypted, err := encryption.Encrypt(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt %s: %v", key, err)
+	}
+	return encrypted, nil
+}
+
+func decryptMetaV2s(objs []MetaV2) error {
+	for i := range objs {
+		value, err := encryption.Decrypt(objs[i].Value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s: %v", objs[i].Key, err)
+		}
+		objs[i].Value = value
+	}
+	return nil
+}
+
+func decryptPendingOperations(ops []MetaPendingOperation) error {
+	for i := range ops {
+		value, err := encryption.Decrypt(ops[i].Value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt pending operation of %s: %v", ops[i].Key, err)
+		}
+		ops[i].Value = value
+	}
+	return nil
+}
+
+// EncryptSensitiveObjects encrypts the sensitive objects and pending operations
+// saved in plain before encryption is enabled
+func EncryptSensitiveObjects() error {
+	if !encryption.Enabled() {
+		return nil
+	}
+	store := objectStorage()
+	objs, err := store.ListByKeyPrefix("/")
+	if err != nil {
+		return err
+	}
+	for i := range objs {
+		if !IsSensitiveKey(objs[i].Key) || encryption.IsEncrypted(objs[i].Value) {
+			continue
+		}
+		if objs[i].Value, err = EncryptValue(objs[i].Key, objs[i].Value); err != nil {
+			return err
+		}
+		if err := store.InsertOrUpdate(&objs[i]); err != nil {
+			return err
+		}
+		klog.V(4).Infof("encrypted %s", objs[i].Key)
+	}
+
+	ops, err := store.ListPendingOperations()
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if !IsSensitiveKey(op.Key) || encryption.IsEncrypted(op.Value) {
+			continue
+		}
+		value, err := EncryptValue(op.Key, op.Value)
+		if err != nil {
+			return err
+		}
+		if err := store.UpdatePendingOperations(map[string]interface{}{"Value": value},
+			dbm.Cond{Expr: "ID", Value: op.ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasEncryptedObjects checks whether any object or pending operation is saved encrypted,
+// which can not be read without the key used to encrypt it
+func HasEncryptedObjects() (bool, error) {
+	store := objectStorage()
+	objs, err := store.ListByKeyPrefix("")
+	if err != nil {
+		return false, err
+	}
+	for _, obj := range objs {
+		if encryption.IsEncrypted(obj.Value) {
+			return true, nil
+		}
+	}
+	ops, err := store.ListPendingOperations()
+	if err != nil {
+		return false, err
+	}
+	for _, op := range ops {
+		if encryption.IsEncrypted(op.Value) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"testing"
+)
+
+func TestIsSensitiveKey(t *testing.T) {
+	defer SetSensitiveResources([]string{"secrets", "configmaps", "serviceaccounttokens"})
+	SetSensitiveResources([]string{"secrets", "widgets.example.com"})
+
+	cases := map[string]bool{
+		"/core/v1/secrets/default/token":             true,
+		"/core/v1/configmaps/default/config":         false,
+		"/example.com/v1/widgets/default/widget":     true,
+		"/example.com/v1/gadgets/default/gadget":     false,
+		"/apps/v1/secrets/default/not-a-core-secret": false,
+		"default/secret/token":                       false,
+	}
+	for key, want := range cases {
+		if got := IsSensitiveKey(key); got != want {
+			t.Errorf("%s: expected %v, but got %v", key, want, got)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/common/constants"
+)
+
+// legacyResource is the api resource of a legacy resource type
+type legacyResource struct {
+	gvr           schema.GroupVersionResource
+	kind          string
+	clusterScoped bool
+}
+
+// legacyResources are the legacy resource types whose records are api objects, they are saved
+// with the same key as the objects saved by metaserver, so that both of them share one record.
+// The records of the other types, like podstatus, are saved with the legacy key.
+var legacyResources = map[string]legacyResource{
+	model.ResourceTypePod:                       {gvr: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, kind: "Pod"},
+	model.ResourceTypeConfigmap:                 {gvr: schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, kind: "ConfigMap"},
+	model.ResourceTypeSecret:                    {gvr: schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, kind: "Secret"},
+	model.ResourceTypeNode:                      {gvr: schema.GroupVersionResource{Version: "v1", Resource: "nodes"}, kind: "Node", clusterScoped: true},
+	constants.ResourceTypeService:               {gvr: schema.GroupVersionResource{Version: "v1", Resource: "services"}, kind: "Service"},
+	constants.ResourceTypeEndpoints:             {gvr: schema.GroupVersionResource{Version: "v1", Resource: "endpoints"}, kind: "Endpoints"},
+	constants.ResourceTypePersistentVolume:      {gvr: schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumes"}, kind: "PersistentVolume", clusterScoped: true},
+	constants.ResourceTypePersistentVolumeClaim: {gvr: schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}, kind: "PersistentVolumeClaim"},
+	constants.ResourceTypeVolumeAttachment:      {gvr: schema.GroupVersionResource{Group: "storage.k8s.io", Version: "v1", Resource: "volumeattachments"}, kind: "VolumeAttachment", clusterScoped: true},
+	model.ResourceTypeLease:                     {gvr: schema.GroupVersionResource{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}, kind: "Lease"},
+}
+
+// legacyType returns the legacy resource type of the gvr, it is empty if there is none
+func legacyType(gvr string) string {
+	for resType, res := range legacyResources {
+		if res.gvr.String() == gvr {
+			return resType
+		}
+	}
+	return ""
+}
+
+// IsLegacyResourceType checks whether the resource type is a legacy type of the api objects
+func IsLegacyResourceType(resType string) bool {
+	_, ok := legacyResources[resType]
+	return ok
+}
+
+// LegacyKeyToKey converts the legacy key {namespace}/{type}/{name}[/{appname}[/{domain}]]
+// to the key of the record in meta_v2
+func LegacyKeyToKey(legacyKey string) string {
+	tokens := strings.Split(legacyKey, constants.ResourceSep)
+	if len(tokens) < 3 || tokens[2] == "" {
+		return legacyKey
+	}
+	res, ok := legacyResources[tokens[1]]
+	if !ok {
+		return legacyKey
+	}
+	group, namespace := res.gvr.Group, tokens[0]
+	if group == "" {
+		group = GroupCore
+	}
+	if res.clusterScoped {
+		namespace = NullNamespace
+	}
+	return fmt.Sprintf("/%s/%s/%s/%s/%s", group, res.gvr.Version, res.gvr.Resource, namespace, tokens[2])
+}
+
+// FromLegacy converts a record in the legacy resource format to the record in meta_v2,
+// the type meta of the api object is set if it is not in the value
+func FromLegacy(legacyKey, resType, appName, domain, value string) *MetaV2 {
+	m := &MetaV2{
+		Key:     LegacyKeyToKey(legacyKey),
+		Type:    resType,
+		AppName: appName,
+		Domain:  domain,
+		Value:   value,
+	}
+	tokens := strings.Split(legacyKey, constants.ResourceSep)
+	if len(tokens) > 2 {
+		m.Namespace, m.Name = tokens[0], tokens[2]
+	}
+	res, ok := legacyResources[resType]
+	if !ok || m.Key == legacyKey {
+		return m
+	}
+	m.GroupVersionResource = res.gvr.String()
+	if res.clusterScoped {
+		m.Namespace = ""
+	}
+
+	var obj struct {
+		metav1.TypeMeta `json:",inline"`
+		Metadata        struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(value), &obj); err != nil {
+		return m
+	}
+	m.ResourceVersion, _ = strconv.ParseUint(obj.Metadata.ResourceVersion, 10, 64)
+	if obj.APIVersion == "" || obj.Kind == "" {
+		m.Value = withTypeMeta(value, res.gvr.GroupVersion().WithKind(res.kind))
+	}
+	return m
+}
+
+// withTypeMeta sets apiVersion and kind of the api object in json format
+func withTypeMeta(value string, gvk schema.GroupVersionKind) string {
+	var obj map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewBufferString(value))
+	// keep the numbers as they are
+	decoder.UseNumber()
+	if err := decoder.Decode(&obj); err != nil {
+		return value
+	}
+	obj["apiVersion"], obj["kind"] = gvk.ToAPIVersionAndKind()
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return value
+	}
+	return string(data)
+}
+
+// LegacyKey returns the key of the record in the legacy resource format
+func (m *MetaV2) LegacyKey() string {
+	if m.GroupVersionResource == "" || m.Type == "" {
+		return m.Key
+	}
+	namespace := m.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	key := strings.Join([]string{namespace, m.Type, m.Name}, constants.ResourceSep)
+	if m.AppName != "" {
+		key += constants.ResourceSep + m.AppName
+		if m.Domain != "" {
+			key += constants.ResourceSep + m.Domain
+		}
+	}
+	return key
+}
@@ -0,0 +1,50 @@
+package v2
+
+import (
+	"testing"
+)
+
+func TestLegacyKeyToKey(t *testing.T) {
+	cases := map[string]string{
+		"default/pod/nginx":             "/core/v1/pods/default/nginx",
+		"default/secret/token/app/edge": "/core/v1/secrets/default/token",
+		"default/node/edge-node":        "/core/v1/nodes/null/edge-node",
+		"kube-node-lease/lease/edge":    "/coordination.k8s.io/v1/leases/kube-node-lease/edge",
+		"default/podstatus/nginx":       "default/podstatus/nginx",
+		"default/pod":                   "default/pod",
+	}
+	for legacyKey, want := range cases {
+		if got := LegacyKeyToKey(legacyKey); got != want {
+			t.Errorf("LegacyKeyToKey(%s) = %s, want %s", legacyKey, got, want)
+		}
+	}
+}
+
+func TestFromLegacy(t *testing.T) {
+	m := FromLegacy("default/pod/nginx", "pod", "", "", `{"metadata":{"name":"nginx","namespace":"default","resourceVersion":"12"}}`)
+	if m.Key != "/core/v1/pods/default/nginx" || m.GroupVersionResource != "/v1, Resource=pods" ||
+		m.Namespace != "default" || m.Name != "nginx" || m.ResourceVersion != 12 {
+		t.Errorf("unexpected record %+v", m)
+	}
+	if m.Value != `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"nginx","namespace":"default","resourceVersion":"12"}}` {
+		t.Errorf("expected type meta set, but got %s", m.Value)
+	}
+	if m.LegacyKey() != "default/pod/nginx" {
+		t.Errorf("expected legacy key default/pod/nginx, but got %s", m.LegacyKey())
+	}
+
+	m = FromLegacy("edge/node/edge-node", "node", "", "", `{"apiVersion":"v1","kind":"Node","metadata":{"name":"edge-node"}}`)
+	if m.Key != "/core/v1/nodes/null/edge-node" || m.Namespace != "" || m.LegacyKey() != "default/node/edge-node" {
+		t.Errorf("unexpected record %+v", m)
+	}
+
+	m = FromLegacy("default/configmap/cm/app/edge", "configmap", "app", "edge", "cm-value")
+	if m.Value != "cm-value" || m.LegacyKey() != "default/configmap/cm/app/edge" {
+		t.Errorf("unexpected record %+v", m)
+	}
+
+	m = FromLegacy("default/podstatus/nginx", "podstatus", "", "", "status")
+	if m.Key != "default/podstatus/nginx" || m.GroupVersionResource != "" || m.LegacyKey() != "default/podstatus/nginx" {
+		t.Errorf("unexpected record %+v", m)
+	}
+}
@@ -1,10 +1,10 @@
 package v2
 
 import (
+	"time"
+
 	"github.com/astaxie/beego/orm"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-
-	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
 )
 
 //constant metatable name reference
@@ -44,40 +44,69 @@ type MetaV2 struct {
 	// Value is the api object in json format
 	// TODO: change to []byte
 	Value string `orm:"column(value); null; type(text)"`
+	// Type is the resource type used by the legacy meta queries, like pod or podstatus,
+	// it is empty for the objects never accessed by the legacy resource format
+	Type string `orm:"column(type); size(32)"`
+	// AppName and Domain are the labels of native apps, they are set only by the legacy writes
+	AppName string `orm:"column(appname); size(256)"`
+	Domain  string `orm:"column(domain); size(256)"`
+	// UpdateTime is the unix time of the last write, it is used to enforce the retention quotas
+	UpdateTime int64 `orm:"column(updatetime)"`
 }
 
 // List a slice of raw data by Group Version Resource Namespace Name
 func RawMetaByGVRNN(gvr schema.GroupVersionResource, namespace string, name string) (*[]MetaV2, error) {
-	objs := new([]MetaV2)
-	var err error
-	// TODO: use getCondition
-	//cond := getCondition(gvr,namespace,name)
-	//klog.Infof("cond:%+v",cond)
-	//_,err = dbm.DBAccess.QueryTable(NewMetaTableName).SetCond(cond).All(objs)
-	if gvr.Empty() {
-		_, err = dbm.DBAccess.QueryTable(NewMetaTableName).All(objs)
-	} else {
-		switch namespace {
-		case NullNamespace, "":
-			switch name {
-			case NullName, "":
-				_, err = dbm.DBAccess.QueryTable(NewMetaTableName).Filter(GVR, gvr.String()).All(objs)
-			default:
-				_, err = dbm.DBAccess.QueryTable(NewMetaTableName).Filter(GVR, gvr.String()).Filter(NAME, name).All(objs)
-			}
-		default:
-			switch name {
-			case NullName, "":
-				_, err = dbm.DBAccess.QueryTable(NewMetaTableName).Filter(GVR, gvr.String()).Filter(NS, namespace).All(objs)
-			default:
-				_, err = dbm.DBAccess.QueryTable(NewMetaTableName).Filter(GVR, gvr.String()).Filter(NS, namespace).Filter(NAME, name).All(objs)
-			}
-		}
-	}
+	objs, err := objectStorage().List(gvr, namespace, name)
 	if err != nil {
 		return nil, err
 	}
-	return objs, nil
+	if err := decryptMetaV2s(objs); err != nil {
+		return nil, err
+	}
+	return &objs, nil
+}
+
+// InsertOrUpdate saves the object, the value of the sensitive object is encrypted.
+// The type is set by the resource of the object, and the native app labels saved
+// by the legacy writes are kept
+func InsertOrUpdate(m *MetaV2) error {
+	value, err := EncryptValue(m.Key, m.Value)
+	if err != nil {
+		return err
+	}
+	encrypted := *m
+	encrypted.Value = value
+	encrypted.UpdateTime = time.Now().Unix()
+	if encrypted.Type == "" {
+		encrypted.Type = legacyType(encrypted.GroupVersionResource)
+	}
+	return objectStorage().InsertOrUpdate(&encrypted)
+}
+
+// DeleteByKey deletes the object of the key
+func DeleteByKey(key string) error {
+	return objectStorage().DeleteByKey(key)
+}
+
+// LatestUpdateTime returns the max unix time the records of the legacy resource type are updated,
+// it is 0 if there is no record
+func LatestUpdateTime(resType string) (int64, error) {
+	stats, err := objectStorage().ListRecordStats(resType)
+	if err != nil {
+		return 0, err
+	}
+	var latest int64
+	for _, stat := range stats {
+		if stat.UpdateTime > latest {
+			latest = stat.UpdateTime
+		}
+	}
+	return latest, nil
+}
+
+// LatestResourceVersion returns the max resource version of the objects saved
+func LatestResourceVersion() (uint64, error) {
+	return objectStorage().LatestResourceVersion()
 }
 
 func getCondition(gvr schema.GroupVersionResource, namespace string, name string) *orm.Condition {
@@ -0,0 +1,136 @@
+package v2
+
+import (
+	"sync"
+
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+)
+
+// PendingOperationTableName is the table of the writes accepted by metaserver while offline
+const PendingOperationTableName = "meta_pending_operation"
+
+// MetaPendingOperation records a write applied to meta_v2 while the cloud is unreachable,
+// the operations are replayed to the cloud in the order of ID on reconnect
+type MetaPendingOperation struct {
+	ID int64 `orm:"column(id); pk; auto"`
+	// Key is the key of the object in meta_v2
+	Key string `orm:"column(key); size(256)"`
+	// Verb is the application verb of the operation: create, update, updatestatus or delete
+	Verb string `orm:"column(verb); size(32)"`
+	// BaseResourceVersion is the resource version of the object before the operation,
+	// which is the resource version in the cloud unless the object is written offline before
+	BaseResourceVersion string `orm:"column(baseresourceversion); size(64)"`
+	// ResourceVersion is the local resource version assigned to the object by the operation
+	ResourceVersion uint64 `orm:"column(resourceversion)"`
+	// Value is the object after the operation in json format
+	Value string `orm:"column(value); null; type(text)"`
+}
+
+// InsertPendingOperation appends the operation to the end of the journal
+func InsertPendingOperation(op *MetaPendingOperation) error {
+	value, err := EncryptValue(op.Key, op.Value)
+	if err != nil {
+		return err
+	}
+	encrypted := *op
+	encrypted.Value = value
+	if err := pendingKeys.load(); err != nil {
+		return err
+	}
+	if err := objectStorage().InsertPendingOperation(&encrypted); err != nil {
+		return err
+	}
+	op.ID = encrypted.ID
+	pendingKeys.add(op.Key)
+	return nil
+}
+
+// ListPendingOperations returns the operations in the order they were accepted
+func ListPendingOperations() ([]MetaPendingOperation, error) {
+	ops, err := objectStorage().ListPendingOperations()
+	if err != nil {
+		return nil, err
+	}
+	if err := decryptPendingOperations(ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// DeletePendingOperation removes the operation from the journal
+func DeletePendingOperation(op *MetaPendingOperation) error {
+	if err := pendingKeys.load(); err != nil {
+		return err
+	}
+	if err := objectStorage().DeletePendingOperation(op.ID); err != nil {
+		return err
+	}
+	pendingKeys.remove(op.Key)
+	return nil
+}
+
+// RebasePendingOperations sets the base resource version of the remaining operations of the key,
+// it is called after an operation of the key is replayed to the cloud
+func RebasePendingOperations(key, baseResourceVersion string) error {
+	return objectStorage().UpdatePendingOperations(map[string]interface{}{"BaseResourceVersion": baseResourceVersion},
+		dbm.Cond{Expr: "Key", Value: key})
+}
+
+// HasPendingOperations checks whether the object of the key has operations not replayed yet
+func HasPendingOperations(key string) (bool, error) {
+	if err := pendingKeys.load(); err != nil {
+		return false, err
+	}
+	return pendingKeys.has(key), nil
+}
+
+// pendingKeys counts the journaled operations of each key, so the reads served from
+// the cloud can skip the objects written offline without scanning the journal
+var pendingKeys = &pendingKeySet{}
+
+type pendingKeySet struct {
+	lock   sync.Mutex
+	loaded bool
+	counts map[string]int
+}
+
+// load counts the operations in the journal on first use
+func (s *pendingKeySet) load() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.loaded {
+		return nil
+	}
+	ops, err := objectStorage().ListPendingOperations()
+	if err != nil {
+		return err
+	}
+	s.counts = make(map[string]int, len(ops))
+	for _, op := range ops {
+		s.counts[op.Key]++
+	}
+	s.loaded = true
+	return nil
+}
+
+func (s *pendingKeySet) add(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.counts[key]++
+}
+
+func (s *pendingKeySet) remove(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.counts[key] <= 1 {
+		delete(s.counts, key)
+		return
+	}
+	s.counts[key]--
+}
+
+func (s *pendingKeySet) has(key string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.counts[key] > 0
+}
@@ -0,0 +1,28 @@
+package v2
+
+import (
+	"testing"
+)
+
+func TestPendingKeySet(t *testing.T) {
+	s := &pendingKeySet{loaded: true, counts: map[string]int{}}
+	s.add("a")
+	s.add("a")
+	s.add("b")
+
+	s.remove("a")
+	if !s.has("a") {
+		t.Errorf("expected key a pending until all its operations are removed")
+	}
+	s.remove("a")
+	if s.has("a") {
+		t.Errorf("expected key a not pending")
+	}
+	if !s.has("b") {
+		t.Errorf("expected key b pending")
+	}
+	s.remove("b")
+	if len(s.counts) != 0 {
+		t.Errorf("expected no key left, but got %v", s.counts)
+	}
+}
@@ -0,0 +1,32 @@
+package v2
+
+import (
+	"time"
+
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+)
+
+// ExceededObjects returns the records of the legacy resource type beyond the quota, the least
+// recently updated records are returned first. The records are deleted by metaserver storage
+// so that the watchers see the deletions.
+func ExceededObjects(resType string, maxCount int32, maxSize int64, before time.Time) ([]MetaV2, error) {
+	store := objectStorage()
+	stats, err := store.ListRecordStats(resType)
+	if err != nil {
+		return nil, err
+	}
+	var objs []MetaV2
+	for _, key := range dbm.ExceededRecords(stats, maxCount, maxSize, before) {
+		obj, exists, err := store.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			objs = append(objs, *obj)
+		}
+	}
+	if err := decryptMetaV2s(objs); err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
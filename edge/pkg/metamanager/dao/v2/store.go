@@ -0,0 +1,289 @@
+package v2
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/astaxie/beego/orm"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+)
+
+// objectStore is the storage of the api objects and the pending operations,
+// the values are saved as they are given
+type objectStore interface {
+	List(gvr schema.GroupVersionResource, namespace string, name string) ([]MetaV2, error)
+	ListByKeyPrefix(prefix string) ([]MetaV2, error)
+	Get(key string) (*MetaV2, bool, error)
+	InsertOrUpdate(m *MetaV2) error
+	DeleteByKey(key string) error
+	LatestResourceVersion() (uint64, error)
+
+	// ListRecordStats lists the update time and value size of the records of the legacy resource type
+	ListRecordStats(resType string) ([]dbm.RecordStat, error)
+
+	InsertPendingOperation(op *MetaPendingOperation) error
+	ListPendingOperations(conds ...dbm.Cond) ([]MetaPendingOperation, error)
+	UpdatePendingOperations(cols map[string]interface{}, conds ...dbm.Cond) error
+	DeletePendingOperation(id int64) error
+}
+
+// objectStorage returns the storage selected by DataBase.DriverName
+func objectStorage() objectStore {
+	if dbm.UseKV() {
+		return kvObjectStore{}
+	}
+	return sqliteObjectStore{}
+}
+
+type sqliteObjectStore struct{}
+
+func (sqliteObjectStore) List(gvr schema.GroupVersionResource, namespace string, name string) ([]MetaV2, error) {
+	objs := new([]MetaV2)
+	var err error
+	// TODO: use getCondition
+	//cond := getCondition(gvr,namespace,name)
+	//klog.Infof("cond:%+v",cond)
+	//_,err = dbm.DBAccess.QueryTable(NewMetaTableName).SetCond(cond).All(objs)
+	if gvr.Empty() {
+		// the records saved by legacy resource types only have no gvr
+		_, err = dbm.DBAccess.QueryTable(NewMetaTableName).Exclude(GVR, "").All(objs)
+	} else {
+		switch namespace {
+		case NullNamespace, "":
+			switch name {
+			case NullName, "":
+				_, err = dbm.DBAccess.QueryTable(NewMetaTableName).Filter(GVR, gvr.String()).All(objs)
+			default:
+				_, err = dbm.DBAccess.QueryTable(NewMetaTableName).Filter(GVR, gvr.String()).Filter(NAME, name).All(objs)
+			}
+		default:
+			switch name {
+			case NullName, "":
+				_, err = dbm.DBAccess.QueryTable(NewMetaTableName).Filter(GVR, gvr.String()).Filter(NS, namespace).All(objs)
+			default:
+				_, err = dbm.DBAccess.QueryTable(NewMetaTableName).Filter(GVR, gvr.String()).Filter(NS, namespace).Filter(NAME, name).All(objs)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return *objs, nil
+}
+
+func (sqliteObjectStore) ListByKeyPrefix(prefix string) ([]MetaV2, error) {
+	var objs []MetaV2
+	_, err := dbm.DBAccess.QueryTable(NewMetaTableName).Filter(KEY+"__startswith", prefix).All(&objs)
+	return objs, err
+}
+
+func (sqliteObjectStore) Get(key string) (*MetaV2, bool, error) {
+	var objs []MetaV2
+	if _, err := dbm.DBAccess.QueryTable(NewMetaTableName).Filter(KEY, key).All(&objs); err != nil || len(objs) == 0 {
+		return nil, false, err
+	}
+	return &objs[0], true, nil
+}
+
+func (sqliteObjectStore) InsertOrUpdate(m *MetaV2) error {
+	// appname and domain are owned by the legacy writes, keep them when the object is updated
+	_, err := dbm.DBAccess.Raw("INSERT INTO meta_v2 (key, groupversionresource, namespace, name, resourceversion, value, type, appname, domain, updatetime) VALUES (?,?,?,?,?,?,?,?,?,?) "+
+		"ON CONFLICT(key) DO UPDATE SET groupversionresource = excluded.groupversionresource, namespace = excluded.namespace, name = excluded.name, "+
+		"resourceversion = excluded.resourceversion, value = excluded.value, type = excluded.type, updatetime = excluded.updatetime",
+		m.Key, m.GroupVersionResource, m.Namespace, m.Name, m.ResourceVersion, m.Value, m.Type, m.AppName, m.Domain, m.UpdateTime).Exec()
+	return err
+}
+
+func (sqliteObjectStore) DeleteByKey(key string) error {
+	_, err := dbm.DBAccess.Delete(&MetaV2{Key: key})
+	return err
+}
+
+func (sqliteObjectStore) LatestResourceVersion() (uint64, error) {
+	m := new(MetaV2)
+	// get the most recent record
+	_, err := dbm.DBAccess.QueryTable(NewMetaTableName).OrderBy("-" + RV).Limit(1).All(m)
+	return m.ResourceVersion, err
+}
+
+func (sqliteObjectStore) ListRecordStats(resType string) ([]dbm.RecordStat, error) {
+	var rows []orm.ParamsList
+	_, err := dbm.DBAccess.Raw("SELECT key, updatetime, LENGTH(value) FROM meta_v2 WHERE type = ?", resType).ValuesList(&rows)
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]dbm.RecordStat, 0, len(rows))
+	for _, row := range rows {
+		if len(row) != 3 {
+			return nil, fmt.Errorf("unexpected columns %v", row)
+		}
+		stat := dbm.RecordStat{Key: fmt.Sprint(row[0])}
+		stat.UpdateTime, _ = strconv.ParseInt(fmt.Sprint(row[1]), 10, 64)
+		stat.Size, _ = strconv.ParseInt(fmt.Sprint(row[2]), 10, 64)
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+func (sqliteObjectStore) InsertPendingOperation(op *MetaPendingOperation) error {
+	_, err := dbm.DBAccess.Insert(op)
+	return err
+}
+
+func (sqliteObjectStore) ListPendingOperations(conds ...dbm.Cond) ([]MetaPendingOperation, error) {
+	var ops []MetaPendingOperation
+	qs := dbm.DBAccess.QueryTable(PendingOperationTableName)
+	for _, cond := range conds {
+		qs = qs.Filter(cond.Expr, cond.Value)
+	}
+	_, err := qs.OrderBy("ID").All(&ops)
+	return ops, err
+}
+
+func (sqliteObjectStore) UpdatePendingOperations(cols map[string]interface{}, conds ...dbm.Cond) error {
+	qs := dbm.DBAccess.QueryTable(PendingOperationTableName)
+	for _, cond := range conds {
+		qs = qs.Filter(cond.Expr, cond.Value)
+	}
+	_, err := qs.Update(orm.Params(cols))
+	return err
+}
+
+func (sqliteObjectStore) DeletePendingOperation(id int64) error {
+	_, err := dbm.DBAccess.Delete(&MetaPendingOperation{ID: id})
+	return err
+}
+
+type kvObjectStore struct{}
+
+func (kvObjectStore) List(gvr schema.GroupVersionResource, namespace string, name string) ([]MetaV2, error) {
+	var conds []dbm.Cond
+	if !gvr.Empty() {
+		conds = append(conds, dbm.Cond{Expr: GVR, Value: gvr.String()})
+		if namespace != NullNamespace && namespace != "" {
+			conds = append(conds, dbm.Cond{Expr: NS, Value: namespace})
+		}
+		if name != NullName && name != "" {
+			conds = append(conds, dbm.Cond{Expr: NAME, Value: name})
+		}
+	}
+	var objs []MetaV2
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, NewMetaTableName, &objs, conds...)
+	})
+	if err != nil || !gvr.Empty() {
+		return objs, err
+	}
+	// the records saved by legacy resource types only have no gvr
+	result := make([]MetaV2, 0, len(objs))
+	for _, obj := range objs {
+		if obj.GroupVersionResource != "" {
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+func (kvObjectStore) ListByKeyPrefix(prefix string) ([]MetaV2, error) {
+	var objs []MetaV2
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, NewMetaTableName, &objs, dbm.Cond{Expr: KEY + "__startswith", Value: prefix})
+	})
+	return objs, err
+}
+
+func (kvObjectStore) Get(key string) (*MetaV2, bool, error) {
+	obj := new(MetaV2)
+	var exists bool
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		var err error
+		exists, err = tx.Get(NewMetaTableName, key, obj)
+		return err
+	})
+	if err != nil || !exists {
+		return nil, false, err
+	}
+	return obj, true, nil
+}
+
+func (kvObjectStore) InsertOrUpdate(m *MetaV2) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		// appname and domain are owned by the legacy writes, keep them when the object is updated
+		old := new(MetaV2)
+		exists, err := tx.Get(NewMetaTableName, m.Key, old)
+		if err != nil {
+			return err
+		}
+		obj := *m
+		if exists {
+			obj.AppName, obj.Domain = old.AppName, old.Domain
+		}
+		return tx.Put(NewMetaTableName, obj.Key, &obj)
+	})
+}
+
+func (kvObjectStore) DeleteByKey(key string) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		return tx.Delete(NewMetaTableName, key)
+	})
+}
+
+func (kvObjectStore) LatestResourceVersion() (uint64, error) {
+	var objs []MetaV2
+	if err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, NewMetaTableName, &objs)
+	}); err != nil {
+		return 0, err
+	}
+	var rv uint64
+	for _, obj := range objs {
+		if obj.ResourceVersion > rv {
+			rv = obj.ResourceVersion
+		}
+	}
+	return rv, nil
+}
+
+func (kvObjectStore) ListRecordStats(resType string) ([]dbm.RecordStat, error) {
+	var objs []MetaV2
+	if err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, NewMetaTableName, &objs, dbm.Cond{Expr: "type", Value: resType})
+	}); err != nil {
+		return nil, err
+	}
+	stats := make([]dbm.RecordStat, 0, len(objs))
+	for _, obj := range objs {
+		stats = append(stats, dbm.RecordStat{Key: obj.Key, UpdateTime: obj.UpdateTime, Size: int64(len(obj.Value))})
+	}
+	return stats, nil
+}
+
+func (kvObjectStore) InsertPendingOperation(op *MetaPendingOperation) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		return dbm.KVInsert(tx, PendingOperationTableName, op)
+	})
+}
+
+func (kvObjectStore) ListPendingOperations(conds ...dbm.Cond) ([]MetaPendingOperation, error) {
+	var ops []MetaPendingOperation
+	// the keys are padded ids, so the operations are listed in the order of id
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return dbm.KVList(tx, PendingOperationTableName, &ops, conds...)
+	})
+	return ops, err
+}
+
+func (kvObjectStore) UpdatePendingOperations(cols map[string]interface{}, conds ...dbm.Cond) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		_, err := dbm.KVUpdate(tx, PendingOperationTableName, new(MetaPendingOperation), cols, conds...)
+		return err
+	})
+}
+
+func (kvObjectStore) DeletePendingOperation(id int64) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		return tx.Delete(PendingOperationTableName, dbm.IDKey(id))
+	})
+}
@@ -1,21 +1,31 @@
 package metamanager
 
 import (
-	"github.com/astaxie/beego/orm"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 
 	"github.com/kubeedge/beehive/pkg/core"
 	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
+	connect "github.com/kubeedge/kubeedge/edge/pkg/common/cloudconnection"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
 	"github.com/kubeedge/kubeedge/edge/pkg/common/modules"
 	metamanagerconfig "github.com/kubeedge/kubeedge/edge/pkg/metamanager/config"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/encryption"
 	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver"
 	metaserverconfig "github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/config"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator"
 	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
 )
 
+// offlineWriteReplayPeriod is the period to retry replaying the writes accepted by metaserver while offline
+const offlineWriteReplayPeriod = time.Minute
+
 type metaManager struct {
 	enable bool
 }
@@ -31,6 +41,7 @@ func newMetaManager(enable bool) *metaManager {
 // Register register metamanager
 func Register(metaManager *v1alpha2.MetaManager) {
 	metamanagerconfig.InitConfigure(metaManager)
+	initEncryption(metaManager.Encryption)
 	meta := newMetaManager(metaManager.Enable)
 	initDBTable(meta)
 	core.Register(meta)
@@ -43,8 +54,53 @@ func initDBTable(module core.Module) {
 		klog.Infof("Module %s is disabled, DB meta for it will not be registered", module.Name())
 		return
 	}
-	orm.RegisterModel(new(dao.Meta))
-	orm.RegisterModel(new(v2.MetaV2))
+	// the legacy meta table is kept to migrate the metas saved by the old versions
+	dbm.RegisterModel(dao.MetaTableName, new(dao.Meta))
+	dbm.RegisterModel(v2.NewMetaTableName, new(v2.MetaV2))
+	dbm.RegisterModel(v2.PendingOperationTableName, new(v2.MetaPendingOperation))
+	dbm.RegisterQuotaFunc(storage.EnforceQuota)
+}
+
+// initEncryption sets the provider to encrypt the sensitive metas once the database is opened,
+// which is before any module accesses the database
+func initEncryption(c *v1alpha2.MetaEncryption) {
+	if c == nil || !c.Enable {
+		return
+	}
+	if c.Resources != nil {
+		dao.SetSensitiveResources(c.Resources)
+		v2.SetSensitiveResources(c.Resources)
+	}
+	dbm.RegisterOpenFunc(func() error {
+		// a new key is not generated if any value is encrypted, which is unreadable without the lost key
+		encrypted, err := v2.HasEncryptedObjects()
+		if err != nil {
+			return fmt.Errorf("failed to check encrypted metas: %v", err)
+		}
+		provider, err := encryption.NewAESGCMProvider(&encryption.FileKeySource{Path: c.KeyFile, Generate: !encrypted})
+		if err != nil {
+			return fmt.Errorf("failed to init meta encryption: %v", err)
+		}
+		encryption.SetProvider(provider)
+		return nil
+	})
+}
+
+// migrateLegacyMetas moves the metas saved in the legacy meta table by the old versions to meta_v2
+func migrateLegacyMetas() {
+	if err := dao.MigrateLegacyMetas(); err != nil {
+		klog.Errorf("failed to migrate legacy metas: %v", err)
+	}
+}
+
+// encryptSensitiveMetas migrates the sensitive metas saved in plain before encryption is enabled
+func encryptSensitiveMetas() {
+	if err := dao.EncryptSensitiveMetas(); err != nil {
+		klog.Errorf("failed to encrypt sensitive metas: %v", err)
+	}
+	if err := v2.EncryptSensitiveObjects(); err != nil {
+		klog.Errorf("failed to encrypt sensitive objects: %v", err)
+	}
 }
 
 func (*metaManager) Name() string {
@@ -60,9 +116,19 @@ func (m *metaManager) Enable() bool {
 }
 
 func (m *metaManager) Start() {
+	migrateLegacyMetas()
+	encryptSensitiveMetas()
 	if metaserverconfig.Config.Enable {
 		imitator.StorageInit()
 		go metaserver.NewMetaServer().Start(beehiveContext.Done())
+		if metaserverconfig.Config.EnableOfflineWrite {
+			// retry the writes failed to replay on reconnect
+			go wait.Until(func() {
+				if connect.IsConnected() {
+					storage.ReplayPendingOperations()
+				}
+			}, offlineWriteReplayPeriod, beehiveContext.Done())
+		}
 	}
 
 	m.runMetaManager()
@@ -63,12 +63,6 @@ func NewApplicationAgent() *Agent {
 }
 
 func (a *Agent) Generate(ctx context.Context, verb metaserver.ApplicationVerb, option interface{}, obj runtime.Object) (*metaserver.Application, error) {
-	// If the connection is lost between EdgeCore and CloudCore, we do not generate
-	// the application since we can not send the application to the CloudCore
-	if !connect.IsConnected() {
-		return nil, connect.ErrConnectionLost
-	}
-
 	key, err := metaserver.KeyFuncReq(ctx, "")
 	if err != nil {
 		return nil, err
@@ -76,7 +70,19 @@ func (a *Agent) Generate(ctx context.Context, verb metaserver.ApplicationVerb, o
 
 	info, _ := apirequest.RequestInfoFrom(ctx)
 
-	app, err := metaserver.NewApplication(ctx, key, verb, metaserverconfig.Config.NodeName, info.Subresource, option, obj)
+	return a.GenerateWithKey(ctx, key, verb, info.Subresource, option, obj)
+}
+
+// GenerateWithKey generates the application of the object key, it is used when
+// the request info is not in the context, e.g. replaying the offline writes
+func (a *Agent) GenerateWithKey(ctx context.Context, key string, verb metaserver.ApplicationVerb, subresource string, option interface{}, obj runtime.Object) (*metaserver.Application, error) {
+	// If the connection is lost between EdgeCore and CloudCore, we do not generate
+	// the application since we can not send the application to the CloudCore
+	if !connect.IsConnected() {
+		return nil, connect.ErrConnectionLost
+	}
+
+	app, err := metaserver.NewApplication(ctx, key, verb, metaserverconfig.Config.NodeName, subresource, option, obj)
 	if err != nil {
 		return nil, err
 	}
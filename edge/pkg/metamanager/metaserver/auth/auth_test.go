@@ -23,7 +23,7 @@ import (
 	"github.com/kubeedge/kubeedge/edge/mocks/beego"
 	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/client"
-	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
 )
 
 const otherPublicKey = `-----BEGIN PUBLIC KEY-----
@@ -351,9 +351,9 @@ func TestTokenGenerateAndValidate(t *testing.T) {
 			ormerMock = beego.NewMockOrmer(mockCtrl)
 			querySeterMock = beego.NewMockQuerySeter(mockCtrl)
 			dbm.DBAccess = ormerMock
-			var fakeTr = new([]dao.Meta)
+			var fakeTr = new([]v2.MetaV2)
 			for _, v := range tc.InitObjs {
-				var tmp = new(dao.Meta)
+				var tmp = new(v2.MetaV2)
 				content, _ := json.Marshal(v)
 				tmp.Type = model.ResourceTypeServiceAccountToken
 				tmp.Value = string(content)
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+)
+
+// the reasons of the decisions in metrics
+const (
+	reasonPolicy = "policy"
+	reasonStale  = "stale"
+	reasonError  = "error"
+)
+
+// edgeAuthorizer authorizes the requests by the RBAC policies cached at local, so that the
+// decisions are made in the same way whether the cloud is reachable or not
+type edgeAuthorizer struct {
+	rbac   authorizer.Authorizer
+	config v1alpha2.MetaServerAuthorization
+	// staleness returns how long the cached policies have not been synced from the cloud
+	staleness func() time.Duration
+}
+
+// NewAuthorizer returns the authorizer evaluating the cached policies by rbac,
+// the requests are denied if the policies can not be evaluated when c is nil
+func NewAuthorizer(rbac authorizer.Authorizer, c *v1alpha2.MetaServerAuthorization) authorizer.Authorizer {
+	registerMetrics()
+	config := v1alpha2.MetaServerAuthorization{FailClosed: true}
+	if c != nil {
+		config = *c
+	}
+	return &edgeAuthorizer{
+		rbac:      rbac,
+		config:    config,
+		staleness: policyStaleness,
+	}
+}
+
+func (a *edgeAuthorizer) Authorize(ctx context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+	start := time.Now()
+	decision, reason, category, err := a.authorize(ctx, attrs)
+	authorizationDuration.Observe(time.Since(start).Seconds())
+	authorizationDecisions.WithLabelValues(decisionString(decision), category).Inc()
+	if a.config.EnableAudit {
+		audit(attrs, decision, reason, err)
+	}
+	return decision, reason, err
+}
+
+func (a *edgeAuthorizer) authorize(ctx context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, string, error) {
+	if a.config.FailClosed && a.config.MaxStaleness > 0 {
+		if d := a.staleness(); d > time.Duration(a.config.MaxStaleness)*time.Second {
+			return authorizer.DecisionDeny, fmt.Sprintf("cached policies are stale, they have not been synced from the cloud for %v", d.Round(time.Second)), reasonStale, nil
+		}
+	}
+
+	decision, reason, err := a.rbac.Authorize(ctx, attrs)
+	if decision == authorizer.DecisionAllow {
+		return decision, reason, reasonPolicy, nil
+	}
+	if err != nil {
+		if a.config.FailClosed {
+			return authorizer.DecisionDeny, fmt.Sprintf("failed to evaluate cached policies: %v", err), reasonError, nil
+		}
+		return decision, reason, reasonError, err
+	}
+	// the requests not allowed are denied by the authorization filter, the reason is given here
+	if reason == "" {
+		reason = "no cached policy allows the request"
+	}
+	return authorizer.DecisionDeny, reason, reasonPolicy, nil
+}
+
+func decisionString(decision authorizer.Decision) string {
+	switch decision {
+	case authorizer.DecisionAllow:
+		return "allow"
+	case authorizer.DecisionDeny:
+		return "deny"
+	default:
+		return "no-opinion"
+	}
+}
+
+// audit logs the decision of the request
+func audit(attrs authorizer.Attributes, decision authorizer.Decision, reason string, err error) {
+	var user string
+	var groups []string
+	if u := attrs.GetUser(); u != nil {
+		user, groups = u.GetName(), u.GetGroups()
+	}
+	if attrs.IsResourceRequest() {
+		klog.InfoS("[metaserver/audit] authorization decision", "user", user, "groups", groups, "verb", attrs.GetVerb(),
+			"apiGroup", attrs.GetAPIGroup(), "resource", attrs.GetResource(), "subresource", attrs.GetSubresource(),
+			"namespace", attrs.GetNamespace(), "name", attrs.GetName(),
+			"decision", decisionString(decision), "reason", reason, "err", err)
+		return
+	}
+	klog.InfoS("[metaserver/audit] authorization decision", "user", user, "groups", groups, "verb", attrs.GetVerb(),
+		"path", attrs.GetPath(), "decision", decisionString(decision), "reason", reason, "err", err)
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+)
+
+func TestEdgeAuthorizer(t *testing.T) {
+	allow := authorizer.AuthorizerFunc(func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+		return authorizer.DecisionAllow, "", nil
+	})
+	noOpinion := authorizer.AuthorizerFunc(func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+		return authorizer.DecisionNoOpinion, "", nil
+	})
+	failed := authorizer.AuthorizerFunc(func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+		return authorizer.DecisionNoOpinion, "", errors.New("database is locked")
+	})
+
+	cases := []struct {
+		name      string
+		rbac      authorizer.Authorizer
+		config    *v1alpha2.MetaServerAuthorization
+		stale     time.Duration
+		expected  authorizer.Decision
+		expectErr bool
+	}{
+		{
+			name:     "allowed by policy",
+			rbac:     allow,
+			config:   &v1alpha2.MetaServerAuthorization{FailClosed: true},
+			expected: authorizer.DecisionAllow,
+		},
+		{
+			name:     "no policy allows with fail closed",
+			rbac:     noOpinion,
+			config:   nil,
+			expected: authorizer.DecisionDeny,
+		},
+		{
+			name:     "no policy allows without fail closed",
+			rbac:     noOpinion,
+			config:   &v1alpha2.MetaServerAuthorization{},
+			expected: authorizer.DecisionDeny,
+		},
+		{
+			name:     "failed to read policies with fail closed",
+			rbac:     failed,
+			config:   &v1alpha2.MetaServerAuthorization{FailClosed: true},
+			expected: authorizer.DecisionDeny,
+		},
+		{
+			name:      "failed to read policies without fail closed",
+			rbac:      failed,
+			config:    &v1alpha2.MetaServerAuthorization{},
+			expected:  authorizer.DecisionNoOpinion,
+			expectErr: true,
+		},
+		{
+			name:     "stale policies with fail closed",
+			rbac:     allow,
+			config:   &v1alpha2.MetaServerAuthorization{FailClosed: true, MaxStaleness: 60, EnableAudit: true},
+			stale:    2 * time.Minute,
+			expected: authorizer.DecisionDeny,
+		},
+		{
+			name:     "policies not stale yet",
+			rbac:     allow,
+			config:   &v1alpha2.MetaServerAuthorization{FailClosed: true, MaxStaleness: 300},
+			stale:    2 * time.Minute,
+			expected: authorizer.DecisionAllow,
+		},
+		{
+			name:     "stale policies without fail closed",
+			rbac:     allow,
+			config:   &v1alpha2.MetaServerAuthorization{MaxStaleness: 60},
+			stale:    2 * time.Minute,
+			expected: authorizer.DecisionAllow,
+		},
+	}
+
+	attrs := authorizer.AttributesRecord{
+		User:            &user.DefaultInfo{Name: "system:serviceaccount:default:app"},
+		Verb:            "list",
+		Resource:        "pods",
+		Namespace:       "default",
+		ResourceRequest: true,
+	}
+	for _, c := range cases {
+		a := NewAuthorizer(c.rbac, c.config).(*edgeAuthorizer)
+		a.staleness = func() time.Duration { return c.stale }
+		decision, _, err := a.Authorize(context.TODO(), attrs)
+		if decision != c.expected {
+			t.Errorf("%s: expected decision %v, but got %v", c.name, c.expected, decision)
+		}
+		if (err != nil) != c.expectErr {
+			t.Errorf("%s: expected error %v, but got %v", c.name, c.expectErr, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"sync"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const metaServerSubsystem = "metaserver"
+
+var (
+	authorizationDecisions = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      metaServerSubsystem,
+			Name:           "authorization_decisions_total",
+			Help:           "Number of authorization decisions of metaserver by decision and reason",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"decision", "reason"},
+	)
+
+	authorizationDuration = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      metaServerSubsystem,
+			Name:           "authorization_duration_seconds",
+			Help:           "Latency of authorization decisions of metaserver in seconds",
+			Buckets:        metrics.ExponentialBuckets(0.0001, 4, 8),
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+)
+
+var registerOnce sync.Once
+
+// registerMetrics registers the metrics to the registry served by edged
+func registerMetrics() {
+	registerOnce.Do(func() {
+		legacyregistry.MustRegister(authorizationDecisions, authorizationDuration)
+	})
+}
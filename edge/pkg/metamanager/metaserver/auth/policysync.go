@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	connect "github.com/kubeedge/kubeedge/edge/pkg/common/cloudconnection"
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+)
+
+// policies tracks when the cached policies are synced from the cloud
+var policies = &policySync{}
+
+type policySync struct {
+	lock   sync.Mutex
+	loaded bool
+	// syncedAt is the last time a policy from the cloud is saved, it is loaded from
+	// the update time of the cached policies after the process starts
+	syncedAt time.Time
+	// failed indicates the latest policy from the cloud failed to be saved
+	failed bool
+}
+
+// PolicySynced records the result of saving the policy sent by the cloud
+func PolicySynced(err error) {
+	policies.lock.Lock()
+	defer policies.lock.Unlock()
+	if err != nil {
+		policies.failed = true
+		return
+	}
+	policies.loaded, policies.syncedAt, policies.failed = true, time.Now(), false
+}
+
+// policyStaleness returns how long the cached policies have not been synced from the cloud.
+// The cloud sends every change of the policies while it is connected, so the policies are
+// synced until the connection is lost, unless one of the policies failed to be saved.
+func policyStaleness() time.Duration {
+	policies.lock.Lock()
+	defer policies.lock.Unlock()
+	if !policies.loaded {
+		updated, err := v2.LatestUpdateTime(model.ResourceTypeSaAccess)
+		if err != nil {
+			klog.Errorf("failed to get the update time of the cached policies, %v", err)
+			return math.MaxInt64
+		}
+		if updated > 0 {
+			policies.syncedAt = time.Unix(updated, 0)
+		}
+		policies.loaded = true
+	}
+	synced := policies.syncedAt
+	if !policies.failed {
+		if connected := connect.LastConnected(); connected.After(synced) {
+			synced = connected
+		}
+	}
+	if synced.IsZero() {
+		return math.MaxInt64
+	}
+	return time.Since(synced)
+}
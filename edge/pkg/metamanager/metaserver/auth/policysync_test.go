@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	connect "github.com/kubeedge/kubeedge/edge/pkg/common/cloudconnection"
+)
+
+func TestPolicyStaleness(t *testing.T) {
+	defer connect.SetConnected(false)
+
+	connect.SetConnected(true)
+	PolicySynced(nil)
+	policies.syncedAt = time.Now().Add(-time.Hour)
+	if d := policyStaleness(); d > time.Minute {
+		t.Errorf("expected policies synced while the cloud is connected, but got staleness %v", d)
+	}
+
+	PolicySynced(errors.New("database is locked"))
+	if d := policyStaleness(); d < time.Hour {
+		t.Errorf("expected policies stale since the last successful sync, but got staleness %v", d)
+	}
+
+	PolicySynced(nil)
+	connect.SetConnected(false)
+	if d := policyStaleness(); d > time.Minute {
+		t.Errorf("expected policies synced until the connection is lost, but got staleness %v", d)
+	}
+}
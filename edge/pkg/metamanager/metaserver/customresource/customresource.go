@@ -0,0 +1,133 @@
+package customresource
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+	rbacv1helpers "k8s.io/kubernetes/pkg/apis/rbac/v1"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
+	metaserverconfig "github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/config"
+	policyv1alpha1 "github.com/kubeedge/kubeedge/pkg/apis/policy/v1alpha1"
+)
+
+// readVerbs are the verbs to read the resource, a custom resource is selected by the
+// ServiceAccountAccess rules if it is allowed to be read by any of them
+var readVerbs = []string{"get", "list", "watch"}
+
+// saAccessRules caches the rules of all the ServiceAccountAccess, it is loaded from the local
+// store on first use and reset whenever a ServiceAccountAccess is saved or deleted
+var saAccessRules = &ruleCache{}
+
+type ruleCache struct {
+	lock   sync.RWMutex
+	loaded bool
+	rules  []rbacv1.PolicyRule
+}
+
+// IsCustomResource checks whether the resource is not built in kubernetes
+func IsCustomResource(gvr schema.GroupVersionResource) bool {
+	return !scheme.Scheme.IsGroupRegistered(gvr.Group)
+}
+
+// OfflineWritable checks whether the writes of the custom resource are accepted while the cloud is
+// unreachable, it is selected by MetaServer.OfflineWriteCustomResources or readable by the
+// ServiceAccountAccess rules. The custom resources are cached and served offline regardless
+func OfflineWritable(gvr schema.GroupVersionResource) bool {
+	if matchCustomResources(metaserverconfig.Config.OfflineWriteCustomResources, gvr) {
+		return true
+	}
+	selected, err := readableByServiceAccountAccess(gvr)
+	if err != nil {
+		klog.Errorf("failed to check the serviceaccountaccess rules of %v: %v", gvr, err)
+		return false
+	}
+	return selected
+}
+
+// matchCustomResources checks whether the resource matches any of {resource}.{group}, *.{group} and *
+func matchCustomResources(customResources []string, gvr schema.GroupVersionResource) bool {
+	for _, cr := range customResources {
+		if cr == "*" {
+			return true
+		}
+		tokens := strings.SplitN(cr, ".", 2)
+		if len(tokens) != 2 || tokens[1] != gvr.Group {
+			continue
+		}
+		if tokens[0] == "*" || tokens[0] == gvr.Resource {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidateServiceAccountAccess drops the cached ServiceAccountAccess rules, they are
+// reloaded from the local store on next check
+func InvalidateServiceAccountAccess() {
+	saAccessRules.lock.Lock()
+	defer saAccessRules.lock.Unlock()
+	saAccessRules.loaded = false
+	saAccessRules.rules = nil
+}
+
+func readableByServiceAccountAccess(gvr schema.GroupVersionResource) (bool, error) {
+	rules, err := saAccessRules.get()
+	if err != nil {
+		return false, err
+	}
+	return rulesAllowRead(rules, gvr), nil
+}
+
+func (c *ruleCache) get() ([]rbacv1.PolicyRule, error) {
+	c.lock.RLock()
+	if c.loaded {
+		defer c.lock.RUnlock()
+		return c.rules, nil
+	}
+	c.lock.RUnlock()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.loaded {
+		return c.rules, nil
+	}
+	rst, err := dao.QueryMeta("type", model.ResourceTypeSaAccess)
+	if err != nil {
+		return nil, err
+	}
+	var rules []rbacv1.PolicyRule
+	for _, v := range *rst {
+		var saAccess policyv1alpha1.ServiceAccountAccess
+		if err := json.Unmarshal([]byte(v), &saAccess); err != nil {
+			klog.Errorf("failed to unmarshal saAccess %v", err)
+			continue
+		}
+		for _, rb := range saAccess.Spec.AccessRoleBinding {
+			rules = append(rules, rb.Rules...)
+		}
+	}
+	c.rules, c.loaded = rules, true
+	return rules, nil
+}
+
+func rulesAllowRead(rules []rbacv1.PolicyRule, gvr schema.GroupVersionResource) bool {
+	for i := range rules {
+		rule := &rules[i]
+		if !rbacv1helpers.APIGroupMatches(rule, gvr.Group) || !rbacv1helpers.ResourceMatches(rule, gvr.Resource, "") {
+			continue
+		}
+		for _, verb := range readVerbs {
+			if rbacv1helpers.VerbMatches(rule, verb) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package customresource
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
+	policyv1alpha1 "github.com/kubeedge/kubeedge/pkg/apis/policy/v1alpha1"
+)
+
+var widgets = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+func TestIsCustomResource(t *testing.T) {
+	cases := map[schema.GroupVersionResource]bool{
+		{Version: "v1", Resource: "pods"}:                                        false,
+		{Group: "apps", Version: "v1", Resource: "deployments"}:                  false,
+		{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}:        false,
+		{Group: "devices.kubeedge.io", Version: "v1alpha2", Resource: "devices"}: true,
+		widgets: true,
+	}
+	for gvr, expected := range cases {
+		if got := IsCustomResource(gvr); got != expected {
+			t.Errorf("IsCustomResource(%v) = %v, expected %v", gvr, got, expected)
+		}
+	}
+}
+
+func TestMatchCustomResources(t *testing.T) {
+	cases := []struct {
+		name            string
+		customResources []string
+		expected        bool
+	}{
+		{name: "none", customResources: nil, expected: false},
+		{name: "all", customResources: []string{"*"}, expected: true},
+		{name: "all of the group", customResources: []string{"*.example.com"}, expected: true},
+		{name: "the resource", customResources: []string{"gadgets.example.com", "widgets.example.com"}, expected: true},
+		{name: "other resource", customResources: []string{"gadgets.example.com"}, expected: false},
+		{name: "other group", customResources: []string{"widgets.example.org", "*.sub.example.com"}, expected: false},
+		{name: "invalid", customResources: []string{"widgets"}, expected: false},
+	}
+	for _, c := range cases {
+		if got := matchCustomResources(c.customResources, widgets); got != c.expected {
+			t.Errorf("%s: expected %v, but got %v", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestRulesAllowRead(t *testing.T) {
+	cases := []struct {
+		name     string
+		rules    []rbacv1.PolicyRule
+		expected bool
+	}{
+		{
+			name:     "no rules",
+			expected: false,
+		},
+		{
+			name:     "list the resource",
+			rules:    []rbacv1.PolicyRule{{APIGroups: []string{"example.com"}, Resources: []string{"widgets"}, Verbs: []string{"list"}}},
+			expected: true,
+		},
+		{
+			name:     "all",
+			rules:    []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+			expected: true,
+		},
+		{
+			name:     "write only",
+			rules:    []rbacv1.PolicyRule{{APIGroups: []string{"example.com"}, Resources: []string{"widgets"}, Verbs: []string{"create", "update"}}},
+			expected: false,
+		},
+		{
+			name:     "subresource only",
+			rules:    []rbacv1.PolicyRule{{APIGroups: []string{"example.com"}, Resources: []string{"widgets/status"}, Verbs: []string{"get"}}},
+			expected: false,
+		},
+		{
+			name: "other resources",
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"widgets"}, Verbs: []string{"get"}},
+				{APIGroups: []string{"example.com"}, Resources: []string{"gadgets"}, Verbs: []string{"get"}},
+			},
+			expected: false,
+		},
+	}
+	for _, c := range cases {
+		if got := rulesAllowRead(c.rules, widgets); got != c.expected {
+			t.Errorf("%s: expected %v, but got %v", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestReadableByServiceAccountAccessCached(t *testing.T) {
+	store, err := dbm.OpenBoltStore(filepath.Join(t.TempDir(), "edgecore.bolt"))
+	if err != nil {
+		t.Fatalf("failed to open bolt store: %v", err)
+	}
+	dbm.KVAccess = store
+	defer func() {
+		dbm.KVAccess = nil
+		store.Close()
+		InvalidateServiceAccountAccess()
+	}()
+	InvalidateServiceAccountAccess()
+
+	if readable, err := readableByServiceAccountAccess(widgets); err != nil || readable {
+		t.Fatalf("expected widgets not readable without serviceaccountaccess, got %v, %v", readable, err)
+	}
+
+	saAccess := policyv1alpha1.ServiceAccountAccess{}
+	saAccess.Name = "reader"
+	saAccess.Namespace = "default"
+	saAccess.Spec.AccessRoleBinding = []policyv1alpha1.AccessRoleBinding{{
+		Rules: []rbacv1.PolicyRule{{APIGroups: []string{"example.com"}, Resources: []string{"widgets"}, Verbs: []string{"get"}}},
+	}}
+	content, err := json.Marshal(saAccess)
+	if err != nil {
+		t.Fatalf("failed to marshal serviceaccountaccess: %v", err)
+	}
+	meta := &dao.Meta{Key: "default/serviceaccountaccess/reader", Type: model.ResourceTypeSaAccess, Value: string(content)}
+	if err := dao.InsertOrUpdate(meta); err != nil {
+		t.Fatalf("failed to save serviceaccountaccess: %v", err)
+	}
+
+	if readable, _ := readableByServiceAccountAccess(widgets); readable {
+		t.Errorf("expected the cached rules used until they are invalidated")
+	}
+	InvalidateServiceAccountAccess()
+	if readable, err := readableByServiceAccountAccess(widgets); err != nil || !readable {
+		t.Errorf("expected widgets readable after the rules are reloaded, got %v, %v", readable, err)
+	}
+}
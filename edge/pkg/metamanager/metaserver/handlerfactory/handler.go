@@ -13,6 +13,7 @@ import (
 	metainternalversionscheme "k8s.io/apimachinery/pkg/apis/meta/internalversion/scheme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -93,6 +94,22 @@ func (f *Factory) Delete() http.Handler {
 	return h
 }
 
+// Discovery serves the discovery documents, like /api and /apis/{group}/{version}
+func (f *Factory) Discovery() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		doc, err := f.storage.Discovery(req.Context(), req.URL.Path)
+		if err != nil {
+			responsewriters.ErrorNegotiated(err, f.scope.Serializer, schema.GroupVersion{}, w, req)
+			return
+		}
+		w.Header().Set("Content-Type", runtime.ContentTypeJSON)
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(doc); err != nil {
+			klog.Errorf("failed to write discovery (%v): %v", req.URL.Path, err)
+		}
+	})
+}
+
 func (f *Factory) getHandler(key string) (http.Handler, bool) {
 	f.lock.RLock()
 	defer f.lock.RUnlock()
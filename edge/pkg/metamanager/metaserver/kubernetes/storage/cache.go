@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator/watchhook"
+	"github.com/kubeedge/kubeedge/pkg/metaserver"
+)
+
+// The objects got from the cloud are written through to local storage, so that they are
+// available when the cloud is unreachable. The local objects with writes not replayed to
+// the cloud yet are newer than the ones in the cloud, they are never overwritten.
+
+// cloudError returns the error of the request which is not served at local
+func cloudError(err error) error {
+	if _, ok := err.(errors.APIStatus); ok {
+		return err
+	}
+	return errors.NewServiceUnavailable(err.Error())
+}
+
+// isCompleteList checks whether the list contains all objects of the list key
+func isCompleteList(options *metainternalversion.ListOptions, list *unstructured.UnstructuredList) bool {
+	if options == nil {
+		return list.GetContinue() == ""
+	}
+	return options.Limit == 0 && options.Continue == "" && list.GetContinue() == "" &&
+		(options.LabelSelector == nil || options.LabelSelector.Empty()) &&
+		(options.FieldSelector == nil || options.FieldSelector.Empty())
+}
+
+// cachedObjects returns the local objects of the key with their resource versions
+func cachedObjects(key string) (map[string]*v2.MetaV2, error) {
+	resp, err := imitator.DefaultV2Client.List(context.TODO(), key)
+	if err != nil {
+		return nil, err
+	}
+	cached := make(map[string]*v2.MetaV2, len(*resp.Kvs))
+	for i := range *resp.Kvs {
+		kv := &(*resp.Kvs)[i]
+		cached[kv.Key] = kv
+	}
+	return cached, nil
+}
+
+// cacheList writes the objects of the list through to local storage,
+// and removes the local objects missing from the list if the list is complete
+func cacheList(key string, list *unstructured.UnstructuredList, complete bool) {
+	cached, err := cachedObjects(key)
+	if err != nil {
+		klog.Errorf("[metaserver/cache] failed to list (%v) at local: %v", key, err)
+		return
+	}
+
+	for i := range list.Items {
+		obj := &list.Items[i]
+		objKey, err := metaserver.KeyFuncObj(obj)
+		if err != nil {
+			klog.Errorf("[metaserver/cache] failed to get key of %v: %v", obj.GetName(), err)
+			continue
+		}
+		stored := cached[objKey]
+		delete(cached, objKey)
+		if !hasPendingOperations(objKey) {
+			cache(objKey, obj, stored)
+		}
+	}
+
+	if !complete {
+		return
+	}
+	listRV, err := imitator.Versioner.ParseResourceVersion(list.GetResourceVersion())
+	if err != nil {
+		klog.Errorf("[metaserver/cache] invalid resource version of list (%v): %v", key, err)
+		return
+	}
+	for objKey, stored := range cached {
+		// the object newer than the list may be created after the list is served
+		if stored.ResourceVersion <= listRV && !hasPendingOperations(objKey) {
+			uncache(stored)
+		}
+	}
+}
+
+// cacheObject writes the object got from the cloud through to local storage
+func cacheObject(obj *unstructured.Unstructured) {
+	key, err := metaserver.KeyFuncObj(obj)
+	if err != nil {
+		klog.Errorf("[metaserver/cache] failed to get key of %v: %v", obj.GetName(), err)
+		return
+	}
+	if hasPendingOperations(key) {
+		return
+	}
+	cached, err := cachedObjects(key)
+	if err != nil {
+		klog.Errorf("[metaserver/cache] failed to get (%v) at local: %v", key, err)
+		return
+	}
+	cache(key, obj, cached[key])
+}
+
+// uncacheObject removes the local object which is not found in the cloud
+func uncacheObject(key string) {
+	if hasPendingOperations(key) {
+		return
+	}
+	cached, err := cachedObjects(key)
+	if err != nil {
+		klog.Errorf("[metaserver/cache] failed to get (%v) at local: %v", key, err)
+		return
+	}
+	if stored, ok := cached[key]; ok {
+		uncache(stored)
+	}
+}
+
+// hasPendingOperations checks whether the object is written offline and not replayed yet,
+// such object is not overwritten by the one got from the cloud
+func hasPendingOperations(key string) bool {
+	pending, err := v2.HasPendingOperations(key)
+	if err != nil {
+		klog.Errorf("[metaserver/cache] failed to check pending operations of (%v): %v", key, err)
+		// keep the local object which may be written offline
+		return true
+	}
+	return pending
+}
+
+// cache saves the object unless the stored one is the same or newer
+func cache(key string, obj *unstructured.Unstructured, stored *v2.MetaV2) {
+	rv, err := imitator.Versioner.ObjectResourceVersion(obj)
+	if err != nil {
+		klog.Errorf("[metaserver/cache] invalid resource version of (%v): %v", key, err)
+		return
+	}
+	eventType := watch.Added
+	if stored != nil {
+		if stored.ResourceVersion >= rv {
+			return
+		}
+		eventType = watch.Modified
+	}
+	if err := imitator.DefaultV2Client.InsertOrUpdateObj(context.TODO(), obj); err != nil {
+		klog.Errorf("[metaserver/cache] failed to save (%v) to local: %v", key, err)
+		return
+	}
+	watchhook.Trigger(watch.Event{Type: eventType, Object: obj})
+}
+
+// uncache deletes the stored object and notifies the watchers, it returns false if the object is not deleted
+func uncache(stored *v2.MetaV2) bool {
+	obj := new(unstructured.Unstructured)
+	if err := obj.UnmarshalJSON([]byte(stored.Value)); err != nil {
+		klog.Errorf("[metaserver/cache] failed to decode (%v): %v", stored.Key, err)
+		return false
+	}
+	if err := imitator.DefaultV2Client.DeleteObj(context.TODO(), obj); err != nil {
+		klog.Errorf("[metaserver/cache] failed to delete (%v) from local: %v", stored.Key, err)
+		return false
+	}
+	klog.V(4).Infof("[metaserver/cache] delete (%v) from local", stored.Key)
+	watchhook.Trigger(watch.Event{Type: watch.Deleted, Object: obj})
+	return true
+}
+
+// EnforceQuota deletes the records of the legacy resource type beyond the quota like they are
+// deleted in the cloud, so that the watchers see the deletions. The objects written offline are
+// kept until they are replayed. It is registered as the dbm.QuotaFunc of metamanager.
+func EnforceQuota(resType string, maxCount int32, maxSize int64, before time.Time) (int64, error) {
+	objs, err := v2.ExceededObjects(resType, maxCount, maxSize, before)
+	if err != nil {
+		return 0, err
+	}
+	var num int64
+	for i := range objs {
+		stored := &objs[i]
+		if hasPendingOperations(stored.Key) {
+			continue
+		}
+		if stored.GroupVersionResource == "" {
+			// the records of the legacy resource types only are never watched
+			if err := v2.DeleteByKey(stored.Key); err != nil {
+				return num, err
+			}
+		} else if !uncache(stored) {
+			continue
+		}
+		num++
+	}
+	return num, nil
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"testing"
+
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestIsCompleteList(t *testing.T) {
+	pagedList := &unstructured.UnstructuredList{}
+	pagedList.SetContinue("token")
+
+	cases := []struct {
+		name     string
+		options  *metainternalversion.ListOptions
+		list     *unstructured.UnstructuredList
+		expected bool
+	}{
+		{
+			name:     "no options",
+			list:     &unstructured.UnstructuredList{},
+			expected: true,
+		},
+		{
+			name: "empty selectors",
+			options: &metainternalversion.ListOptions{
+				LabelSelector: labels.Everything(),
+				FieldSelector: fields.Everything(),
+			},
+			list:     &unstructured.UnstructuredList{},
+			expected: true,
+		},
+		{
+			name:     "label selector",
+			options:  &metainternalversion.ListOptions{LabelSelector: labels.SelectorFromSet(labels.Set{"app": "nginx"})},
+			list:     &unstructured.UnstructuredList{},
+			expected: false,
+		},
+		{
+			name:     "field selector",
+			options:  &metainternalversion.ListOptions{FieldSelector: fields.OneTermEqualSelector("spec.nodeName", "node")},
+			list:     &unstructured.UnstructuredList{},
+			expected: false,
+		},
+		{
+			name:     "limit",
+			options:  &metainternalversion.ListOptions{Limit: 10},
+			list:     &unstructured.UnstructuredList{},
+			expected: false,
+		},
+		{
+			name:     "continue",
+			options:  &metainternalversion.ListOptions{Continue: "token"},
+			list:     &unstructured.UnstructuredList{},
+			expected: false,
+		},
+		{
+			name:     "paged list",
+			options:  &metainternalversion.ListOptions{},
+			list:     pagedList,
+			expected: false,
+		},
+	}
+
+	for _, c := range cases {
+		if got := isCompleteList(c.options, c.list); got != c.expected {
+			t.Errorf("%s: expected %v, but got %v", c.name, c.expected, got)
+		}
+	}
+}
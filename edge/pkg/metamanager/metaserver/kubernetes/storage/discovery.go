@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+	"github.com/kubeedge/kubeedge/pkg/metaserver"
+)
+
+// Discovery returns the discovery document of the path from the cloud, the document
+// is cached locally and served while the cloud is unreachable
+func (r *REST) Discovery(ctx context.Context, path string) ([]byte, error) {
+	doc, err := func() ([]byte, error) {
+		app, err := r.Agent.GenerateWithKey(ctx, path, metaserver.Discovery, "", nil, nil)
+		if err != nil {
+			klog.Errorf("[metaserver/reststorage] failed to generate application: %v", err)
+			return nil, err
+		}
+		defer app.Close()
+		if err := r.Agent.Apply(app); err != nil {
+			klog.Errorf("[metaserver/reststorage] failed to get discovery (%v) from cloud: %v", path, err)
+			return nil, err
+		}
+		return app.RespBody, nil
+	}()
+
+	if err == nil {
+		// save to local, ignore error
+		if err := v2.SaveDiscovery(path, string(doc)); err != nil {
+			klog.Errorf("[metaserver/reststorage] failed to save discovery (%v) to local: %v", path, err)
+		}
+		return doc, nil
+	}
+	if _, ok := err.(errors.APIStatus); ok {
+		// the api group or version is removed from the cloud
+		if errors.IsNotFound(err) {
+			if err := v2.DeleteDiscovery(path); err != nil {
+				klog.Errorf("[metaserver/reststorage] failed to delete discovery (%v) from local: %v", path, err)
+			}
+		}
+		return nil, err
+	}
+
+	cached, found, dbErr := v2.GetDiscovery(path)
+	if dbErr != nil {
+		klog.Errorf("[metaserver/reststorage] failed to get discovery (%v) at local: %v", path, dbErr)
+	}
+	if !found {
+		return nil, cloudError(err)
+	}
+	klog.Infof("[metaserver/reststorage] successfully process discovery req (%v) at local", path)
+	return []byte(cached), nil
+}
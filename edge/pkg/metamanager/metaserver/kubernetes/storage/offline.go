@@ -0,0 +1,290 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"sync"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage"
+	storeerr "k8s.io/apiserver/pkg/storage/errors"
+	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/apiserver/pkg/util/dryrun"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+
+	connect "github.com/kubeedge/kubeedge/edge/pkg/common/cloudconnection"
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+	metaserverconfig "github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/config"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/customresource"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator"
+	"github.com/kubeedge/kubeedge/pkg/metaserver"
+	"github.com/kubeedge/kubeedge/pkg/metaserver/util"
+)
+
+var (
+	// writeLock keeps the replay from interleaving with the writes through metaserver,
+	// the replay holds the write lock and the writes hold the read lock
+	writeLock sync.RWMutex
+	// offlineLock serializes the writes accepted offline, so that the local objects
+	// are journaled in the order they are written
+	offlineLock sync.Mutex
+)
+
+// acceptOffline checks whether the write failed for the unreachable cloud can be accepted locally,
+// the writes rejected by the cloud and the writes of the custom resources not selected are never accepted
+func acceptOffline(ctx context.Context, err error) bool {
+	if !metaserverconfig.Config.EnableOfflineWrite {
+		return false
+	}
+	if _, ok := err.(errors.APIStatus); ok {
+		return false
+	}
+	return (stderrors.Is(err, connect.ErrConnectionLost) || !connect.IsConnected()) && writableOffline(ctx)
+}
+
+// writableOffline checks whether the writes of the requested resource are journaled while the cloud is
+// unreachable, the custom resources are journaled only if they are selected by OfflineWriteCustomResources
+func writableOffline(ctx context.Context) bool {
+	info, ok := apirequest.RequestInfoFrom(ctx)
+	if !ok {
+		return true
+	}
+	gvr := schema.GroupVersionResource{Group: info.APIGroup, Version: info.APIVersion, Resource: info.Resource}
+	return !customresource.IsCustomResource(gvr) || customresource.OfflineWritable(gvr)
+}
+
+func qualifiedResource(ctx context.Context) (schema.GroupResource, string) {
+	info, _ := apirequest.RequestInfoFrom(ctx)
+	return schema.GroupResource{Group: info.APIGroup, Resource: info.Resource}, info.Name
+}
+
+// journal records the write accepted offline, which is replayed to the cloud on reconnect
+func journal(verb metaserver.ApplicationVerb, key, baseRV string, obj *unstructured.Unstructured) error {
+	value, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	rv, err := imitator.Versioner.ObjectResourceVersion(obj)
+	if err != nil {
+		return err
+	}
+	return v2.InsertPendingOperation(&v2.MetaPendingOperation{
+		Key:                 key,
+		Verb:                string(verb),
+		BaseResourceVersion: baseRV,
+		ResourceVersion:     rv,
+		Value:               string(value),
+	})
+}
+
+func (r *REST) createOffline(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	offlineLock.Lock()
+	defer offlineLock.Unlock()
+
+	info, _ := apirequest.RequestInfoFrom(ctx)
+	resource, _ := qualifiedResource(ctx)
+	unstrObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, errors.NewInternalError(fmt.Errorf("unexpected object type %T", obj))
+	}
+	if unstrObj.GetNamespace() == "" && info.Namespace != "" {
+		unstrObj.SetNamespace(info.Namespace)
+	}
+	if unstrObj.GetName() == "" && unstrObj.GetGenerateName() != "" {
+		unstrObj.SetName(names.SimpleNameGenerator.GenerateName(unstrObj.GetGenerateName()))
+	}
+	if unstrObj.GetName() == "" {
+		return nil, errors.NewBadRequest("name or generateName is required")
+	}
+	if unstrObj.GroupVersionKind().Empty() {
+		unstrObj.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   info.APIGroup,
+			Version: info.APIVersion,
+			Kind:    util.UnsafeResourceToKind(info.Resource),
+		})
+	}
+	unstrObj.SetUID(uuid.NewUUID())
+	unstrObj.SetCreationTimestamp(metav1.Now())
+	if createValidation != nil {
+		if err := createValidation(ctx, unstrObj); err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := metaserver.KeyFuncObj(unstrObj)
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	isDryRun := dryrun.IsDryRun(options.DryRun)
+	out := new(unstructured.Unstructured)
+	if err := r.Store.Storage.Create(ctx, key, unstrObj, out, 0, isDryRun); err != nil {
+		return nil, storeerr.InterpretCreateError(err, resource, unstrObj.GetName())
+	}
+	if isDryRun {
+		return out, nil
+	}
+	if err := journal(metaserver.Create, key, "", out); err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	klog.Infof("[metaserver/reststorage] create (%v) at local while offline", key)
+	return out, nil
+}
+
+// updateOffline updates the local object with the object returned by getUpdated,
+// only the status is updated for the status subresource
+func (r *REST) updateOffline(ctx context.Context, isDryRun bool,
+	getUpdated func(existing *unstructured.Unstructured) (*unstructured.Unstructured, error)) (runtime.Object, error) {
+	offlineLock.Lock()
+	defer offlineLock.Unlock()
+
+	info, _ := apirequest.RequestInfoFrom(ctx)
+	resource, name := qualifiedResource(ctx)
+	key, err := metaserver.KeyFuncReq(ctx, "")
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+
+	var baseRV string
+	out := new(unstructured.Unstructured)
+	tryUpdate := func(input runtime.Object, _ storage.ResponseMeta) (runtime.Object, *uint64, error) {
+		existing, ok := input.(*unstructured.Unstructured)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected object type %T", input)
+		}
+		baseRV = existing.GetResourceVersion()
+		updated, err := getUpdated(existing.DeepCopy())
+		if err != nil {
+			return nil, nil, err
+		}
+		if rv := updated.GetResourceVersion(); rv != "" && rv != baseRV {
+			return nil, nil, errors.NewConflict(resource, name, fmt.Errorf(genericregistry.OptimisticLockErrorMsg))
+		}
+		if info.Subresource == "status" {
+			status, found, err := unstructured.NestedFieldNoCopy(updated.Object, "status")
+			if err != nil {
+				return nil, nil, err
+			}
+			updated = existing.DeepCopy()
+			if found {
+				updated.Object["status"] = status
+			} else {
+				delete(updated.Object, "status")
+			}
+		}
+		return updated, nil, nil
+	}
+	if err := r.Store.Storage.GuaranteedUpdate(ctx, key, out, false, nil, tryUpdate, isDryRun, nil); err != nil {
+		return nil, storeerr.InterpretUpdateError(err, resource, name)
+	}
+	if isDryRun {
+		return out, nil
+	}
+
+	verb := metaserver.Update
+	if info.Subresource == "status" {
+		verb = metaserver.UpdateStatus
+	}
+	if err := journal(verb, key, baseRV, out); err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	klog.Infof("[metaserver/reststorage] update (%v) at local while offline", key)
+	return out, nil
+}
+
+func (r *REST) patchOffline(ctx context.Context, pi metaserver.PatchInfo) (runtime.Object, error) {
+	return r.updateOffline(ctx, dryrun.IsDryRun(pi.Options.DryRun), func(existing *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+		return applyPatch(existing, pi.PatchType, pi.Data)
+	})
+}
+
+// applyPatch applies the patch to the object, server side apply is not supported offline
+func applyPatch(obj *unstructured.Unstructured, patchType types.PatchType, data []byte) (*unstructured.Unstructured, error) {
+	original, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var patched []byte
+	switch patchType {
+	case types.JSONPatchType:
+		var patch jsonpatch.Patch
+		if patch, err = jsonpatch.DecodePatch(data); err == nil {
+			patched, err = patch.Apply(original)
+		}
+	case types.MergePatchType:
+		patched, err = jsonpatch.MergePatch(original, data)
+	case types.StrategicMergePatchType:
+		schemaObj, schemeErr := scheme.Scheme.New(obj.GroupVersionKind())
+		if schemeErr != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("strategic merge patch is not supported for %v while offline", obj.GroupVersionKind()))
+		}
+		patched, err = strategicpatch.StrategicMergePatch(original, data, schemaObj)
+	default:
+		return nil, errors.NewBadRequest(fmt.Sprintf("patch type %s is not supported while offline", patchType))
+	}
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+
+	ret := new(unstructured.Unstructured)
+	if err := json.Unmarshal(patched, ret); err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	return ret, nil
+}
+
+// deleteOffline deletes the local object immediately, the finalizers and graceful deletion
+// are handled by the cloud when the deletion is replayed
+func (r *REST) deleteOffline(ctx context.Context, key string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
+	offlineLock.Lock()
+	defer offlineLock.Unlock()
+
+	resource, name := qualifiedResource(ctx)
+	var preconditions *storage.Preconditions
+	if options != nil && options.Preconditions != nil {
+		preconditions = &storage.Preconditions{
+			UID:             options.Preconditions.UID,
+			ResourceVersion: options.Preconditions.ResourceVersion,
+		}
+	}
+	var baseRV string
+	validate := func(ctx context.Context, obj runtime.Object) error {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		baseRV = accessor.GetResourceVersion()
+		if deleteValidation != nil {
+			return deleteValidation(ctx, obj)
+		}
+		return nil
+	}
+
+	isDryRun := options != nil && dryrun.IsDryRun(options.DryRun)
+	out := new(unstructured.Unstructured)
+	if err := r.Store.Storage.Delete(ctx, key, out, preconditions, validate, isDryRun, nil); err != nil {
+		return nil, false, storeerr.InterpretDeleteError(err, resource, name)
+	}
+	if isDryRun {
+		return out, true, nil
+	}
+	if err := journal(metaserver.Delete, key, baseRV, out); err != nil {
+		return nil, false, errors.NewInternalError(err)
+	}
+	klog.Infof("[metaserver/reststorage] delete (%v) at local while offline", key)
+	return out, true, nil
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestApplyPatch(t *testing.T) {
+	newPod := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":   "pod",
+				"labels": map[string]interface{}{"app": "nginx"},
+			},
+		}}
+	}
+
+	cases := []struct {
+		name      string
+		patchType types.PatchType
+		data      string
+		expected  map[string]string
+		wantErr   bool
+	}{
+		{
+			name:      "json patch",
+			patchType: types.JSONPatchType,
+			data:      `[{"op":"add","path":"/metadata/labels/env","value":"edge"}]`,
+			expected:  map[string]string{"app": "nginx", "env": "edge"},
+		},
+		{
+			name:      "merge patch",
+			patchType: types.MergePatchType,
+			data:      `{"metadata":{"labels":{"app":null,"env":"edge"}}}`,
+			expected:  map[string]string{"env": "edge"},
+		},
+		{
+			name:      "strategic merge patch",
+			patchType: types.StrategicMergePatchType,
+			data:      `{"metadata":{"labels":{"env":"edge"}}}`,
+			expected:  map[string]string{"app": "nginx", "env": "edge"},
+		},
+		{
+			name:      "apply patch is not supported",
+			patchType: types.ApplyPatchType,
+			data:      `{}`,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid json patch",
+			patchType: types.JSONPatchType,
+			data:      `{}`,
+			wantErr:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ret, err := applyPatch(newPod(), c.patchType, []byte(c.data))
+			if (err != nil) != c.wantErr {
+				t.Fatalf("expected error %v, but got %v", c.wantErr, err)
+			}
+			if c.wantErr {
+				return
+			}
+			labels := ret.GetLabels()
+			if len(labels) != len(c.expected) {
+				t.Fatalf("expected labels %v, but got %v", c.expected, labels)
+			}
+			for k, v := range c.expected {
+				if labels[k] != v {
+					t.Errorf("expected labels %v, but got %v", c.expected, labels)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/agent"
+	metaserverconfig "github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/config"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator/watchhook"
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+	"github.com/kubeedge/kubeedge/pkg/metaserver"
+)
+
+// ReplayPendingOperations replays the writes accepted while offline to the cloud in order.
+// The replay stops at the first write failed for the unreachable cloud, and the remaining
+// writes are replayed next time. The writes conflicting with the cloud are resolved by the
+// configured OfflineWriteConflictPolicy, and the writes rejected by the cloud are discarded.
+func ReplayPendingOperations() {
+	if !metaserverconfig.Config.EnableOfflineWrite {
+		return
+	}
+	// the writes through metaserver wait for the replay, so they are applied after the
+	// writes accepted while offline, and no new write is journaled during the replay
+	writeLock.Lock()
+	defer writeLock.Unlock()
+
+	ops, err := v2.ListPendingOperations()
+	if err != nil {
+		klog.Errorf("[metaserver/replay] failed to list pending operations: %v", err)
+		return
+	}
+	if len(ops) == 0 {
+		return
+	}
+	klog.Infof("[metaserver/replay] replaying %d writes accepted while offline", len(ops))
+
+	r := newReplayer(agent.DefaultAgent, metaserverconfig.Config.OfflineWriteConflictPolicy, ops)
+	for i := range ops {
+		if err := r.replay(&ops[i]); err != nil {
+			klog.Warningf("[metaserver/replay] stop replaying, %d writes remain: %v", len(ops)-i, err)
+			return
+		}
+		if err := v2.DeletePendingOperation(&ops[i]); err != nil {
+			klog.Errorf("[metaserver/replay] failed to delete pending operation %d: %v", ops[i].ID, err)
+			return
+		}
+	}
+	klog.Infof("[metaserver/replay] successfully replayed %d writes", len(ops))
+}
+
+type replayer struct {
+	agent  *agent.Agent
+	policy v1alpha2.OfflineWriteConflictPolicy
+	// cloudRV is the latest resource version in the cloud of the replayed objects
+	cloudRV map[string]string
+	// pending is the number of the writes of the object not replayed yet
+	pending map[string]int
+	// discarded is the objects whose remaining writes are discarded for the conflicts
+	discarded sets.String
+}
+
+func newReplayer(agent *agent.Agent, policy v1alpha2.OfflineWriteConflictPolicy, ops []v2.MetaPendingOperation) *replayer {
+	r := &replayer{
+		agent:     agent,
+		policy:    policy,
+		cloudRV:   make(map[string]string),
+		pending:   make(map[string]int),
+		discarded: sets.NewString(),
+	}
+	for _, op := range ops {
+		r.pending[op.Key]++
+	}
+	return r
+}
+
+// replay returns error only if the write should be retried later
+func (r *replayer) replay(op *v2.MetaPendingOperation) error {
+	r.pending[op.Key]--
+	if r.discarded.Has(op.Key) {
+		klog.Infof("[metaserver/replay] discard %s (%s) for the conflict", op.Verb, op.Key)
+		return nil
+	}
+	obj := new(unstructured.Unstructured)
+	if err := obj.UnmarshalJSON([]byte(op.Value)); err != nil {
+		klog.Errorf("[metaserver/replay] discard %s (%s) for the invalid object: %v", op.Verb, op.Key, err)
+		return nil
+	}
+
+	verb := metaserver.ApplicationVerb(op.Verb)
+	switch verb {
+	case metaserver.Create:
+		resetForCreate(obj)
+		ret, err := r.apply(op.Key, verb, obj)
+		if errors.IsAlreadyExists(err) {
+			return r.resolveConflict(op.Key, verb, obj)
+		}
+		return r.done(op.Key, verb, ret, err)
+	case metaserver.Update, metaserver.UpdateStatus:
+		obj.SetResourceVersion(r.baseResourceVersion(op))
+		ret, err := r.apply(op.Key, verb, obj)
+		if errors.IsConflict(err) || errors.IsNotFound(err) {
+			return r.resolveConflict(op.Key, verb, obj)
+		}
+		return r.done(op.Key, verb, ret, err)
+	case metaserver.Delete:
+		var preconditions *metav1.Preconditions
+		// the deletion does not overwrite the changes in the cloud if cloud wins
+		if rv := r.baseResourceVersion(op); rv != "" && r.policy != v1alpha2.OfflineWriteLocalWins {
+			preconditions = &metav1.Preconditions{ResourceVersion: &rv}
+		}
+		_, err := r.applyWithOption(op.Key, verb, metav1.DeleteOptions{Preconditions: preconditions}, nil)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if errors.IsConflict(err) {
+			r.discarded.Insert(op.Key)
+			return r.restore(op.Key)
+		}
+		return r.done(op.Key, verb, nil, err)
+	default:
+		klog.Errorf("[metaserver/replay] discard unsupported verb %s (%s)", op.Verb, op.Key)
+		return nil
+	}
+}
+
+// baseResourceVersion returns the resource version in the cloud the write is based on
+func (r *replayer) baseResourceVersion(op *v2.MetaPendingOperation) string {
+	if rv, ok := r.cloudRV[op.Key]; ok {
+		return rv
+	}
+	return op.BaseResourceVersion
+}
+
+// resolveConflict resolves the write conflicting with the object in the cloud,
+// CloudWins discards the remaining writes of the object and restores the object from the cloud,
+// LocalWins overwrites the object in the cloud with the local one
+func (r *replayer) resolveConflict(key string, verb metaserver.ApplicationVerb, obj *unstructured.Unstructured) error {
+	klog.Warningf("[metaserver/replay] %s (%s) conflicts with the cloud, resolved by %s", verb, key, r.policy)
+	if r.policy != v1alpha2.OfflineWriteLocalWins {
+		r.discarded.Insert(key)
+		return r.restore(key)
+	}
+
+	cloudObj, err := r.get(key)
+	switch {
+	case errors.IsNotFound(err):
+		resetForCreate(obj)
+		verb = metaserver.Create
+	case err != nil:
+		return r.done(key, metaserver.Get, nil, err)
+	default:
+		if verb == metaserver.Create {
+			verb = metaserver.Update
+		}
+		obj.SetUID(cloudObj.GetUID())
+		obj.SetCreationTimestamp(cloudObj.GetCreationTimestamp())
+		obj.SetResourceVersion(cloudObj.GetResourceVersion())
+	}
+	ret, err := r.apply(key, verb, obj)
+	return r.done(key, verb, ret, err)
+}
+
+// done handles the result of the replayed write
+func (r *replayer) done(key string, verb metaserver.ApplicationVerb, ret *unstructured.Unstructured, err error) error {
+	if err == nil {
+		if ret != nil {
+			r.cloudRV[key] = ret.GetResourceVersion()
+			// the local object is newer if there are writes not replayed yet
+			if r.pending[key] == 0 {
+				saveLocal(ret)
+			} else if err := v2.RebasePendingOperations(key, ret.GetResourceVersion()); err != nil {
+				klog.Errorf("[metaserver/replay] failed to rebase pending operations of (%s): %v", key, err)
+			}
+		}
+		return nil
+	}
+	if _, ok := err.(errors.APIStatus); !ok {
+		return err
+	}
+	klog.Errorf("[metaserver/replay] %s (%s) is rejected by the cloud and discarded: %v", verb, key, err)
+	r.discarded.Insert(key)
+	return r.restore(key)
+}
+
+// restore replaces the local object with the one in the cloud
+func (r *replayer) restore(key string) error {
+	cloudObj, err := r.get(key)
+	if errors.IsNotFound(err) {
+		deleteLocal(key)
+		return nil
+	}
+	if err != nil {
+		if _, ok := err.(errors.APIStatus); ok {
+			klog.Errorf("[metaserver/replay] failed to restore (%s) from the cloud: %v", key, err)
+			return nil
+		}
+		return err
+	}
+	saveLocal(cloudObj)
+	return nil
+}
+
+func (r *replayer) get(key string) (*unstructured.Unstructured, error) {
+	return r.applyWithOption(key, metaserver.Get, metav1.GetOptions{}, nil)
+}
+
+func (r *replayer) apply(key string, verb metaserver.ApplicationVerb, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	var option interface{} = metav1.UpdateOptions{}
+	if verb == metaserver.Create {
+		option = metav1.CreateOptions{}
+	}
+	return r.applyWithOption(key, verb, option, obj)
+}
+
+func (r *replayer) applyWithOption(key string, verb metaserver.ApplicationVerb, option interface{}, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	var subresource string
+	if verb == metaserver.UpdateStatus {
+		subresource = "status"
+	}
+	// avoid the typed nil object in the request body
+	var reqObj runtime.Object
+	if obj != nil {
+		reqObj = obj
+	}
+	app, err := r.agent.GenerateWithKey(context.Background(), key, verb, subresource, option, reqObj)
+	if err != nil {
+		return nil, err
+	}
+	defer app.Close()
+	if err := r.agent.Apply(app); err != nil {
+		return nil, err
+	}
+	if verb == metaserver.Delete {
+		return nil, nil
+	}
+	ret := new(unstructured.Unstructured)
+	if err := json.Unmarshal(app.RespBody, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// resetForCreate clears the fields set locally, which are set by the cloud on creation
+func resetForCreate(obj *unstructured.Unstructured) {
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetCreationTimestamp(metav1.Time{})
+}
+
+func saveLocal(obj *unstructured.Unstructured) {
+	if err := imitator.DefaultV2Client.InsertOrUpdateObj(context.TODO(), obj); err != nil {
+		klog.Errorf("[metaserver/replay] failed to save (%s) to local: %v", metaserver.KeyFunc(obj), err)
+		return
+	}
+	watchhook.Trigger(watch.Event{Type: watch.Modified, Object: obj})
+}
+
+func deleteLocal(key string) {
+	resp, err := imitator.DefaultV2Client.List(context.TODO(), key)
+	if err != nil || len(*resp.Kvs) == 0 {
+		return
+	}
+	obj := new(unstructured.Unstructured)
+	if err := obj.UnmarshalJSON([]byte((*resp.Kvs)[0].Value)); err != nil {
+		klog.Errorf("[metaserver/replay] failed to decode (%s): %v", key, err)
+		return
+	}
+	if err := imitator.DefaultV2Client.DeleteObj(context.TODO(), obj); err != nil {
+		klog.Errorf("[metaserver/replay] failed to delete (%s) from local: %v", key, err)
+		return
+	}
+	watchhook.Trigger(watch.Event{Type: watch.Deleted, Object: obj})
+}
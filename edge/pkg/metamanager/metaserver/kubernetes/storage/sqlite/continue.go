@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubeedge/kubeedge/pkg/metaserver"
+)
+
+// continueToken is the continue token of the paged list, which is encoded
+// in the same format as the token of kube-apiserver
+type continueToken struct {
+	APIVersion      string `json:"v"`
+	ResourceVersion int64  `json:"rv"`
+	// StartKey is the key of the last object returned, the next page starts after it
+	StartKey string `json:"start"`
+}
+
+const continueTokenAPIVersion = "meta.k8s.io/v1"
+
+// encodeContinue returns the continue token of the list after the lastKey
+func encodeContinue(lastKey string, resourceVersion int64) (string, error) {
+	out, err := json.Marshal(&continueToken{
+		APIVersion:      continueTokenAPIVersion,
+		ResourceVersion: resourceVersion,
+		StartKey:        lastKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(out), nil
+}
+
+// decodeContinue returns the key of the last object returned by the previous page,
+// the token must be issued by the list of the same resource
+func decodeContinue(continueValue, listKey string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(continueValue)
+	if err != nil {
+		return "", fmt.Errorf("continue key is not valid: %v", err)
+	}
+	var c continueToken
+	if err := json.Unmarshal(data, &c); err != nil {
+		return "", fmt.Errorf("continue key is not valid: %v", err)
+	}
+	if c.APIVersion != continueTokenAPIVersion {
+		return "", fmt.Errorf("continue key is not valid: server does not recognize this encoded key")
+	}
+	startGVR, _, _ := metaserver.ParseKey(c.StartKey)
+	listGVR, _, _ := metaserver.ParseKey(listKey)
+	if c.StartKey == "" || startGVR != listGVR {
+		return "", fmt.Errorf("continue key is not valid: it is not issued by the list of %v", listGVR)
+	}
+	return c.StartKey, nil
+}
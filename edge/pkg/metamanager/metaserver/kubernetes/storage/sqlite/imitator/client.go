@@ -3,18 +3,24 @@ package imitator
 import (
 	"context"
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/apiserver/pkg/storage/etcd3"
 
+	beehiveContext "github.com/kubeedge/beehive/pkg/core/context"
 	"github.com/kubeedge/beehive/pkg/core/model"
-	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator/watchhook"
 )
 
+// bookmarkPeriod is the period to send the bookmark events to the watchers
+const bookmarkPeriod = time.Minute
+
 // DefaultV2Client is the only one client. Because of v2Client
 // maintainers the revision and message cache(todo), so we do not see
 // there are multi-clients.
@@ -30,11 +36,17 @@ type Client interface {
 
 	GetRevision() uint64
 	SetRevision(version interface{})
+	// LocalRevision returns the resource version of the object written locally, it is the
+	// latest revision synced from the cloud and is not increased by the local writes,
+	// so that it never collides with the revisions assigned by the cloud later
+	LocalRevision() uint64
 
 	// This set of functions for upper storage
 	List(ctx context.Context, key string) (Resp, error)
 	Get(ctx context.Context, key string) (Resp, error)
-	Watch(ctx context.Context, key string, ResourceVersion uint64) <-chan watch.Event
+	// Watch watches the events of the key after the resource version, the events in the history
+	// are sent first if the resource version is not 0
+	Watch(ctx context.Context, key string, ResourceVersion uint64) (<-chan watch.Event, error)
 }
 
 type Resp struct {
@@ -54,9 +66,11 @@ func newV2Client() Client {
 
 // StorageInit must be called before using imitator storage (run metaserver or metamanager)
 func StorageInit() {
-	m := new(v2.MetaV2)
 	// get the most recent record as the init resource version
-	_, err := dbm.DBAccess.QueryTable(v2.NewMetaTableName).OrderBy("-" + v2.RV).Limit(1).All(m)
+	rv, err := v2.LatestResourceVersion()
 	utilruntime.Must(err)
-	DefaultV2Client.SetRevision(m.ResourceVersion)
+	DefaultV2Client.SetRevision(rv)
+	// the events before are not in the history
+	watchhook.SetCompactedRevision(rv)
+	go wait.Until(watchhook.SendBookmarks, bookmarkPeriod, beehiveContext.Done())
 }
@@ -17,7 +17,6 @@ import (
 
 	"github.com/kubeedge/beehive/pkg/core/model"
 	"github.com/kubeedge/kubeedge/common/constants"
-	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
 	"github.com/kubeedge/kubeedge/edge/pkg/common/modules"
 	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator/watchhook"
@@ -75,6 +74,9 @@ func (s *imitator) InsertOrUpdateObj(ctx context.Context, obj runtime.Object) er
 		return err
 	}
 	objRv, err := s.versioner.ObjectResourceVersion(obj)
+	if err != nil {
+		return err
+	}
 	m := v2.MetaV2{
 		Key:                  key,
 		GroupVersionResource: gvr.String(),
@@ -85,14 +87,14 @@ func (s *imitator) InsertOrUpdateObj(ctx context.Context, obj runtime.Object) er
 	}
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	_, err = dbm.DBAccess.Raw("INSERT OR REPLACE INTO meta_v2 (key, groupversionresource, namespace,name,resourceversion,value) VALUES (?,?,?,?,?,?)", m.Key, m.GroupVersionResource, m.Namespace, m.Name, m.ResourceVersion, m.Value).Exec()
+	err = v2.InsertOrUpdate(&m)
 	var maxRetryTimes = 3
 	for i := 1; err != nil; i++ {
 		klog.Errorf("failed to access database:%v", err)
 		if i == maxRetryTimes {
 			return fmt.Errorf("failed to access database after %v times try", i)
 		}
-		_, err = dbm.DBAccess.Raw("INSERT OR REPLACE INTO meta_v2 (key, groupversionresource, namespace,name,resourceversion,value) VALUES (?,?,?,?,?,?)", m.Key, m.GroupVersionResource, m.Namespace, m.Name, m.ResourceVersion, m.Value).Exec()
+		err = v2.InsertOrUpdate(&m)
 	}
 	if objRv > s.GetRevision() {
 		s.SetRevision(objRv)
@@ -101,11 +103,8 @@ func (s *imitator) InsertOrUpdateObj(ctx context.Context, obj runtime.Object) er
 	return nil
 }
 func (s *imitator) Delete(ctx context.Context, key string) error {
-	m := v2.MetaV2{
-		Key: key,
-	}
 	s.lock.Lock()
-	_, err := dbm.DBAccess.Delete(&m)
+	err := v2.DeleteByKey(key)
 	if err != nil {
 		klog.Errorf("[imitator] delete error: %v", err)
 	}
@@ -178,13 +177,19 @@ func (s *imitator) SetRevision(version interface{}) {
 	}
 }
 
-func (s *imitator) Watch(ctx context.Context, key string, rev uint64) <-chan watch.Event {
+func (s *imitator) LocalRevision() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.revision
+}
+
+func (s *imitator) Watch(ctx context.Context, key string, rev uint64) (<-chan watch.Event, error) {
 	wch := make(chan watch.Event)
-	receiver := watchhook.NewChanReceiver(wch)
+	receiver := watchhook.NewChanReceiver(wch, ctx.Done())
 	wh, err := watchhook.NewWatchHook(key, rev, receiver)
 	if err != nil {
 		klog.Errorf("add hook for %s failed, %v", key, err)
-		return nil
+		return nil, err
 	}
 
 	go func() {
@@ -192,7 +197,7 @@ func (s *imitator) Watch(ctx context.Context, key string, rev uint64) <-chan wat
 		wh.Stop()
 		close(wch)
 	}()
-	return wch
+	return wch, nil
 }
 
 // Event transform the message to watch.event
@@ -0,0 +1,75 @@
+package watchhook
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// historySize is the max number of the events kept in memory to resume the watches
+const historySize = 1024
+
+// historyEvent is the event triggered with the key and resource version of its object
+type historyEvent struct {
+	gvr       schema.GroupVersionResource
+	namespace string
+	name      string
+	rev       uint64
+	event     watch.Event
+}
+
+var (
+	// history is the latest events triggered, in the order they are triggered
+	history []historyEvent
+	// compactedRev is the max revision of the events not in the history, the watches
+	// from the revisions before it are too old to be resumed
+	compactedRev uint64
+	// latestRev is the max revision of the events triggered
+	latestRev uint64
+)
+
+// SetCompactedRevision sets the revision before which the events are not in the history,
+// it is the revision of the storage when the history starts
+func SetCompactedRevision(rev uint64) {
+	hooksLock.Lock()
+	defer hooksLock.Unlock()
+	if rev > compactedRev {
+		compactedRev = rev
+	}
+	if rev > latestRev {
+		latestRev = rev
+	}
+}
+
+// record adds the event to the history and removes the oldest one if the history is full,
+// it must be called with hooksLock held
+func record(e historyEvent) {
+	if len(history) == historySize {
+		if history[0].rev > compactedRev {
+			compactedRev = history[0].rev
+		}
+		history[0] = historyEvent{}
+		history = history[1:]
+	}
+	history = append(history, e)
+	if e.rev > latestRev {
+		latestRev = e.rev
+	}
+}
+
+// eventsAfter returns the events of the hook after the revision in the history,
+// it must be called with hooksLock held
+func eventsAfter(hook *WatchHook, rev uint64) ([]hookEvent, error) {
+	if rev < compactedRev {
+		return nil, errors.NewResourceExpired(fmt.Sprintf("too old resource version: %d (%d)", rev, compactedRev))
+	}
+	var events []hookEvent
+	for _, e := range history {
+		if hook.matches(e.gvr, e.namespace, e.name, e.rev) {
+			events = append(events, hookEvent{rev: e.rev, event: e.event})
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,235 @@
+package watchhook
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+type sliceReceiver struct {
+	lock   sync.Mutex
+	events []watch.Event
+}
+
+func (r *sliceReceiver) Receive(e watch.Event) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *sliceReceiver) resourceVersions(t *testing.T) []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var rvs []string
+	for _, e := range r.events {
+		accessor, err := meta.Accessor(e.Object)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rvs = append(rvs, string(e.Type)+"/"+accessor.GetResourceVersion())
+	}
+	return rvs
+}
+
+func resetHistory() {
+	hooksLock.Lock()
+	defer hooksLock.Unlock()
+	hooks = make(map[string]*WatchHook)
+	history, compactedRev, latestRev = nil, 0, 0
+}
+
+func newPod(name string, rev int) *unstructured.Unstructured {
+	obj := new(unstructured.Unstructured)
+	obj.SetAPIVersion("v1")
+	obj.SetKind("Pod")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetResourceVersion(strconv.Itoa(rev))
+	return obj
+}
+
+func triggerPod(name string, rev int) {
+	Trigger(watch.Event{Type: watch.Modified, Object: newPod(name, rev)})
+}
+
+func TestResumeFromHistory(t *testing.T) {
+	resetHistory()
+	SetCompactedRevision(10)
+	triggerPod("foo", 11)
+	triggerPod("bar", 12)
+	triggerPod("foo", 13)
+
+	if _, err := NewWatchHook("/core/v1/pods/default", 9, &sliceReceiver{}); !errors.IsResourceExpired(err) {
+		t.Errorf("expected resource expired error, but got %v", err)
+	}
+
+	receiver := &sliceReceiver{}
+	wh, err := NewWatchHook("/core/v1/pods/default/foo", 11, receiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	triggerPod("foo", 14)
+	triggerPod("bar", 15)
+	wh.Stop()
+	triggerPod("foo", 16)
+
+	expected := []string{"MODIFIED/13", "MODIFIED/14"}
+	if got := receiver.resourceVersions(t); len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("expected events %v, but got %v", expected, got)
+	}
+}
+
+func TestHistoryCompaction(t *testing.T) {
+	resetHistory()
+	for i := 1; i <= historySize+2; i++ {
+		triggerPod("foo", i)
+	}
+	if len(history) != historySize {
+		t.Errorf("expected %d events in history, but got %d", historySize, len(history))
+	}
+	if _, err := NewWatchHook("/core/v1/pods", 1, &sliceReceiver{}); !errors.IsResourceExpired(err) {
+		t.Errorf("expected resource expired error, but got %v", err)
+	}
+	receiver := &sliceReceiver{}
+	wh, err := NewWatchHook("/core/v1/pods", 2, receiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wh.Stop()
+	if got := len(receiver.resourceVersions(t)); got != historySize {
+		t.Errorf("expected %d events replayed, but got %d", historySize, got)
+	}
+}
+
+func TestSendBookmarks(t *testing.T) {
+	resetHistory()
+	SetCompactedRevision(10)
+	triggerPod("foo", 11)
+
+	receiver := &sliceReceiver{}
+	wh, err := NewWatchHook("/core/v1/pods", 0, receiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SendBookmarks()
+	wh.Stop()
+
+	expected := "BOOKMARK/11"
+	if got := receiver.resourceVersions(t); len(got) != 1 || got[0] != expected {
+		t.Errorf("expected events [%v], but got %v", expected, got)
+	}
+	if kind := receiver.events[0].Object.GetObjectKind().GroupVersionKind().Kind; kind != "Pod" {
+		t.Errorf("expected kind Pod of bookmark, but got %v", kind)
+	}
+}
+
+func TestTriggerLocal(t *testing.T) {
+	resetHistory()
+	SetCompactedRevision(10)
+
+	receiver := &sliceReceiver{}
+	wh, err := NewWatchHook("/core/v1/pods", 10, receiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the local write is stamped with the latest revision synced from the cloud
+	TriggerLocal(watch.Event{Type: watch.Modified, Object: newPod("foo", 10)})
+	triggerPod("bar", 10)
+	triggerPod("bar", 11)
+	wh.Stop()
+
+	expected := []string{"MODIFIED/10", "MODIFIED/11"}
+	if got := receiver.resourceVersions(t); len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("expected events %v, but got %v", expected, got)
+	}
+}
+
+func TestSendBookmarksWithSentRevision(t *testing.T) {
+	resetHistory()
+	SetCompactedRevision(10)
+
+	receiver := &sliceReceiver{}
+	wh, err := NewWatchHook("/core/v1/pods/default/foo", 10, receiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	triggerPod("foo", 11)
+	triggerPod("bar", 12)
+	SendBookmarks()
+	wh.Stop()
+
+	// the bookmark is not ahead of the events sent to the hook
+	expected := []string{"MODIFIED/11", "BOOKMARK/11"}
+	if got := receiver.resourceVersions(t); len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("expected events %v, but got %v", expected, got)
+	}
+}
+
+func TestTriggerWithBlockedReceiver(t *testing.T) {
+	resetHistory()
+	SetCompactedRevision(10)
+
+	stop := make(chan struct{})
+	blocked, err := NewWatchHook("/core/v1/pods", 0, NewChanReceiver(make(chan watch.Event), stop))
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver := &sliceReceiver{}
+	wh, err := NewWatchHook("/core/v1/pods", 0, receiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	triggerPod("foo", 11)
+	triggerPod("foo", 12)
+	SendBookmarks()
+	wh.Stop()
+	close(stop)
+	blocked.Stop()
+
+	expected := []string{"MODIFIED/11", "MODIFIED/12", "BOOKMARK/12"}
+	if got := receiver.resourceVersions(t); len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] || got[2] != expected[2] {
+		t.Errorf("expected events %v, but got %v", expected, got)
+	}
+}
+
+func TestTriggerFallsBehind(t *testing.T) {
+	resetHistory()
+	SetCompactedRevision(10)
+
+	ch, stop := make(chan watch.Event), make(chan struct{})
+	defer close(stop)
+	wh, err := NewWatchHook("/core/v1/pods", 0, NewChanReceiver(ch, stop))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wh.Stop()
+	for i := 0; i <= maxQueuedEvents+1; i++ {
+		triggerPod("foo", 11+i)
+	}
+
+	// the events dequeued before the queue is full are sent before the error
+	for {
+		e := <-ch
+		if e.Type != watch.Error {
+			continue
+		}
+		if err := errors.FromObject(e.Object); !errors.IsResourceExpired(err) {
+			t.Errorf("expected resource expired error, but got %v", err)
+		}
+		break
+	}
+	triggerPod("foo", maxQueuedEvents+13)
+	select {
+	case e := <-ch:
+		t.Errorf("expected no events after the error, but got %v", e.Type)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -5,14 +5,19 @@ import (
 	"sync"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/apiserver/pkg/storage/etcd3"
 	"k8s.io/klog/v2"
 
 	"github.com/kubeedge/kubeedge/pkg/metaserver"
+	"github.com/kubeedge/kubeedge/pkg/metaserver/util"
 )
 
 var (
+	// hooksLock protects hooks and the history, the events are queued to the hooks
+	// with it held so that the hooks receive the events in the same order
 	hooksLock sync.Mutex
 	// hooks is a map from hook.id to hook
 	hooks = make(map[string]*WatchHook)
@@ -21,6 +26,10 @@ var (
 func AddHook(hook *WatchHook) error {
 	hooksLock.Lock()
 	defer hooksLock.Unlock()
+	return addHook(hook)
+}
+
+func addHook(hook *WatchHook) error {
 	if _, exists := hooks[hook.id]; exists {
 		return fmt.Errorf("unable to add hook %v because it was already registered", hook.id)
 	}
@@ -40,43 +49,66 @@ func DeleteHook(id string) error {
 
 // Trigger trigger the corresponding hook to serve watch based on the event passed in
 func Trigger(e watch.Event) {
+	trigger(e, false)
+}
+
+// TriggerLocal triggers the hooks with the event of the object written locally, whose resource
+// version is the latest revision synced from the cloud. The hooks watching from this revision
+// receive the event as well, because the local write happens after it.
+func TriggerLocal(e watch.Event) {
+	trigger(e, true)
+}
+
+func trigger(e watch.Event, local bool) {
 	key, err := metaserver.KeyFuncObj(e.Object)
 	if err != nil {
 		klog.Errorf("failed to get key, %v", err)
 		return
 	}
 	gvr, ns, name := metaserver.ParseKey(key)
-	//TODO why not lock hooks ?
+	accessor, err := meta.Accessor(e.Object)
+	if err != nil {
+		klog.Errorf("failed to get accessor, %v", err)
+		return
+	}
+	rev, err := etcd3.Versioner.ParseResourceVersion(accessor.GetResourceVersion())
+	if err != nil {
+		klog.Errorf("failed to parse resource version, %v", err)
+		return
+	}
+
+	matchRev := rev
+	if local {
+		matchRev++
+	}
+
+	hooksLock.Lock()
+	defer hooksLock.Unlock()
+	record(historyEvent{gvr: gvr, namespace: ns, name: name, rev: rev, event: e})
 	for _, hook := range hooks {
-		compGVR, compNS, compName, compRev := true, true, true, true
-		if !hook.GetGVR().Empty() {
-			compGVR = hook.GetGVR() == gvr
-		}
-		if hook.GetNamespace() != "" {
-			compNS = hook.GetNamespace() == ns
-		}
-		if hook.GetName() != "" {
-			compName = hook.GetName() == name
-		}
-		if hook.GetResourceVersion() != 0 {
-			accessor, err := meta.Accessor(e.Object)
-			if err != nil {
-				klog.Errorf("failed to get accessor, %v", err)
-				return
-			}
-			rev, err := etcd3.Versioner.ParseResourceVersion(accessor.GetResourceVersion())
-			if err != nil {
-				klog.Errorf("failed to parse resource version, %v", err)
-				return
-			}
-			compRev = hook.GetResourceVersion() < rev
-		}
-		if compGVR && compNS && compName && compRev {
-			err = hook.Do(e)
-			if err != nil {
-				klog.Errorf("failed to operate event, %v", err)
-				return
-			}
+		if hook.matches(gvr, ns, name, matchRev) {
+			hook.enqueue(hookEvent{rev: rev, event: e})
 		}
 	}
 }
+
+// SendBookmarks sends the bookmark events to the hooks, the watchers not allowing bookmarks drop them.
+// Each bookmark is sent with the revision of the latest event sent to the hook before it, so the
+// watch can be resumed from the revision of the bookmark.
+func SendBookmarks() {
+	hooksLock.Lock()
+	defer hooksLock.Unlock()
+	for _, hook := range hooks {
+		hook.enqueue(hookEvent{event: watch.Event{Type: watch.Bookmark}})
+	}
+}
+
+// bookmarkObject returns the object of the bookmark event, only its type and resource version are set
+func bookmarkObject(gvr schema.GroupVersionResource, rev uint64) *unstructured.Unstructured {
+	obj := new(unstructured.Unstructured)
+	obj.SetGroupVersionKind(gvr.GroupVersion().WithKind(util.UnsafeResourceToKind(gvr.Resource)))
+	if err := etcd3.Versioner.UpdateObject(obj, rev); err != nil {
+		klog.Errorf("failed to set resource version of bookmark, %v", err)
+	}
+	return obj
+}
@@ -1,20 +1,29 @@
 package watchhook
 
-import "k8s.io/apimachinery/pkg/watch"
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/watch"
+)
 
 type Receiver interface {
 	Receive(event watch.Event) error
 }
 type ChanReceiver struct {
-	ch chan<- watch.Event
+	ch   chan<- watch.Event
+	stop <-chan struct{}
 }
 
 func (hc *ChanReceiver) Receive(event watch.Event) error {
-	//TODO: recover when hc.ch is closed if panic occurs
-	hc.ch <- event
-	return nil
+	select {
+	case hc.ch <- event:
+		return nil
+	case <-hc.stop:
+		return fmt.Errorf("receiver stopped, %s event dropped", event.Type)
+	}
 }
 
-func NewChanReceiver(ch chan<- watch.Event) *ChanReceiver {
-	return &ChanReceiver{ch: ch}
+// NewChanReceiver returns the receiver sending the events to ch until stop is closed
+func NewChanReceiver(ch chan<- watch.Event, stop <-chan struct{}) *ChanReceiver {
+	return &ChanReceiver{ch: ch, stop: stop}
 }
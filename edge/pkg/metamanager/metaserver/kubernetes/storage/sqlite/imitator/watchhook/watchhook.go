@@ -1,9 +1,11 @@
 package watchhook
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/klog/v2"
@@ -11,6 +13,11 @@ import (
 	"github.com/kubeedge/kubeedge/pkg/metaserver"
 )
 
+// maxQueuedEvents is the max number of the events queued to a hook, it is more than the
+// events replayed from the history. The watch whose receiver falls behind is closed with
+// the ResourceExpired error, so that the client relists instead of missing events
+const maxQueuedEvents = 2 * historySize
+
 type WatchHook struct {
 	GVR             schema.GroupVersionResource
 	Namespace       string
@@ -19,8 +26,32 @@ type WatchHook struct {
 	id              string
 	Receiver
 	lock sync.Mutex
+
+	// queue keeps the events to be sent to the receiver in order, they are queued with
+	// hooksLock held and sent by the goroutine of the hook, so that a slow receiver does
+	// not block the other hooks
+	queueLock sync.Mutex
+	queue     []hookEvent
+	notify    chan struct{}
+	stopCh    chan struct{}
+	done      chan struct{}
+	stopOnce  sync.Once
+	// sentRev is the revision of the latest event sent to the receiver
+	sentRev uint64
+	// expired is set once the queue is full, the events are no longer queued
+	expired bool
 }
 
+// hookEvent is the event queued to the hook, the bookmark is queued without object,
+// it is made with the revision of the latest event sent when it is dequeued
+type hookEvent struct {
+	rev   uint64
+	event watch.Event
+}
+
+// NewWatchHook registers the hook to receive the events of the key after the revision rev.
+// If rev is not 0, the events after rev in the history are sent before the new events, and
+// the ResourceExpired error is returned if some of them are no longer in the history
 func NewWatchHook(key string, rev uint64, receiver Receiver) (*WatchHook, error) {
 	id := uuid.New().String()
 	gvr, ns, name := metaserver.ParseKey(key)
@@ -31,9 +62,30 @@ func NewWatchHook(key string, rev uint64, receiver Receiver) (*WatchHook, error)
 		Name:            name,
 		ResourceVersion: rev,
 		Receiver:        receiver,
+		sentRev:         rev,
+		notify:          make(chan struct{}, 1),
+		stopCh:          make(chan struct{}),
+		done:            make(chan struct{}),
 	}
-	err := AddHook(wh)
-	return wh, err
+
+	hooksLock.Lock()
+	defer hooksLock.Unlock()
+	if rev == 0 {
+		// the hook watches from now on, the events before the latest revision are not sent
+		wh.sentRev = latestRev
+	} else {
+		events, err := eventsAfter(wh, rev)
+		if err != nil {
+			return nil, err
+		}
+		wh.queue = events
+	}
+	if err := addHook(wh); err != nil {
+		return nil, err
+	}
+	go wh.run()
+	wh.wakeup()
+	return wh, nil
 }
 
 func (h *WatchHook) Do(event watch.Event) error {
@@ -42,6 +94,99 @@ func (h *WatchHook) Do(event watch.Event) error {
 	return h.Receive(event)
 }
 
+// enqueue queues the event to be sent to the receiver, it never blocks. If the queue is full,
+// the queued events are dropped and the ResourceExpired error is sent as the last event
+func (h *WatchHook) enqueue(e hookEvent) {
+	h.queueLock.Lock()
+	if h.expired {
+		h.queueLock.Unlock()
+		return
+	}
+	if e.event.Type == watch.Bookmark && len(h.queue) > 0 && h.queue[len(h.queue)-1].event.Type == watch.Bookmark {
+		// the previous bookmark is not sent yet
+		h.queueLock.Unlock()
+		return
+	}
+	if len(h.queue) >= maxQueuedEvents {
+		klog.Warningf("watch hook %s falls behind by %d events, close it", h.id, len(h.queue))
+		status := errors.NewResourceExpired(fmt.Sprintf("the watch falls behind by %d events", len(h.queue))).Status()
+		h.queue = []hookEvent{{event: watch.Event{Type: watch.Error, Object: &status}}}
+		h.expired = true
+	} else {
+		h.queue = append(h.queue, e)
+	}
+	h.queueLock.Unlock()
+	h.wakeup()
+}
+
+func (h *WatchHook) wakeup() {
+	select {
+	case h.notify <- struct{}{}:
+	default:
+	}
+}
+
+// run sends the queued events to the receiver until the hook is stopped,
+// the events queued before the hook is stopped are sent as well
+func (h *WatchHook) run() {
+	defer close(h.done)
+	for {
+		select {
+		case <-h.notify:
+			h.flush()
+		case <-h.stopCh:
+			h.flush()
+			return
+		}
+	}
+}
+
+func (h *WatchHook) flush() {
+	for {
+		h.queueLock.Lock()
+		events := h.queue
+		h.queue = nil
+		h.queueLock.Unlock()
+		if len(events) == 0 {
+			return
+		}
+		for _, e := range events {
+			h.send(e)
+		}
+	}
+}
+
+func (h *WatchHook) send(e hookEvent) {
+	h.Lock()
+	defer h.UnLock()
+	event := e.event
+	if event.Type == watch.Bookmark {
+		if h.GVR.Empty() || h.sentRev == 0 {
+			return
+		}
+		event.Object = bookmarkObject(h.GVR, h.sentRev)
+	} else if e.rev > h.sentRev {
+		h.sentRev = e.rev
+	}
+	if err := h.Receive(event); err != nil {
+		klog.Errorf("failed to operate event, %v", err)
+	}
+}
+
+// matches checks whether the event of the object is watched by the hook
+func (h *WatchHook) matches(gvr schema.GroupVersionResource, namespace, name string, rev uint64) bool {
+	if !h.GVR.Empty() && h.GVR != gvr {
+		return false
+	}
+	if h.Namespace != "" && h.Namespace != namespace {
+		return false
+	}
+	if h.Name != "" && h.Name != name {
+		return false
+	}
+	return h.ResourceVersion == 0 || h.ResourceVersion < rev
+}
+
 func (h *WatchHook) GetGVR() schema.GroupVersionResource {
 	return h.GVR
 }
@@ -57,12 +202,16 @@ func (h *WatchHook) GetResourceVersion() uint64 {
 	return h.ResourceVersion
 }
 
+// Stop unregisters the hook, the receiver is not used after it returns
 func (h *WatchHook) Stop() {
-	h.Lock()
-	defer h.UnLock()
 	if err := DeleteHook(h.id); err != nil {
 		klog.Error(err)
 	}
+	// wait for the queued events being sent
+	h.stopOnce.Do(func() {
+		close(h.stopCh)
+	})
+	<-h.done
 }
 
 func (h *WatchHook) Lock() {
@@ -4,19 +4,20 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/conversion"
-	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/apiserver/pkg/storage"
 	"k8s.io/klog/v2"
 
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator/watchhook"
 	"github.com/kubeedge/kubeedge/pkg/metaserver"
 	"github.com/kubeedge/kubeedge/pkg/metaserver/util"
 )
@@ -35,13 +36,81 @@ func (s *store) Versioner() storage.Versioner {
 	return s.versioner
 }
 
+// Create, Delete and GuaranteedUpdate only write the local storage, they are used to
+// accept the writes while the cloud is unreachable
+
 func (s *store) Create(ctx context.Context, key string, obj, out runtime.Object, ttl uint64) error {
-	panic("Do not call this function")
+	if _, err := s.getLocal(key); err == nil {
+		return storage.NewKeyExistsError(key, 0)
+	} else if !storage.IsNotFound(err) {
+		return err
+	}
+	if err := s.versioner.PrepareObjectForStorage(obj); err != nil {
+		return fmt.Errorf("PrepareObjectForStorage failed: %v", err)
+	}
+	return s.write(watch.Added, obj, out)
 }
 
 func (s *store) Delete(ctx context.Context, key string, out runtime.Object, preconditions *storage.Preconditions,
 	validateDeletion storage.ValidateObjectFunc, cachedExistingObject runtime.Object) error {
-	panic("Do not call this function")
+	existing, err := s.getLocal(key)
+	if err != nil {
+		return err
+	}
+	if preconditions != nil {
+		if err := preconditions.Check(key, existing); err != nil {
+			return err
+		}
+	}
+	if validateDeletion != nil {
+		if err := validateDeletion(ctx, existing); err != nil {
+			return err
+		}
+	}
+	return s.write(watch.Deleted, existing, out)
+}
+
+// getLocal returns the object of the key in local storage
+func (s *store) getLocal(key string) (*unstructured.Unstructured, error) {
+	resp, err := s.client.List(context.TODO(), key)
+	if err != nil {
+		return nil, storage.NewInternalError(err.Error())
+	}
+	if len(*resp.Kvs) == 0 {
+		return nil, storage.NewKeyNotFoundError(key, 0)
+	}
+	obj := new(unstructured.Unstructured)
+	if err := runtime.DecodeInto(s.codec, []byte((*resp.Kvs)[0].Value), obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// write saves the object with the local resource version, notifies the watchers
+// and decodes the saved object into out
+func (s *store) write(eventType watch.EventType, obj, out runtime.Object) error {
+	if err := s.versioner.UpdateObject(obj, s.client.LocalRevision()); err != nil {
+		return err
+	}
+	var err error
+	if eventType == watch.Deleted {
+		err = s.client.DeleteObj(context.TODO(), obj)
+	} else {
+		err = s.client.InsertOrUpdateObj(context.TODO(), obj)
+	}
+	if err != nil {
+		return storage.NewInternalError(err.Error())
+	}
+	watchhook.TriggerLocal(watch.Event{Type: eventType, Object: obj})
+
+	if out == nil {
+		return nil
+	}
+	data, err := runtime.Encode(s.codec, obj)
+	if err != nil {
+		return err
+	}
+	return runtime.DecodeInto(s.codec, data, out)
 }
 
 func (s *store) Watch(ctx context.Context, key string, opts storage.ListOptions) (watch.Interface, error) {
@@ -77,35 +146,65 @@ func (s *store) GetList(ctx context.Context, key string, opts storage.ListOption
 		return fmt.Errorf("need ptr to slice: %v", err)
 	}
 
-	resp, err := s.client.List(context.TODO(), key)
+	var startKey string
+	if opts.Predicate.Continue != "" {
+		if len(opts.ResourceVersion) > 0 && opts.ResourceVersion != "0" {
+			return apierrors.NewBadRequest("specifying resource version is not allowed when using continue")
+		}
+		startKey, err = decodeContinue(opts.Predicate.Continue, key)
+		if err != nil {
+			return apierrors.NewBadRequest(fmt.Sprintf("invalid continue token: %v", err))
+		}
+	}
 
-	if err != nil || len(*resp.Kvs) == 0 {
+	resp, err := s.client.List(context.TODO(), key)
+	if err != nil {
 		klog.Error(err)
 		return err
 	}
+	// the objects are listed in the order of key to support paging
+	kvs := *resp.Kvs
+	sort.Slice(kvs, func(i, j int) bool {
+		return kvs[i].Key < kvs[j].Key
+	})
+
 	unstrList := listObj.(*unstructured.UnstructuredList)
-	for _, v := range *resp.Kvs {
+	var lastKey string
+	var remaining int64
+	for i, v := range kvs {
+		if startKey != "" && v.Key <= startKey {
+			continue
+		}
+		if opts.Predicate.Limit > 0 && int64(len(unstrList.Items)) == opts.Predicate.Limit {
+			remaining = int64(len(kvs) - i)
+			break
+		}
 		var unstrObj unstructured.Unstructured
 		err := runtime.DecodeInto(s.codec, []byte(v.Value), &unstrObj)
 		if err != nil {
 			return err
 		}
-
-		labelSet := labels.Set(unstrObj.GetLabels())
-		if !opts.Predicate.Label.Matches(labelSet) {
-			continue
-		}
-
-		// only support metadata.name & metadata.namespace
-		fieldSet := fields.Set{
-			"metadata.name":      unstrObj.GetName(),
-			"metadata.namespace": unstrObj.GetNamespace(),
+		matched, err := opts.Predicate.Matches(&unstrObj)
+		if err != nil {
+			return err
 		}
-		if !opts.Predicate.Field.Matches(fieldSet) {
+		if !matched {
 			continue
 		}
 
 		unstrList.Items = append(unstrList.Items, unstrObj)
+		lastKey = v.Key
+	}
+	if remaining > 0 {
+		next, err := encodeContinue(lastKey, int64(resp.Revision))
+		if err != nil {
+			return err
+		}
+		unstrList.SetContinue(next)
+		// the remaining count is unknown until the remaining objects are filtered
+		if opts.Predicate.Empty() {
+			unstrList.SetRemainingItemCount(&remaining)
+		}
 	}
 	rv := strconv.FormatUint(resp.Revision, 10)
 	unstrList.SetResourceVersion(rv)
@@ -116,7 +215,28 @@ func (s *store) GetList(ctx context.Context, key string, opts storage.ListOption
 }
 
 func (s *store) GuaranteedUpdate(ctx context.Context, key string, ptrToType runtime.Object, ignoreNotFound bool, precondtions *storage.Preconditions, tryUpdate storage.UpdateFunc, cachedExistingObject runtime.Object) error {
-	panic("Do not call this function")
+	eventType := watch.Modified
+	existing, err := s.getLocal(key)
+	if storage.IsNotFound(err) && ignoreNotFound {
+		eventType = watch.Added
+		existing = new(unstructured.Unstructured)
+	} else if err != nil {
+		return err
+	}
+	if precondtions != nil {
+		if err := precondtions.Check(key, existing); err != nil {
+			return err
+		}
+	}
+	rv, err := s.versioner.ObjectResourceVersion(existing)
+	if err != nil {
+		return err
+	}
+	updated, _, err := tryUpdate(existing, storage.ResponseMeta{ResourceVersion: rv})
+	if err != nil {
+		return err
+	}
+	return s.write(eventType, updated, ptrToType)
 }
 
 func (s *store) Count(key string) (int64, error) {
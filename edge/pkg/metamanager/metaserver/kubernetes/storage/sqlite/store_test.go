@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/storage"
+
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator"
+	"github.com/kubeedge/kubeedge/pkg/metaserver/util"
+)
+
+// fakeClient lists the objects in memory
+type fakeClient struct {
+	imitator.Client
+	kvs      []v2.MetaV2
+	revision uint64
+}
+
+func (c *fakeClient) List(_ context.Context, _ string) (imitator.Resp, error) {
+	kvs := append([]v2.MetaV2{}, c.kvs...)
+	return imitator.Resp{Kvs: &kvs, Revision: c.revision}, nil
+}
+
+func newFakeStore(t *testing.T, pods int) *store {
+	client := &fakeClient{revision: 100}
+	// insert in the reverse order to verify the list is sorted by key
+	for i := pods - 1; i >= 0; i-- {
+		pod := &unstructured.Unstructured{}
+		pod.SetAPIVersion("v1")
+		pod.SetKind("Pod")
+		pod.SetNamespace("default")
+		pod.SetName(fmt.Sprintf("pod-%d", i))
+		_ = unstructured.SetNestedField(pod.Object, fmt.Sprintf("node-%d", i%2), "spec", "nodeName")
+		value, err := pod.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.kvs = append(client.kvs, v2.MetaV2{
+			Key:   fmt.Sprintf("/core/v1/pods/default/pod-%d", i),
+			Value: string(value),
+		})
+	}
+	return &store{
+		client:    client,
+		versioner: imitator.Versioner,
+		codec:     unstructured.UnstructuredJSONScheme,
+	}
+}
+
+func listNames(t *testing.T, s *store, pred storage.SelectionPredicate) ([]string, string) {
+	list := &unstructured.UnstructuredList{}
+	err := s.GetList(context.TODO(), "/core/v1/pods/default", storage.ListOptions{Predicate: pred}, list)
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	var names []string
+	for _, item := range list.Items {
+		names = append(names, item.GetName())
+	}
+	return names, list.GetContinue()
+}
+
+func TestGetListPaging(t *testing.T) {
+	s := newFakeStore(t, 5)
+	pred := storage.SelectionPredicate{
+		Label:    labels.Everything(),
+		Field:    fields.Everything(),
+		GetAttrs: util.UnstructuredAttr,
+		Limit:    2,
+	}
+
+	var names []string
+	for page := 0; ; page++ {
+		if page > 3 {
+			t.Fatalf("too many pages")
+		}
+		pageNames, next := listNames(t, s, pred)
+		names = append(names, pageNames...)
+		if next == "" {
+			break
+		}
+		pred.Continue = next
+	}
+
+	expected := "[pod-0 pod-1 pod-2 pod-3 pod-4]"
+	if fmt.Sprint(names) != expected {
+		t.Errorf("expected %s, but got %v", expected, names)
+	}
+}
+
+func TestGetListFieldSelector(t *testing.T) {
+	s := newFakeStore(t, 5)
+	pred := storage.SelectionPredicate{
+		Label:    labels.Everything(),
+		Field:    fields.OneTermEqualSelector("spec.nodeName", "node-1"),
+		GetAttrs: util.UnstructuredAttr,
+	}
+	names, next := listNames(t, s, pred)
+	if fmt.Sprint(names) != "[pod-1 pod-3]" || next != "" {
+		t.Errorf("expected [pod-1 pod-3] without continue, but got %v, continue %q", names, next)
+	}
+}
+
+func TestGetListInvalidContinue(t *testing.T) {
+	s := newFakeStore(t, 1)
+	token, err := encodeContinue("/core/v1/services/default/svc", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []string{"invalid", token} {
+		pred := storage.SelectionPredicate{Label: labels.Everything(), Field: fields.Everything(), Continue: c}
+		err := s.GetList(context.TODO(), "/core/v1/pods/default", storage.ListOptions{Predicate: pred}, &unstructured.UnstructuredList{})
+		if err == nil {
+			t.Errorf("expected error for continue token %q", c)
+		}
+	}
+}
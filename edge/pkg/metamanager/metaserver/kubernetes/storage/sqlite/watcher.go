@@ -60,6 +60,8 @@ type watchChan struct {
 	ctx               context.Context
 	cancel            context.CancelFunc
 	incomingEventChan chan *watch.Event
+	// wch is the channel of the events from the imitator
+	wch <-chan watch.Event
 	// added is map show an obj whether it has been added to watch chan befor
 	added      map[string]bool
 	resultChan chan watch.Event
@@ -82,17 +84,21 @@ func (wc *watchChan) Receive(e watch.Event) error {
 // Watch watches on a key and returns a watch.Interface that transfers relevant notifications.
 // If rev is zero, it will return the existing object(s) and then start watching from
 // the maximum revision+1 from returned objects.
-// If rev is non-zero, it will watch events happened after given revision.
+// If rev is non-zero, it will watch events happened after given revision, the ResourceExpired
+// error is returned if the events are no longer in the history of the imitator.
 // If recursive is false, it watches on given key.
 // If recursive is true, it watches any children and directories under the key, excluding the root key itself.
 // pred must be non-nil. Only if pred matches the change, it will be returned.
 func (w *watcher) Watch(ctx context.Context, key string, rev int64, recursive bool, pred storage.SelectionPredicate) (watch.Interface, error) {
-	// TODO: support rev != 0
+	wc := w.createWatchChan(ctx, key, rev, recursive, pred)
 	if rev != 0 {
-		klog.Warningf("base storage now only support rev == 0, but get rev == %v, force set to 0!", rev)
-		rev = 0
+		wch, err := w.client.Watch(wc.ctx, key, uint64(rev))
+		if err != nil {
+			wc.cancel()
+			return nil, err
+		}
+		wc.wch = wch
 	}
-	wc := w.createWatchChan(ctx, key, rev, recursive, pred)
 	go wc.run()
 	return wc, nil
 }
@@ -112,6 +118,7 @@ func (w *watcher) createWatchChan(ctx context.Context, key string, rev int64, re
 	if pred.Empty() {
 		// The filter doesn't filter out any object.
 		wc.internalPred = storage.Everything
+		wc.internalPred.AllowWatchBookmarks = pred.AllowWatchBookmarks
 	}
 	wc.ctx, wc.cancel = context.WithCancel(ctx)
 	return wc
@@ -227,15 +234,27 @@ func logWatchChannelErr(err error) {
 // - watch on given key and send events to process.
 func (wc *watchChan) startWatching(watchClosedCh chan struct{}) {
 	klog.Infof("start watching, rev:%v", wc.initialRev)
-	if wc.initialRev == 0 {
+	if wc.wch == nil {
 		if err := wc.sync(); err != nil {
 			klog.Errorf("failed to sync with latest state: %v", err)
 			wc.sendError(err)
 			return
 		}
+		// the events after the synced revision in the history are sent too
+		wch, err := wc.watcher.client.Watch(wc.ctx, wc.key, uint64(wc.initialRev))
+		if err != nil {
+			klog.Errorf("failed to watch from revision %v: %v", wc.initialRev, err)
+			wc.sendError(err)
+			return
+		}
+		wc.wch = wch
 	}
-	wch := wc.watcher.client.Watch(wc.ctx, wc.key, uint64(wc.initialRev+1))
-	for wres := range wch {
+	for wres := range wc.wch {
+		if wres.Type == watch.Error {
+			// the imitator closes the watch falling behind with ResourceExpired
+			wc.sendError(apierrors.FromObject(wres.Object))
+			return
+		}
 		wc.sendEvent(&wres)
 	}
 	wc.sendError(fmt.Errorf("stop to watch sqlite/meta_v2"))
@@ -249,6 +268,17 @@ func (wc *watchChan) processEvent(wg *sync.WaitGroup) {
 	for {
 		select {
 		case e := <-wc.incomingEventChan:
+			if e.Type == watch.Bookmark {
+				if !wc.internalPred.AllowWatchBookmarks {
+					continue
+				}
+				select {
+				case wc.resultChan <- *e:
+				case <-wc.ctx.Done():
+					return
+				}
+				continue
+			}
 			var res = e
 			key, err := metaserver.KeyFuncObj(e.Object)
 			if err != nil {
@@ -312,6 +342,13 @@ func (wc *watchChan) acceptAll() bool {
 }
 
 func transformErrorToEvent(err error) *watch.Event {
+	if apiErr, ok := err.(apierrors.APIStatus); ok {
+		status := apiErr.Status()
+		return &watch.Event{
+			Type:   watch.Error,
+			Object: &status,
+		}
+	}
 	status := apierrors.NewInternalError(err).Status()
 	return &watch.Event{
 		Type:   watch.Error,
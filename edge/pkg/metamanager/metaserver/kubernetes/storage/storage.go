@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
@@ -17,11 +18,11 @@ import (
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
 	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/util/dryrun"
 	"k8s.io/klog/v2"
 
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/agent"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite"
-	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator"
 	"github.com/kubeedge/kubeedge/pkg/metaserver"
 	"github.com/kubeedge/kubeedge/pkg/metaserver/util"
 )
@@ -90,6 +91,11 @@ func (r *REST) Get(ctx context.Context, name string, options *metav1.GetOptions)
 		defer app.Close()
 		if err != nil {
 			klog.Errorf("[metaserver/reststorage] failed to get obj from cloud: %v", err)
+			if errors.IsNotFound(err) {
+				if key, err := metaserver.KeyFuncReq(ctx, ""); err == nil {
+					uncacheObject(key)
+				}
+			}
 			return nil, err
 		}
 		var obj = new(unstructured.Unstructured)
@@ -98,7 +104,7 @@ func (r *REST) Get(ctx context.Context, name string, options *metav1.GetOptions)
 			return nil, err
 		}
 		// save to local, ignore error
-		imitator.DefaultV2Client.InsertOrUpdateObj(context.TODO(), obj)
+		cacheObject(obj)
 		klog.Infof("[metaserver/reststorage] successfully process get req (%v) through cloud", info.Path)
 		return obj, nil
 	}()
@@ -134,7 +140,10 @@ func (r *REST) List(ctx context.Context, options *metainternalversion.ListOption
 		if err != nil {
 			return nil, err
 		}
-		// imitator.DefaultV2Client.InsertOrUpdateObj(context.TODO(), list)
+		// save to local, ignore error
+		if key, err := metaserver.KeyFuncReq(ctx, ""); err == nil {
+			cacheList(key, list, isCompleteList(options, list))
+		}
 		klog.Infof("[metaserver/reststorage] successfully process list req (%v) through cloud", info.Path)
 		return list, nil
 	}()
@@ -184,6 +193,10 @@ func (r *REST) Watch(ctx context.Context, options *metainternalversion.ListOptio
 }
 
 func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	writeLock.RLock()
+	defer writeLock.RUnlock()
+
+	reqObj := obj
 	obj, err := func() (runtime.Object, error) {
 		app, err := r.Agent.Generate(ctx, metaserver.Create, *options, obj)
 		if err != nil {
@@ -204,8 +217,11 @@ func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation
 		return retObj, nil
 	}()
 
+	if err != nil && acceptOffline(ctx, err) {
+		return r.createOffline(ctx, reqObj, createValidation, options)
+	}
 	if err != nil {
-		klog.Errorf("[metaserver/reststorage] failed to create (%v)", metaserver.KeyFunc(obj))
+		klog.Errorf("[metaserver/reststorage] failed to create (%v)", metaserver.KeyFunc(reqObj))
 		return nil, err
 	}
 
@@ -214,15 +230,24 @@ func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation
 }
 
 func (r *REST) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
+	writeLock.RLock()
+	defer writeLock.RUnlock()
+
 	key, _ := metaserver.KeyFuncReq(ctx, "")
 	app, err := r.Agent.Generate(ctx, metaserver.Delete, options, nil)
 	if err != nil {
+		if acceptOffline(ctx, err) {
+			return r.deleteOffline(ctx, key, deleteValidation, options)
+		}
 		klog.Errorf("[metaserver/reststorage] failed to generate application: %v", err)
 		return nil, false, err
 	}
 	err = r.Agent.Apply(app)
 	defer app.Close()
 	if err != nil {
+		if acceptOffline(ctx, err) {
+			return r.deleteOffline(ctx, key, deleteValidation, options)
+		}
 		klog.Errorf("[metaserver/reststorage] failed to delete (%v) through cloud", key)
 		return nil, false, err
 	}
@@ -231,6 +256,9 @@ func (r *REST) Delete(ctx context.Context, name string, deleteValidation rest.Va
 }
 
 func (r *REST) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	writeLock.RLock()
+	defer writeLock.RUnlock()
+
 	obj, err := objInfo.UpdatedObject(ctx, nil)
 	if err != nil {
 		return nil, false, errors.NewInternalError(err)
@@ -243,12 +271,31 @@ func (r *REST) Update(ctx context.Context, name string, objInfo rest.UpdatedObje
 	} else {
 		app, err = r.Agent.Generate(ctx, metaserver.Update, options, obj)
 	}
-	if err != nil {
-		klog.Errorf("[metaserver/reststorage] failed to generate application: %v", err)
-		return nil, false, err
+	if err == nil {
+		defer app.Close()
+		err = r.Agent.Apply(app)
 	}
-	defer app.Close()
-	if err := r.Agent.Apply(app); err != nil {
+	if err != nil {
+		if acceptOffline(ctx, err) {
+			retObj, err := r.updateOffline(ctx, dryrun.IsDryRun(options.DryRun), func(existing *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+				updated, err := objInfo.UpdatedObject(ctx, existing)
+				if err != nil {
+					return nil, err
+				}
+				if updateValidation != nil {
+					if err := updateValidation(ctx, updated, existing); err != nil {
+						return nil, err
+					}
+				}
+				unstrObj, ok := updated.(*unstructured.Unstructured)
+				if !ok {
+					return nil, fmt.Errorf("unexpected object type %T", updated)
+				}
+				return unstrObj, nil
+			})
+			return retObj, false, err
+		}
+		klog.Errorf("[metaserver/reststorage] failed to update obj: %v", err)
 		return nil, false, err
 	}
 	retObj := new(unstructured.Unstructured)
@@ -259,13 +306,19 @@ func (r *REST) Update(ctx context.Context, name string, objInfo rest.UpdatedObje
 }
 
 func (r *REST) Patch(ctx context.Context, pi metaserver.PatchInfo) (runtime.Object, error) {
+	writeLock.RLock()
+	defer writeLock.RUnlock()
+
 	app, err := r.Agent.Generate(ctx, metaserver.Patch, pi, nil)
-	if err != nil {
-		klog.Errorf("[metaserver/reststorage] failed to generate application: %v", err)
-		return nil, err
+	if err == nil {
+		defer app.Close()
+		err = r.Agent.Apply(app)
 	}
-	defer app.Close()
-	if err := r.Agent.Apply(app); err != nil {
+	if err != nil {
+		if acceptOffline(ctx, err) {
+			return r.patchOffline(ctx, pi)
+		}
+		klog.Errorf("[metaserver/reststorage] failed to patch obj: %v", err)
 		return nil, err
 	}
 	retObj := new(unstructured.Unstructured)
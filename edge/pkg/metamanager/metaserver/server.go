@@ -40,6 +40,7 @@ import (
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/handlerfactory"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/serializer"
 	kefeatures "github.com/kubeedge/kubeedge/pkg/features"
+	"github.com/kubeedge/kubeedge/pkg/metaserver"
 )
 
 // MetaServer is simplification of server.GenericAPIServer
@@ -59,11 +60,11 @@ type metaServerAuth struct {
 }
 
 func buildAuth() *metaServerAuth {
-	newAuthorizer := rbac.New(
+	newAuthorizer := auth.NewAuthorizer(rbac.New(
 		&client.RoleGetter{},
 		&client.RoleBindingLister{},
 		&client.ClusterRoleGetter{},
-		&client.ClusterRoleBindingLister{})
+		&client.ClusterRoleBindingLister{}), metaserverconfig.Config.Authorization)
 
 	allPublicKeys := []interface{}{}
 	for _, keyfile := range metaserverconfig.Config.ServiceAccountKeyFiles {
@@ -234,6 +235,10 @@ func (ls *MetaServer) BuildBasicHandler() http.Handler {
 			}
 			return
 		}
+		if ok && reqInfo.Verb == "get" && metaserver.IsDiscoveryPath(reqInfo.Path) {
+			ls.Factory.Discovery().ServeHTTP(w, req)
+			return
+		}
 
 		err := fmt.Errorf("not a resource req")
 		responsewriters.ErrorNegotiated(errors.NewInternalError(err), ls.NegotiatedSerializer, schema.GroupVersion{}, w, req)
@@ -21,6 +21,11 @@ import (
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/client"
 	metaManagerConfig "github.com/kubeedge/kubeedge/edge/pkg/metamanager/config"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/auth"
+	metaserverconfig "github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/config"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/customresource"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/metaserver/kubernetes/storage/sqlite/imitator"
 )
 
@@ -201,8 +206,14 @@ func parseResourceFromObject(message *model.Message) (string, string, string) {
 	return resKey, appName, domain
 }
 
-func (m *metaManager) handleMessage(message *model.Message) error {
+func (m *metaManager) handleMessage(message *model.Message) (err error) {
 	resKey, resType, _, appName, domain := parseResource(message)
+	if resType == model.ResourceTypeSaAccess {
+		defer func() {
+			customresource.InvalidateServiceAccountAccess()
+			auth.PolicySynced(err)
+		}()
+	}
 	if appName == "" || domain == "" {
 		objResKey, objAppName, objDomain := parseResourceFromObject(message)
 		if objAppName != "" {
@@ -215,6 +226,11 @@ func (m *metaManager) handleMessage(message *model.Message) error {
 			}
 		}
 	}
+	// the objects watched through dynamiccontroller are saved by the imitator with their api keys,
+	// they are saved in the legacy resource format only if they are of the legacy types
+	if message.GetSource() == cloudmodules.DynamicControllerModuleName && !v2.IsLegacyResourceType(resType) {
+		return nil
+	}
 	switch message.GetOperation() {
 	case model.InsertOperation, model.UpdateOperation, model.PatchOperation, model.ResponseOperation:
 		content, err := message.GetContentData()
@@ -538,6 +554,14 @@ func (m *metaManager) processVolume(message model.Message) {
 	klog.Infof("process volume send to cloud resp[%+v]", resp)
 }
 
+// processNodeConnection replays the writes accepted by metaserver while offline once the cloud is connected
+func (m *metaManager) processNodeConnection(message model.Message) {
+	content, _ := message.GetContent().(string)
+	if content == connect.CloudConnected && metaserverconfig.Config.Enable {
+		go storage.ReplayPendingOperations()
+	}
+}
+
 func (m *metaManager) process(message model.Message) {
 	operation := message.GetOperation()
 
@@ -554,6 +578,8 @@ func (m *metaManager) process(message model.Message) {
 		m.processQuery(message)
 	case model.ResponseOperation:
 		m.processResponse(message)
+	case edgeCommonMessage.OperationNodeConnection:
+		m.processNodeConnection(message)
 	case constants.CSIOperationTypeCreateVolume,
 		constants.CSIOperationTypeDeleteVolume,
 		constants.CSIOperationTypeControllerPublishVolume,
@@ -37,7 +37,7 @@ import (
 	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
 	"github.com/kubeedge/kubeedge/edge/pkg/common/modules"
 	metaManagerConfig "github.com/kubeedge/kubeedge/edge/pkg/metamanager/config"
-	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
 	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
 )
 
@@ -572,9 +572,9 @@ func TestProcessDelete(t *testing.T) {
 		}
 	})
 
-	fakeDao := new([]dao.Meta)
-	fakeDaoArray := make([]dao.Meta, 1)
-	fakeDaoArray[0] = dao.Meta{Key: "Test", Value: string(podBytes)}
+	fakeDao := new([]v2.MetaV2)
+	fakeDaoArray := make([]v2.MetaV2, 1)
+	fakeDaoArray[0] = v2.MetaV2{Key: "Test", Value: string(podBytes)}
 	fakeDao = &fakeDaoArray
 	querySetterMock.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(querySetterMock).Times(3)
 	querySetterMock.EXPECT().All(gomock.Any()).SetArg(0, *fakeDao).Return(int64(1), nil).Times(1)
@@ -691,9 +691,9 @@ func TestProcessQuery(t *testing.T) {
 	})
 
 	// No error and connected true
-	fakeDao := new([]dao.Meta)
-	fakeDaoArray := make([]dao.Meta, 1)
-	fakeDaoArray[0] = dao.Meta{Key: "Test", Value: MessageTest}
+	fakeDao := new([]v2.MetaV2)
+	fakeDaoArray := make([]v2.MetaV2, 1)
+	fakeDaoArray[0] = v2.MetaV2{Key: "Test", Value: MessageTest}
 	fakeDao = &fakeDaoArray
 	querySetterMock.EXPECT().All(gomock.Any()).SetArg(0, *fakeDao).Return(int64(1), nil).Times(1)
 	querySetterMock.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(querySetterMock).Times(1)
@@ -16,6 +16,7 @@ limitations under the License.
 package dao
 
 import (
+	"github.com/astaxie/beego/orm"
 	"k8s.io/klog/v2"
 
 	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
@@ -31,30 +32,104 @@ type TargetUrls struct {
 
 // InsertUrls insert target_urls
 func InsertUrls(url string) error {
-	_, err := dbm.DBAccess.Raw("INSERT OR REPLACE INTO target_urls (url) VALUES (?)", url).Exec()
-	klog.V(4).Infof("INSERT result %v", err)
-	return err
+	return urlStorage().Insert(url)
 }
 
 // DeleteUrlsByKey delete target_urls by key
 func DeleteUrlsByKey(key string) error {
-	num, err := dbm.DBAccess.QueryTable(TargetUrlsName).Filter("url", key).Delete()
-	klog.V(4).Infof("Delete affected Num: %d, %v", num, err)
-	return err
+	return urlStorage().DeleteByKey(key)
 }
 
 func IsTableEmpty() bool {
 	var count int64
-	if count, _ = dbm.DBAccess.QueryTable(TargetUrlsName).Count(); count > 0 {
+	if count, _ = urlStorage().Count(); count > 0 {
 		return false
 	}
 	return true
 }
 
 func GetUrlsByKey(key string) (result *TargetUrls, err error) {
+	return urlStorage().Get(key)
+}
+
+// urlStore is the storage of the target urls
+type urlStore interface {
+	Insert(url string) error
+	DeleteByKey(key string) error
+	Count() (int64, error)
+	Get(key string) (*TargetUrls, error)
+}
+
+// urlStorage returns the storage selected by DataBase.DriverName
+func urlStorage() urlStore {
+	if dbm.UseKV() {
+		return kvURLStore{}
+	}
+	return sqliteURLStore{}
+}
+
+type sqliteURLStore struct{}
+
+func (sqliteURLStore) Insert(url string) error {
+	_, err := dbm.DBAccess.Raw("INSERT OR REPLACE INTO target_urls (url) VALUES (?)", url).Exec()
+	klog.V(4).Infof("INSERT result %v", err)
+	return err
+}
+
+func (sqliteURLStore) DeleteByKey(key string) error {
+	num, err := dbm.DBAccess.QueryTable(TargetUrlsName).Filter("url", key).Delete()
+	klog.V(4).Infof("Delete affected Num: %d, %v", num, err)
+	return err
+}
+
+func (sqliteURLStore) Count() (int64, error) {
+	return dbm.DBAccess.QueryTable(TargetUrlsName).Count()
+}
+
+func (sqliteURLStore) Get(key string) (*TargetUrls, error) {
 	targetUrls := new(TargetUrls)
 	if err := dbm.DBAccess.QueryTable(TargetUrlsName).Filter("url", key).One(targetUrls); err != nil {
 		return nil, err
 	}
 	return targetUrls, nil
 }
+
+type kvURLStore struct{}
+
+func (kvURLStore) Insert(url string) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		return tx.Put(TargetUrlsName, url, &TargetUrls{URL: url})
+	})
+}
+
+func (kvURLStore) DeleteByKey(key string) error {
+	return dbm.KVAccess.Update(func(tx dbm.KVTx) error {
+		return tx.Delete(TargetUrlsName, key)
+	})
+}
+
+func (kvURLStore) Count() (int64, error) {
+	var count int64
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		return tx.ForEach(TargetUrlsName, func(string, []byte) error {
+			count++
+			return nil
+		})
+	})
+	return count, err
+}
+
+func (kvURLStore) Get(key string) (*TargetUrls, error) {
+	targetUrls := new(TargetUrls)
+	err := dbm.KVAccess.View(func(tx dbm.KVTx) error {
+		exists, err := tx.Get(TargetUrlsName, key, targetUrls)
+		if err == nil && !exists {
+			err = orm.ErrNoRows
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return targetUrls, nil
+}
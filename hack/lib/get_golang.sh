@@ -202,6 +202,8 @@ ALL_BINARIES_AND_TARGETS=(
   iptablesmanager:cloud/cmd/iptablesmanager
   edgemark:edge/cmd/edgemark
   controllermanager:cloud/cmd/controllermanager
+  simulator-mapper:mappers/simulator/cmd/simulator-mapper
+  modbus-mapper:mappers/modbus/cmd/modbus-mapper
 )
 
 kubeedge::golang::get_target_by_binary() {
@@ -423,16 +425,26 @@ kubeedge::golang::get_pkg_test_dirs() {
     echo "${dirArray[@]}"
 }
 
+kubeedge::golang::get_mappers_test_dirs() {
+    cd ${KUBEEDGE_ROOT}
+    findDirs=$(find -L ./mappers \
+	    -name '*_test.go' -print | xargs -n1 dirname | uniq)
+    dirArray=(${findDirs// /})
+    echo "${dirArray[@]}"
+}
+
 read -ra KUBEEDGE_CLOUD_TESTCASES <<< "$(kubeedge::golang::get_cloud_test_dirs)"
 read -ra KUBEEDGE_EDGE_TESTCASES <<< "$(kubeedge::golang::get_edge_test_dirs)"
 read -ra KUBEEDGE_KEADM_TESTCASES <<< "$(kubeedge::golang::get_keadm_test_dirs)"
 read -ra KUBEEDGE_PKG_TESTCASES <<< "$(kubeedge::golang::get_pkg_test_dirs)"
+read -ra KUBEEDGE_MAPPERS_TESTCASES <<< "$(kubeedge::golang::get_mappers_test_dirs)"
 
 readonly KUBEEDGE_ALL_TESTCASES=(
   ${KUBEEDGE_CLOUD_TESTCASES[@]}
   ${KUBEEDGE_EDGE_TESTCASES[@]}
   ${KUBEEDGE_KEADM_TESTCASES[@]}
   ${KUBEEDGE_PKG_TESTCASES[@]}
+  ${KUBEEDGE_MAPPERS_TESTCASES[@]}
 )
 
 ALL_COMPONENTS_AND_GETTESTDIRS_FUNCTIONS=(
@@ -440,6 +452,7 @@ ALL_COMPONENTS_AND_GETTESTDIRS_FUNCTIONS=(
   edge::::kubeedge::golang::get_edge_test_dirs
   keadm::::kubeedge::golang::get_keadm_test_dirs
   pkg::::kubeedge::golang::get_pkg_test_dirs
+  mappers::::kubeedge::golang::get_mappers_test_dirs
 )
 
 kubeedge::golang::get_testdirs_by_component() {
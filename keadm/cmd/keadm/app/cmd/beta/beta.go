@@ -25,11 +25,12 @@ func NewBeta() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "beta",
 		Short: "keadm beta command",
-		Long:  `keadm beta command provides some subcommands that are still in testing, but have complete functions and can be used in advance, but now it contains nothing`,
+		Long:  `keadm beta command provides some subcommands that are still in testing, but have complete functions and can be used in advance`,
 	}
 
 	cmd.ResetFlags()
 
 	// here we will retain the beta sum-commands, in future we can add some beta commands
+	cmd.AddCommand(NewMigrateDB())
 	return cmd
 }
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package beta
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+	"github.com/kubeedge/kubeedge/edge/pkg/devicetwin/dtclient"
+	eventbusdao "github.com/kubeedge/kubeedge/edge/pkg/eventbus/dao"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+	servicebusdao "github.com/kubeedge/kubeedge/edge/pkg/servicebus/dao"
+	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+)
+
+var (
+	migrateDBLongDescription = `
+"keadm beta migrate-db" copies the data of edgecore from a database to another one,
+it is used when DataBase.DriverName of edgecore is changed. Edgecore must be stopped
+before the migration, and the target must not exist.
+`
+	migrateDBExample = `
+# Migrate the sqlite database to the bolt key-value store
+keadm beta migrate-db --from-driver=sqlite3 --from=/var/lib/kubeedge/edgecore.db --to-driver=bolt --to=/var/lib/kubeedge/edgecore.bolt
+`
+)
+
+// MigrateDBOptions is the options of migrate-db command
+type MigrateDBOptions struct {
+	FromDriver string
+	From       string
+	ToDriver   string
+	To         string
+}
+
+// NewMigrateDB returns the command to migrate the edgecore database
+func NewMigrateDB() *cobra.Command {
+	opts := &MigrateDBOptions{}
+	cmd := &cobra.Command{
+		Use:     "migrate-db",
+		Short:   "Migrate the edgecore database between the storage backends",
+		Long:    migrateDBLongDescription,
+		Example: migrateDBExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.To == "" {
+				return fmt.Errorf("the target database must be specified by --to")
+			}
+			registerEdgeModels()
+			if err := dbm.Migrate(opts.FromDriver, opts.From, opts.ToDriver, opts.To); err != nil {
+				return err
+			}
+			fmt.Printf("Migrated %s to %s, set DataBase.DriverName to %s and DataBase.DataSource to %s in the edgecore config\n",
+				opts.From, opts.To, opts.ToDriver, opts.To)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.FromDriver, "from-driver", v1alpha2.DataBaseDriverName, "The driver of the source database, sqlite3 or bolt")
+	cmd.Flags().StringVar(&opts.From, "from", v1alpha2.DataBaseDataSource, "The source database")
+	cmd.Flags().StringVar(&opts.ToDriver, "to-driver", v1alpha2.DataBaseDriverBolt, "The driver of the target database, sqlite3 or bolt")
+	cmd.Flags().StringVar(&opts.To, "to", "", "The target database, which must not exist")
+	return cmd
+}
+
+// registerEdgeModels registers the tables of all edgecore modules
+func registerEdgeModels() {
+	dbm.RegisterModel(dao.MetaTableName, new(dao.Meta))
+	dbm.RegisterModel(v2.NewMetaTableName, new(v2.MetaV2))
+	dbm.RegisterModel(v2.PendingOperationTableName, new(v2.MetaPendingOperation))
+	dbm.RegisterModel(dtclient.DeviceTableName, new(dtclient.Device))
+	dbm.RegisterModel(dtclient.DeviceAttrTableName, new(dtclient.DeviceAttr))
+	dbm.RegisterModel(dtclient.DeviceTwinTableName, new(dtclient.DeviceTwin))
+	dbm.RegisterModel(eventbusdao.SubTopicsName, new(eventbusdao.SubTopics))
+	dbm.RegisterModel(servicebusdao.TargetUrlsName, new(servicebusdao.TargetUrls))
+}
@@ -39,6 +39,9 @@ import (
 	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
 	"github.com/kubeedge/kubeedge/edge/pkg/common/util"
 	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/encryption"
+	v2 "github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/v2"
+	"github.com/kubeedge/kubeedge/keadm/cmd/keadm/app/cmd/common"
 	edgecoreCfg "github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
 )
 
@@ -139,15 +142,17 @@ func addGetOtherFlags(cmd *cobra.Command, getOption *GetOptions) {
 	cmd.Flags().StringVarP(&getOption.Namespace, "namespace", "n", getOption.Namespace, "List the requested object(s) in specified namespaces")
 	cmd.Flags().StringVarP(getOption.PrintFlags.OutputFormat, "output", "o", *getOption.PrintFlags.OutputFormat, "Indicate the output format. Currently supports formats such as yaml|json|wide")
 	cmd.Flags().StringVarP(&getOption.LabelSelector, "selector", "l", getOption.LabelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
-	cmd.Flags().StringVarP(&getOption.DataPath, "edgedb-path", "p", getOption.DataPath, "Indicate the edge node database path, the default path is \"/var/lib/kubeedge/edgecore.db\"")
+	cmd.Flags().StringVarP(&getOption.DataPath, "edgedb-path", "p", getOption.DataPath, "Indicate the edge node database path, the default path is the data source in the EdgeCore config")
 	cmd.Flags().BoolVarP(&getOption.AllNamespace, "all-namespaces", "A", getOption.AllNamespace, "List the requested object(s) across all namespaces")
+	cmd.Flags().StringVarP(&getOption.Config, common.EdgecoreConfig, "c", getOption.Config,
+		fmt.Sprintf("Indicate the EdgeCore config file to decrypt the encrypted resources, default is %s", common.EdgecoreConfigPath))
 }
 
-// NewGetOptions returns a GetOptions with default EdgeCore database source.
+// NewGetOptions returns a GetOptions with default EdgeCore config.
 func NewGetOptions() *GetOptions {
 	opts := &GetOptions{
 		Namespace:  "default",
-		DataPath:   edgecoreCfg.DataBaseDataSource,
+		Config:     common.EdgecoreConfigPath,
 		PrintFlags: NewGetPrintFlags(),
 	}
 
@@ -160,6 +165,7 @@ type GetOptions struct {
 	Namespace     string
 	LabelSelector string
 	DataPath      string
+	Config        string
 
 	PrintFlags *PrintFlags
 }
@@ -224,16 +230,23 @@ func (g *GetOptions) Validate(args []string) error {
 	if !isAvailableResources(args[0]) {
 		return fmt.Errorf("unrecognized resource type: %v. ", args[0])
 	}
+	config, err := loadEdgeCoreConfig(g.Config)
+	if err != nil {
+		return err
+	}
 	if len(g.DataPath) == 0 {
-		fmt.Printf("not specified the EdgeCore database path, use the default path: %v. ", g.DataPath)
+		g.DataPath = config.DataBase.DataSource
 	}
 	if !isFileExist(g.DataPath) {
 		return fmt.Errorf("edgeCore database file %v not exist. ", g.DataPath)
 	}
 
-	if err := InitDB(edgecoreCfg.DataBaseDriverName, edgecoreCfg.DataBaseAliasName, g.DataPath); err != nil {
+	if err := InitDB(config.DataBase.DriverName, config.DataBase.AliasName, g.DataPath); err != nil {
 		return fmt.Errorf("failed to initialize database: %v ", err)
 	}
+	if err := initEncryption(config.Modules.MetaManager.Encryption); err != nil {
+		return fmt.Errorf("failed to initialize encryption: %v ", err)
+	}
 	if len(*g.PrintFlags.OutputFormat) > 0 {
 		format := strings.ToLower(*g.PrintFlags.OutputFormat)
 		g.PrintFlags.OutputFormat = &format
@@ -453,8 +466,17 @@ func isFileExist(path string) bool {
 	return err == nil || os.IsExist(err)
 }
 
-// InitDB Init DB info
+// InitDB Init DB info, the metas are read from the key-value store by the DAOs if the driver is bolt
 func InitDB(driverName, dbName, dataSource string) error {
+	if driverName == dbm.DriverBolt {
+		// the store is locked by the running EdgeCore
+		kv, err := dbm.OpenBoltStore(dataSource)
+		if err != nil {
+			return fmt.Errorf("%v, the store can not be read while EdgeCore is running ", err)
+		}
+		dbm.KVAccess = kv
+		return nil
+	}
 	if err := orm.RegisterDriver(driverName, orm.DRSqlite); err != nil {
 		return fmt.Errorf("failed to register driver: %v ", err)
 	}
@@ -464,7 +486,8 @@ func InitDB(driverName, dbName, dataSource string) error {
 		dataSource); err != nil {
 		return fmt.Errorf("failed to register db: %v ", err)
 	}
-	orm.RegisterModel(new(dao.Meta))
+	// the metas are read from meta_v2, which is migrated by edgecore at startup
+	orm.RegisterModel(new(dao.Meta), new(v2.MetaV2))
 
 	// create orm
 	dbm.DBAccess = orm.NewOrm()
@@ -474,6 +497,33 @@ func InitDB(driverName, dbName, dataSource string) error {
 	return nil
 }
 
+// loadEdgeCoreConfig parses the EdgeCore config file,
+// the default config is returned if the file does not exist
+func loadEdgeCoreConfig(path string) (*edgecoreCfg.EdgeCoreConfig, error) {
+	config := edgecoreCfg.NewDefaultEdgeCoreConfig()
+	if !isFileExist(path) {
+		return config, nil
+	}
+	if err := config.Parse(path); err != nil {
+		return nil, fmt.Errorf("failed to parse EdgeCore config %v: %v ", path, err)
+	}
+	return config, nil
+}
+
+// initEncryption sets the provider to decrypt the sensitive metas with the key of EdgeCore,
+// the key is never generated since the metas can not be decrypted with a new key
+func initEncryption(c *edgecoreCfg.MetaEncryption) error {
+	if c == nil || !c.Enable {
+		return nil
+	}
+	provider, err := encryption.NewAESGCMProvider(&encryption.FileKeySource{Path: c.KeyFile})
+	if err != nil {
+		return err
+	}
+	encryption.SetProvider(provider)
+	return nil
+}
+
 // IsExistName verify the filed in the resNames exists in the name
 func isExistName(resNames []string, name string) bool {
 	value := false
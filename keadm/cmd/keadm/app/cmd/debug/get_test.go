@@ -14,8 +14,16 @@ limitations under the License.
 package debug
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/kubeedge/edge/pkg/common/dbm"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao"
+	"github.com/kubeedge/kubeedge/edge/pkg/metamanager/dao/encryption"
 )
 
 func TestSplitSelectorParameters(t *testing.T) {
@@ -67,3 +75,75 @@ func TestSplitSelectorParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestInitEncryption(t *testing.T) {
+	defer encryption.SetProvider(nil)
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "meta.key")
+	p, err := encryption.NewAESGCMProvider(&encryption.FileKeySource{Path: keyFile, Generate: true})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	encrypted, err := p.Encrypt("plain")
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	configFile := filepath.Join(dir, "edgecore.yaml")
+	data := fmt.Sprintf("modules:\n  metaManager:\n    encryption:\n      enable: true\n      keyFile: %s\n", keyFile)
+	if err := os.WriteFile(configFile, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	config, err := loadEdgeCoreConfig(configFile)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if err := initEncryption(config.Modules.MetaManager.Encryption); err != nil {
+		t.Fatalf("failed to init encryption: %v", err)
+	}
+	if value, err := encryption.Decrypt(encrypted); err != nil || value != "plain" {
+		t.Errorf("expected value decrypted with the key of edgecore, got %q, %v", value, err)
+	}
+
+	// the key is not generated to decrypt the metas
+	config.Modules.MetaManager.Encryption.KeyFile = filepath.Join(dir, "missing.key")
+	if err := initEncryption(config.Modules.MetaManager.Encryption); err == nil {
+		t.Errorf("expected error with missing key file")
+	}
+	if _, err := os.Stat(config.Modules.MetaManager.Encryption.KeyFile); !os.IsNotExist(err) {
+		t.Errorf("expected key file not generated, got %v", err)
+	}
+}
+
+func TestInitDBBolt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "edgecore.bolt")
+	kv, err := dbm.OpenBoltStore(path)
+	if err != nil {
+		t.Fatalf("failed to open bolt store: %v", err)
+	}
+	dbm.KVAccess = kv
+	defer func() {
+		dbm.KVAccess.Close()
+		dbm.KVAccess = nil
+	}()
+	pod := `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"nginx","namespace":"default"}}`
+	if err := dao.InsertOrUpdate(&dao.Meta{Key: "default/pod/nginx", Type: model.ResourceTypePod, Value: pod}); err != nil {
+		t.Fatalf("failed to save meta: %v", err)
+	}
+	kv.Close()
+	dbm.KVAccess = nil
+
+	if err := InitDB(dbm.DriverBolt, "default", path); err != nil {
+		t.Fatalf("failed to init database: %v", err)
+	}
+	if !dbm.UseKV() {
+		t.Fatalf("expected metas read from the key-value store")
+	}
+	results, err := NewGetOptions().queryDataFromDatabase(model.ResourceTypePod, nil)
+	if err != nil {
+		t.Fatalf("failed to query pods: %v", err)
+	}
+	if len(results) != 1 || results[0].Value != pod {
+		t.Errorf("expected pod nginx, got %v", results)
+	}
+}
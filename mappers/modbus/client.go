@@ -0,0 +1,211 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// the function codes of Modbus
+const (
+	FuncReadCoils              byte = 0x01
+	FuncReadDiscreteInputs     byte = 0x02
+	FuncReadHoldingRegisters   byte = 0x03
+	FuncReadInputRegisters     byte = 0x04
+	FuncWriteSingleCoil        byte = 0x05
+	FuncWriteSingleRegister    byte = 0x06
+	FuncWriteMultipleCoils     byte = 0x0F
+	FuncWriteMultipleRegisters byte = 0x10
+
+	exceptionFlag byte = 0x80
+	coilOn             = 0xFF00
+
+	// maxReadBits and maxReadRegisters are the maximum quantities of a read request
+	maxReadBits      = 2000
+	maxReadRegisters = 125
+)
+
+// ExceptionError is the exception response of the slave
+type ExceptionError struct {
+	Function byte
+	Code     byte
+}
+
+func (e *ExceptionError) Error() string {
+	return fmt.Sprintf("modbus exception %d of function %d", e.Code, e.Function)
+}
+
+// TransportError is the error of sending the request or receiving the response,
+// the connection to the slave may be broken and should be created again
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// transporter sends the protocol data units to the slaves over TCP or a serial line
+type transporter interface {
+	// Send sends the request PDU to the slave and returns the response PDU
+	Send(slaveID byte, pdu []byte) ([]byte, error)
+	Close() error
+}
+
+// Client is a Modbus client of a slave, it is not safe for concurrent use
+type Client struct {
+	transporter transporter
+	slaveID     byte
+}
+
+// Close closes the connection to the slave
+func (c *Client) Close() error {
+	return c.transporter.Close()
+}
+
+// ReadCoils reads the quantity of coils from the address
+func (c *Client) ReadCoils(address, quantity uint16) ([]bool, error) {
+	return c.readBits(FuncReadCoils, address, quantity)
+}
+
+// ReadDiscreteInputs reads the quantity of discrete inputs from the address
+func (c *Client) ReadDiscreteInputs(address, quantity uint16) ([]bool, error) {
+	return c.readBits(FuncReadDiscreteInputs, address, quantity)
+}
+
+// ReadHoldingRegisters reads the quantity of holding registers from the address,
+// it returns the big-endian bytes of the registers
+func (c *Client) ReadHoldingRegisters(address, quantity uint16) ([]byte, error) {
+	return c.readRegisters(FuncReadHoldingRegisters, address, quantity)
+}
+
+// ReadInputRegisters reads the quantity of input registers from the address,
+// it returns the big-endian bytes of the registers
+func (c *Client) ReadInputRegisters(address, quantity uint16) ([]byte, error) {
+	return c.readRegisters(FuncReadInputRegisters, address, quantity)
+}
+
+// WriteSingleCoil writes the coil at the address
+func (c *Client) WriteSingleCoil(address uint16, value bool) error {
+	var v uint16
+	if value {
+		v = coilOn
+	}
+	_, err := c.send(FuncWriteSingleCoil, uint16Bytes(address, v), 4)
+	return err
+}
+
+// WriteSingleRegister writes the holding register at the address
+func (c *Client) WriteSingleRegister(address, value uint16) error {
+	_, err := c.send(FuncWriteSingleRegister, uint16Bytes(address, value), 4)
+	return err
+}
+
+// WriteMultipleCoils writes the coils from the address
+func (c *Client) WriteMultipleCoils(address uint16, values []bool) error {
+	data := packBits(values)
+	request := append(uint16Bytes(address, uint16(len(values))), byte(len(data)))
+	_, err := c.send(FuncWriteMultipleCoils, append(request, data...), 4)
+	return err
+}
+
+// WriteMultipleRegisters writes the big-endian bytes of the holding registers from the address
+func (c *Client) WriteMultipleRegisters(address uint16, data []byte) error {
+	if len(data) == 0 || len(data)%2 != 0 {
+		return fmt.Errorf("invalid length %d of registers data", len(data))
+	}
+	request := append(uint16Bytes(address, uint16(len(data)/2)), byte(len(data)))
+	_, err := c.send(FuncWriteMultipleRegisters, append(request, data...), 4)
+	return err
+}
+
+func (c *Client) readBits(function byte, address, quantity uint16) ([]bool, error) {
+	if quantity == 0 || quantity > maxReadBits {
+		return nil, fmt.Errorf("quantity %d must be between 1 and %d", quantity, maxReadBits)
+	}
+	data, err := c.readBytes(function, address, quantity, int(quantity+7)/8)
+	if err != nil {
+		return nil, err
+	}
+	return unpackBits(data, int(quantity)), nil
+}
+
+func (c *Client) readRegisters(function byte, address, quantity uint16) ([]byte, error) {
+	if quantity == 0 || quantity > maxReadRegisters {
+		return nil, fmt.Errorf("quantity %d must be between 1 and %d", quantity, maxReadRegisters)
+	}
+	return c.readBytes(function, address, quantity, int(quantity)*2)
+}
+
+// readBytes sends a read request and returns the data of the response which has the count of bytes
+func (c *Client) readBytes(function byte, address, quantity uint16, count int) ([]byte, error) {
+	response, err := c.send(function, uint16Bytes(address, quantity), 1+count)
+	if err != nil {
+		return nil, err
+	}
+	if int(response[0]) != count {
+		return nil, fmt.Errorf("response has %d bytes, want %d", response[0], count)
+	}
+	return response[1:], nil
+}
+
+// send sends the request of the function and returns the data of the response which has the length
+func (c *Client) send(function byte, data []byte, length int) ([]byte, error) {
+	response, err := c.transporter.Send(c.slaveID, append([]byte{function}, data...))
+	if err != nil {
+		return nil, &TransportError{Err: err}
+	}
+	if len(response) == 2 && response[0] == function|exceptionFlag {
+		return nil, &ExceptionError{Function: function, Code: response[1]}
+	}
+	if len(response) != 1+length || response[0] != function {
+		return nil, fmt.Errorf("invalid response % x of function %d", response, function)
+	}
+	return response[1:], nil
+}
+
+func uint16Bytes(values ...uint16) []byte {
+	data := make([]byte, 2*len(values))
+	for i, v := range values {
+		binary.BigEndian.PutUint16(data[2*i:], v)
+	}
+	return data
+}
+
+// packBits packs the bits in bytes, the first bit is the least significant bit of the first byte
+func packBits(values []bool) []byte {
+	data := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v {
+			data[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return data
+}
+
+func unpackBits(data []byte, quantity int) []bool {
+	values := make([]bool, quantity)
+	for i := range values {
+		values[i] = data[i/8]&(1<<uint(i%8)) != 0
+	}
+	return values
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testClient reads and writes all kinds of registers of the slave through the client
+func testClient(t *testing.T, s *slave, client *Client) {
+	s.discreteInputs[3] = true
+	s.inputRegisters[4] = 0x1234
+
+	if err := client.WriteSingleCoil(1, true); err != nil {
+		t.Fatalf("WriteSingleCoil: %v", err)
+	}
+	if err := client.WriteMultipleCoils(8, []bool{true, false, true}); err != nil {
+		t.Fatalf("WriteMultipleCoils: %v", err)
+	}
+	coils, err := client.ReadCoils(0, 11)
+	if err != nil {
+		t.Fatalf("ReadCoils: %v", err)
+	}
+	want := []bool{false, true, false, false, false, false, false, false, true, false, true}
+	if !reflect.DeepEqual(coils, want) {
+		t.Errorf("ReadCoils = %v, want %v", coils, want)
+	}
+
+	inputs, err := client.ReadDiscreteInputs(2, 2)
+	if err != nil {
+		t.Fatalf("ReadDiscreteInputs: %v", err)
+	}
+	if !reflect.DeepEqual(inputs, []bool{false, true}) {
+		t.Errorf("ReadDiscreteInputs = %v, want [false true]", inputs)
+	}
+
+	if err := client.WriteSingleRegister(10, 0xABCD); err != nil {
+		t.Fatalf("WriteSingleRegister: %v", err)
+	}
+	if err := client.WriteMultipleRegisters(11, []byte{0x01, 0x02, 0x03, 0x04}); err != nil {
+		t.Fatalf("WriteMultipleRegisters: %v", err)
+	}
+	registers, err := client.ReadHoldingRegisters(10, 3)
+	if err != nil {
+		t.Fatalf("ReadHoldingRegisters: %v", err)
+	}
+	if want := []byte{0xAB, 0xCD, 0x01, 0x02, 0x03, 0x04}; !reflect.DeepEqual(registers, want) {
+		t.Errorf("ReadHoldingRegisters = % x, want % x", registers, want)
+	}
+
+	registers, err = client.ReadInputRegisters(4, 1)
+	if err != nil {
+		t.Fatalf("ReadInputRegisters: %v", err)
+	}
+	if want := []byte{0x12, 0x34}; !reflect.DeepEqual(registers, want) {
+		t.Errorf("ReadInputRegisters = % x, want % x", registers, want)
+	}
+
+	_, err = client.ReadHoldingRegisters(slaveSize, 1)
+	var exception *ExceptionError
+	if !errors.As(err, &exception) || exception.Code != exceptionIllegalDataAddress {
+		t.Errorf("expected illegal data address exception, got %v", err)
+	}
+}
+
+func TestTCPClient(t *testing.T) {
+	s := &slave{id: 1}
+	client, err := NewTCPClient(s.serveTCP(t), 1, time.Second)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer client.Close()
+	testClient(t, s, client)
+}
+
+func TestTCPClientTimeout(t *testing.T) {
+	s := &slave{id: 1, failures: 1}
+	client, err := NewTCPClient(s.serveTCP(t), 1, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer client.Close()
+	if _, err := client.ReadCoils(0, 1); err == nil {
+		t.Errorf("expected timeout of unanswered request")
+	}
+}
+
+// pipeSerialLines returns the serial lines which open pipes instead of the serial ports,
+// the other ends of the pipes are served by serve
+func pipeSerialLines(serve func(port io.ReadWriteCloser)) *serialLines {
+	return &serialLines{
+		lines: make(map[string]*serialLine),
+		open: func(SerialConfig) (io.ReadWriteCloser, error) {
+			port, slavePort := net.Pipe()
+			go serve(slavePort)
+			return port, nil
+		},
+	}
+}
+
+func TestRTUClient(t *testing.T) {
+	s := &slave{id: 7}
+	lines := pipeSerialLines(s.serveRTU)
+	client, err := lines.newClient(SerialConfig{Port: "/dev/ttyS0"}, 7, time.Second)
+	if err != nil {
+		t.Fatalf("failed to create rtu client: %v", err)
+	}
+	defer client.Close()
+	testClient(t, s, client)
+}
+
+func TestRTUClientsShareSerialLine(t *testing.T) {
+	slaves := []*slave{{id: 1}, {id: 2}}
+	frames := make(chan time.Time, 100)
+	opened := 0
+	lines := pipeSerialLines(func(port io.ReadWriteCloser) {
+		opened++
+		serveRTUSlaves(port, frames, slaves...)
+	})
+	config := SerialConfig{Port: "/dev/ttyS0", BaudRate: 9600, DataBits: 8, Parity: "even", StopBits: 1}
+
+	var clients []*Client
+	for _, s := range slaves {
+		client, err := lines.newClient(config, s.id, time.Second)
+		if err != nil {
+			t.Fatalf("failed to create rtu client of slave %d: %v", s.id, err)
+		}
+		clients = append(clients, client)
+	}
+	other := config
+	other.BaudRate = 19200
+	if _, err := lines.newClient(other, 3, time.Second); err == nil {
+		t.Errorf("expected error of opening the serial port with another config")
+	}
+
+	// the clients of the slaves on the same port send the requests concurrently
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*len(clients))
+	for _, client := range clients {
+		wg.Add(1)
+		go func(client *Client) {
+			defer wg.Done()
+			for i := 0; i < 5; i++ {
+				value := uint16(client.slaveID)*10 + uint16(i)
+				if err := client.WriteSingleRegister(0, value); err != nil {
+					errs <- err
+					return
+				}
+				if data, err := client.ReadHoldingRegisters(0, 1); err != nil || binary.BigEndian.Uint16(data) != value {
+					errs <- fmt.Errorf("read of slave %d = % x, %v, want %d", client.slaveID, data, err, value)
+					return
+				}
+			}
+		}(client)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	for _, s := range slaves {
+		if want := uint16(s.id)*10 + 4; s.holdingRegisters[0] != want {
+			t.Errorf("holding register of slave %d = %d, want %d", s.id, s.holdingRegisters[0], want)
+		}
+	}
+
+	// the port is closed after all clients are closed
+	_ = clients[0].Close()
+	_ = clients[0].Close()
+	if _, err := clients[1].ReadHoldingRegisters(0, 1); err != nil {
+		t.Errorf("failed to read after the other client is closed: %v", err)
+	}
+	_ = clients[1].Close()
+
+	// the frames are the requests and the responses in turn, they are closed with the port
+	var times []time.Time
+	for frame := range frames {
+		times = append(times, frame)
+	}
+	if opened != 1 || len(lines.lines) != 0 {
+		t.Errorf("serial port is opened %d times and %d lines are left, want opened once and closed", opened, len(lines.lines))
+	}
+	delay := frameDelay(config.BaudRate)
+	for i := 2; i < len(times); i += 2 {
+		if silence := times[i].Sub(times[i-1]); silence < delay {
+			t.Errorf("silence before request %d is %v, want at least %v", i/2, silence, delay)
+		}
+	}
+}
+
+func TestFrameDelay(t *testing.T) {
+	if delay := frameDelay(9600); delay < 4*time.Millisecond || delay > 4100*time.Microsecond {
+		t.Errorf("frame delay at 9600 baud = %v, want about 4ms", delay)
+	}
+	if delay := frameDelay(115200); delay != minFrameDelay {
+		t.Errorf("frame delay at 115200 baud = %v, want %v", delay, minFrameDelay)
+	}
+}
+
+func TestCRC16(t *testing.T) {
+	// the check value of CRC-16/MODBUS
+	if crc := crc16([]byte("123456789")); crc != 0x4B37 {
+		t.Errorf("crc16 = %#x, want 0x4b37", crc)
+	}
+}
+
+func TestPackBits(t *testing.T) {
+	bits := []bool{true, false, false, true, false, false, false, false, false, true}
+	data := packBits(bits)
+	if want := []byte{0x09, 0x02}; !reflect.DeepEqual(data, want) {
+		t.Errorf("packBits = % x, want % x", data, want)
+	}
+	if unpacked := unpackBits(data, len(bits)); !reflect.DeepEqual(unpacked, bits) {
+		t.Errorf("unpackBits = %v, want %v", unpacked, bits)
+	}
+}
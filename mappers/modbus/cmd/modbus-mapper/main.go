@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	apiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/kubeedge/mappers/modbus"
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+)
+
+func main() {
+	command := newModbusMapperCommand()
+	if err := command.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// newModbusMapperCommand creates a *cobra.Command object with default parameters
+func newModbusMapperCommand() *cobra.Command {
+	var configFile string
+	cmd := &cobra.Command{
+		Use: "modbus-mapper",
+		Long: `The modbus mapper accesses the devices of the modbus protocol over Modbus TCP or Modbus RTU.
+It registers to edgecore through DMI, reports the values of the coils and registers of the properties,
+and writes the desired values of the ReadWrite properties to the devices.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := modbus.LoadConfig(configFile)
+			if err != nil {
+				return err
+			}
+			return sdk.NewMapper(config.Config, modbus.NewDriver()).Run(apiserver.SetupSignalContext())
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			for _, arg := range args {
+				if len(arg) > 0 {
+					return fmt.Errorf("%q does not take any arguments, got %q", cmd.CommandPath(), args)
+				}
+			}
+			return nil
+		},
+	}
+
+	fs := cmd.Flags()
+	klog.InitFlags(flag.CommandLine)
+	fs.AddGoFlagSet(flag.CommandLine)
+	fs.StringVar(&configFile, "config", "", "The path to the configuration file of the Modbus mapper.")
+	return cmd
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+)
+
+// Codec converts the values of a property from and to the registers by the visitor of the property.
+//
+// The registers are big-endian, IsRegisterSwap reverses the order of the registers and IsSwap swaps
+// the high and low bytes of each register. The swapped bytes are decoded as:
+//   - boolean: true if any byte is not zero
+//   - int: the signed integer of 1, 2 or 4 registers multiplied by the scale
+//   - float: the signed integer of 1 register, or the IEEE 754 number of 2 or 4 registers,
+//     multiplied by the scale
+//   - string: the characters of the registers without the trailing zeros
+//
+// The scale is 1 if it is not set, the values are divided by the scale before they are written.
+type Codec struct {
+	// Type is the type of the property
+	Type string
+	// Registers is the number of registers of the property
+	Registers      int
+	Scale          float64
+	IsSwap         bool
+	IsRegisterSwap bool
+}
+
+// scale returns the scale of the codec, it is 1 if not set
+func (c *Codec) scale() float64 {
+	if c.Scale == 0 {
+		return 1
+	}
+	return c.Scale
+}
+
+// swap applies the swaps to the bytes of the registers, it is its own inverse
+func (c *Codec) swap(data []byte) []byte {
+	swapped := make([]byte, len(data))
+	copy(swapped, data)
+	n := len(swapped) / 2
+	if c.IsRegisterSwap {
+		for i := 0; i < n/2; i++ {
+			j := n - 1 - i
+			swapped[2*i], swapped[2*j] = swapped[2*j], swapped[2*i]
+			swapped[2*i+1], swapped[2*j+1] = swapped[2*j+1], swapped[2*i+1]
+		}
+	}
+	if c.IsSwap {
+		for i := 0; i < n; i++ {
+			swapped[2*i], swapped[2*i+1] = swapped[2*i+1], swapped[2*i]
+		}
+	}
+	return swapped
+}
+
+// Decode converts the bytes of the registers to the value of the property
+func (c *Codec) Decode(data []byte) (string, error) {
+	if len(data) != 2*c.Registers {
+		return "", fmt.Errorf("got %d bytes of %d registers", len(data), c.Registers)
+	}
+	data = c.swap(data)
+	switch c.Type {
+	case sdk.TypeBoolean:
+		return strconv.FormatBool(bytes.Count(data, []byte{0}) != len(data)), nil
+	case sdk.TypeString:
+		return string(bytes.TrimRight(data, "\x00")), nil
+	case sdk.TypeInt:
+		v, err := decodeInt(data)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(int64(math.Round(float64(v)*c.scale())), 10), nil
+	case sdk.TypeFloat:
+		var v float64
+		switch len(data) {
+		case 2:
+			v = float64(int16(binary.BigEndian.Uint16(data)))
+		case 4:
+			v = float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+		case 8:
+			v = math.Float64frombits(binary.BigEndian.Uint64(data))
+		default:
+			return "", fmt.Errorf("float property must have 1, 2 or 4 registers")
+		}
+		return strconv.FormatFloat(v*c.scale(), 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported property type %s", c.Type)
+	}
+}
+
+// Encode converts the value of the property to the bytes of the registers
+func (c *Codec) Encode(value string) ([]byte, error) {
+	data := make([]byte, 2*c.Registers)
+	switch c.Type {
+	case sdk.TypeBoolean:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %s", value)
+		}
+		if v && len(data) > 0 {
+			data[len(data)-1] = 1
+		}
+	case sdk.TypeString:
+		if len(value) > len(data) {
+			return nil, fmt.Errorf("string %s is longer than %d registers", value, c.Registers)
+		}
+		copy(data, value)
+	case sdk.TypeInt:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int %s", value)
+		}
+		if err := encodeInt(data, int64(math.Round(v/c.scale()))); err != nil {
+			return nil, err
+		}
+	case sdk.TypeFloat:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float %s", value)
+		}
+		v /= c.scale()
+		switch len(data) {
+		case 2:
+			if err := encodeInt(data, int64(math.Round(v))); err != nil {
+				return nil, err
+			}
+		case 4:
+			binary.BigEndian.PutUint32(data, math.Float32bits(float32(v)))
+		case 8:
+			binary.BigEndian.PutUint64(data, math.Float64bits(v))
+		default:
+			return nil, fmt.Errorf("float property must have 1, 2 or 4 registers")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported property type %s", c.Type)
+	}
+	return c.swap(data), nil
+}
+
+// DecodeBits converts the coils or the discrete inputs to the value of the property,
+// the boolean property has one bit and the int property is the unsigned integer of the bits, the first bit is the lowest
+func (c *Codec) DecodeBits(bits []bool) (string, error) {
+	switch c.Type {
+	case sdk.TypeBoolean:
+		return strconv.FormatBool(bits[0]), nil
+	case sdk.TypeInt:
+		var v int64
+		for i, bit := range bits {
+			if bit {
+				v |= 1 << uint(i)
+			}
+		}
+		return strconv.FormatInt(v, 10), nil
+	default:
+		return "", fmt.Errorf("property of type %s can not be read from coils or discrete inputs", c.Type)
+	}
+}
+
+// EncodeBits converts the value of the property to the count of coils
+func (c *Codec) EncodeBits(value string, count int) ([]bool, error) {
+	bits := make([]bool, count)
+	switch c.Type {
+	case sdk.TypeBoolean:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %s", value)
+		}
+		bits[0] = v
+	case sdk.TypeInt:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v < 0 || (count < 63 && v >= 1<<uint(count)) {
+			return nil, fmt.Errorf("int %s does not fit in %d coils", value, count)
+		}
+		for i := range bits {
+			bits[i] = v&(1<<uint(i)) != 0
+		}
+	default:
+		return nil, fmt.Errorf("property of type %s can not be written to coils", c.Type)
+	}
+	return bits, nil
+}
+
+func decodeInt(data []byte) (int64, error) {
+	switch len(data) {
+	case 2:
+		return int64(int16(binary.BigEndian.Uint16(data))), nil
+	case 4:
+		return int64(int32(binary.BigEndian.Uint32(data))), nil
+	case 8:
+		return int64(binary.BigEndian.Uint64(data)), nil
+	default:
+		return 0, fmt.Errorf("int property must have 1, 2 or 4 registers")
+	}
+}
+
+func encodeInt(data []byte, v int64) error {
+	switch len(data) {
+	case 2:
+		if v < math.MinInt16 || v > math.MaxInt16 {
+			return fmt.Errorf("%d does not fit in 1 register", v)
+		}
+		binary.BigEndian.PutUint16(data, uint16(v))
+	case 4:
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			return fmt.Errorf("%d does not fit in 2 registers", v)
+		}
+		binary.BigEndian.PutUint32(data, uint32(v))
+	case 8:
+		binary.BigEndian.PutUint64(data, uint64(v))
+	default:
+		return fmt.Errorf("int property must have 1, 2 or 4 registers")
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+)
+
+func TestCodec(t *testing.T) {
+	cases := []struct {
+		name  string
+		codec Codec
+		data  []byte
+		value string
+	}{
+		{
+			name:  "int16",
+			codec: Codec{Type: sdk.TypeInt, Registers: 1},
+			data:  []byte{0xFF, 0xFE},
+			value: "-2",
+		},
+		{
+			name:  "int32 with register swap",
+			codec: Codec{Type: sdk.TypeInt, Registers: 2, IsRegisterSwap: true},
+			data:  []byte{0x00, 0x02, 0x00, 0x01},
+			value: "65538",
+		},
+		{
+			name:  "int64 with byte swap",
+			codec: Codec{Type: sdk.TypeInt, Registers: 4, IsSwap: true},
+			data:  []byte{0, 0, 0, 0, 0, 0, 0x01, 0x00},
+			value: "1",
+		},
+		{
+			name:  "scaled int",
+			codec: Codec{Type: sdk.TypeInt, Registers: 1, Scale: 10},
+			data:  []byte{0x00, 0x0C},
+			value: "120",
+		},
+		{
+			name:  "scaled int16 float",
+			codec: Codec{Type: sdk.TypeFloat, Registers: 1, Scale: 0.1},
+			data:  []byte{0x00, 0xFB},
+			value: "25.1",
+		},
+		{
+			name:  "float32 with both swaps",
+			codec: Codec{Type: sdk.TypeFloat, Registers: 2, IsSwap: true, IsRegisterSwap: true},
+			// 1.5 is 0x3FC00000
+			data:  []byte{0x00, 0x00, 0xC0, 0x3F},
+			value: "1.5",
+		},
+		{
+			name:  "float64",
+			codec: Codec{Type: sdk.TypeFloat, Registers: 4},
+			// -0.25 is 0xBFD0000000000000
+			data:  []byte{0xBF, 0xD0, 0, 0, 0, 0, 0, 0},
+			value: "-0.25",
+		},
+		{
+			name:  "boolean",
+			codec: Codec{Type: sdk.TypeBoolean, Registers: 1},
+			data:  []byte{0x00, 0x01},
+			value: "true",
+		},
+		{
+			name:  "string",
+			codec: Codec{Type: sdk.TypeString, Registers: 3},
+			data:  []byte{'o', 'k', '!', 0, 0, 0},
+			value: "ok!",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			value, err := c.codec.Decode(c.data)
+			if err != nil || value != c.value {
+				t.Errorf("Decode = %q, %v, want %q", value, err, c.value)
+			}
+			data, err := c.codec.Encode(c.value)
+			if err != nil || !reflect.DeepEqual(data, c.data) {
+				t.Errorf("Encode = % x, %v, want % x", data, err, c.data)
+			}
+		})
+	}
+}
+
+func TestCodecErrors(t *testing.T) {
+	if _, err := (&Codec{Type: sdk.TypeInt, Registers: 2}).Decode([]byte{0, 1}); err == nil {
+		t.Errorf("expected error of wrong length")
+	}
+	if _, err := (&Codec{Type: sdk.TypeFloat, Registers: 3}).Decode(make([]byte, 6)); err == nil {
+		t.Errorf("expected error of float with 3 registers")
+	}
+	if _, err := (&Codec{Type: sdk.TypeInt, Registers: 1}).Encode("40000"); err == nil {
+		t.Errorf("expected error of int overflowing a register")
+	}
+	if _, err := (&Codec{Type: sdk.TypeString, Registers: 1}).Encode("abc"); err == nil {
+		t.Errorf("expected error of too long string")
+	}
+	if _, err := (&Codec{Type: sdk.TypeBoolean, Registers: 1}).Encode("yes"); err == nil {
+		t.Errorf("expected error of invalid boolean")
+	}
+}
+
+func TestCodecBits(t *testing.T) {
+	codec := &Codec{Type: sdk.TypeInt}
+	bits, err := codec.EncodeBits("5", 4)
+	if err != nil || !reflect.DeepEqual(bits, []bool{true, false, true, false}) {
+		t.Errorf("EncodeBits = %v, %v", bits, err)
+	}
+	if value, err := codec.DecodeBits(bits); err != nil || value != "5" {
+		t.Errorf("DecodeBits = %q, %v, want 5", value, err)
+	}
+	if _, err := codec.EncodeBits("16", 4); err == nil {
+		t.Errorf("expected error of int overflowing the coils")
+	}
+
+	codec = &Codec{Type: sdk.TypeBoolean}
+	if value, err := codec.DecodeBits([]bool{true}); err != nil || value != "true" {
+		t.Errorf("DecodeBits = %q, %v, want true", value, err)
+	}
+	if _, err := (&Codec{Type: sdk.TypeFloat}).DecodeBits([]bool{true}); err == nil {
+		t.Errorf("expected error of float coils")
+	}
+}
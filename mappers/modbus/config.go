@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+)
+
+const (
+	// DefaultName is the default name of the Modbus mapper
+	DefaultName = "modbus-mapper"
+	// DefaultProtocol is the protocol served by the Modbus mapper
+	DefaultProtocol = "modbus"
+	// DefaultAddress is the default unix socket the Modbus mapper listens on
+	DefaultAddress = "/etc/kubeedge/modbus.sock"
+)
+
+// Config is the configuration of the Modbus mapper
+type Config struct {
+	sdk.Config
+}
+
+// NewDefaultConfig returns a config with the default values
+func NewDefaultConfig() *Config {
+	return &Config{
+		Config: sdk.NewDefaultConfig(DefaultName, DefaultProtocol, DefaultAddress),
+	}
+}
+
+// LoadConfig loads the config from the yaml file, the fields which are not set keep the default values
+func LoadConfig(path string) (*Config, error) {
+	config := NewDefaultConfig()
+	if path == "" {
+		return config, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %v", path, err)
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	pb "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
+)
+
+// DefaultTimeout is the timeout of the requests to the devices if the device does not set collectTimeout
+const DefaultTimeout = time.Second
+
+// Driver accesses the devices over Modbus TCP or Modbus RTU.
+//
+// A device connects over TCP if protocol.common.tcp is set, otherwise over the serial port of protocol.common.com,
+// the devices on the same serial port share it.
+// protocol.common.collectTimeout is the timeout of each request in milliseconds and the reads are retried
+// protocol.common.collectRetryTimes times.
+type Driver struct {
+	newTCPClient func(address string, slaveID byte, timeout time.Duration) (*Client, error)
+	newRTUClient func(config SerialConfig, slaveID byte, timeout time.Duration) (*Client, error)
+}
+
+// NewDriver creates the driver of the Modbus mapper
+func NewDriver() *Driver {
+	return &Driver{newTCPClient: NewTCPClient, newRTUClient: NewRTUClient}
+}
+
+// modbusDevice keeps the connection to a device
+type modbusDevice struct {
+	// client is nil after it is closed for a transport error, and created again by the next request
+	client     *Client
+	dial       func() (*Client, error)
+	retryTimes int
+}
+
+// getClient returns the client of the device, it creates the client if the previous one is closed
+func (m *modbusDevice) getClient() (*Client, error) {
+	if m.client == nil {
+		client, err := m.dial()
+		if err != nil {
+			return nil, &TransportError{Err: err}
+		}
+		m.client = client
+	}
+	return m.client, nil
+}
+
+// checkError closes the client if the error is a transport error, the connection may be broken
+// or receive the late responses of the timed out requests
+func (m *modbusDevice) checkError(err error) {
+	var transportErr *TransportError
+	if m.client != nil && errors.As(err, &transportErr) {
+		_ = m.client.Close()
+		m.client = nil
+	}
+}
+
+// Connect connects to the slave of the device
+func (d *Driver) Connect(ctx context.Context, device *sdk.Device) error {
+	protocol := device.Instance.GetSpec().GetProtocol()
+	if protocol.GetModbus() == nil {
+		return fmt.Errorf("device %s has no modbus protocol config", device.Name)
+	}
+	slaveID := protocol.GetModbus().SlaveID
+	if slaveID < 0 || slaveID > 255 {
+		return fmt.Errorf("invalid slave id %d", slaveID)
+	}
+	common := protocol.GetCommon()
+	timeout := DefaultTimeout
+	if common.GetCollectTimeout() > 0 {
+		timeout = time.Duration(common.GetCollectTimeout()) * time.Millisecond
+	}
+
+	var dial func() (*Client, error)
+	switch {
+	case common.GetTcp() != nil:
+		address := net.JoinHostPort(common.GetTcp().Ip, strconv.FormatInt(common.GetTcp().Port, 10))
+		dial = func() (*Client, error) {
+			return d.newTCPClient(address, byte(slaveID), timeout)
+		}
+	case common.GetCom() != nil:
+		com := common.GetCom()
+		config := SerialConfig{
+			Port:     com.SerialPort,
+			BaudRate: int(com.BaudRate),
+			DataBits: int(com.DataBits),
+			Parity:   com.Parity,
+			StopBits: int(com.StopBits),
+		}
+		dial = func() (*Client, error) {
+			return d.newRTUClient(config, byte(slaveID), timeout)
+		}
+	default:
+		return fmt.Errorf("device %s has neither tcp nor com config", device.Name)
+	}
+	client, err := dial()
+	if err != nil {
+		return fmt.Errorf("failed to connect to device %s: %v", device.Name, err)
+	}
+	device.Client = &modbusDevice{client: client, dial: dial, retryTimes: int(common.GetCollectRetryTimes())}
+	return nil
+}
+
+// ReadProperty reads the registers of the property and decodes the value,
+// the read is retried collectRetryTimes times if it fails, and the client is created again
+// before retrying if the read fails for a transport error
+func (d *Driver) ReadProperty(ctx context.Context, device *sdk.Device, property *sdk.Property) (string, error) {
+	modbus := device.Client.(*modbusDevice)
+	visitor, codec, err := newCodec(property)
+	if err != nil {
+		return "", err
+	}
+	var value string
+	for i := 0; i <= modbus.retryTimes; i++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err == nil {
+				err = ctxErr
+			}
+			return "", err
+		}
+		var client *Client
+		if client, err = modbus.getClient(); err == nil {
+			if value, err = read(client, visitor, codec); err == nil {
+				return value, nil
+			}
+			modbus.checkError(err)
+		}
+		klog.V(4).Infof("Failed to read property %s of device %s: %v", property.Name, device.Name, err)
+	}
+	return "", err
+}
+
+// WriteProperty encodes the value and writes the coils or the holding registers of the property,
+// the client is created again by the next request if the write fails for a transport error
+func (d *Driver) WriteProperty(ctx context.Context, device *sdk.Device, property *sdk.Property, value string) error {
+	modbus := device.Client.(*modbusDevice)
+	visitor, codec, err := newCodec(property)
+	if err != nil {
+		return err
+	}
+	client, err := modbus.getClient()
+	if err != nil {
+		return err
+	}
+	err = write(client, visitor, codec, value)
+	modbus.checkError(err)
+	return err
+}
+
+// write encodes the value and writes the coils or the holding registers of the visitor
+func write(client *Client, visitor *pb.VisitorConfigModbus, codec *Codec, value string) error {
+	address := uint16(visitor.Offset)
+	switch v1alpha2.ModbusRegisterType(visitor.Register) {
+	case v1alpha2.ModbusRegisterTypeCoilRegister:
+		bits, err := codec.EncodeBits(value, codec.Registers)
+		if err != nil {
+			return err
+		}
+		if len(bits) == 1 {
+			return client.WriteSingleCoil(address, bits[0])
+		}
+		return client.WriteMultipleCoils(address, bits)
+	case v1alpha2.ModbusRegisterTypeHoldingRegister:
+		data, err := codec.Encode(value)
+		if err != nil {
+			return err
+		}
+		if len(data) == 2 {
+			return client.WriteSingleRegister(address, uint16(data[0])<<8|uint16(data[1]))
+		}
+		return client.WriteMultipleRegisters(address, data)
+	default:
+		return fmt.Errorf("register %s is not writable", visitor.Register)
+	}
+}
+
+// Close closes the connection to the device
+func (d *Driver) Close(ctx context.Context, device *sdk.Device) error {
+	if modbus, ok := device.Client.(*modbusDevice); ok && modbus.client != nil {
+		return modbus.client.Close()
+	}
+	return nil
+}
+
+// newCodec returns the modbus visitor of the property and its codec, the limit is 1 if not set
+func newCodec(property *sdk.Property) (*pb.VisitorConfigModbus, *Codec, error) {
+	visitor := property.Visitor.GetModbus()
+	if visitor == nil {
+		return nil, nil, fmt.Errorf("property %s has no modbus visitor", property.Name)
+	}
+	if visitor.Offset < 0 || visitor.Offset > 0xFFFF {
+		return nil, nil, fmt.Errorf("invalid offset %d of property %s", visitor.Offset, property.Name)
+	}
+	limit := visitor.Limit
+	if limit == 0 {
+		limit = 1
+	}
+	if limit < 0 || limit > maxReadRegisters {
+		return nil, nil, fmt.Errorf("invalid limit %d of property %s", visitor.Limit, property.Name)
+	}
+	return visitor, &Codec{
+		Type:           property.Type,
+		Registers:      int(limit),
+		Scale:          visitor.Scale,
+		IsSwap:         visitor.IsSwap,
+		IsRegisterSwap: visitor.IsRegisterSwap,
+	}, nil
+}
+
+// read reads the registers of the visitor and decodes the value
+func read(client *Client, visitor *pb.VisitorConfigModbus, codec *Codec) (string, error) {
+	address, quantity := uint16(visitor.Offset), uint16(codec.Registers)
+	switch v1alpha2.ModbusRegisterType(visitor.Register) {
+	case v1alpha2.ModbusRegisterTypeCoilRegister:
+		bits, err := client.ReadCoils(address, quantity)
+		if err != nil {
+			return "", err
+		}
+		return codec.DecodeBits(bits)
+	case v1alpha2.ModbusRegisterTypeDiscreteInputRegister:
+		bits, err := client.ReadDiscreteInputs(address, quantity)
+		if err != nil {
+			return "", err
+		}
+		return codec.DecodeBits(bits)
+	case v1alpha2.ModbusRegisterTypeHoldingRegister:
+		data, err := client.ReadHoldingRegisters(address, quantity)
+		if err != nil {
+			return "", err
+		}
+		return codec.Decode(data)
+	case v1alpha2.ModbusRegisterTypeInputRegister:
+		data, err := client.ReadInputRegisters(address, quantity)
+		if err != nil {
+			return "", err
+		}
+		return codec.Decode(data)
+	default:
+		return "", fmt.Errorf("unknown register %s", visitor.Register)
+	}
+}
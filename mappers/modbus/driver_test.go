@@ -0,0 +1,253 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+	pb "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
+)
+
+func newTestProperty(name, propertyType, accessMode string, visitor *pb.VisitorConfigModbus) *sdk.Property {
+	return &sdk.Property{
+		Name:       name,
+		Type:       propertyType,
+		AccessMode: accessMode,
+		Visitor:    &pb.DevicePropertyVisitor{PropertyName: name, Modbus: visitor},
+	}
+}
+
+func newTestDevice(t *testing.T, address string, retryTimes int64) *sdk.Device {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		t.Fatalf("invalid address %s: %v", address, err)
+	}
+	portNumber, _ := strconv.ParseInt(port, 10, 64)
+	return &sdk.Device{
+		Name: "thermometer",
+		Instance: &pb.Device{
+			Name: "thermometer",
+			Spec: &pb.DeviceSpec{
+				Protocol: &pb.ProtocolConfig{
+					Modbus: &pb.ProtocolConfigModbus{SlaveID: 1},
+					Common: &pb.ProtocolConfigCommon{
+						Tcp:               &pb.ProtocolConfigTCP{Ip: host, Port: portNumber},
+						CollectTimeout:    100,
+						CollectRetryTimes: retryTimes,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDriver(t *testing.T) {
+	s := &slave{id: 1}
+	s.inputRegisters[0] = 251
+	// 100000 with swapped registers
+	s.inputRegisters[1], s.inputRegisters[2] = 0x86A0, 0x0001
+	s.discreteInputs[5] = true
+
+	ctx := context.Background()
+	driver := NewDriver()
+	device := newTestDevice(t, s.serveTCP(t), 0)
+	if err := driver.Connect(ctx, device); err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer driver.Close(ctx, device)
+
+	reads := []struct {
+		property *sdk.Property
+		value    string
+	}{
+		{
+			property: newTestProperty("temperature", sdk.TypeFloat, "ReadOnly",
+				&pb.VisitorConfigModbus{Register: "InputRegister", Offset: 0, Scale: 0.1}),
+			value: "25.1",
+		},
+		{
+			property: newTestProperty("counter", sdk.TypeInt, "ReadOnly",
+				&pb.VisitorConfigModbus{Register: "InputRegister", Offset: 1, Limit: 2, IsRegisterSwap: true}),
+			value: "100000",
+		},
+		{
+			property: newTestProperty("alarm", sdk.TypeBoolean, "ReadOnly",
+				&pb.VisitorConfigModbus{Register: "DiscreteInputRegister", Offset: 5}),
+			value: "true",
+		},
+	}
+	for _, r := range reads {
+		value, err := driver.ReadProperty(ctx, device, r.property)
+		if err != nil || value != r.value {
+			t.Errorf("read %s = %q, %v, want %q", r.property.Name, value, err, r.value)
+		}
+	}
+
+	writes := []struct {
+		property *sdk.Property
+		value    string
+		check    func() bool
+	}{
+		{
+			property: newTestProperty("target", sdk.TypeFloat, "ReadWrite",
+				&pb.VisitorConfigModbus{Register: "HoldingRegister", Offset: 10, Scale: 0.5}),
+			value: "21.5",
+			check: func() bool { return s.holdingRegisters[10] == 43 },
+		},
+		{
+			property: newTestProperty("limit", sdk.TypeFloat, "ReadWrite",
+				&pb.VisitorConfigModbus{Register: "HoldingRegister", Offset: 20, Limit: 2}),
+			value: "1.5",
+			check: func() bool { return s.holdingRegisters[20] == 0x3FC0 && s.holdingRegisters[21] == 0 },
+		},
+		{
+			property: newTestProperty("power", sdk.TypeBoolean, "ReadWrite",
+				&pb.VisitorConfigModbus{Register: "CoilRegister", Offset: 3}),
+			value: "true",
+			check: func() bool { return s.coils[3] },
+		},
+		{
+			property: newTestProperty("mode", sdk.TypeInt, "ReadWrite",
+				&pb.VisitorConfigModbus{Register: "CoilRegister", Offset: 6, Limit: 3}),
+			value: "6",
+			check: func() bool { return !s.coils[6] && s.coils[7] && s.coils[8] },
+		},
+	}
+	for _, w := range writes {
+		if err := driver.WriteProperty(ctx, device, w.property, w.value); err != nil {
+			t.Errorf("failed to write %s: %v", w.property.Name, err)
+			continue
+		}
+		s.Lock()
+		ok := w.check()
+		s.Unlock()
+		if !ok {
+			t.Errorf("unexpected registers after writing %s", w.property.Name)
+		}
+		if value, err := driver.ReadProperty(ctx, device, w.property); err != nil || value != w.value {
+			t.Errorf("read %s = %q, %v, want %q", w.property.Name, value, err, w.value)
+		}
+	}
+
+	if err := driver.WriteProperty(ctx, device, reads[0].property, "20"); err == nil {
+		t.Errorf("expected error of writing input register")
+	}
+	if _, err := driver.ReadProperty(ctx, device, &sdk.Property{Name: "unknown", Visitor: &pb.DevicePropertyVisitor{}}); err == nil {
+		t.Errorf("expected error of property without modbus visitor")
+	}
+}
+
+func TestDriverRetry(t *testing.T) {
+	s := &slave{id: 1, failures: 2}
+	s.holdingRegisters[0] = 42
+	property := newTestProperty("level", sdk.TypeInt, "ReadOnly",
+		&pb.VisitorConfigModbus{Register: "HoldingRegister", Offset: 0})
+
+	ctx := context.Background()
+	driver := NewDriver()
+	device := newTestDevice(t, s.serveTCP(t), 2)
+	if err := driver.Connect(ctx, device); err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer driver.Close(ctx, device)
+
+	value, err := driver.ReadProperty(ctx, device, property)
+	if err != nil || value != "42" {
+		t.Errorf("read = %q, %v, want 42 after retries", value, err)
+	}
+}
+
+func TestDriverReconnect(t *testing.T) {
+	s := &slave{id: 1}
+	s.holdingRegisters[0] = 42
+	property := newTestProperty("level", sdk.TypeInt, "ReadWrite",
+		&pb.VisitorConfigModbus{Register: "HoldingRegister", Offset: 0})
+
+	ctx := context.Background()
+	driver := NewDriver()
+	device := newTestDevice(t, s.serveTCP(t), 1)
+	if err := driver.Connect(ctx, device); err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer driver.Close(ctx, device)
+
+	// the connection is broken, the read is retried with a new client
+	modbus := device.Client.(*modbusDevice)
+	_ = modbus.client.Close()
+	if value, err := driver.ReadProperty(ctx, device, property); err != nil || value != "42" {
+		t.Errorf("read = %q, %v, want 42 after the client is created again", value, err)
+	}
+
+	// the write fails and the next request creates the client again
+	_ = modbus.client.Close()
+	if err := driver.WriteProperty(ctx, device, property, "43"); err == nil {
+		t.Errorf("expected error of writing over the broken connection")
+	}
+	if err := driver.WriteProperty(ctx, device, property, "43"); err != nil {
+		t.Errorf("failed to write after the client is created again: %v", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := driver.ReadProperty(canceled, device, property); err != context.Canceled {
+		t.Errorf("read with canceled context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestDriverConnect(t *testing.T) {
+	ctx := context.Background()
+	device := &sdk.Device{Name: "device", Instance: &pb.Device{Spec: &pb.DeviceSpec{}}}
+	if err := NewDriver().Connect(ctx, device); err == nil {
+		t.Errorf("expected error of device without modbus protocol")
+	}
+	device.Instance.Spec.Protocol = &pb.ProtocolConfig{Modbus: &pb.ProtocolConfigModbus{SlaveID: 300}}
+	if err := NewDriver().Connect(ctx, device); err == nil {
+		t.Errorf("expected error of invalid slave id")
+	}
+
+	var config SerialConfig
+	driver := &Driver{newRTUClient: func(c SerialConfig, slaveID byte, timeout time.Duration) (*Client, error) {
+		config = c
+		return pipeSerialLines((&slave{id: slaveID}).serveRTU).newClient(c, slaveID, timeout)
+	}}
+	device.Instance.Spec.Protocol = &pb.ProtocolConfig{
+		Modbus: &pb.ProtocolConfigModbus{SlaveID: 2},
+		Common: &pb.ProtocolConfigCommon{
+			Com: &pb.ProtocolConfigCOM{SerialPort: "/dev/ttyS0", BaudRate: 9600, DataBits: 8, Parity: "even", StopBits: 1},
+		},
+	}
+	if err := driver.Connect(ctx, device); err != nil {
+		t.Fatalf("failed to connect over rtu: %v", err)
+	}
+	defer driver.Close(ctx, device)
+	if want := (SerialConfig{Port: "/dev/ttyS0", BaudRate: 9600, DataBits: 8, Parity: "even", StopBits: 1}); config != want {
+		t.Errorf("serial config = %+v, want %+v", config, want)
+	}
+	property := newTestProperty("power", sdk.TypeBoolean, "ReadWrite",
+		&pb.VisitorConfigModbus{Register: "CoilRegister", Offset: 1})
+	if err := driver.WriteProperty(ctx, device, property, "true"); err != nil {
+		t.Fatalf("failed to write over rtu: %v", err)
+	}
+	if value, err := driver.ReadProperty(ctx, device, property); err != nil || value != "true" {
+		t.Errorf("read over rtu = %q, %v, want true", value, err)
+	}
+}
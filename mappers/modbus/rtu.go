@@ -0,0 +1,221 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// SerialConfig is the config of the serial line of Modbus RTU
+type SerialConfig struct {
+	// Port is the device of the serial port like /dev/ttyS0
+	Port     string
+	BaudRate int
+	// DataBits is 5, 6, 7 or 8
+	DataBits int
+	// Parity is none, even or odd
+	Parity string
+	// StopBits is 1 or 2
+	StopBits int
+}
+
+// minFrameDelay is the silent interval between the frames if the baud rate is higher than 19200,
+// Modbus RTU fixes it instead of 3.5 character times
+const minFrameDelay = 1750 * time.Microsecond
+
+// serialLine is a serial port shared by the clients of the slaves on it, the requests are sent
+// one by one and separated by the silent interval of 3.5 character times required by Modbus RTU
+type serialLine struct {
+	sync.Mutex
+	config     SerialConfig
+	port       io.ReadWriteCloser
+	frameDelay time.Duration
+	// lastFrame is the time when the last frame is received or the last request fails
+	lastFrame time.Time
+	// refs is the number of the clients of the line, the port is closed when it is 0
+	refs int
+}
+
+// serialLines keeps the serial lines opened by the clients, keyed by the serial port
+type serialLines struct {
+	sync.Mutex
+	lines map[string]*serialLine
+	open  func(config SerialConfig) (io.ReadWriteCloser, error)
+}
+
+var defaultSerialLines = &serialLines{
+	lines: make(map[string]*serialLine),
+	open:  openSerialPort,
+}
+
+// rtuTransporter sends the requests over Modbus RTU
+type rtuTransporter struct {
+	lines     *serialLines
+	line      *serialLine
+	timeout   time.Duration
+	closeOnce sync.Once
+}
+
+// NewRTUClient talks to the slave over Modbus RTU, the timeout applies to each request.
+// The clients of the slaves on the same serial port share the port, which is opened by the first
+// client and closed after all of them are closed, the serial configs of the clients must be the same
+func NewRTUClient(config SerialConfig, slaveID byte, timeout time.Duration) (*Client, error) {
+	return defaultSerialLines.newClient(config, slaveID, timeout)
+}
+
+func (l *serialLines) newClient(config SerialConfig, slaveID byte, timeout time.Duration) (*Client, error) {
+	line, err := l.acquire(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		transporter: &rtuTransporter{lines: l, line: line, timeout: timeout},
+		slaveID:     slaveID,
+	}, nil
+}
+
+// acquire returns the serial line of the port, the port is opened if it has no client yet
+func (l *serialLines) acquire(config SerialConfig) (*serialLine, error) {
+	l.Lock()
+	defer l.Unlock()
+	if line, ok := l.lines[config.Port]; ok {
+		if line.config != config {
+			return nil, fmt.Errorf("serial port %s is opened with config %+v, not %+v", config.Port, line.config, config)
+		}
+		line.refs++
+		return line, nil
+	}
+	port, err := l.open(config)
+	if err != nil {
+		return nil, err
+	}
+	line := &serialLine{config: config, port: port, frameDelay: frameDelay(config.BaudRate), refs: 1}
+	l.lines[config.Port] = line
+	return line, nil
+}
+
+// release closes the port of the serial line if it has no client any more
+func (l *serialLines) release(line *serialLine) error {
+	l.Lock()
+	defer l.Unlock()
+	line.refs--
+	if line.refs > 0 {
+		return nil
+	}
+	delete(l.lines, line.config.Port)
+	return line.port.Close()
+}
+
+// frameDelay returns the silent interval of 3.5 characters of 11 bits at the baud rate
+func frameDelay(baudRate int) time.Duration {
+	if baudRate <= 0 || baudRate > 19200 {
+		return minFrameDelay
+	}
+	return time.Duration(float64(time.Second) * 3.5 * 11 / float64(baudRate))
+}
+
+func (t *rtuTransporter) Send(slaveID byte, pdu []byte) ([]byte, error) {
+	line := t.line
+	line.Lock()
+	defer line.Unlock()
+	if wait := time.Until(line.lastFrame.Add(line.frameDelay)); wait > 0 {
+		time.Sleep(wait)
+	}
+	response, err := t.send(line.port, slaveID, pdu)
+	line.lastFrame = time.Now()
+	return response, err
+}
+
+func (t *rtuTransporter) send(port io.ReadWriter, slaveID byte, pdu []byte) ([]byte, error) {
+	request := append([]byte{slaveID}, pdu...)
+	crc := crc16(request)
+	request = append(request, byte(crc), byte(crc>>8))
+
+	if port, ok := port.(interface{ SetDeadline(time.Time) error }); ok {
+		if err := port.SetDeadline(time.Now().Add(t.timeout)); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := port.Write(request); err != nil {
+		return nil, err
+	}
+
+	// the length of the response depends on the function, read the slave id and the function first
+	response := make([]byte, 2, maxPDULength+3)
+	if _, err := io.ReadFull(port, response); err != nil {
+		return nil, err
+	}
+	var remaining int
+	switch function := response[1]; {
+	case function&exceptionFlag != 0:
+		// exception code and crc
+		remaining = 3
+	case function <= FuncReadInputRegisters:
+		count := make([]byte, 1)
+		if _, err := io.ReadFull(port, count); err != nil {
+			return nil, err
+		}
+		response = append(response, count[0])
+		remaining = int(count[0]) + 2
+	default:
+		// address, value or quantity and crc
+		remaining = 6
+	}
+	rest := make([]byte, remaining)
+	if _, err := io.ReadFull(port, rest); err != nil {
+		return nil, err
+	}
+	response = append(response, rest...)
+
+	n := len(response)
+	if crc := binary.LittleEndian.Uint16(response[n-2:]); crc != crc16(response[:n-2]) {
+		return nil, fmt.Errorf("invalid crc of response % x", response)
+	}
+	if response[0] != slaveID {
+		return nil, fmt.Errorf("response of slave %d, want %d", response[0], slaveID)
+	}
+	return response[1 : n-2], nil
+}
+
+// Close releases the serial line, it is safe to close the transporter more than once
+func (t *rtuTransporter) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		err = t.lines.release(t.line)
+	})
+	return err
+}
+
+// crc16 returns the Modbus CRC of the data
+func crc16(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
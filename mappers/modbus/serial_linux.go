@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+var baudRates = map[int]uint32{
+	50:     unix.B50,
+	75:     unix.B75,
+	110:    unix.B110,
+	134:    unix.B134,
+	150:    unix.B150,
+	200:    unix.B200,
+	300:    unix.B300,
+	600:    unix.B600,
+	1200:   unix.B1200,
+	1800:   unix.B1800,
+	2400:   unix.B2400,
+	4800:   unix.B4800,
+	9600:   unix.B9600,
+	19200:  unix.B19200,
+	38400:  unix.B38400,
+	57600:  unix.B57600,
+	115200: unix.B115200,
+}
+
+var dataBits = map[int]uint32{
+	5: unix.CS5,
+	6: unix.CS6,
+	7: unix.CS7,
+	8: unix.CS8,
+}
+
+// openSerialPort opens the serial port in raw mode with the config
+func openSerialPort(config SerialConfig) (io.ReadWriteCloser, error) {
+	baudRate, ok := baudRates[config.BaudRate]
+	if !ok {
+		return nil, fmt.Errorf("unsupported baud rate %d", config.BaudRate)
+	}
+	size, ok := dataBits[config.DataBits]
+	if !ok {
+		return nil, fmt.Errorf("unsupported data bits %d", config.DataBits)
+	}
+
+	cflag := baudRate | size | unix.CREAD | unix.CLOCAL
+	switch config.Parity {
+	case "", "none":
+	case "even":
+		cflag |= unix.PARENB
+	case "odd":
+		cflag |= unix.PARENB | unix.PARODD
+	default:
+		return nil, fmt.Errorf("unsupported parity %s", config.Parity)
+	}
+	switch config.StopBits {
+	case 0, 1:
+	case 2:
+		cflag |= unix.CSTOPB
+	default:
+		return nil, fmt.Errorf("unsupported stop bits %d", config.StopBits)
+	}
+
+	// the port is opened in non-blocking mode so that the reads and writes support deadlines
+	port, err := os.OpenFile(config.Port, os.O_RDWR|unix.O_NOCTTY|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	termios := &unix.Termios{Cflag: cflag}
+	// read returns as soon as one byte is available
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(int(port.Fd()), unix.TCSETS, termios); err != nil {
+		port.Close()
+		return nil, fmt.Errorf("failed to set attributes of serial port %s: %v", config.Port, err)
+	}
+	return port, nil
+}
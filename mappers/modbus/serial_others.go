@@ -0,0 +1,30 @@
+//go:build !linux
+// +build !linux
+
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"fmt"
+	"io"
+)
+
+// openSerialPort is only supported on linux
+func openSerialPort(config SerialConfig) (io.ReadWriteCloser, error) {
+	return nil, fmt.Errorf("modbus RTU is not supported on this platform")
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	exceptionIllegalFunction    byte = 0x01
+	exceptionIllegalDataAddress byte = 0x02
+	slaveSize                        = 100
+)
+
+// slave simulates a Modbus slave with the coils, discrete inputs, holding registers and input registers
+type slave struct {
+	sync.Mutex
+	id               byte
+	coils            [slaveSize]bool
+	discreteInputs   [slaveSize]bool
+	holdingRegisters [slaveSize]uint16
+	inputRegisters   [slaveSize]uint16
+	// failures is the number of the next requests which are not answered
+	failures int
+}
+
+// handle returns the response PDU of the request PDU, or nil if the request is not answered
+func (s *slave) handle(pdu []byte) []byte {
+	s.Lock()
+	defer s.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return nil
+	}
+	function := pdu[0]
+	exception := func(code byte) []byte {
+		return []byte{function | exceptionFlag, code}
+	}
+	if len(pdu) < 5 {
+		return exception(exceptionIllegalFunction)
+	}
+	address, quantity := int(binary.BigEndian.Uint16(pdu[1:])), int(binary.BigEndian.Uint16(pdu[3:]))
+	switch function {
+	case FuncReadCoils, FuncReadDiscreteInputs:
+		if address+quantity > slaveSize {
+			return exception(exceptionIllegalDataAddress)
+		}
+		bits := s.coils[address : address+quantity]
+		if function == FuncReadDiscreteInputs {
+			bits = s.discreteInputs[address : address+quantity]
+		}
+		data := packBits(bits)
+		return append([]byte{function, byte(len(data))}, data...)
+	case FuncReadHoldingRegisters, FuncReadInputRegisters:
+		if address+quantity > slaveSize {
+			return exception(exceptionIllegalDataAddress)
+		}
+		registers := s.holdingRegisters[address : address+quantity]
+		if function == FuncReadInputRegisters {
+			registers = s.inputRegisters[address : address+quantity]
+		}
+		return append([]byte{function, byte(2 * quantity)}, uint16Bytes(registers...)...)
+	case FuncWriteSingleCoil:
+		if address >= slaveSize {
+			return exception(exceptionIllegalDataAddress)
+		}
+		s.coils[address] = quantity == coilOn
+		return pdu
+	case FuncWriteSingleRegister:
+		if address >= slaveSize {
+			return exception(exceptionIllegalDataAddress)
+		}
+		s.holdingRegisters[address] = uint16(quantity)
+		return pdu
+	case FuncWriteMultipleCoils:
+		if address+quantity > slaveSize {
+			return exception(exceptionIllegalDataAddress)
+		}
+		copy(s.coils[address:], unpackBits(pdu[6:], quantity))
+		return pdu[:5]
+	case FuncWriteMultipleRegisters:
+		if address+quantity > slaveSize {
+			return exception(exceptionIllegalDataAddress)
+		}
+		for i := 0; i < quantity; i++ {
+			s.holdingRegisters[address+i] = binary.BigEndian.Uint16(pdu[6+2*i:])
+		}
+		return pdu[:5]
+	default:
+		return exception(exceptionIllegalFunction)
+	}
+}
+
+// serveTCP serves the slave over Modbus TCP on a local port and returns the address
+func (s *slave) serveTCP(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serveTCPConn(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func (s *slave) serveTCPConn(conn net.Conn) {
+	defer conn.Close()
+	for {
+		header := make([]byte, mbapHeaderLength)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		pdu := make([]byte, binary.BigEndian.Uint16(header[4:])-1)
+		if _, err := io.ReadFull(conn, pdu); err != nil {
+			return
+		}
+		if header[6] != s.id {
+			continue
+		}
+		response := s.handle(pdu)
+		if response == nil {
+			continue
+		}
+		binary.BigEndian.PutUint16(header[4:], uint16(1+len(response)))
+		if _, err := conn.Write(append(header, response...)); err != nil {
+			return
+		}
+	}
+}
+
+// serveRTU serves the slave over Modbus RTU on the port, the length of the requests are known by the function
+func (s *slave) serveRTU(port io.ReadWriteCloser) {
+	serveRTUSlaves(port, nil, s)
+}
+
+// serveRTUSlaves serves the slaves on the same serial line, the requests of other slaves are ignored.
+// If frames is not nil, the times when the requests arrive and the responses are sent are sent to it
+func serveRTUSlaves(port io.ReadWriteCloser, frames chan<- time.Time, slaves ...*slave) {
+	defer port.Close()
+	if frames != nil {
+		defer close(frames)
+	}
+	for {
+		request := make([]byte, 8)
+		if _, err := io.ReadFull(port, request); err != nil {
+			return
+		}
+		if function := request[1]; function == FuncWriteMultipleCoils || function == FuncWriteMultipleRegisters {
+			// the byte count follows the quantity
+			rest := make([]byte, 1+int(request[6]))
+			if _, err := io.ReadFull(port, rest); err != nil {
+				return
+			}
+			request = append(request, rest...)
+		}
+		if frames != nil {
+			frames <- time.Now()
+		}
+		n := len(request)
+		if binary.LittleEndian.Uint16(request[n-2:]) != crc16(request[:n-2]) {
+			continue
+		}
+		var s *slave
+		for _, candidate := range slaves {
+			if candidate.id == request[0] {
+				s = candidate
+			}
+		}
+		if s == nil {
+			continue
+		}
+		response := s.handle(request[1 : n-2])
+		if response == nil {
+			continue
+		}
+		response = append([]byte{s.id}, response...)
+		crc := crc16(response)
+		if _, err := port.Write(append(response, byte(crc), byte(crc>>8))); err != nil {
+			return
+		}
+		if frames != nil {
+			frames <- time.Now()
+		}
+	}
+}
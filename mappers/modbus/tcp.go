@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modbus
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+const (
+	// mbapHeaderLength is the length of the Modbus application protocol header of Modbus TCP
+	mbapHeaderLength = 7
+	maxPDULength     = 253
+)
+
+// tcpTransporter sends the requests over Modbus TCP
+type tcpTransporter struct {
+	conn          net.Conn
+	timeout       time.Duration
+	transactionID uint16
+}
+
+// NewTCPClient connects to the slave over Modbus TCP, the timeout applies to connecting and each request
+func NewTCPClient(address string, slaveID byte, timeout time.Duration) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		transporter: &tcpTransporter{conn: conn, timeout: timeout},
+		slaveID:     slaveID,
+	}, nil
+}
+
+func (t *tcpTransporter) Send(slaveID byte, pdu []byte) ([]byte, error) {
+	t.transactionID++
+	request := make([]byte, mbapHeaderLength, mbapHeaderLength+len(pdu))
+	binary.BigEndian.PutUint16(request[0:], t.transactionID)
+	// the protocol identifier of Modbus is 0
+	binary.BigEndian.PutUint16(request[4:], uint16(1+len(pdu)))
+	request[6] = slaveID
+	request = append(request, pdu...)
+
+	if err := t.conn.SetDeadline(time.Now().Add(t.timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := t.conn.Write(request); err != nil {
+		return nil, err
+	}
+
+	for {
+		header := make([]byte, mbapHeaderLength)
+		if _, err := io.ReadFull(t.conn, header); err != nil {
+			return nil, err
+		}
+		length := int(binary.BigEndian.Uint16(header[4:]))
+		if length < 2 || length > maxPDULength+1 {
+			return nil, fmt.Errorf("invalid length %d of response", length)
+		}
+		response := make([]byte, length-1)
+		if _, err := io.ReadFull(t.conn, response); err != nil {
+			return nil, err
+		}
+		// the response of a previous request which timed out may arrive late, skip it
+		if id := binary.BigEndian.Uint16(header[0:]); id != t.transactionID {
+			continue
+		}
+		if header[6] != slaveID {
+			return nil, fmt.Errorf("response of slave %d, want %d", header[6], slaveID)
+		}
+		return response, nil
+	}
+}
+
+func (t *tcpTransporter) Close() error {
+	return t.conn.Close()
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultDMIAddress is the default unix socket of the DMI server in edgecore
+	DefaultDMIAddress = "/etc/kubeedge/dmi.sock"
+	// DefaultCollectCycle is the default cycle to collect a property which visitor has no collectCycle
+	DefaultCollectCycle = time.Second
+	// DefaultReportCycle is the default cycle to report a property which visitor has no reportCycle
+	DefaultReportCycle = time.Second
+	// DefaultMaxBatchSize is the default maximum number of the values of a device kept for reporting
+	DefaultMaxBatchSize = 1000
+	// DefaultMaxReadFailures is the default number of the consecutive failed reads to reconnect to a device
+	DefaultMaxReadFailures = 3
+	// DefaultInitialBackoff is the default first delay to retry a failed registration, connection or report
+	DefaultInitialBackoff = time.Second
+	// DefaultMaxBackoff is the default maximum delay to retry a failed registration, connection or report
+	DefaultMaxBackoff = 30 * time.Second
+
+	addressTCPPrefix  = "tcp://"
+	addressUnixPrefix = "unix://"
+	networkTCP        = "tcp"
+	networkUnix       = "unix"
+)
+
+// Config is the configuration shared by the mappers, a mapper embeds it in its own configuration
+type Config struct {
+	// Name is the name of the mapper registered to edgecore
+	Name string `json:"name,omitempty"`
+	// Version is the version of the mapper registered to edgecore
+	Version string `json:"version,omitempty"`
+	// Protocol is the protocol registered to edgecore, the devices with the same protocol are managed by the mapper
+	Protocol string `json:"protocol,omitempty"`
+	// Address is where the mapper serves DMI for edgecore, a unix socket path,
+	// or tcp://host:port for the mapper on another host which requires TLS
+	Address string `json:"address,omitempty"`
+	// DMIAddress is the address of the DMI server in edgecore, a unix socket path or tcp://host:port which requires TLS
+	DMIAddress string `json:"dmiAddress,omitempty"`
+	// TLS is the mutual TLS config of DMI over TCP
+	TLS *TLSConfig `json:"tls,omitempty"`
+	// CollectCycle is the cycle to collect a property which visitor has no collectCycle
+	CollectCycle metav1.Duration `json:"collectCycle,omitempty"`
+	// ReportCycle is the cycle to report a property which visitor has no reportCycle
+	ReportCycle metav1.Duration `json:"reportCycle,omitempty"`
+	// MaxBatchSize is the maximum number of the values of a device kept for reporting,
+	// the oldest values are dropped when the reports fail for a long time
+	MaxBatchSize int `json:"maxBatchSize,omitempty"`
+	// MaxReadFailures is the number of the consecutive failed reads of a device,
+	// the device is closed and connected again after it
+	MaxReadFailures int `json:"maxReadFailures,omitempty"`
+	// InitialBackoff and MaxBackoff bound the delay to retry a failed registration, connection or report
+	InitialBackoff metav1.Duration `json:"initialBackoff,omitempty"`
+	MaxBackoff     metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// TLSConfig is the mutual TLS config of DMI over TCP
+type TLSConfig struct {
+	// CAFile is the CA certificate which signs the certificates of both edgecore and the mapper
+	CAFile string `json:"caFile,omitempty"`
+	// CertFile and KeyFile are the certificate and the private key of the mapper
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+}
+
+// NewDefaultConfig returns a config of the mapper with the default values
+func NewDefaultConfig(name, protocol, address string) Config {
+	return Config{
+		Name:            name,
+		Version:         "v1.0.0",
+		Protocol:        protocol,
+		Address:         address,
+		DMIAddress:      DefaultDMIAddress,
+		CollectCycle:    metav1.Duration{Duration: DefaultCollectCycle},
+		ReportCycle:     metav1.Duration{Duration: DefaultReportCycle},
+		MaxBatchSize:    DefaultMaxBatchSize,
+		MaxReadFailures: DefaultMaxReadFailures,
+		InitialBackoff:  metav1.Duration{Duration: DefaultInitialBackoff},
+		MaxBackoff:      metav1.Duration{Duration: DefaultMaxBackoff},
+	}
+}
+
+// Validate checks the config
+func (c *Config) Validate() error {
+	if c.Name == "" || c.Protocol == "" {
+		return fmt.Errorf("name and protocol of the mapper are required")
+	}
+	if c.Address == "" || c.DMIAddress == "" {
+		return fmt.Errorf("address and dmiAddress are required")
+	}
+	if c.CollectCycle.Duration <= 0 || c.ReportCycle.Duration <= 0 {
+		return fmt.Errorf("collectCycle and reportCycle must be positive")
+	}
+	if c.MaxBatchSize <= 0 {
+		return fmt.Errorf("maxBatchSize must be positive")
+	}
+	if c.MaxReadFailures <= 0 {
+		return fmt.Errorf("maxReadFailures must be positive")
+	}
+	if c.InitialBackoff.Duration <= 0 || c.MaxBackoff.Duration < c.InitialBackoff.Duration {
+		return fmt.Errorf("initialBackoff must be positive and not larger than maxBackoff")
+	}
+	if strings.HasPrefix(c.Address, addressTCPPrefix) || strings.HasPrefix(c.DMIAddress, addressTCPPrefix) {
+		if c.TLS == nil || c.TLS.CAFile == "" || c.TLS.CertFile == "" || c.TLS.KeyFile == "" {
+			return fmt.Errorf("tls is required for DMI over TCP")
+		}
+	}
+	return nil
+}
+
+// parseAddress returns the network and the address of a unix socket path, unix:// or tcp:// address
+func parseAddress(address string) (string, string) {
+	if strings.HasPrefix(address, addressTCPPrefix) {
+		return networkTCP, strings.TrimPrefix(address, addressTCPPrefix)
+	}
+	return networkUnix, strings.TrimPrefix(address, addressUnixPrefix)
+}
+
+// tlsConfig returns the mutual TLS config used by both the DMI server of the mapper and the client of edgecore
+func (c *TLSConfig) tlsConfig() (*tls.Config, error) {
+	caPEM, err := os.ReadFile(c.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca file %s: %v", c.CAFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("failed to parse ca file %s", c.CAFile)
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %v", err)
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}, nil
+}
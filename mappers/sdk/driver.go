@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	pb "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
+)
+
+// the types of the property values reported to edgecore
+const (
+	TypeInt     = "int"
+	TypeFloat   = "float"
+	TypeBoolean = "boolean"
+	TypeString  = "string"
+)
+
+const accessModeReadWrite = "ReadWrite"
+
+// Driver accesses the devices of the protocol served by the mapper.
+// The methods are never called concurrently for the same device, but may be for different devices
+type Driver interface {
+	// Connect connects to the device, it is called when the device is registered or updated,
+	// or after the device fails to be read for maxReadFailures times, and retried with backoff until it succeeds
+	Connect(ctx context.Context, device *Device) error
+	// ReadProperty reads the value of the property from the device
+	ReadProperty(ctx context.Context, device *Device, property *Property) (string, error)
+	// WriteProperty writes the desired value of the ReadWrite property to the device
+	WriteProperty(ctx context.Context, device *Device, property *Property, value string) error
+	// Close disconnects from the device, it is called when the device is removed or updated,
+	// or before connecting to the device again
+	Close(ctx context.Context, device *Device) error
+}
+
+// Device is a device instance managed by the mapper
+type Device struct {
+	// Name is the name of the device
+	Name string
+	// Instance is the device received from edgecore
+	Instance *pb.Device
+	// Model is the device model of the device
+	Model *pb.DeviceModel
+	// Properties are the properties with a visitor, they are collected and reported by the mapper
+	Properties []*Property
+	// Client is set by the driver in Connect to keep the connection to the device
+	Client interface{}
+}
+
+// Property is a property of a device with its visitor
+type Property struct {
+	// Name is the name of the property
+	Name string
+	// Type is the type reported to edgecore, one of int, float, boolean and string
+	Type string
+	// AccessMode is ReadWrite or ReadOnly
+	AccessMode string
+	// DefaultValue is the default value of the device model
+	DefaultValue string
+	// Spec is the property of the device model
+	Spec *pb.DeviceProperty
+	// Visitor is the visitor of the property which tells the driver how to access it
+	Visitor *pb.DevicePropertyVisitor
+	// CollectCycle and ReportCycle are the cycles of the visitor, or the default cycles of the mapper
+	CollectCycle time.Duration
+	ReportCycle  time.Duration
+}
+
+// Writable returns whether the desired values can be written to the property
+func (p *Property) Writable() bool {
+	return p.AccessMode == accessModeReadWrite
+}
+
+// newDevice creates the device with the properties which have visitors
+func newDevice(instance *pb.Device, model *pb.DeviceModel, config *Config) *Device {
+	device := &Device{
+		Name:     instance.Name,
+		Instance: instance,
+		Model:    model,
+	}
+	specs := make(map[string]*pb.DeviceProperty)
+	for _, p := range model.GetSpec().GetProperties() {
+		specs[p.Name] = p
+	}
+	for _, visitor := range instance.GetSpec().GetPropertyVisitors() {
+		spec, ok := specs[visitor.PropertyName]
+		if !ok {
+			continue
+		}
+		property := &Property{
+			Name:         spec.Name,
+			Spec:         spec,
+			Visitor:      visitor,
+			CollectCycle: config.CollectCycle.Duration,
+			ReportCycle:  config.ReportCycle.Duration,
+		}
+		property.Type, property.AccessMode, property.DefaultValue = describeProperty(spec.GetType())
+		// the cycles of the visitor are in nanoseconds
+		if visitor.CollectCycle > 0 {
+			property.CollectCycle = time.Duration(visitor.CollectCycle)
+		}
+		if visitor.ReportCycle > 0 {
+			property.ReportCycle = time.Duration(visitor.ReportCycle)
+		}
+		device.Properties = append(device.Properties, property)
+	}
+	return device
+}
+
+// property returns the property of the name, or nil if the device has no visitor of it
+func (d *Device) property(name string) *Property {
+	for _, property := range d.Properties {
+		if property.Name == name {
+			return property
+		}
+	}
+	return nil
+}
+
+// describeProperty returns the reported type, the access mode and the default value of the property type
+func describeProperty(t *pb.PropertyType) (string, string, string) {
+	switch {
+	case t.GetInt() != nil:
+		return TypeInt, t.GetInt().AccessMode, strconv.FormatInt(t.GetInt().DefaultValue, 10)
+	case t.GetDouble() != nil:
+		return TypeFloat, t.GetDouble().AccessMode, strconv.FormatFloat(t.GetDouble().DefaultValue, 'f', -1, 64)
+	case t.GetFloat() != nil:
+		return TypeFloat, t.GetFloat().AccessMode, strconv.FormatFloat(float64(t.GetFloat().DefaultValue), 'f', -1, 32)
+	case t.GetBoolean() != nil:
+		return TypeBoolean, t.GetBoolean().AccessMode, strconv.FormatBool(t.GetBoolean().DefaultValue)
+	case t.GetString_() != nil:
+		return TypeString, t.GetString_().AccessMode, t.GetString_().DefaultValue
+	case t.GetBytes() != nil:
+		return TypeString, t.GetBytes().AccessMode, ""
+	default:
+		return TypeString, "", ""
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sdk implements the common parts of the mappers on top of DMI. A mapper only implements
+// a Driver of its protocol, and the Mapper of the sdk registers to edgecore, keeps the devices and
+// device models, collects and reports the properties by their visitors and writes the desired values.
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+
+	pb "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
+)
+
+const (
+	// APIVersion is the version of DMI implemented by the sdk
+	APIVersion = "v1alpha1"
+
+	dmiTimeout = 10 * time.Second
+)
+
+// Mapper implements DeviceMapperService for the driver and reports the properties
+// of the devices through DeviceManagerService of edgecore
+type Mapper struct {
+	pb.UnimplementedDeviceMapperServiceServer
+	healthpb.UnimplementedHealthServer
+
+	config Config
+	driver Driver
+	// manager is the DeviceManagerService client of edgecore
+	manager pb.DeviceManagerServiceClient
+	backoff *flowcontrol.Backoff
+	// ctx is the context of Run, the device workers stop when it is done
+	ctx context.Context
+
+	mu sync.Mutex
+	// models are the device models, key is the model name
+	models map[string]*pb.DeviceModel
+	// workers are the workers of the devices, key is the device name
+	workers map[string]*deviceWorker
+}
+
+// NewMapper creates a mapper of the driver
+func NewMapper(config Config, driver Driver) *Mapper {
+	return &Mapper{
+		config:  config,
+		driver:  driver,
+		backoff: flowcontrol.NewBackOff(config.InitialBackoff.Duration, config.MaxBackoff.Duration),
+		models:  make(map[string]*pb.DeviceModel),
+		workers: make(map[string]*deviceWorker),
+	}
+}
+
+// Run serves DeviceMapperService, registers the mapper to edgecore and manages the devices
+// until the context is done
+func (m *Mapper) Run(ctx context.Context) error {
+	if err := m.config.Validate(); err != nil {
+		return err
+	}
+	m.ctx = ctx
+
+	grpcServer, lis, err := m.newServer()
+	if err != nil {
+		return err
+	}
+	pb.RegisterDeviceMapperServiceServer(grpcServer, m)
+	healthpb.RegisterHealthServer(grpcServer, m)
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			klog.Errorf("mapper %s stops serving with err: %v", m.config.Name, err)
+		}
+	}()
+	defer grpcServer.Stop()
+
+	conn, err := m.dialManager()
+	if err != nil {
+		return fmt.Errorf("failed to connect to edgecore: %v", err)
+	}
+	defer conn.Close()
+	m.manager = pb.NewDeviceManagerServiceClient(conn)
+
+	backoff := wait.Backoff{
+		Duration: m.config.InitialBackoff.Duration,
+		Factor:   2,
+		Cap:      m.config.MaxBackoff.Duration,
+		Steps:    math.MaxInt32,
+	}
+	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
+		if err := m.register(ctx); err != nil {
+			klog.Warningf("failed to register mapper %s to edgecore, will retry: %v", m.config.Name, err)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	klog.Infof("mapper %s is registered with protocol %s", m.config.Name, m.config.Protocol)
+
+	<-ctx.Done()
+	m.mu.Lock()
+	workers := m.workers
+	m.workers = make(map[string]*deviceWorker)
+	m.mu.Unlock()
+	for _, worker := range workers {
+		worker.stop()
+	}
+	return nil
+}
+
+// newServer creates the grpc server and its listener on the address of the mapper
+func (m *Mapper) newServer() (*grpc.Server, net.Listener, error) {
+	network, address := parseAddress(m.config.Address)
+	var opts []grpc.ServerOption
+	if network == networkTCP {
+		tlsConfig, err := m.config.TLS.tlsConfig()
+		if err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else if err := os.Remove(address); err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("failed to remove socket %s: %v", address, err)
+	}
+	lis, err := net.Listen(network, address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen on %s: %v", m.config.Address, err)
+	}
+	return grpc.NewServer(opts...), lis, nil
+}
+
+// dialManager connects to the DMI server of edgecore
+func (m *Mapper) dialManager() (*grpc.ClientConn, error) {
+	network, address := parseAddress(m.config.DMIAddress)
+	if network == networkTCP {
+		tlsConfig, err := m.config.TLS.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		return grpc.Dial(address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+	return grpc.Dial(address, grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, networkUnix, addr)
+		}))
+}
+
+// register registers the mapper to edgecore and starts the devices returned
+func (m *Mapper) register(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, dmiTimeout)
+	defer cancel()
+	resp, err := m.manager.MapperRegister(ctx, &pb.MapperRegisterRequest{
+		WithData: true,
+		Mapper: &pb.MapperInfo{
+			Name:       m.config.Name,
+			Version:    m.config.Version,
+			ApiVersion: APIVersion,
+			Protocol:   m.config.Protocol,
+			Address:    []byte(m.config.Address),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, model := range resp.ModelList {
+		m.models[model.Name] = model
+	}
+	for _, instance := range resp.DeviceList {
+		if err := m.startDevice(instance); err != nil {
+			klog.Errorf("failed to start device %s: %v", instance.Name, err)
+		}
+	}
+	return nil
+}
+
+// reportDeviceStatus reports the status of a device to edgecore
+func (m *Mapper) reportDeviceStatus(ctx context.Context, request *pb.ReportDeviceStatusRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, dmiTimeout)
+	defer cancel()
+	_, err := m.manager.ReportDeviceStatus(ctx, request)
+	return err
+}
+
+// startDevice starts the worker of the device instance, the new worker stops the previous worker
+// of the device before connecting to it, so the caller holding the lock does not wait for it
+func (m *Mapper) startDevice(instance *pb.Device) error {
+	if instance == nil || instance.Name == "" {
+		return fmt.Errorf("device name is empty")
+	}
+	modelName := instance.GetSpec().GetDeviceModelReference()
+	model, ok := m.models[modelName]
+	if !ok {
+		return fmt.Errorf("device model %s of device %s is not found", modelName, instance.Name)
+	}
+
+	worker := newDeviceWorker(newDevice(instance, model, &m.config), m.driver, &m.config, m.reportDeviceStatus, m.backoff)
+	worker.start(m.ctx, m.workers[instance.Name])
+	m.workers[instance.Name] = worker
+	return nil
+}
+
+// RegisterDevice starts collecting the properties of the device
+func (m *Mapper) RegisterDevice(ctx context.Context, in *pb.RegisterDeviceRequest) (*pb.RegisterDeviceResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.startDevice(in.GetDevice()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	klog.Infof("device %s is registered", in.Device.Name)
+	return &pb.RegisterDeviceResponse{DeviceName: in.Device.Name}, nil
+}
+
+// RemoveDevice stops collecting the properties of the device and closes it
+func (m *Mapper) RemoveDevice(ctx context.Context, in *pb.RemoveDeviceRequest) (*pb.RemoveDeviceResponse, error) {
+	m.mu.Lock()
+	worker, ok := m.workers[in.DeviceName]
+	delete(m.workers, in.DeviceName)
+	m.mu.Unlock()
+	// the device is closed when the worker is stopped
+	if ok {
+		worker.stop()
+	}
+	klog.Infof("device %s is removed", in.DeviceName)
+	return &pb.RemoveDeviceResponse{}, nil
+}
+
+// UpdateDevice reconnects to the device with the updated spec and desired values
+func (m *Mapper) UpdateDevice(ctx context.Context, in *pb.UpdateDeviceRequest) (*pb.UpdateDeviceResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.startDevice(in.GetDevice()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	klog.Infof("device %s is updated", in.Device.Name)
+	return &pb.UpdateDeviceResponse{}, nil
+}
+
+// CreateDeviceModel adds the device model
+func (m *Mapper) CreateDeviceModel(ctx context.Context, in *pb.CreateDeviceModelRequest) (*pb.CreateDeviceModelResponse, error) {
+	if in.GetModel().GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "device model name is empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.models[in.Model.Name] = in.Model
+	return &pb.CreateDeviceModelResponse{DeviceModelName: in.Model.Name}, nil
+}
+
+// RemoveDeviceModel removes the device model
+func (m *Mapper) RemoveDeviceModel(ctx context.Context, in *pb.RemoveDeviceModelRequest) (*pb.RemoveDeviceModelResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.models, in.ModelName)
+	return &pb.RemoveDeviceModelResponse{}, nil
+}
+
+// UpdateDeviceModel updates the device model and restarts the devices of the model
+func (m *Mapper) UpdateDeviceModel(ctx context.Context, in *pb.UpdateDeviceModelRequest) (*pb.UpdateDeviceModelResponse, error) {
+	if in.GetModel().GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "device model name is empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.models[in.Model.Name] = in.Model
+	for _, worker := range m.workers {
+		instance := worker.device.Instance
+		if instance.GetSpec().GetDeviceModelReference() != in.Model.Name {
+			continue
+		}
+		if err := m.startDevice(instance); err != nil {
+			klog.Errorf("failed to restart device %s with the updated model: %v", instance.Name, err)
+		}
+	}
+	return &pb.UpdateDeviceModelResponse{}, nil
+}
+
+// UpdateDeviceStatus writes the desired values to the ReadWrite properties of the device,
+// the values are kept and written again when the device does not have them
+func (m *Mapper) UpdateDeviceStatus(ctx context.Context, in *pb.UpdateDeviceStatusRequest) (*pb.UpdateDeviceStatusResponse, error) {
+	m.mu.Lock()
+	worker, ok := m.workers[in.DeviceName]
+	m.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "device %s is not found", in.DeviceName)
+	}
+	for _, twin := range in.GetDesiredDevice().GetTwins() {
+		if err := worker.setDesired(ctx, twin.PropertyName, twin.GetDesired().GetValue()); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+	return &pb.UpdateDeviceStatusResponse{}, nil
+}
+
+// GetDevice returns the device with its state and the desired values
+func (m *Mapper) GetDevice(ctx context.Context, in *pb.GetDeviceRequest) (*pb.GetDeviceResponse, error) {
+	m.mu.Lock()
+	worker, ok := m.workers[in.DeviceName]
+	m.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "device %s is not found", in.DeviceName)
+	}
+	return &pb.GetDeviceResponse{Device: worker.status()}, nil
+}
+
+// Check reports the mapper is serving
+func (m *Mapper) Check(ctx context.Context, in *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pb "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
+)
+
+// fakeManager is the DeviceManagerService of edgecore, it fails the first failures reports
+type fakeManager struct {
+	pb.UnimplementedDeviceManagerServiceServer
+
+	mu       sync.Mutex
+	mapper   *pb.MapperInfo
+	devices  []*pb.Device
+	failures int
+	requests []*pb.ReportDeviceStatusRequest
+}
+
+func (f *fakeManager) MapperRegister(ctx context.Context, in *pb.MapperRegisterRequest) (*pb.MapperRegisterResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mapper = in.Mapper
+	return &pb.MapperRegisterResponse{ModelList: []*pb.DeviceModel{testModel()}, DeviceList: f.devices}, nil
+}
+
+func (f *fakeManager) ReportDeviceStatus(ctx context.Context, in *pb.ReportDeviceStatusRequest) (*pb.ReportDeviceStatusResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return nil, fmt.Errorf("edgecore is busy")
+	}
+	f.requests = append(f.requests, in)
+	return &pb.ReportDeviceStatusResponse{}, nil
+}
+
+// reported returns the reported values of the property of the device in order
+func (f *fakeManager) reported(device, property string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var values []string
+	for _, request := range f.requests {
+		if request.DeviceName != device {
+			continue
+		}
+		for _, twin := range request.ReportedDevice.Twins {
+			if twin.PropertyName == property {
+				values = append(values, twin.Reported.Value)
+			}
+		}
+	}
+	return values
+}
+
+// fakeDriver keeps the values of the properties in memory, key is device/property
+type fakeDriver struct {
+	mu        sync.Mutex
+	values    map[string]string
+	writes    map[string][]string
+	connected map[string]bool
+	connects  map[string]int
+	// readErr fails the reads of all properties if it is set
+	readErr error
+}
+
+func newFakeDriver() *fakeDriver {
+	return &fakeDriver{
+		values:    make(map[string]string),
+		writes:    make(map[string][]string),
+		connected: make(map[string]bool),
+		connects:  make(map[string]int),
+	}
+}
+
+func (f *fakeDriver) Connect(ctx context.Context, device *Device) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.connected[device.Name] = true
+	f.connects[device.Name]++
+	return nil
+}
+
+func (f *fakeDriver) ReadProperty(ctx context.Context, device *Device, property *Property) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.readErr != nil {
+		return "", f.readErr
+	}
+	if value, ok := f.values[device.Name+"/"+property.Name]; ok {
+		return value, nil
+	}
+	return property.DefaultValue, nil
+}
+
+func (f *fakeDriver) WriteProperty(ctx context.Context, device *Device, property *Property, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := device.Name + "/" + property.Name
+	f.values[key] = value
+	f.writes[key] = append(f.writes[key], value)
+	return nil
+}
+
+func (f *fakeDriver) Close(ctx context.Context, device *Device) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.connected[device.Name] = false
+	return nil
+}
+
+func (f *fakeDriver) set(key, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.values[key] = value
+}
+
+func (f *fakeDriver) writeCount(key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.writes[key])
+}
+
+func (f *fakeDriver) setReadErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.readErr = err
+}
+
+func (f *fakeDriver) connectCount(device string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.connects[device]
+}
+
+func (f *fakeDriver) isConnected(device string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.connected[device]
+}
+
+func testModel() *pb.DeviceModel {
+	return &pb.DeviceModel{
+		Name: "sensor-model",
+		Spec: &pb.DeviceModelSpec{
+			Properties: []*pb.DeviceProperty{
+				{
+					Name: "temperature",
+					Type: &pb.PropertyType{Int: &pb.PropertyTypeInt64{AccessMode: "ReadOnly", DefaultValue: 20}},
+				},
+				{
+					Name: "threshold",
+					Type: &pb.PropertyType{Double: &pb.PropertyTypeDouble{AccessMode: accessModeReadWrite, DefaultValue: 50}},
+				},
+				{
+					Name: "label",
+					Type: &pb.PropertyType{String_: &pb.PropertyTypeString{AccessMode: accessModeReadWrite}},
+				},
+			},
+		},
+	}
+}
+
+func testDevice(name string, desired map[string]string) *pb.Device {
+	device := &pb.Device{
+		Name: name,
+		Spec: &pb.DeviceSpec{
+			DeviceModelReference: "sensor-model",
+			PropertyVisitors: []*pb.DevicePropertyVisitor{
+				{PropertyName: "temperature", CollectCycle: int64(20 * time.Millisecond), ReportCycle: int64(50 * time.Millisecond)},
+				{PropertyName: "threshold"},
+			},
+		},
+		Status: &pb.DeviceStatus{},
+	}
+	for property, value := range desired {
+		device.Status.Twins = append(device.Status.Twins, &pb.Twin{
+			PropertyName: property,
+			Desired:      &pb.TwinProperty{Value: value},
+		})
+	}
+	return device
+}
+
+func eventually(t *testing.T, description string, condition func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", description)
+}
+
+func startMapper(t *testing.T, manager *fakeManager, driver Driver) (*Mapper, context.CancelFunc) {
+	dir := t.TempDir()
+	dmiSocket := filepath.Join(dir, "dmi.sock")
+	lis, err := net.Listen("unix", dmiSocket)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterDeviceManagerServiceServer(grpcServer, manager)
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	config := NewDefaultConfig("test-mapper", "test", filepath.Join(dir, "mapper.sock"))
+	config.DMIAddress = dmiSocket
+	config.CollectCycle = metav1.Duration{Duration: 100 * time.Millisecond}
+	config.ReportCycle = metav1.Duration{Duration: 100 * time.Millisecond}
+	config.InitialBackoff = metav1.Duration{Duration: 50 * time.Millisecond}
+	config.MaxBackoff = metav1.Duration{Duration: 100 * time.Millisecond}
+	mapper := NewMapper(config, driver)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := mapper.Run(ctx); err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	}()
+	return mapper, func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestMapperReportsAndReconciles(t *testing.T) {
+	manager := &fakeManager{
+		devices: []*pb.Device{testDevice("sensor-1", map[string]string{"threshold": "60"})},
+		// the first reports fail and the values are reported in a batch later
+		failures: 2,
+	}
+	driver := newFakeDriver()
+	mapper, stop := startMapper(t, manager, driver)
+	defer stop()
+
+	// the desired value of the registered device is written once it is connected
+	eventually(t, "desired value written", func() bool { return driver.writeCount("sensor-1/threshold") > 0 })
+	eventually(t, "values reported", func() bool { return len(manager.reported("sensor-1", "temperature")) > 3 })
+	manager.mu.Lock()
+	if manager.mapper.Protocol != "test" || manager.mapper.ApiVersion != APIVersion {
+		t.Errorf("registered mapper = %+v", manager.mapper)
+	}
+	first := manager.requests[0]
+	if first.ReportedDevice.State != DeviceStateOnline {
+		t.Errorf("first reported state = %s, want %s", first.ReportedDevice.State, DeviceStateOnline)
+	}
+	// the values collected while the reports failed are reported together
+	if len(first.ReportedDevice.Twins) < 2 {
+		t.Errorf("first report has %d values, want a batch", len(first.ReportedDevice.Twins))
+	}
+	if twin := first.ReportedDevice.Twins[0]; twin.Reported.Metadata[metadataType] == "" || twin.Reported.Metadata[metadataTimestamp] == "" {
+		t.Errorf("reported metadata = %v, want type and timestamp", twin.Reported.Metadata)
+	}
+	manager.mu.Unlock()
+
+	// the device is reconciled to the desired value when it changes on the device
+	writes := driver.writeCount("sensor-1/threshold")
+	driver.set("sensor-1/threshold", "10")
+	eventually(t, "desired value reconciled", func() bool { return driver.writeCount("sensor-1/threshold") > writes })
+
+	// the desired values updated by edgecore are written to ReadWrite properties only
+	ctx := context.Background()
+	_, err := mapper.UpdateDeviceStatus(ctx, &pb.UpdateDeviceStatusRequest{
+		DeviceName: "sensor-1",
+		DesiredDevice: &pb.DeviceStatus{Twins: []*pb.Twin{
+			{PropertyName: "threshold", Desired: &pb.TwinProperty{Value: "70.0"}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateDeviceStatus() error = %v", err)
+	}
+	eventually(t, "updated desired value reported", func() bool {
+		values := manager.reported("sensor-1", "threshold")
+		return len(values) > 0 && values[len(values)-1] == "70.0"
+	})
+	_, err = mapper.UpdateDeviceStatus(ctx, &pb.UpdateDeviceStatusRequest{
+		DeviceName: "sensor-1",
+		DesiredDevice: &pb.DeviceStatus{Twins: []*pb.Twin{
+			{PropertyName: "temperature", Desired: &pb.TwinProperty{Value: "30"}},
+		}},
+	})
+	if err == nil {
+		t.Errorf("UpdateDeviceStatus() of a ReadOnly property, want error")
+	}
+
+	resp, err := mapper.GetDevice(ctx, &pb.GetDeviceRequest{DeviceName: "sensor-1"})
+	if err != nil {
+		t.Fatalf("GetDevice() error = %v", err)
+	}
+	for _, twin := range resp.Device.Status.Twins {
+		if twin.PropertyName == "threshold" && twin.Desired.Value != "70.0" {
+			t.Errorf("desired value of threshold = %s, want 70.0", twin.Desired.Value)
+		}
+	}
+}
+
+func TestMapperManagesDevices(t *testing.T) {
+	manager := &fakeManager{}
+	driver := newFakeDriver()
+	mapper, stop := startMapper(t, manager, driver)
+	defer stop()
+	eventually(t, "mapper registered", func() bool {
+		manager.mu.Lock()
+		defer manager.mu.Unlock()
+		return manager.mapper != nil
+	})
+
+	ctx := context.Background()
+	if _, err := mapper.RegisterDevice(ctx, &pb.RegisterDeviceRequest{Device: &pb.Device{
+		Name: "sensor-2",
+		Spec: &pb.DeviceSpec{DeviceModelReference: "missing"},
+	}}); err == nil {
+		t.Errorf("RegisterDevice() of an unknown device model, want error")
+	}
+	if _, err := mapper.RegisterDevice(ctx, &pb.RegisterDeviceRequest{Device: testDevice("sensor-2", nil)}); err != nil {
+		t.Fatalf("RegisterDevice() error = %v", err)
+	}
+	eventually(t, "registered device reported", func() bool { return len(manager.reported("sensor-2", "temperature")) > 0 })
+
+	if _, err := mapper.UpdateDevice(ctx, &pb.UpdateDeviceRequest{Device: testDevice("sensor-2", map[string]string{"threshold": "80"})}); err != nil {
+		t.Fatalf("UpdateDevice() error = %v", err)
+	}
+	eventually(t, "desired value of updated device written", func() bool { return driver.writeCount("sensor-2/threshold") > 0 })
+
+	if _, err := mapper.RemoveDevice(ctx, &pb.RemoveDeviceRequest{DeviceName: "sensor-2"}); err != nil {
+		t.Fatalf("RemoveDevice() error = %v", err)
+	}
+	if driver.isConnected("sensor-2") {
+		t.Errorf("removed device is still connected")
+	}
+	if _, err := mapper.GetDevice(ctx, &pb.GetDeviceRequest{DeviceName: "sensor-2"}); err == nil {
+		t.Errorf("GetDevice() of a removed device, want error")
+	}
+}
+
+func TestMapperReconnectsDevice(t *testing.T) {
+	manager := &fakeManager{devices: []*pb.Device{testDevice("sensor-3", nil)}}
+	driver := newFakeDriver()
+	_, stop := startMapper(t, manager, driver)
+	defer stop()
+	eventually(t, "device connected", func() bool { return driver.connectCount("sensor-3") == 1 })
+
+	driver.setReadErr(fmt.Errorf("connection reset"))
+	eventually(t, "device reconnected after failed reads", func() bool { return driver.connectCount("sensor-3") > 1 })
+
+	driver.setReadErr(nil)
+	reported := len(manager.reported("sensor-3", "temperature"))
+	eventually(t, "device reported after reconnected", func() bool { return len(manager.reported("sensor-3", "temperature")) > reported })
+}
+
+func TestEqualValues(t *testing.T) {
+	tests := []struct {
+		valueType string
+		a, b      string
+		want      bool
+	}{
+		{valueType: TypeFloat, a: "70", b: "70.0", want: true},
+		{valueType: TypeInt, a: "1", b: "2", want: false},
+		{valueType: TypeString, a: "70", b: "70.0", want: false},
+		{valueType: TypeBoolean, a: "true", b: "true", want: true},
+	}
+	for _, tt := range tests {
+		if got := equalValues(tt.valueType, tt.a, tt.b); got != tt.want {
+			t.Errorf("equalValues(%s, %s, %s) = %v, want %v", tt.valueType, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNewDevice(t *testing.T) {
+	config := NewDefaultConfig("test-mapper", "test", "/tmp/mapper.sock")
+	device := newDevice(testDevice("sensor-1", nil), testModel(), &config)
+	if len(device.Properties) != 2 {
+		t.Fatalf("device has %d properties, want the 2 properties with visitors", len(device.Properties))
+	}
+	temperature, threshold := device.property("temperature"), device.property("threshold")
+	if temperature.Type != TypeInt || temperature.DefaultValue != "20" || temperature.Writable() {
+		t.Errorf("temperature = %+v", temperature)
+	}
+	if temperature.CollectCycle != 20*time.Millisecond || temperature.ReportCycle != 50*time.Millisecond {
+		t.Errorf("cycles of temperature = %v, %v, want the cycles of the visitor", temperature.CollectCycle, temperature.ReportCycle)
+	}
+	if threshold.Type != TypeFloat || !threshold.Writable() || threshold.CollectCycle != DefaultCollectCycle {
+		t.Errorf("threshold = %+v", threshold)
+	}
+	if device.property("label") != nil {
+		t.Errorf("property without visitor is collected")
+	}
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+
+	pb "github.com/kubeedge/kubeedge/pkg/apis/dmi/v1alpha1"
+)
+
+const (
+	// DeviceStateOnline is reported when the device is connected and its properties can be read
+	DeviceStateOnline = "online"
+	// DeviceStateOffline is reported when the device can not be connected or read
+	DeviceStateOffline = "offline"
+
+	// the metadata keys of the reported values read by edgecore
+	metadataType      = "type"
+	metadataTimestamp = "timestamp"
+)
+
+// reportFunc reports the status of a device to edgecore
+type reportFunc func(ctx context.Context, request *pb.ReportDeviceStatusRequest) error
+
+// sample is a value of a property read from the device
+type sample struct {
+	property  *Property
+	value     string
+	timestamp time.Time
+}
+
+type writeRequest struct {
+	property *Property
+	value    string
+	result   chan error
+}
+
+// deviceWorker collects, reports and reconciles the properties of a device, all driver calls
+// of the device are made in its run loop
+type deviceWorker struct {
+	device  *Device
+	driver  Driver
+	config  *Config
+	report  reportFunc
+	backoff *flowcontrol.Backoff
+
+	writes chan writeRequest
+	cancel context.CancelFunc
+	// done is closed when the run loop exits
+	done chan struct{}
+
+	mu sync.Mutex
+	// desired are the desired values of the ReadWrite properties, key is the property name
+	desired map[string]string
+	// samples are the values not reported yet, the oldest first
+	samples []sample
+	// lastReport is the time when the property is reported last time, key is the property name
+	lastReport    map[string]time.Time
+	state         string
+	reportedState string
+}
+
+func newDeviceWorker(device *Device, driver Driver, config *Config, report reportFunc, backoff *flowcontrol.Backoff) *deviceWorker {
+	w := &deviceWorker{
+		device:     device,
+		driver:     driver,
+		config:     config,
+		report:     report,
+		backoff:    backoff,
+		writes:     make(chan writeRequest),
+		done:       make(chan struct{}),
+		desired:    make(map[string]string),
+		lastReport: make(map[string]time.Time),
+	}
+	for _, twin := range device.Instance.GetStatus().GetTwins() {
+		property := device.property(twin.PropertyName)
+		if property != nil && property.Writable() && twin.GetDesired().GetValue() != "" {
+			w.desired[property.Name] = twin.GetDesired().GetValue()
+		}
+	}
+	return w
+}
+
+// start starts the run loop and the report loop of the device, the run loop connects to the device
+// after the previous worker of the device is stopped
+func (w *deviceWorker) start(ctx context.Context, previous *deviceWorker) {
+	ctx, w.cancel = context.WithCancel(ctx)
+	go w.run(ctx, previous)
+	go w.runReport(ctx)
+}
+
+// stop stops the loops and waits until the device is closed
+func (w *deviceWorker) stop() {
+	w.cancel()
+	<-w.done
+}
+
+func (w *deviceWorker) run(ctx context.Context, previous *deviceWorker) {
+	defer close(w.done)
+	if previous != nil {
+		previous.stop()
+	}
+	for w.connect(ctx) {
+		w.serve(ctx)
+		if err := w.driver.Close(context.Background(), w.device); err != nil {
+			klog.Errorf("failed to close device %s: %v", w.device.Name, err)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		// the device is connected again after backoff
+		w.setState(DeviceStateOffline)
+		w.backoff.Next(w.device.Name, time.Now())
+		delay := w.backoff.Get(w.device.Name)
+		klog.Errorf("failed to read device %s for %d times, will reconnect in %v", w.device.Name, w.config.MaxReadFailures, delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// serve writes the desired values, collects the properties and handles the writes of the connected device,
+// it returns when the context is done or the device fails to be read for MaxReadFailures times
+func (w *deviceWorker) serve(ctx context.Context) {
+	for _, property := range w.device.Properties {
+		if value, ok := w.desiredValue(property.Name); ok {
+			_ = w.write(ctx, property, value)
+		}
+	}
+
+	failures := 0
+	lastCollect := make(map[string]time.Time)
+	ticker := time.NewTicker(minCycle(w.device.Properties, func(p *Property) time.Duration { return p.CollectCycle }))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case request := <-w.writes:
+			request.result <- w.write(ctx, request.property, request.value)
+		case now := <-ticker.C:
+			for _, property := range w.device.Properties {
+				if now.Sub(lastCollect[property.Name]) < property.CollectCycle {
+					continue
+				}
+				lastCollect[property.Name] = now
+				if w.collect(ctx, property, now) {
+					failures = 0
+					continue
+				}
+				if failures++; failures >= w.config.MaxReadFailures {
+					return
+				}
+			}
+		}
+	}
+}
+
+// connect connects to the device with backoff, it returns false if the context is done
+func (w *deviceWorker) connect(ctx context.Context) bool {
+	for {
+		err := w.driver.Connect(ctx, w.device)
+		if err == nil {
+			w.setState(DeviceStateOnline)
+			klog.Infof("device %s is connected", w.device.Name)
+			return true
+		}
+		w.setState(DeviceStateOffline)
+		w.backoff.Next(w.device.Name, time.Now())
+		delay := w.backoff.Get(w.device.Name)
+		klog.Errorf("failed to connect to device %s, will retry in %v: %v", w.device.Name, delay, err)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+	}
+}
+
+// collect reads the property and writes the desired value again if the device does not have it,
+// it returns false if the property fails to be read
+func (w *deviceWorker) collect(ctx context.Context, property *Property, now time.Time) bool {
+	value, err := w.driver.ReadProperty(ctx, w.device, property)
+	if err != nil {
+		klog.Errorf("failed to read property %s of device %s: %v", property.Name, w.device.Name, err)
+		w.setState(DeviceStateOffline)
+		return false
+	}
+	// the backoff of reconnecting is reset once the device is read after connected
+	w.backoff.Reset(w.device.Name)
+	w.setState(DeviceStateOnline)
+
+	w.mu.Lock()
+	w.samples = append(w.samples, sample{property: property, value: value, timestamp: now})
+	if dropped := len(w.samples) - w.config.MaxBatchSize; dropped > 0 {
+		klog.Warningf("drop %d values of device %s which are not reported", dropped, w.device.Name)
+		w.samples = w.samples[dropped:]
+	}
+	w.mu.Unlock()
+
+	if desired, ok := w.desiredValue(property.Name); ok && !equalValues(property.Type, value, desired) {
+		klog.Infof("property %s of device %s is %s, reconcile it to desired value %s", property.Name, w.device.Name, value, desired)
+		_ = w.write(ctx, property, desired)
+	}
+	return true
+}
+
+func (w *deviceWorker) write(ctx context.Context, property *Property, value string) error {
+	if err := w.driver.WriteProperty(ctx, w.device, property, value); err != nil {
+		klog.Errorf("failed to write %s to property %s of device %s: %v", value, property.Name, w.device.Name, err)
+		return err
+	}
+	return nil
+}
+
+// setDesired keeps the desired value of the property and writes it to the device,
+// an empty value stops reconciling the property
+func (w *deviceWorker) setDesired(ctx context.Context, name, value string) error {
+	property := w.device.property(name)
+	if property == nil {
+		return fmt.Errorf("device %s has no visitor of property %s", w.device.Name, name)
+	}
+	if !property.Writable() {
+		return fmt.Errorf("property %s of device %s is not ReadWrite", name, w.device.Name)
+	}
+
+	w.mu.Lock()
+	if value == "" {
+		delete(w.desired, name)
+		w.mu.Unlock()
+		return nil
+	}
+	w.desired[name] = value
+	w.mu.Unlock()
+
+	request := writeRequest{property: property, value: value, result: make(chan error, 1)}
+	select {
+	case w.writes <- request:
+	case <-ctx.Done():
+		return fmt.Errorf("device %s is not connected, the desired value will be written once it is connected", w.device.Name)
+	case <-w.done:
+		return fmt.Errorf("device %s is stopped", w.device.Name)
+	}
+	select {
+	case err := <-request.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *deviceWorker) desiredValue(name string) (string, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	value, ok := w.desired[name]
+	return value, ok
+}
+
+func (w *deviceWorker) setState(state string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.state = state
+}
+
+// runReport reports the collected values and the state of the device in batches
+func (w *deviceWorker) runReport(ctx context.Context) {
+	ticker := time.NewTicker(minCycle(w.device.Properties, func(p *Property) time.Duration { return p.ReportCycle }))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.flush(ctx, now)
+		}
+	}
+}
+
+// flush reports the values of the properties which are due in one request,
+// the values are kept and reported again after backoff if the report fails
+func (w *deviceWorker) flush(ctx context.Context, now time.Time) {
+	backoffKey := "report/" + w.device.Name
+	if w.backoff.IsInBackOffSinceUpdate(backoffKey, now) {
+		return
+	}
+
+	w.mu.Lock()
+	var due, kept []sample
+	dueProperties := make(map[string]bool)
+	for _, s := range w.samples {
+		isDue, ok := dueProperties[s.property.Name]
+		if !ok {
+			isDue = now.Sub(w.lastReport[s.property.Name]) >= s.property.ReportCycle
+			dueProperties[s.property.Name] = isDue
+		}
+		if isDue {
+			due = append(due, s)
+		} else {
+			kept = append(kept, s)
+		}
+	}
+	state := w.state
+	if len(due) == 0 && state == w.reportedState {
+		w.mu.Unlock()
+		return
+	}
+	w.samples = kept
+	w.mu.Unlock()
+
+	request := &pb.ReportDeviceStatusRequest{
+		DeviceName:     w.device.Name,
+		ReportedDevice: &pb.DeviceStatus{},
+	}
+	if state != w.reportedState {
+		request.ReportedDevice.State = state
+	}
+	for _, s := range due {
+		request.ReportedDevice.Twins = append(request.ReportedDevice.Twins, &pb.Twin{
+			PropertyName: s.property.Name,
+			Reported: &pb.TwinProperty{
+				Value: s.value,
+				Metadata: map[string]string{
+					metadataType:      s.property.Type,
+					metadataTimestamp: strconv.FormatInt(s.timestamp.UnixNano()/int64(time.Millisecond), 10),
+				},
+			},
+		})
+	}
+
+	if err := w.report(ctx, request); err != nil {
+		w.backoff.Next(backoffKey, now)
+		klog.Errorf("failed to report %d values of device %s, will retry in %v: %v",
+			len(due), w.device.Name, w.backoff.Get(backoffKey), err)
+		w.mu.Lock()
+		w.samples = append(due, w.samples...)
+		if dropped := len(w.samples) - w.config.MaxBatchSize; dropped > 0 {
+			w.samples = w.samples[dropped:]
+		}
+		w.mu.Unlock()
+		return
+	}
+	w.backoff.Reset(backoffKey)
+	w.mu.Lock()
+	w.reportedState = state
+	for name, isDue := range dueProperties {
+		if isDue {
+			w.lastReport[name] = now
+		}
+	}
+	w.mu.Unlock()
+}
+
+// status returns the device with the desired values kept by the worker
+func (w *deviceWorker) status() *pb.Device {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	device := &pb.Device{
+		Name:   w.device.Name,
+		Spec:   w.device.Instance.GetSpec(),
+		Status: &pb.DeviceStatus{State: w.state},
+	}
+	for _, property := range w.device.Properties {
+		device.Status.Twins = append(device.Status.Twins, &pb.Twin{
+			PropertyName: property.Name,
+			Desired:      &pb.TwinProperty{Value: w.desired[property.Name]},
+		})
+	}
+	return device
+}
+
+// minCycle returns the minimum cycle of the properties, or a second if the device has no property
+func minCycle(properties []*Property, cycle func(*Property) time.Duration) time.Duration {
+	min := time.Duration(0)
+	for _, property := range properties {
+		if c := cycle(property); min == 0 || c < min {
+			min = c
+		}
+	}
+	if min <= 0 {
+		return time.Second
+	}
+	return min
+}
+
+// equalValues compares the values of the type, the numbers are compared by their values
+func equalValues(valueType, a, b string) bool {
+	if a == b {
+		return true
+	}
+	if valueType != TypeInt && valueType != TypeFloat {
+		return false
+	}
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	return errX == nil && errY == nil && x == y
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	apiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+	"github.com/kubeedge/kubeedge/mappers/simulator"
+)
+
+func main() {
+	command := newSimulatorMapperCommand()
+	if err := command.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// newSimulatorMapperCommand creates a *cobra.Command object with default parameters
+func newSimulatorMapperCommand() *cobra.Command {
+	var configFile string
+	cmd := &cobra.Command{
+		Use: "simulator-mapper",
+		Long: `The simulator mapper simulates the devices of its protocol for testing. It registers to edgecore
+through DMI and reports the property values generated by the configured generators.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := simulator.LoadConfig(configFile)
+			if err != nil {
+				return err
+			}
+			return sdk.NewMapper(config.Config, simulator.NewDriver(config)).Run(apiserver.SetupSignalContext())
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			for _, arg := range args {
+				if len(arg) > 0 {
+					return fmt.Errorf("%q does not take any arguments, got %q", cmd.CommandPath(), args)
+				}
+			}
+			return nil
+		},
+	}
+
+	fs := cmd.Flags()
+	klog.InitFlags(flag.CommandLine)
+	fs.AddGoFlagSet(flag.CommandLine)
+	fs.StringVar(&configFile, "config", "", "The path to the configuration file of the simulator mapper.")
+	return cmd
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+)
+
+const (
+	// DefaultName is the default name of the simulator mapper
+	DefaultName = "simulator-mapper"
+	// DefaultProtocol is the default protocol served by the simulator mapper,
+	// devices use it as the name of their customized protocol
+	DefaultProtocol = "simulator"
+	// DefaultAddress is the default unix socket the simulator mapper listens on
+	DefaultAddress = "/etc/kubeedge/simulator.sock"
+)
+
+// Config is the configuration of the simulator mapper
+type Config struct {
+	sdk.Config
+	// Properties are the generators of the properties of all devices, key is the property name
+	Properties map[string]GeneratorConfig `json:"properties,omitempty"`
+	// Devices are the generators of the properties of a device which override Properties,
+	// key is the device name and then the property name
+	Devices map[string]map[string]GeneratorConfig `json:"devices,omitempty"`
+}
+
+// NewDefaultConfig returns a config with the default values
+func NewDefaultConfig() *Config {
+	return &Config{
+		Config: sdk.NewDefaultConfig(DefaultName, DefaultProtocol, DefaultAddress),
+	}
+}
+
+// LoadConfig loads the config from the yaml file, the fields which are not set keep the default values
+func LoadConfig(path string) (*Config, error) {
+	config := NewDefaultConfig()
+	if path == "" {
+		return config, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %v", path, err)
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// generatorConfig returns the generator of the property of the device, it returns false if none is configured
+func (c *Config) generatorConfig(device, property string) (GeneratorConfig, bool) {
+	if generator, ok := c.Devices[device][property]; ok {
+		return generator, true
+	}
+	generator, ok := c.Properties[property]
+	return generator, ok
+}
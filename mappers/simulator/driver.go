@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+)
+
+// Driver simulates the devices, the values of the properties are generated by the configured generators
+type Driver struct {
+	config *Config
+	now    func() time.Time
+}
+
+// NewDriver creates the driver of the simulator mapper
+func NewDriver(config *Config) *Driver {
+	return &Driver{config: config, now: time.Now}
+}
+
+// simulatedDevice keeps the generators and the written values of a device
+type simulatedDevice struct {
+	generators map[string]Generator
+	// written are the values written to the ReadWrite properties, they are read instead of the generated values
+	written map[string]string
+}
+
+// Connect creates the generators of the properties, the properties without a configured generator
+// generate the default value of the device model
+func (d *Driver) Connect(ctx context.Context, device *sdk.Device) error {
+	simulated := &simulatedDevice{
+		generators: make(map[string]Generator),
+		written:    make(map[string]string),
+	}
+	for _, property := range device.Properties {
+		generatorConfig, ok := d.config.generatorConfig(device.Name, property.Name)
+		if !ok {
+			generatorConfig = GeneratorConfig{Type: GeneratorConstant, Value: property.DefaultValue}
+		}
+		generator, err := NewGenerator(generatorConfig, property.Type)
+		if err != nil {
+			return fmt.Errorf("invalid generator of property %s: %v", property.Name, err)
+		}
+		simulated.generators[property.Name] = generator
+	}
+	device.Client = simulated
+	return nil
+}
+
+// ReadProperty returns the value written to the property, or the next generated value
+func (d *Driver) ReadProperty(ctx context.Context, device *sdk.Device, property *sdk.Property) (string, error) {
+	simulated := device.Client.(*simulatedDevice)
+	if value, ok := simulated.written[property.Name]; ok {
+		return value, nil
+	}
+	return simulated.generators[property.Name].Next(d.now()), nil
+}
+
+// WriteProperty keeps the value of the property
+func (d *Driver) WriteProperty(ctx context.Context, device *sdk.Device, property *sdk.Property, value string) error {
+	device.Client.(*simulatedDevice).written[property.Name] = value
+	return nil
+}
+
+// Close does nothing as the device is not connected
+func (d *Driver) Close(ctx context.Context, device *sdk.Device) error {
+	return nil
+}
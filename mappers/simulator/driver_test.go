@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+)
+
+func TestDriver(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Properties = map[string]GeneratorConfig{
+		"temperature": {Type: GeneratorConstant, Value: "25"},
+	}
+	config.Devices = map[string]map[string]GeneratorConfig{
+		"sensor-2": {"temperature": {Type: GeneratorConstant, Value: "30"}},
+	}
+	driver := NewDriver(config)
+	newDevice := func(name string) *sdk.Device {
+		return &sdk.Device{
+			Name: name,
+			Properties: []*sdk.Property{
+				{Name: "temperature", Type: sdk.TypeInt, AccessMode: "ReadOnly", DefaultValue: "20"},
+				{Name: "switch", Type: sdk.TypeBoolean, AccessMode: "ReadWrite", DefaultValue: "false"},
+			},
+		}
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		device      string
+		temperature string
+	}{
+		{device: "sensor-1", temperature: "25"},
+		{device: "sensor-2", temperature: "30"},
+	}
+	for _, tt := range tests {
+		device := newDevice(tt.device)
+		if err := driver.Connect(ctx, device); err != nil {
+			t.Fatalf("Connect() error = %v", err)
+		}
+		if value, _ := driver.ReadProperty(ctx, device, device.Properties[0]); value != tt.temperature {
+			t.Errorf("temperature of %s = %s, want %s", tt.device, value, tt.temperature)
+		}
+
+		// the property without generator reads the default value until a value is written
+		if value, _ := driver.ReadProperty(ctx, device, device.Properties[1]); value != "false" {
+			t.Errorf("switch of %s = %s, want the default value", tt.device, value)
+		}
+		if err := driver.WriteProperty(ctx, device, device.Properties[1], "true"); err != nil {
+			t.Fatalf("WriteProperty() error = %v", err)
+		}
+		if value, _ := driver.ReadProperty(ctx, device, device.Properties[1]); value != "true" {
+			t.Errorf("switch of %s = %s, want the written value", tt.device, value)
+		}
+	}
+
+	config.Properties["switch"] = GeneratorConfig{Type: GeneratorSine}
+	config.Properties["temperature"] = GeneratorConfig{Type: GeneratorReplay, File: "missing.csv"}
+	if err := driver.Connect(ctx, newDevice("sensor-1")); err == nil {
+		t.Errorf("Connect() with an invalid generator, want error")
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+)
+
+// GeneratorType is the type of a value generator
+type GeneratorType string
+
+const (
+	// GeneratorConstant always generates the same value
+	GeneratorConstant GeneratorType = "constant"
+	// GeneratorRandomWalk adds a random step to the last value
+	GeneratorRandomWalk GeneratorType = "randomWalk"
+	// GeneratorSine generates a sine wave
+	GeneratorSine GeneratorType = "sine"
+	// GeneratorReplay replays a column of a csv file
+	GeneratorReplay GeneratorType = "replay"
+)
+
+const defaultSinePeriod = time.Minute
+
+// GeneratorConfig is the configuration of a value generator
+type GeneratorConfig struct {
+	// Type is the type of the generator
+	Type GeneratorType `json:"type"`
+	// Value is the value of the constant generator
+	Value string `json:"value,omitempty"`
+	// Start is the first value of the random walk generator
+	Start float64 `json:"start,omitempty"`
+	// Step is the maximum change between two values of the random walk generator, it is 1 if not set
+	Step float64 `json:"step,omitempty"`
+	// Min and Max bound the values of the random walk generator, the values are not bounded if both are zero
+	Min float64 `json:"min,omitempty"`
+	Max float64 `json:"max,omitempty"`
+	// Amplitude, Offset and Period describe the wave of the sine generator,
+	// the value is offset + amplitude * sin(2π * t / period) and the period is one minute if not set
+	Amplitude float64         `json:"amplitude,omitempty"`
+	Offset    float64         `json:"offset,omitempty"`
+	Period    metav1.Duration `json:"period,omitempty"`
+	// File is the csv file of the replay generator, its first line is the header
+	File string `json:"file,omitempty"`
+	// Column is the header of the column replayed, the first column is replayed if not set
+	Column string `json:"column,omitempty"`
+}
+
+// Generator generates the values of a property
+type Generator interface {
+	// Next returns the value of the property at the time
+	Next(now time.Time) string
+}
+
+// NewGenerator creates the generator of the config for a property of the value type,
+// the numeric generators only support the int, float and boolean properties.
+// Positive values are reported as true for the boolean properties
+func NewGenerator(config GeneratorConfig, valueType string) (Generator, error) {
+	switch config.Type {
+	case GeneratorConstant:
+		return &constantGenerator{value: config.Value}, nil
+	case GeneratorReplay:
+		return newReplayGenerator(config.File, config.Column)
+	}
+
+	if valueType != sdk.TypeInt && valueType != sdk.TypeFloat && valueType != sdk.TypeBoolean {
+		return nil, fmt.Errorf("generator %s does not support the properties of type %s", config.Type, valueType)
+	}
+	switch config.Type {
+	case GeneratorRandomWalk:
+		step := config.Step
+		if step == 0 {
+			step = 1
+		}
+		return &randomWalkGenerator{
+			valueType: valueType,
+			value:     config.Start,
+			step:      step,
+			min:       config.Min,
+			max:       config.Max,
+			rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		}, nil
+	case GeneratorSine:
+		period := config.Period.Duration
+		if period <= 0 {
+			period = defaultSinePeriod
+		}
+		return &sineGenerator{
+			valueType: valueType,
+			amplitude: config.Amplitude,
+			offset:    config.Offset,
+			period:    period,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown generator type %q", config.Type)
+	}
+}
+
+type constantGenerator struct {
+	value string
+}
+
+func (g *constantGenerator) Next(time.Time) string {
+	return g.value
+}
+
+type randomWalkGenerator struct {
+	valueType string
+	value     float64
+	step      float64
+	min       float64
+	max       float64
+	rand      *rand.Rand
+}
+
+func (g *randomWalkGenerator) Next(time.Time) string {
+	g.value += (g.rand.Float64()*2 - 1) * g.step
+	if g.min != 0 || g.max != 0 {
+		g.value = math.Max(g.min, math.Min(g.max, g.value))
+	}
+	return formatValue(g.valueType, g.value)
+}
+
+type sineGenerator struct {
+	valueType string
+	amplitude float64
+	offset    float64
+	period    time.Duration
+	// start is the time of the first value
+	start time.Time
+}
+
+func (g *sineGenerator) Next(now time.Time) string {
+	if g.start.IsZero() {
+		g.start = now
+	}
+	phase := 2 * math.Pi * float64(now.Sub(g.start)) / float64(g.period)
+	return formatValue(g.valueType, g.offset+g.amplitude*math.Sin(phase))
+}
+
+type replayGenerator struct {
+	values []string
+	next   int
+}
+
+// newReplayGenerator reads the column of the csv file, the values are replayed in a loop
+func newReplayGenerator(file, column string) (*replayGenerator, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay file: %v", err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replay file %s: %v", file, err)
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("replay file %s has no values", file)
+	}
+
+	index := -1
+	for i, header := range records[0] {
+		if column == "" || header == column {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("replay file %s has no column %s", file, column)
+	}
+	values := make([]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		values = append(values, record[index])
+	}
+	return &replayGenerator{values: values}, nil
+}
+
+func (g *replayGenerator) Next(time.Time) string {
+	value := g.values[g.next]
+	g.next = (g.next + 1) % len(g.values)
+	return value
+}
+
+func formatValue(valueType string, value float64) string {
+	switch valueType {
+	case sdk.TypeInt:
+		return strconv.FormatInt(int64(math.Round(value)), 10)
+	case sdk.TypeBoolean:
+		return strconv.FormatBool(value > 0)
+	default:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeedge/kubeedge/mappers/sdk"
+)
+
+func TestConstantGenerator(t *testing.T) {
+	g, err := NewGenerator(GeneratorConfig{Type: GeneratorConstant, Value: "on"}, sdk.TypeString)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if v := g.Next(time.Now()); v != "on" {
+			t.Errorf("Next() = %s, want on", v)
+		}
+	}
+}
+
+func TestRandomWalkGenerator(t *testing.T) {
+	g, err := NewGenerator(GeneratorConfig{Type: GeneratorRandomWalk, Start: 50, Step: 5, Min: 40, Max: 60}, sdk.TypeInt)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	last := 50
+	for i := 0; i < 1000; i++ {
+		v, err := strconv.Atoi(g.Next(time.Now()))
+		if err != nil {
+			t.Fatalf("Next() is not an int: %v", err)
+		}
+		if v < 40 || v > 60 {
+			t.Fatalf("Next() = %d, want between 40 and 60", v)
+		}
+		if v-last > 5 || last-v > 5 {
+			t.Fatalf("Next() = %d after %d, want a step no larger than 5", v, last)
+		}
+		last = v
+	}
+
+	if _, err := NewGenerator(GeneratorConfig{Type: GeneratorRandomWalk}, sdk.TypeString); err == nil {
+		t.Errorf("NewGenerator() of a string property, want error")
+	}
+}
+
+func TestSineGenerator(t *testing.T) {
+	g, err := NewGenerator(GeneratorConfig{
+		Type:      GeneratorSine,
+		Amplitude: 10,
+		Offset:    20,
+		Period:    metav1.Duration{Duration: 4 * time.Second},
+	}, sdk.TypeFloat)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	start := time.Now()
+	expected := []string{"20", "30", "20", "10"}
+	for i, want := range expected {
+		v, err := strconv.ParseFloat(g.Next(start.Add(time.Duration(i)*time.Second)), 64)
+		if err != nil {
+			t.Fatalf("Next() is not a float: %v", err)
+		}
+		if strconv.FormatFloat(v, 'f', 6, 64) != want+".000000" {
+			t.Errorf("Next() at %ds = %v, want %s", i, v, want)
+		}
+	}
+}
+
+func TestReplayGenerator(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "replay.csv")
+	if err := os.WriteFile(file, []byte("time,temperature\n1,20\n2,21\n3,22\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewGenerator(GeneratorConfig{Type: GeneratorReplay, File: file, Column: "temperature"}, sdk.TypeInt)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	for _, want := range []string{"20", "21", "22", "20"} {
+		if v := g.Next(time.Now()); v != want {
+			t.Errorf("Next() = %s, want %s", v, want)
+		}
+	}
+
+	if _, err := NewGenerator(GeneratorConfig{Type: GeneratorReplay, File: file, Column: "humidity"}, sdk.TypeInt); err == nil {
+		t.Errorf("NewGenerator() with an unknown column, want error")
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		valueType string
+		value     float64
+		want      string
+	}{
+		{valueType: sdk.TypeInt, value: 1.6, want: "2"},
+		{valueType: sdk.TypeFloat, value: 1.5, want: "1.5"},
+		{valueType: sdk.TypeBoolean, value: 0.1, want: "true"},
+		{valueType: sdk.TypeBoolean, value: -0.1, want: "false"},
+	}
+	for _, tt := range tests {
+		if got := formatValue(tt.valueType, tt.value); got != tt.want {
+			t.Errorf("formatValue(%s, %v) = %s, want %s", tt.valueType, tt.value, got, tt.want)
+		}
+	}
+}
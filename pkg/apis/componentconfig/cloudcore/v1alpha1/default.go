@@ -101,9 +101,13 @@ func NewDefaultCloudCoreConfig() *CloudCoreConfig {
 			DeviceController: &DeviceController{
 				Enable: true,
 				Buffer: &DeviceControllerBuffer{
-					UpdateDeviceStatus: constants.DefaultUpdateDeviceStatusBuffer,
-					DeviceEvent:        constants.DefaultDeviceEventBuffer,
-					DeviceModelEvent:   constants.DefaultDeviceModelEventBuffer,
+					UpdateDeviceStatus:        constants.DefaultUpdateDeviceStatusBuffer,
+					DeviceEvent:               constants.DefaultDeviceEventBuffer,
+					DeviceModelEvent:          constants.DefaultDeviceModelEventBuffer,
+					DeviceCommandRequestEvent: constants.DefaultDeviceCommandRequestEventBuffer,
+					UpdateDeviceCommandResult: constants.DefaultUpdateDeviceCommandResultBuffer,
+					DeviceAlertRuleEvent:      constants.DefaultDeviceAlertRuleEventBuffer,
+					UpdateDeviceAlert:         constants.DefaultUpdateDeviceAlertBuffer,
 				},
 				Load: &DeviceControllerLoad{
 					UpdateDeviceStatusWorkers: constants.DefaultUpdateDeviceStatusWorkers,
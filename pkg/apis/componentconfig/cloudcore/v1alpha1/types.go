@@ -134,9 +134,21 @@ type CloudHub struct {
 	// KeepaliveInterval indicates keep-alive interval (second)
 	// default 30
 	KeepaliveInterval int32 `json:"keepaliveInterval,omitempty"`
+	// MaxKeepaliveInterval indicates the maximum keep-alive interval (second), the interval is
+	// extended up to it according to the heart beat and link quality reported by edge node,
+	// so that a node on a slow or lossy link is not regarded as disconnected too early.
+	// 0 means the keep-alive interval is not adapted
+	// default 0
+	MaxKeepaliveInterval int32 `json:"maxKeepaliveInterval,omitempty"`
 	// NodeLimit is a maximum number of edge node that can connect to the single CloudCore
 	// default 1000         // TODO: tune NodeLimit
 	NodeLimit int32 `json:"nodeLimit,omitempty"`
+	// SessionResumeGracePeriod indicates how long (second) the session of a disconnected
+	// edge node is kept, an edge node reconnected within the period with the session token
+	// resumes its session and the messages not acknowledged are sent again.
+	// 0 means session resumption is disabled
+	// default 0
+	SessionResumeGracePeriod int32 `json:"sessionResumeGracePeriod,omitempty"`
 	// TLSCAFile indicates ca file path
 	// default "/etc/kubeedge/ca/rootCA.crt"
 	TLSCAFile string `json:"tlsCAFile,omitempty"`
@@ -169,6 +181,10 @@ type CloudHub struct {
 	// EdgeCertSigningDuration indicates the validity period of edge certificate
 	// default 365d
 	EdgeCertSigningDuration time.Duration `json:"edgeCertSigningDuration,omitempty"`
+	// EdgeCertKeyAlgorithms indicates the key algorithms allowed for edge certificates,
+	// the supported ones are ECDSA-P256, ECDSA-P384, RSA-2048, RSA-4096 and Ed25519
+	// default empty, which means all the supported key algorithms are allowed
+	EdgeCertKeyAlgorithms []string `json:"edgeCertKeyAlgorithms,omitempty"`
 	// TokenRefreshDuration indicates the interval of cloudcore token refresh, unit is hour
 	// default 12h
 	TokenRefreshDuration time.Duration `json:"tokenRefreshDuration,omitempty"`
@@ -402,6 +418,18 @@ type DeviceControllerBuffer struct {
 	// DeviceModelEvent indicates the buffer of device model event
 	// default 1
 	DeviceModelEvent int32 `json:"deviceModelEvent,omitempty"`
+	// DeviceCommandRequestEvent indicates the buffer of device command request event
+	// default 1
+	DeviceCommandRequestEvent int32 `json:"deviceCommandRequestEvent,omitempty"`
+	// UpdateDeviceCommandResult indicates the buffer of update device command result
+	// default 1024
+	UpdateDeviceCommandResult int32 `json:"updateDeviceCommandResult,omitempty"`
+	// DeviceAlertRuleEvent indicates the buffer of device alert rule event
+	// default 1
+	DeviceAlertRuleEvent int32 `json:"deviceAlertRuleEvent,omitempty"`
+	// UpdateDeviceAlert indicates the buffer of update device alert
+	// default 1024
+	UpdateDeviceAlert int32 `json:"updateDeviceAlert,omitempty"`
 }
 
 // DeviceControllerLoad indicates the deviceController load
@@ -30,6 +30,7 @@ import (
 	netutils "k8s.io/utils/net"
 
 	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/cloudcore/v1alpha1"
+	"github.com/kubeedge/kubeedge/pkg/util/pki"
 	utilvalidation "github.com/kubeedge/kubeedge/pkg/util/validation"
 )
 
@@ -127,6 +128,20 @@ func ValidateModuleCloudHub(c v1alpha1.CloudHub) field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("TokenRefreshDuration"),
 			c.TokenRefreshDuration, "TokenRefreshDuration must be positive"))
 	}
+	if c.MaxKeepaliveInterval != 0 && c.MaxKeepaliveInterval < c.KeepaliveInterval {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("MaxKeepaliveInterval"),
+			c.MaxKeepaliveInterval, "MaxKeepaliveInterval must not be less than KeepaliveInterval"))
+	}
+	if c.SessionResumeGracePeriod < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("SessionResumeGracePeriod"),
+			c.SessionResumeGracePeriod, "SessionResumeGracePeriod must not be negative"))
+	}
+	for _, algorithm := range c.EdgeCertKeyAlgorithms {
+		if !pki.IsSupportedKeyAlgorithm(algorithm) {
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("EdgeCertKeyAlgorithms"),
+				algorithm, pki.SupportedKeyAlgorithms))
+		}
+	}
 	return allErrs
 }
 
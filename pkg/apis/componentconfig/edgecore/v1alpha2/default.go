@@ -27,6 +27,7 @@ import (
 	"github.com/kubeedge/kubeedge/common/constants"
 	metaconfig "github.com/kubeedge/kubeedge/pkg/apis/componentconfig/meta/v1alpha1"
 	"github.com/kubeedge/kubeedge/pkg/util"
+	"github.com/kubeedge/kubeedge/pkg/util/pki"
 	mikunode "github.com/qbox/mikud-live/common/node"
 )
 
@@ -47,6 +48,12 @@ func NewDefaultEdgeCoreConfig() *EdgeCoreConfig {
 			DriverName: DataBaseDriverName,
 			AliasName:  DataBaseAliasName,
 			DataSource: DataBaseDataSource,
+			Maintenance: &DataBaseMaintenance{
+				IntegrityCheck:   true,
+				SnapshotPath:     DataBaseSnapshotPath,
+				SnapshotPeriod:   3600,
+				CompactionPeriod: 86400,
+			},
 		},
 		Modules: &Modules{
 			Edged: &Edged{
@@ -77,6 +84,7 @@ func NewDefaultEdgeCoreConfig() *EdgeCoreConfig {
 			EdgeHub: &EdgeHub{
 				Enable:            true,
 				Heartbeat:         15,
+				MinHeartbeat:      5,
 				MessageQPS:        constants.DefaultQPS,
 				MessageBurst:      constants.DefaultBurst,
 				ProjectID:         "e632aba927ea4ac2b575ec1603d56f10",
@@ -101,8 +109,11 @@ func NewDefaultEdgeCoreConfig() *EdgeCoreConfig {
 					Scheme: "https",
 					Host:   net.JoinHostPort(localIP, "10002"),
 				}).String(),
-				Token:              "",
-				RotateCertificates: true,
+				Token:                 "",
+				RotateCertificates:    true,
+				KeyAlgorithm:          pki.KeyAlgorithmECDSAP256,
+				RotateKey:             true,
+				CertRotationThreshold: 80,
 			},
 			EventBus: &EventBus{
 				Enable:               true,
@@ -129,12 +140,22 @@ func NewDefaultEdgeCoreConfig() *EdgeCoreConfig {
 				ContextSendModule:  metaconfig.ModuleNameEdgeHub,
 				RemoteQueryTimeout: constants.DefaultRemoteQueryTimeout,
 				MetaServer: &MetaServer{
-					Enable:                false,
-					Server:                constants.DefaultMetaServerAddr,
-					TLSCaFile:             constants.DefaultCAFile,
-					TLSCertFile:           constants.DefaultCertFile,
-					TLSPrivateKeyFile:     constants.DefaultKeyFile,
-					ServiceAccountIssuers: []string{constants.DefaultServiceAccountIssuer},
+					Enable:                     false,
+					Server:                     constants.DefaultMetaServerAddr,
+					TLSCaFile:                  constants.DefaultCAFile,
+					TLSCertFile:                constants.DefaultCertFile,
+					TLSPrivateKeyFile:          constants.DefaultKeyFile,
+					ServiceAccountIssuers:      []string{constants.DefaultServiceAccountIssuer},
+					EnableOfflineWrite:         false,
+					OfflineWriteConflictPolicy: OfflineWriteCloudWins,
+					Authorization: &MetaServerAuthorization{
+						FailClosed: true,
+					},
+				},
+				Encryption: &MetaEncryption{
+					Enable:    false,
+					KeyFile:   constants.DefaultMetaEncryptionKeyFile,
+					Resources: []string{"secrets", "configmaps", "serviceaccounttokens"},
 				},
 			},
 			ServiceBus: &ServiceBus{
@@ -145,6 +166,20 @@ func NewDefaultEdgeCoreConfig() *EdgeCoreConfig {
 			},
 			DeviceTwin: &DeviceTwin{
 				Enable: true,
+				TimeSeries: &DeviceTwinTimeSeries{
+					Enable:               false,
+					MaxAge:               3600,
+					MaxPointsPerProperty: 3600,
+					UploadPeriod:         60,
+					UploadBatchSize:      1000,
+				},
+				DMI: &DeviceTwinDMI{
+					SocketPath: constants.DefaultDMISockPath,
+					TCP: &DeviceTwinDMITCP{
+						Enable:  false,
+						Address: constants.DefaultDMITCPAddress,
+					},
+				},
 			},
 			DBTest: &DBTest{
 				Enable: false,
@@ -32,6 +32,17 @@ const (
 	MqttModeExternal MqttMode = 2
 )
 
+// OfflineWriteConflictPolicy indicates how the conflicts are resolved when the writes
+// accepted by MetaServer while offline are replayed to the cloud
+type OfflineWriteConflictPolicy string
+
+const (
+	// OfflineWriteCloudWins discards the conflicting local write and keeps the object in the cloud
+	OfflineWriteCloudWins OfflineWriteConflictPolicy = "CloudWins"
+	// OfflineWriteLocalWins overwrites the object in the cloud with the local write
+	OfflineWriteLocalWins OfflineWriteConflictPolicy = "LocalWins"
+)
+
 const (
 	CGroupDriverCGroupFS = "cgroupfs"
 	CGroupDriverSystemd  = "systemd"
@@ -40,10 +51,18 @@ const (
 const (
 	// DataBaseDriverName is sqlite3
 	DataBaseDriverName = "sqlite3"
+	// DataBaseDriverBolt is the embedded key-value store bbolt
+	DataBaseDriverBolt = "bolt"
 	// DataBaseAliasName is default
 	DataBaseAliasName = "default"
 	// DataBaseDataSource is edge.db
 	DataBaseDataSource = "/var/lib/kubeedge/edgecore.db"
+	// DataBaseSnapshotPath is the snapshot of edge.db
+	DataBaseSnapshotPath = "/var/lib/kubeedge/edgecore.db.snapshot"
+	// DataBaseJournalModeWAL is the write-ahead log journal mode of sqlite3
+	DataBaseJournalModeWAL = "WAL"
+	// DataBaseJournalModeDelete is the rollback journal mode of sqlite3
+	DataBaseJournalModeDelete = "DELETE"
 )
 
 type ProtocolName string
@@ -64,7 +83,8 @@ type EdgeCoreConfig struct {
 
 // DataBase indicates the database info
 type DataBase struct {
-	// DriverName indicates database driver name
+	// DriverName indicates database driver name, sqlite3 or bolt,
+	// use "keadm beta migrate-db" to migrate the data when the driver is changed
 	// default "sqlite3"
 	DriverName string `json:"driverName,omitempty"`
 	// AliasName indicates alias name
@@ -73,6 +93,53 @@ type DataBase struct {
 	// DataSource indicates the data source path
 	// default "/var/lib/kubeedge/edgecore.db"
 	DataSource string `json:"dataSource,omitempty"`
+	// JournalMode indicates the journal mode of sqlite3, WAL or DELETE,
+	// the mode of the database file is kept if it is empty
+	// default ""
+	JournalMode string `json:"journalMode,omitempty"`
+	// Maintenance indicates the integrity check, snapshot and compaction of the database
+	Maintenance *DataBaseMaintenance `json:"maintenance,omitempty"`
+	// Quotas indicates the retention and size quotas of the records by resource type
+	Quotas []DataBaseQuota `json:"quotas,omitempty"`
+}
+
+// DataBaseMaintenance indicates the integrity check, snapshot and compaction of the database
+type DataBaseMaintenance struct {
+	// IntegrityCheck indicates whether to check the integrity of the database at startup,
+	// the corrupted database is moved aside and recovered from the snapshot,
+	// edgecore fails to start if the database can not be checked, e.g. it is locked
+	// default true
+	IntegrityCheck bool `json:"integrityCheck"`
+	// SnapshotPath indicates the path of the snapshot of the database
+	// default "/var/lib/kubeedge/edgecore.db.snapshot"
+	SnapshotPath string `json:"snapshotPath,omitempty"`
+	// SnapshotPeriod indicates the period to take the snapshot, 0 means no snapshot is taken
+	// default 3600 (second)
+	SnapshotPeriod int32 `json:"snapshotPeriod,omitempty"`
+	// CompactionPeriod indicates the period to checkpoint the write-ahead log and vacuum the database,
+	// 0 means no compaction, it only works for sqlite3
+	// default 86400 (second)
+	CompactionPeriod int32 `json:"compactionPeriod,omitempty"`
+}
+
+// DataBaseQuota indicates the quota of the records of a resource type
+type DataBaseQuota struct {
+	// ResourceType indicates the resource type of the records, like pod or podstatus of the metas,
+	// or the table device_twin or sub_topics of the device twins and the subscribed topics.
+	// The metas beyond the quota are deleted since they are synced from the cloud again, while the
+	// device twins and the topics can not be rebuilt, so the new ones beyond the quota are refused
+	// and Retention does not apply to them
+	// +Required
+	ResourceType string `json:"resourceType,omitempty"`
+	// MaxCount indicates the max number of the records, the least recently updated records
+	// beyond it are deleted, 0 means no limit
+	MaxCount int32 `json:"maxCount,omitempty"`
+	// MaxSize indicates the max total size of the record values in bytes, the least recently
+	// updated records beyond it are deleted, 0 means no limit
+	MaxSize int64 `json:"maxSize,omitempty"`
+	// Retention indicates how long the records are kept after the last update, 0 means no limit
+	// default 0 (second)
+	Retention int32 `json:"retention,omitempty"`
 }
 
 // Modules indicates the modules which edgeCore will be used
@@ -805,6 +872,14 @@ type EdgeHub struct {
 	// Heartbeat indicates heart beat (second)
 	// default 15
 	Heartbeat int32 `json:"heartbeat,omitempty"`
+	// AdaptiveHeartbeat indicates whether the heart beat interval is adapted to the link quality,
+	// heart beats are sent more frequently when keepalive messages are lost, and Heartbeat
+	// becomes the maximum interval
+	// default false
+	AdaptiveHeartbeat bool `json:"adaptiveHeartbeat,omitempty"`
+	// MinHeartbeat indicates the minimum heart beat (second) when AdaptiveHeartbeat is enabled
+	// default 5
+	MinHeartbeat int32 `json:"minHeartbeat,omitempty"`
 	// MessageQPS is the QPS to allow while send message to cloudHub.
 	// DefaultQPS: 30
 	MessageQPS int32 `json:"messageQPS,omitempty"`
@@ -837,6 +912,18 @@ type EdgeHub struct {
 	// RotateCertificates indicates whether edge certificate can be rotated
 	// default true
 	RotateCertificates bool `json:"rotateCertificates,omitempty"`
+	// KeyAlgorithm indicates the key algorithm of edge certificate,
+	// supports ECDSA-P256, ECDSA-P384, RSA-2048, RSA-4096 and Ed25519
+	// default "ECDSA-P256"
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+	// RotateKey indicates whether a new private key is generated when edge certificate is rotated,
+	// the current private key is reused if set to false
+	// default true
+	RotateKey bool `json:"rotateKey,omitempty"`
+	// CertRotationThreshold indicates the percentage of the certificate lifetime after which
+	// edge certificate is rotated, a jitter of 10 percent is applied to spread the rotations
+	// default 80
+	CertRotationThreshold int32 `json:"certRotationThreshold,omitempty"`
 }
 
 // EdgeHubQUIC indicates the quic client config
@@ -956,6 +1043,25 @@ type MetaManager struct {
 	RemoteQueryTimeout int32 `json:"remoteQueryTimeout,omitempty"`
 	// The config of MetaServer
 	MetaServer *MetaServer `json:"metaServer,omitempty"`
+	// Encryption indicates the config to encrypt the sensitive resources stored in database
+	Encryption *MetaEncryption `json:"encryption,omitempty"`
+}
+
+// MetaEncryption indicates the config to encrypt the sensitive metas at rest
+type MetaEncryption struct {
+	// Enable indicates whether the sensitive resources are encrypted before saved to database,
+	// the plain values saved before are encrypted when edgecore starts
+	// default false
+	Enable bool `json:"enable"`
+	// KeyFile indicates the file of the AES key, which is 16, 24 or 32 bytes encoded in base64,
+	// a 32 bytes key is generated if the file does not exist and no value is encrypted yet,
+	// edgecore fails to start if the file is missing but encrypted values exist
+	// default "/etc/kubeedge/keys/meta.key"
+	KeyFile string `json:"keyFile,omitempty"`
+	// Resources indicates the resources whose values are encrypted, in the format of {resource}
+	// for the core group or {resource}.{group} for the others
+	// default ["secrets", "configmaps", "serviceaccounttokens"]
+	Resources []string `json:"resources,omitempty"`
 }
 
 type MetaServer struct {
@@ -967,6 +1073,41 @@ type MetaServer struct {
 	ServiceAccountIssuers  []string `json:"serviceAccountIssuers"`
 	APIAudiences           []string `json:"apiAudiences"`
 	ServiceAccountKeyFiles []string `json:"serviceAccountKeyFiles"`
+	// EnableOfflineWrite indicates whether the create, update, patch and delete requests are
+	// accepted locally while the cloud is unreachable, and replayed to the cloud on reconnect
+	// default false
+	EnableOfflineWrite bool `json:"enableOfflineWrite,omitempty"`
+	// OfflineWriteConflictPolicy indicates how the conflicts are resolved when the offline writes are replayed,
+	// CloudWins or LocalWins
+	// default CloudWins
+	OfflineWriteConflictPolicy OfflineWriteConflictPolicy `json:"offlineWriteConflictPolicy,omitempty"`
+	// OfflineWriteCustomResources indicates the custom resources whose writes are accepted locally while the
+	// cloud is unreachable when EnableOfflineWrite is true, in the format of {resource}.{group}, {resource} may
+	// be * to select all resources of the group, * selects all custom resources. The custom resources readable
+	// by the ServiceAccountAccess rules are selected too. All the custom resources are cached locally and
+	// served while the cloud is unreachable, no matter whether they are selected
+	// default nil
+	OfflineWriteCustomResources []string `json:"offlineWriteCustomResources,omitempty"`
+	// Authorization indicates how the requests are authorized at local when the RequireAuthorization feature gate is enabled
+	Authorization *MetaServerAuthorization `json:"authorization,omitempty"`
+}
+
+// MetaServerAuthorization indicates how the requests are authorized by the RBAC policies cached at local,
+// the policies are the ServiceAccountAccess objects of the service accounts on the node
+type MetaServerAuthorization struct {
+	// FailClosed indicates whether the requests are denied when the cached policies can not be evaluated,
+	// i.e. the policies are stale or fail to be read. Otherwise the stale policies are still used and the
+	// requests get internal errors if the policies fail to be read. The requests allowed by no policy are always denied
+	// default true
+	FailClosed bool `json:"failClosed"`
+	// MaxStaleness indicates the seconds after which the cached policies are stale if they are not synced from
+	// the cloud, the policies are synced while the cloud is connected unless one of them fails to be saved,
+	// 0 means the cached policies are never stale
+	// default 0
+	MaxStaleness int32 `json:"maxStaleness,omitempty"`
+	// EnableAudit indicates whether the authorization decisions are logged
+	// default false
+	EnableAudit bool `json:"enableAudit,omitempty"`
 }
 
 // ServiceBus indicates the ServiceBus module config
@@ -1006,6 +1147,72 @@ type DeviceTwin struct {
 	// if set to false (for debugging etc.), skip checking other DeviceTwin configs.
 	// default true
 	Enable bool `json:"enable"`
+	// TimeSeries indicates the edge-local history of the property values reported by mappers
+	TimeSeries *DeviceTwinTimeSeries `json:"timeSeries,omitempty"`
+	// DMI indicates the listeners of the device management interface which mappers register to
+	DMI *DeviceTwinDMI `json:"dmi,omitempty"`
+}
+
+// DeviceTwinDMI indicates the listeners of the device management interface (DMI)
+type DeviceTwinDMI struct {
+	// SocketPath indicates the unix domain socket for the mappers on the same host
+	// default "/etc/kubeedge/dmi.sock"
+	SocketPath string `json:"socketPath,omitempty"`
+	// TCP indicates the listener with mutual TLS for the mappers on other hosts
+	TCP *DeviceTwinDMITCP `json:"tcp,omitempty"`
+}
+
+// DeviceTwinDMITCP indicates the TCP listener of DMI. The mappers must present client certificates
+// issued by the CA of TLSCAFile, and the mappers registered with tcp:// addresses are called
+// with the same certificates.
+// The certificates of the edge node for cloudhub can not be used, the certificate of TLSCertFile
+// must have the SANs of the address which mappers dial and both serverAuth and clientAuth usages,
+// and the CA of TLSCAFile should only issue the certificates of mappers.
+// A mapper can only register the protocol which is the common name or one of the DNS SANs of
+// its certificate, and can not change the address of a mapper registered by another certificate
+type DeviceTwinDMITCP struct {
+	// Enable indicates whether DMI is served over TCP
+	// default false
+	Enable bool `json:"enable"`
+	// Address indicates the address to listen on
+	// default "0.0.0.0:10352"
+	Address string `json:"address,omitempty"`
+	// TLSCAFile indicates the CA dedicated to mappers which verifies the certificates of mappers,
+	// it is required when DMI is served over TCP
+	TLSCAFile string `json:"tlsCaFile,omitempty"`
+	// TLSCertFile indicates the certificate dedicated to DMI of the edge node,
+	// it is required when DMI is served over TCP
+	TLSCertFile string `json:"tlsCertFile,omitempty"`
+	// TLSPrivateKeyFile indicates the private key of TLSCertFile,
+	// it is required when DMI is served over TCP
+	TLSPrivateKeyFile string `json:"tlsPrivateKeyFile,omitempty"`
+}
+
+// DeviceTwinTimeSeries indicates the edge-local history of the device property values,
+// which is kept in memory and queried through the DMI socket
+type DeviceTwinTimeSeries struct {
+	// Enable indicates whether the history of the property values is kept
+	// default false
+	Enable bool `json:"enable"`
+	// MaxAge indicates how long the values of a property are kept
+	// default 3600 (second)
+	MaxAge int32 `json:"maxAge,omitempty"`
+	// MaxPointsPerProperty indicates the maximum number of values kept for a property
+	// default 3600
+	MaxPointsPerProperty int32 `json:"maxPointsPerProperty,omitempty"`
+	// UploadTopic indicates the topic of the router eventbus source which the history is uploaded to.
+	// The history is reported to cloud in batches when the edge node is connected and forwarded to the topic,
+	// the next batch is uploaded after the previous one is confirmed by cloud.
+	// The history is kept in memory only, values not uploaded yet are lost when edgecore restarts.
+	// The history is not uploaded if it is empty
+	// default ""
+	UploadTopic string `json:"uploadTopic,omitempty"`
+	// UploadPeriod indicates the period to upload the history
+	// default 60 (second)
+	UploadPeriod int32 `json:"uploadPeriod,omitempty"`
+	// UploadBatchSize indicates the maximum number of values uploaded in a batch, one batch is uploaded per period
+	// default 1000
+	UploadBatchSize int32 `json:"uploadBatchSize,omitempty"`
 }
 
 // DBTest indicates the DBTest module config
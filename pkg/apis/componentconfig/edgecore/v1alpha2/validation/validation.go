@@ -18,14 +18,18 @@ package validation
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"path"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/apis/core/validation"
 
+	"github.com/kubeedge/kubeedge/common/constants"
 	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+	"github.com/kubeedge/kubeedge/pkg/util/pki"
 	utilvalidation "github.com/kubeedge/kubeedge/pkg/util/validation"
 )
 
@@ -47,6 +51,12 @@ func ValidateEdgeCoreConfiguration(c *v1alpha2.EdgeCoreConfig) field.ErrorList {
 // ValidateDataBase validates `db` and returns an errorList if it is invalid
 func ValidateDataBase(db v1alpha2.DataBase) field.ErrorList {
 	allErrs := field.ErrorList{}
+	switch db.DriverName {
+	case "", v1alpha2.DataBaseDriverName, v1alpha2.DataBaseDriverBolt:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("DriverName"), db.DriverName,
+			[]string{v1alpha2.DataBaseDriverName, v1alpha2.DataBaseDriverBolt}))
+	}
 	sourceDir := path.Dir(db.DataSource)
 	if !utilvalidation.FileIsExist(sourceDir) {
 		if err := os.MkdirAll(sourceDir, os.ModePerm); err != nil {
@@ -54,6 +64,44 @@ func ValidateDataBase(db v1alpha2.DataBase) field.ErrorList {
 				fmt.Sprintf("create DataSoure dir %v error ", sourceDir)))
 		}
 	}
+	switch db.JournalMode {
+	case "", v1alpha2.DataBaseJournalModeWAL, v1alpha2.DataBaseJournalModeDelete:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("JournalMode"), db.JournalMode,
+			[]string{v1alpha2.DataBaseJournalModeWAL, v1alpha2.DataBaseJournalModeDelete}))
+	}
+	if m := db.Maintenance; m != nil {
+		if m.SnapshotPeriod < 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("Maintenance", "SnapshotPeriod"), m.SnapshotPeriod,
+				"must be greater than or equal to 0"))
+		}
+		if m.CompactionPeriod < 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("Maintenance", "CompactionPeriod"), m.CompactionPeriod,
+				"must be greater than or equal to 0"))
+		}
+		if m.SnapshotPath == "" && (m.IntegrityCheck || m.SnapshotPeriod > 0) {
+			allErrs = append(allErrs, field.Required(field.NewPath("Maintenance", "SnapshotPath"),
+				"snapshot path is required to take snapshots or recover the database"))
+		}
+		if m.SnapshotPath != "" && path.Clean(m.SnapshotPath) == path.Clean(db.DataSource) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("Maintenance", "SnapshotPath"), m.SnapshotPath,
+				"must be different from DataSource"))
+		}
+	}
+	resTypes := make(map[string]bool, len(db.Quotas))
+	for i, q := range db.Quotas {
+		quotaPath := field.NewPath("Quotas").Index(i)
+		switch {
+		case q.ResourceType == "":
+			allErrs = append(allErrs, field.Required(quotaPath.Child("ResourceType"), ""))
+		case resTypes[q.ResourceType]:
+			allErrs = append(allErrs, field.Duplicate(quotaPath.Child("ResourceType"), q.ResourceType))
+		}
+		resTypes[q.ResourceType] = true
+		if q.MaxCount < 0 || q.MaxSize < 0 || q.Retention < 0 {
+			allErrs = append(allErrs, field.Invalid(quotaPath, q, "quotas must be greater than or equal to 0"))
+		}
+	}
 	return allErrs
 }
 
@@ -101,6 +149,21 @@ func ValidateModuleEdgeHub(h v1alpha2.EdgeHub) field.ErrorList {
 			"MessageBurst must not be a negative number"))
 	}
 
+	if h.AdaptiveHeartbeat && (h.MinHeartbeat <= 0 || h.MinHeartbeat > h.Heartbeat) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("minHeartbeat"), h.MinHeartbeat,
+			"MinHeartbeat must be positive and not greater than Heartbeat"))
+	}
+
+	if h.KeyAlgorithm != "" && !pki.IsSupportedKeyAlgorithm(h.KeyAlgorithm) {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("keyAlgorithm"), h.KeyAlgorithm,
+			pki.SupportedKeyAlgorithms))
+	}
+
+	if h.CertRotationThreshold != 0 && (h.CertRotationThreshold <= 10 || h.CertRotationThreshold >= 90) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("certRotationThreshold"), h.CertRotationThreshold,
+			"CertRotationThreshold must be greater than 10 and less than 90"))
+	}
+
 	return allErrs
 }
 
@@ -124,6 +187,40 @@ func ValidateModuleMetaManager(m v1alpha2.MetaManager) field.ErrorList {
 		return field.ErrorList{}
 	}
 	allErrs := field.ErrorList{}
+	if m.MetaServer != nil {
+		switch m.MetaServer.OfflineWriteConflictPolicy {
+		case "", v1alpha2.OfflineWriteCloudWins, v1alpha2.OfflineWriteLocalWins:
+		default:
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("metaServer.offlineWriteConflictPolicy"),
+				m.MetaServer.OfflineWriteConflictPolicy,
+				[]string{string(v1alpha2.OfflineWriteCloudWins), string(v1alpha2.OfflineWriteLocalWins)}))
+		}
+		for i, cr := range m.MetaServer.OfflineWriteCustomResources {
+			if cr == "*" {
+				continue
+			}
+			if tokens := strings.SplitN(cr, ".", 2); len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("metaServer.offlineWriteCustomResources").Index(i),
+					cr, "must be * or in the format of {resource}.{group}"))
+			}
+		}
+		if a := m.MetaServer.Authorization; a != nil && a.MaxStaleness < 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("metaServer.authorization.maxStaleness"),
+				a.MaxStaleness, "must be greater than or equal to 0"))
+		}
+	}
+	if m.Encryption != nil && m.Encryption.Enable && m.Encryption.KeyFile == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("encryption.keyFile"),
+			"keyFile must be set when encryption is enabled"))
+	}
+	if m.Encryption != nil {
+		for i, resource := range m.Encryption.Resources {
+			if tokens := strings.SplitN(resource, ".", 2); tokens[0] == "" || (len(tokens) == 2 && tokens[1] == "") {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("encryption.resources").Index(i),
+					resource, "must be in the format of {resource} or {resource}.{group}"))
+			}
+		}
+	}
 	return allErrs
 }
 
@@ -142,6 +239,37 @@ func ValidateModuleDeviceTwin(d v1alpha2.DeviceTwin) field.ErrorList {
 		return field.ErrorList{}
 	}
 	allErrs := field.ErrorList{}
+	if ts := d.TimeSeries; ts != nil && ts.Enable {
+		if ts.MaxAge <= 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("TimeSeries", "MaxAge"), ts.MaxAge,
+				"must be greater than 0"))
+		}
+		if ts.MaxPointsPerProperty <= 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("TimeSeries", "MaxPointsPerProperty"), ts.MaxPointsPerProperty,
+				"must be greater than 0"))
+		}
+		if ts.UploadTopic != "" && ts.UploadPeriod <= 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("TimeSeries", "UploadPeriod"), ts.UploadPeriod,
+				"must be greater than 0 to upload the history"))
+		}
+		if ts.UploadTopic != "" && ts.UploadBatchSize <= 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("TimeSeries", "UploadBatchSize"), ts.UploadBatchSize,
+				"must be greater than 0 to upload the history"))
+		}
+	}
+	if dmi := d.DMI; dmi != nil && dmi.TCP != nil && dmi.TCP.Enable {
+		if _, _, err := net.SplitHostPort(dmi.TCP.Address); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("DMI", "TCP", "Address"), dmi.TCP.Address,
+				err.Error()))
+		}
+		if dmi.TCP.TLSCAFile == "" || dmi.TCP.TLSCertFile == "" || dmi.TCP.TLSPrivateKeyFile == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("DMI", "TCP", "TLS"),
+				"tlsCaFile, tlsCertFile and tlsPrivateKeyFile are required for mutual TLS"))
+		} else if dmi.TCP.TLSCAFile == constants.DefaultCAFile || dmi.TCP.TLSCertFile == constants.DefaultCertFile {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("DMI", "TCP", "TLS"), dmi.TCP.TLSCertFile,
+				"the certificates of the edge node for cloudhub can not be used, a dedicated certificate and mapper CA are required"))
+		}
+	}
 	return allErrs
 }
 
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/kubeedge/kubeedge/pkg/apis/componentconfig/edgecore/v1alpha2"
+	"github.com/kubeedge/kubeedge/pkg/util/pki"
 )
 
 func TestValidateEdgeCoreConfiguration(t *testing.T) {
@@ -69,6 +70,32 @@ func TestValidateDataBase(t *testing.T) {
 	if errs := ValidateDataBase(db); len(errs) > 0 {
 		t.Errorf("file %v should not created, err is %v", nonexistentFile, errs)
 	}
+
+	db = v1alpha2.DataBase{
+		DriverName: "mysql",
+		DataSource: filepath.Join(dir, "edgecore.db"),
+	}
+	if errs := ValidateDataBase(db); len(errs) != 1 {
+		t.Errorf("driver %v should not be supported, err is %v", db.DriverName, errs)
+	}
+
+	db = v1alpha2.DataBase{
+		DataSource:  filepath.Join(dir, "edgecore.db"),
+		JournalMode: "MEMORY",
+		Maintenance: &v1alpha2.DataBaseMaintenance{
+			IntegrityCheck: true,
+			SnapshotPeriod: -1,
+		},
+		Quotas: []v1alpha2.DataBaseQuota{
+			{ResourceType: "podstatus", MaxCount: 10},
+			{ResourceType: "podstatus", Retention: -1},
+			{MaxSize: 1024},
+		},
+	}
+	// journal mode, snapshot period, snapshot path, duplicate type, negative retention, required type
+	if errs := ValidateDataBase(db); len(errs) != 6 {
+		t.Errorf("expected 6 errors, but got %v", errs)
+	}
 }
 
 func TestValidateModuleEdged(t *testing.T) {
@@ -203,6 +230,37 @@ func TestValidateModuleEdgeHub(t *testing.T) {
 			result: field.ErrorList{field.Invalid(field.NewPath("messageBurst"),
 				int32(-1), "MessageBurst must not be a negative number")},
 		},
+		{
+			name: "case6 KeyAlgorithm not supported",
+			input: v1alpha2.EdgeHub{
+				Enable: true,
+				WebSocket: &v1alpha2.EdgeHubWebSocket{
+					Enable: true,
+				},
+				Quic: &v1alpha2.EdgeHubQUIC{
+					Enable: false,
+				},
+				KeyAlgorithm: "RSA-1024",
+			},
+			result: field.ErrorList{field.NotSupported(field.NewPath("keyAlgorithm"),
+				"RSA-1024", pki.SupportedKeyAlgorithms)},
+		},
+		{
+			name: "case7 CertRotationThreshold out of range",
+			input: v1alpha2.EdgeHub{
+				Enable: true,
+				WebSocket: &v1alpha2.EdgeHubWebSocket{
+					Enable: true,
+				},
+				Quic: &v1alpha2.EdgeHubQUIC{
+					Enable: false,
+				},
+				KeyAlgorithm:          pki.KeyAlgorithmEd25519,
+				CertRotationThreshold: 95,
+			},
+			result: field.ErrorList{field.Invalid(field.NewPath("certRotationThreshold"),
+				int32(95), "CertRotationThreshold must be greater than 10 and less than 90")},
+		},
 	}
 
 	for _, c := range cases {
@@ -272,6 +330,73 @@ func TestValidateModuleMetaManager(t *testing.T) {
 			},
 			expected: field.ErrorList{},
 		},
+		{
+			name: "case3 invalid offline write conflict policy",
+			input: v1alpha2.MetaManager{
+				Enable: true,
+				MetaServer: &v1alpha2.MetaServer{
+					OfflineWriteConflictPolicy: "EdgeWins",
+				},
+			},
+			expected: field.ErrorList{field.NotSupported(field.NewPath("metaServer.offlineWriteConflictPolicy"),
+				v1alpha2.OfflineWriteConflictPolicy("EdgeWins"),
+				[]string{string(v1alpha2.OfflineWriteCloudWins), string(v1alpha2.OfflineWriteLocalWins)})},
+		},
+		{
+			name: "case4 encryption without key file",
+			input: v1alpha2.MetaManager{
+				Enable: true,
+				Encryption: &v1alpha2.MetaEncryption{
+					Enable: true,
+				},
+			},
+			expected: field.ErrorList{field.Required(field.NewPath("encryption.keyFile"),
+				"keyFile must be set when encryption is enabled")},
+		},
+		{
+			name: "case5 invalid encryption resources",
+			input: v1alpha2.MetaManager{
+				Enable: true,
+				Encryption: &v1alpha2.MetaEncryption{
+					Resources: []string{"secrets", "widgets.example.com", "", "widgets."},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("encryption.resources").Index(2),
+					"", "must be in the format of {resource} or {resource}.{group}"),
+				field.Invalid(field.NewPath("encryption.resources").Index(3),
+					"widgets.", "must be in the format of {resource} or {resource}.{group}"),
+			},
+		},
+		{
+			name: "case6 invalid custom resources",
+			input: v1alpha2.MetaManager{
+				Enable: true,
+				MetaServer: &v1alpha2.MetaServer{
+					OfflineWriteCustomResources: []string{"*", "*.example.com", "widgets.example.com", "widgets", ".example.com"},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("metaServer.offlineWriteCustomResources").Index(3),
+					"widgets", "must be * or in the format of {resource}.{group}"),
+				field.Invalid(field.NewPath("metaServer.offlineWriteCustomResources").Index(4),
+					".example.com", "must be * or in the format of {resource}.{group}"),
+			},
+		},
+		{
+			name: "case7 negative authorization max staleness",
+			input: v1alpha2.MetaManager{
+				Enable: true,
+				MetaServer: &v1alpha2.MetaServer{
+					Authorization: &v1alpha2.MetaServerAuthorization{
+						FailClosed:   true,
+						MaxStaleness: -1,
+					},
+				},
+			},
+			expected: field.ErrorList{field.Invalid(field.NewPath("metaServer.authorization.maxStaleness"),
+				int32(-1), "must be greater than or equal to 0")},
+		},
 	}
 
 	for _, c := range cases {
@@ -330,6 +455,91 @@ func TestValidateModuleDeviceTwin(t *testing.T) {
 			},
 			expected: field.ErrorList{},
 		},
+		{
+			name: "case3 time series enabled",
+			input: v1alpha2.DeviceTwin{
+				Enable: true,
+				TimeSeries: &v1alpha2.DeviceTwinTimeSeries{
+					Enable:               true,
+					MaxAge:               3600,
+					MaxPointsPerProperty: 3600,
+					UploadTopic:          "default/device-history",
+					UploadPeriod:         60,
+					UploadBatchSize:      1000,
+				},
+			},
+			expected: field.ErrorList{},
+		},
+		{
+			name: "case4 invalid time series",
+			input: v1alpha2.DeviceTwin{
+				Enable: true,
+				TimeSeries: &v1alpha2.DeviceTwinTimeSeries{
+					Enable:               true,
+					MaxAge:               0,
+					MaxPointsPerProperty: 3600,
+					UploadTopic:          "default/device-history",
+					UploadPeriod:         60,
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("TimeSeries", "MaxAge"), int32(0), "must be greater than 0"),
+				field.Invalid(field.NewPath("TimeSeries", "UploadBatchSize"), int32(0), "must be greater than 0 to upload the history"),
+			},
+		},
+		{
+			name: "case5 dmi over tcp enabled",
+			input: v1alpha2.DeviceTwin{
+				Enable: true,
+				DMI: &v1alpha2.DeviceTwinDMI{
+					SocketPath: "/etc/kubeedge/dmi.sock",
+					TCP: &v1alpha2.DeviceTwinDMITCP{
+						Enable:            true,
+						Address:           "0.0.0.0:10352",
+						TLSCAFile:         "/etc/kubeedge/dmi/mapperCA.crt",
+						TLSCertFile:       "/etc/kubeedge/dmi/dmi.crt",
+						TLSPrivateKeyFile: "/etc/kubeedge/dmi/dmi.key",
+					},
+				},
+			},
+			expected: field.ErrorList{},
+		},
+		{
+			name: "case6 dmi over tcp with the certificates for cloudhub",
+			input: v1alpha2.DeviceTwin{
+				Enable: true,
+				DMI: &v1alpha2.DeviceTwinDMI{
+					TCP: &v1alpha2.DeviceTwinDMITCP{
+						Enable:            true,
+						Address:           "0.0.0.0:10352",
+						TLSCAFile:         "/etc/kubeedge/ca/rootCA.crt",
+						TLSCertFile:       "/etc/kubeedge/certs/server.crt",
+						TLSPrivateKeyFile: "/etc/kubeedge/certs/server.key",
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("DMI", "TCP", "TLS"), "/etc/kubeedge/certs/server.crt",
+					"the certificates of the edge node for cloudhub can not be used, a dedicated certificate and mapper CA are required"),
+			},
+		},
+		{
+			name: "case7 invalid dmi over tcp",
+			input: v1alpha2.DeviceTwin{
+				Enable: true,
+				DMI: &v1alpha2.DeviceTwinDMI{
+					TCP: &v1alpha2.DeviceTwinDMITCP{
+						Enable:    true,
+						Address:   "10352",
+						TLSCAFile: "/etc/kubeedge/ca/rootCA.crt",
+					},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("DMI", "TCP", "Address"), "10352", "address 10352: missing port in address"),
+				field.Required(field.NewPath("DMI", "TCP", "TLS"), "tlsCaFile, tlsCertFile and tlsPrivateKeyFile are required for mutual TLS"),
+			},
+		},
 	}
 
 	for _, c := range cases {
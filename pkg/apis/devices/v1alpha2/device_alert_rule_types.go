@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeviceAlertRuleSpec is the specification of an alert rule evaluated on the edge node
+// against the values reported for a device property.
+type DeviceAlertRuleSpec struct {
+	// Required: DeviceName is the name of the device in the same namespace
+	// whose reported values are evaluated.
+	// The rule is synced to the edge node which the device is bound to.
+	DeviceName string `json:"deviceName,omitempty"`
+	// Required: PropertyName is the name of the device twin property to evaluate.
+	PropertyName string `json:"propertyName,omitempty"`
+	// Required: Condition which fires the alert.
+	Condition AlertCondition `json:"condition,omitempty"`
+	// Actions taken on the edge node when the alert fires or resolves.
+	// +optional
+	Actions AlertActions `json:"actions,omitempty"`
+}
+
+// AlertConditionType is the type of an alert condition.
+// +kubebuilder:validation:Enum=Threshold;RateOfChange;Absent
+type AlertConditionType string
+
+// Valid values of AlertConditionType
+const (
+	// AlertConditionThreshold compares each reported value with the value of the condition.
+	AlertConditionThreshold AlertConditionType = "Threshold"
+	// AlertConditionRateOfChange compares the change per second between two reported values
+	// with the value of the condition.
+	AlertConditionRateOfChange AlertConditionType = "RateOfChange"
+	// AlertConditionAbsent fires when no value is reported for the duration of the condition.
+	AlertConditionAbsent AlertConditionType = "Absent"
+)
+
+// AlertOperator is the operator to compare the observed value with the value of a condition.
+// +kubebuilder:validation:Enum=gt;ge;lt;le;eq;ne
+type AlertOperator string
+
+// Valid values of AlertOperator
+const (
+	AlertOperatorGreaterThan      AlertOperator = "gt"
+	AlertOperatorGreaterThanEqual AlertOperator = "ge"
+	AlertOperatorLessThan         AlertOperator = "lt"
+	AlertOperatorLessThanEqual    AlertOperator = "le"
+	AlertOperatorEqual            AlertOperator = "eq"
+	AlertOperatorNotEqual         AlertOperator = "ne"
+)
+
+// AlertCondition describes when an alert fires.
+type AlertCondition struct {
+	// Required: Type of the condition.
+	Type AlertConditionType `json:"type,omitempty"`
+	// Operator to compare the observed value, i.e. the reported value for Threshold
+	// or the change per second for RateOfChange, with Value.
+	// Required for Threshold and RateOfChange.
+	// +optional
+	Operator AlertOperator `json:"operator,omitempty"`
+	// Value to compare with. Values are compared as numbers if both are numbers,
+	// otherwise only eq and ne are supported and values are compared as strings.
+	// Required for Threshold and RateOfChange.
+	// +optional
+	Value string `json:"value,omitempty"`
+	// AbsentForSeconds is the duration without any reported value after which the alert fires.
+	// Required for Absent.
+	// +optional
+	AbsentForSeconds int32 `json:"absentForSeconds,omitempty"`
+}
+
+// AlertActions describes what the edge node does when an alert fires or resolves.
+type AlertActions struct {
+	// Topic of the edge eventbus to publish the alert events to.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+	// RecordEvent records a Kubernetes Event for the rule when the alert fires or resolves.
+	// The events are recorded once the edge node is connected to the cloud.
+	// +optional
+	RecordEvent bool `json:"recordEvent,omitempty"`
+	// DesiredWrites are the desired values written to the device twins on the same edge node
+	// when the alert fires.
+	// +optional
+	DesiredWrites []AlertDesiredWrite `json:"desiredWrites,omitempty"`
+}
+
+// AlertDesiredWrite is a desired value to write to a device twin property.
+type AlertDesiredWrite struct {
+	// Required: DeviceName is the name of the device in the same namespace.
+	DeviceName string `json:"deviceName,omitempty"`
+	// Required: PropertyName is the name of the device twin property.
+	PropertyName string `json:"propertyName,omitempty"`
+	// Required: Value is the desired value of the property. It is validated against the device model
+	// of the device, the same as the desired values of the device twins.
+	Value string `json:"value,omitempty"`
+}
+
+// DeviceAlertRuleStatus reports the last state of the alert observed on the edge node.
+type DeviceAlertRuleStatus struct {
+	// NodeName is the edge node which the rule is synced to.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+	// Firing is whether the alert is firing on the edge node.
+	// +optional
+	Firing bool `json:"firing,omitempty"`
+	// Message is a human readable message about the last transition of the alert.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the time when the alert fired or resolved last time on the edge node.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeviceAlertRule is the Schema for the alert rules of device properties evaluated on edge nodes.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Device",type=string,JSONPath=`.spec.deviceName`
+// +kubebuilder:printcolumn:name="Property",type=string,JSONPath=`.spec.propertyName`
+// +kubebuilder:printcolumn:name="Firing",type=boolean,JSONPath=`.status.firing`
+type DeviceAlertRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeviceAlertRuleSpec   `json:"spec,omitempty"`
+	Status DeviceAlertRuleStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeviceAlertRuleList contains a list of DeviceAlertRule
+type DeviceAlertRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeviceAlertRule `json:"items"`
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeviceCommandRequestSpec is the specification of a command invocation on a device.
+type DeviceCommandRequestSpec struct {
+	// Required: DeviceName is the name of the device in the same namespace
+	// to invoke the command on.
+	DeviceName string `json:"deviceName,omitempty"`
+	// Required: Command is the name of the command declared by the device model.
+	Command string `json:"command,omitempty"`
+	// Parameters of this invocation, the keys should be the parameter names
+	// declared by the device command.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// TimeoutSeconds limits the duration of the invocation.
+	// Default to 30.
+	// If set to 0, we'll use the default value 30.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// DeviceCommandRequestPhase describes the phase of a command invocation.
+// +kubebuilder:validation:Enum=Pending;Sent;Succeeded;Failed
+type DeviceCommandRequestPhase string
+
+// Valid values of DeviceCommandRequestPhase
+const (
+	// DeviceCommandRequestPending means the request is not routed to the edge node yet.
+	DeviceCommandRequestPending DeviceCommandRequestPhase = "Pending"
+	// DeviceCommandRequestSent means the request is sent to the edge node and waits for the result.
+	DeviceCommandRequestSent DeviceCommandRequestPhase = "Sent"
+	// DeviceCommandRequestSucceeded means the mapper executed the command successfully.
+	DeviceCommandRequestSucceeded DeviceCommandRequestPhase = "Succeeded"
+	// DeviceCommandRequestFailed means the command is rejected, failed or timed out.
+	DeviceCommandRequestFailed DeviceCommandRequestPhase = "Failed"
+)
+
+// DeviceCommandRequestStatus reports the progress and the result of a command invocation.
+type DeviceCommandRequestStatus struct {
+	// Phase of the invocation.
+	// +optional
+	Phase DeviceCommandRequestPhase `json:"phase,omitempty"`
+	// NodeName is the edge node which the request is routed to.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+	// StatusCode returned by the mapper for the command.
+	// +optional
+	StatusCode string `json:"statusCode,omitempty"`
+	// Result returned by the device for the command.
+	// +optional
+	Result string `json:"result,omitempty"`
+	// Message is a human readable message indicating details about the failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// SentTime is the time when the request is sent to the edge node.
+	// +optional
+	SentTime *metav1.Time `json:"sentTime,omitempty"`
+	// CompletionTime is the time when the invocation succeeded or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeviceCommandRequest is the Schema for invoking a command of a device from the cloud.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Device",type=string,JSONPath=`.spec.deviceName`
+// +kubebuilder:printcolumn:name="Command",type=string,JSONPath=`.spec.command`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+type DeviceCommandRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeviceCommandRequestSpec   `json:"spec,omitempty"`
+	Status DeviceCommandRequestStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeviceCommandRequestList contains a list of DeviceCommandRequest
+type DeviceCommandRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeviceCommandRequest `json:"items"`
+}
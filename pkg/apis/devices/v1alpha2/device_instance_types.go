@@ -172,6 +172,13 @@ type DeviceStatus struct {
 	// Optional: A passive device won't have twin properties and this list could be empty.
 	// +optional
 	Twins []Twin `json:"twins,omitempty"`
+	// State of the device like online, offline or unknown, it is reported by the mapper,
+	// and set to offline by the edge node when the mapper of the device is gone.
+	// +optional
+	State string `json:"state,omitempty"`
+	// LastOnlineTime is the time when the state of the device is reported last time.
+	// +optional
+	LastOnlineTime string `json:"lastOnlineTime,omitempty"`
 }
 
 // Twin provides a logical representation of control properties (writable properties in the
@@ -189,6 +196,18 @@ type Twin struct {
 	Desired TwinProperty `json:"desired,omitempty"`
 	// Required: the reported property value.
 	Reported TwinProperty `json:"reported,omitempty"`
+	// The version of the desired value merged by edgecore. Cloud updates of the desired value which
+	// are not based on the edge version are rejected by edgecore and reported as conflict events.
+	// +optional
+	DesiredVersion *TwinVersion `json:"desiredVersion,omitempty"`
+}
+
+// TwinVersion is the version of the twin value updated by the cloud and the edge.
+type TwinVersion struct {
+	// The version of the last update from the cloud.
+	CloudVersion int64 `json:"cloud"`
+	// The version of the last update on the edge.
+	EdgeVersion int64 `json:"edge"`
 }
 
 // TwinProperty represents the device property for which an Expected/Actual state can be defined.
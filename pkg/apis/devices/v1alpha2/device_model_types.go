@@ -27,6 +27,10 @@ type DeviceModelSpec struct {
 	Protocol string `json:"protocol,omitempty"`
 	// Required: List of device properties.
 	Properties []DeviceProperty `json:"properties,omitempty"`
+	// List of device commands, the imperative actions like reboot or calibrate
+	// which the device supports. Commands must be unique by command.name.
+	// +optional
+	Commands []DeviceCommand `json:"commands,omitempty"`
 }
 
 // DeviceProperty describes an individual device property / attribute like temperature / humidity etc.
@@ -40,6 +44,25 @@ type DeviceProperty struct {
 	Type PropertyType `json:"type,omitempty"`
 }
 
+// DeviceCommand describes an imperative action which the device supports, it is
+// invoked on the device by the mapper through a DeviceCommandRequest.
+type DeviceCommand struct {
+	// Required: The device command name.
+	Name string `json:"name,omitempty"`
+	// The device command description.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// The url which the mapper uses to access the command on the device.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// The method of the command, it is interpreted by the mapper of the protocol.
+	// +optional
+	Method string `json:"method,omitempty"`
+	// List of the parameter names which the command accepts.
+	// +optional
+	Parameters []string `json:"parameters,omitempty"`
+}
+
 // Represents the type and data validation of a property.
 // Only one of its members may be specified.
 type PropertyType struct {
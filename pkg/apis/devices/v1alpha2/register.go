@@ -67,6 +67,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DeviceList{},
 		&DeviceModel{},
 		&DeviceModelList{},
+		&DeviceCommandRequest{},
+		&DeviceCommandRequestList{},
+		&DeviceAlertRule{},
+		&DeviceAlertRuleList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -79,6 +83,12 @@ func AddDeviceCrds(scheme *runtime.Scheme) error {
 	// Add DeviceModel
 	scheme.AddKnownTypes(SchemeGroupVersion, &DeviceModel{}, &DeviceModelList{})
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	// Add DeviceCommandRequest
+	scheme.AddKnownTypes(SchemeGroupVersion, &DeviceCommandRequest{}, &DeviceCommandRequestList{})
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	// Add DeviceAlertRule
+	scheme.AddKnownTypes(SchemeGroupVersion, &DeviceAlertRule{}, &DeviceAlertRuleList{})
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 
 	return nil
 }
@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+)
+
+// ValidateDevice validates the device against its device model and returns an errorList if it is invalid,
+// model is nil if the device model does not exist
+func ValidateDevice(device *v1alpha2.Device, model *v1alpha2.DeviceModel) field.ErrorList {
+	allErrs := field.ErrorList{}
+	modelRefPath := field.NewPath("spec", "deviceModelRef")
+	if device.Spec.DeviceModelRef == nil || device.Spec.DeviceModelRef.Name == "" {
+		return append(allErrs, field.Required(modelRefPath, "device model must be referenced"))
+	}
+	if model == nil {
+		return append(allErrs, field.NotFound(modelRefPath.Child("name"), device.Spec.DeviceModelRef.Name))
+	}
+
+	for i, visitor := range device.Spec.PropertyVisitors {
+		if FindProperty(model, visitor.PropertyName) == nil {
+			allErrs = append(allErrs, field.NotFound(field.NewPath("spec", "propertyVisitors").Index(i).Child("propertyName"),
+				visitor.PropertyName))
+		}
+	}
+
+	for i, twin := range device.Status.Twins {
+		twinPath := field.NewPath("status", "twins").Index(i)
+		property := FindProperty(model, twin.PropertyName)
+		if property == nil {
+			allErrs = append(allErrs, field.NotFound(twinPath.Child("propertyName"), twin.PropertyName))
+			continue
+		}
+		if twin.Desired.Value == "" {
+			continue
+		}
+		if err := ValidateDesiredValue(property, twin.Desired.Value); err != nil {
+			allErrs = append(allErrs, field.Invalid(twinPath.Child("desired", "value"), twin.Desired.Value, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// ValidateAlertDesiredWrites validates the desired values written by the alert rule against the device models
+// of the target devices, the same as the desired values of devices. models maps the names of the target devices
+// to their device models, the model is nil if the device or its device model does not exist
+func ValidateAlertDesiredWrites(rule *v1alpha2.DeviceAlertRule, models map[string]*v1alpha2.DeviceModel) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, write := range rule.Spec.Actions.DesiredWrites {
+		writePath := field.NewPath("spec", "actions", "desiredWrites").Index(i)
+		model := models[write.DeviceName]
+		if model == nil {
+			allErrs = append(allErrs, field.NotFound(writePath.Child("deviceName"), write.DeviceName))
+			continue
+		}
+		property := FindProperty(model, write.PropertyName)
+		if property == nil {
+			allErrs = append(allErrs, field.NotFound(writePath.Child("propertyName"), write.PropertyName))
+			continue
+		}
+		if err := ValidateDesiredValue(property, write.Value); err != nil {
+			allErrs = append(allErrs, field.Invalid(writePath.Child("value"), write.Value, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// FindProperty returns the property of the device model, or nil if the model does not define it
+func FindProperty(model *v1alpha2.DeviceModel, name string) *v1alpha2.DeviceProperty {
+	for i := range model.Spec.Properties {
+		if model.Spec.Properties[i].Name == name {
+			return &model.Spec.Properties[i]
+		}
+	}
+	return nil
+}
+
+// ValidateDesiredValue validates the desired value against the type of the property,
+// the value must be writable, parsable as the type and within the range of the type if the range is set.
+// The range is not set if both minimum and maximum are zero
+func ValidateDesiredValue(property *v1alpha2.DeviceProperty, value string) error {
+	t := property.Type
+	switch {
+	case t.Int != nil:
+		if t.Int.AccessMode == v1alpha2.ReadOnly {
+			return errReadOnly(property)
+		}
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("property %s must be an int", property.Name)
+		}
+		if (t.Int.Minimum != 0 || t.Int.Maximum != 0) && (v < t.Int.Minimum || v > t.Int.Maximum) {
+			return fmt.Errorf("property %s must be between %d and %d", property.Name, t.Int.Minimum, t.Int.Maximum)
+		}
+	case t.Double != nil:
+		if t.Double.AccessMode == v1alpha2.ReadOnly {
+			return errReadOnly(property)
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("property %s must be a double", property.Name)
+		}
+		if (t.Double.Minimum != 0 || t.Double.Maximum != 0) && (v < t.Double.Minimum || v > t.Double.Maximum) {
+			return fmt.Errorf("property %s must be between %v and %v", property.Name, t.Double.Minimum, t.Double.Maximum)
+		}
+	case t.Float != nil:
+		if t.Float.AccessMode == v1alpha2.ReadOnly {
+			return errReadOnly(property)
+		}
+		v, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return fmt.Errorf("property %s must be a float", property.Name)
+		}
+		min, max := float64(t.Float.Minimum), float64(t.Float.Maximum)
+		if (min != 0 || max != 0) && (v < min || v > max) {
+			return fmt.Errorf("property %s must be between %v and %v", property.Name, t.Float.Minimum, t.Float.Maximum)
+		}
+	case t.Boolean != nil:
+		if t.Boolean.AccessMode == v1alpha2.ReadOnly {
+			return errReadOnly(property)
+		}
+		if value != "true" && value != "false" {
+			return fmt.Errorf("property %s must be true or false", property.Name)
+		}
+	case t.String != nil:
+		if t.String.AccessMode == v1alpha2.ReadOnly {
+			return errReadOnly(property)
+		}
+	case t.Bytes != nil:
+		if t.Bytes.AccessMode == v1alpha2.ReadOnly {
+			return errReadOnly(property)
+		}
+	default:
+		return fmt.Errorf("property %s has no type", property.Name)
+	}
+	return nil
+}
+
+func errReadOnly(property *v1alpha2.DeviceProperty) error {
+	return fmt.Errorf("property %s is ReadOnly", property.Name)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+)
+
+func testDeviceModel() *v1alpha2.DeviceModel {
+	model := &v1alpha2.DeviceModel{}
+	model.Name = "sensor-model"
+	model.Spec.Properties = []v1alpha2.DeviceProperty{
+		{
+			Name: "temperature",
+			Type: v1alpha2.PropertyType{Int: &v1alpha2.PropertyTypeInt64{AccessMode: v1alpha2.ReadOnly}},
+		},
+		{
+			Name: "threshold",
+			Type: v1alpha2.PropertyType{Int: &v1alpha2.PropertyTypeInt64{AccessMode: v1alpha2.ReadWrite, Minimum: 0, Maximum: 100}},
+		},
+		{
+			Name: "ratio",
+			Type: v1alpha2.PropertyType{Double: &v1alpha2.PropertyTypeDouble{AccessMode: v1alpha2.ReadWrite, Minimum: -1, Maximum: 1}},
+		},
+		{
+			Name: "enabled",
+			Type: v1alpha2.PropertyType{Boolean: &v1alpha2.PropertyTypeBoolean{AccessMode: v1alpha2.ReadWrite}},
+		},
+		{
+			Name: "label",
+			Type: v1alpha2.PropertyType{String: &v1alpha2.PropertyTypeString{AccessMode: v1alpha2.ReadWrite}},
+		},
+	}
+	return model
+}
+
+func TestValidateDesiredValue(t *testing.T) {
+	model := testDeviceModel()
+	cases := []struct {
+		property string
+		value    string
+		wantErr  bool
+	}{
+		{property: "temperature", value: "20", wantErr: true},
+		{property: "threshold", value: "100"},
+		{property: "threshold", value: "101", wantErr: true},
+		{property: "threshold", value: "1.5", wantErr: true},
+		{property: "ratio", value: "-0.5"},
+		{property: "ratio", value: "1.5", wantErr: true},
+		{property: "enabled", value: "true"},
+		{property: "enabled", value: "yes", wantErr: true},
+		{property: "label", value: "anything"},
+	}
+	for _, c := range cases {
+		err := ValidateDesiredValue(FindProperty(model, c.property), c.value)
+		if (err != nil) != c.wantErr {
+			t.Errorf("ValidateDesiredValue(%s, %s) error = %v, want error %v", c.property, c.value, err, c.wantErr)
+		}
+	}
+}
+
+func TestValidateDevice(t *testing.T) {
+	model := testDeviceModel()
+	newDevice := func(modelName string, visitors []string, twins map[string]string) *v1alpha2.Device {
+		device := &v1alpha2.Device{}
+		if modelName != "" {
+			device.Spec.DeviceModelRef = &v1.LocalObjectReference{Name: modelName}
+		}
+		for _, name := range visitors {
+			device.Spec.PropertyVisitors = append(device.Spec.PropertyVisitors, v1alpha2.DevicePropertyVisitor{PropertyName: name})
+		}
+		for name, value := range twins {
+			device.Status.Twins = append(device.Status.Twins, v1alpha2.Twin{
+				PropertyName: name,
+				Desired:      v1alpha2.TwinProperty{Value: value},
+			})
+		}
+		return device
+	}
+
+	cases := []struct {
+		name     string
+		device   *v1alpha2.Device
+		model    *v1alpha2.DeviceModel
+		expected field.ErrorList
+	}{
+		{
+			name:     "case1 valid device",
+			device:   newDevice("sensor-model", []string{"temperature", "threshold"}, map[string]string{"threshold": "50", "temperature": ""}),
+			model:    model,
+			expected: field.ErrorList{},
+		},
+		{
+			name:   "case2 no device model",
+			device: newDevice("", nil, nil),
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "deviceModelRef"), "device model must be referenced"),
+			},
+		},
+		{
+			name:   "case3 device model not found",
+			device: newDevice("missing", nil, nil),
+			expected: field.ErrorList{
+				field.NotFound(field.NewPath("spec", "deviceModelRef", "name"), "missing"),
+			},
+		},
+		{
+			name:   "case4 undefined properties and invalid desired value",
+			device: newDevice("sensor-model", []string{"humidity"}, map[string]string{"threshold": "200"}),
+			model:  model,
+			expected: field.ErrorList{
+				field.NotFound(field.NewPath("spec", "propertyVisitors").Index(0).Child("propertyName"), "humidity"),
+				field.Invalid(field.NewPath("status", "twins").Index(0).Child("desired", "value"), "200",
+					"property threshold must be between 0 and 100"),
+			},
+		},
+	}
+	for _, c := range cases {
+		if result := ValidateDevice(c.device, c.model); !reflect.DeepEqual(result, c.expected) {
+			t.Errorf("%v: expected %v, but got %v", c.name, c.expected, result)
+		}
+	}
+}
+
+func TestValidateAlertDesiredWrites(t *testing.T) {
+	rule := &v1alpha2.DeviceAlertRule{}
+	rule.Spec.Actions.DesiredWrites = []v1alpha2.AlertDesiredWrite{
+		{DeviceName: "sensor", PropertyName: "threshold", Value: "50"},
+		{DeviceName: "sensor", PropertyName: "temperature", Value: "20"},
+		{DeviceName: "sensor", PropertyName: "threshold", Value: "200"},
+		{DeviceName: "sensor", PropertyName: "humidity", Value: "50"},
+		{DeviceName: "missing", PropertyName: "threshold", Value: "50"},
+	}
+	writesPath := field.NewPath("spec", "actions", "desiredWrites")
+	expected := field.ErrorList{
+		field.Invalid(writesPath.Index(1).Child("value"), "20", "property temperature is ReadOnly"),
+		field.Invalid(writesPath.Index(2).Child("value"), "200", "property threshold must be between 0 and 100"),
+		field.NotFound(writesPath.Index(3).Child("propertyName"), "humidity"),
+		field.NotFound(writesPath.Index(4).Child("deviceName"), "missing"),
+	}
+	result := ValidateAlertDesiredWrites(rule, map[string]*v1alpha2.DeviceModel{"sensor": testDeviceModel()})
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, but got %v", expected, result)
+	}
+}
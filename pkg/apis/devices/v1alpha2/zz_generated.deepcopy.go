@@ -26,6 +26,59 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertActions) DeepCopyInto(out *AlertActions) {
+	*out = *in
+	if in.DesiredWrites != nil {
+		in, out := &in.DesiredWrites, &out.DesiredWrites
+		*out = make([]AlertDesiredWrite, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertActions.
+func (in *AlertActions) DeepCopy() *AlertActions {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertCondition) DeepCopyInto(out *AlertCondition) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertCondition.
+func (in *AlertCondition) DeepCopy() *AlertCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertDesiredWrite) DeepCopyInto(out *AlertDesiredWrite) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertDesiredWrite.
+func (in *AlertDesiredWrite) DeepCopy() *AlertDesiredWrite {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertDesiredWrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BluetoothOperations) DeepCopyInto(out *BluetoothOperations) {
 	*out = *in
@@ -114,6 +167,239 @@ func (in *Device) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceAlertRule) DeepCopyInto(out *DeviceAlertRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceAlertRule.
+func (in *DeviceAlertRule) DeepCopy() *DeviceAlertRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceAlertRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeviceAlertRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceAlertRuleList) DeepCopyInto(out *DeviceAlertRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeviceAlertRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceAlertRuleList.
+func (in *DeviceAlertRuleList) DeepCopy() *DeviceAlertRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceAlertRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeviceAlertRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceAlertRuleSpec) DeepCopyInto(out *DeviceAlertRuleSpec) {
+	*out = *in
+	out.Condition = in.Condition
+	in.Actions.DeepCopyInto(&out.Actions)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceAlertRuleSpec.
+func (in *DeviceAlertRuleSpec) DeepCopy() *DeviceAlertRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceAlertRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceAlertRuleStatus) DeepCopyInto(out *DeviceAlertRuleStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceAlertRuleStatus.
+func (in *DeviceAlertRuleStatus) DeepCopy() *DeviceAlertRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceAlertRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceCommand) DeepCopyInto(out *DeviceCommand) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceCommand.
+func (in *DeviceCommand) DeepCopy() *DeviceCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceCommandRequest) DeepCopyInto(out *DeviceCommandRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceCommandRequest.
+func (in *DeviceCommandRequest) DeepCopy() *DeviceCommandRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceCommandRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeviceCommandRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceCommandRequestList) DeepCopyInto(out *DeviceCommandRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeviceCommandRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceCommandRequestList.
+func (in *DeviceCommandRequestList) DeepCopy() *DeviceCommandRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceCommandRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeviceCommandRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceCommandRequestSpec) DeepCopyInto(out *DeviceCommandRequestSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceCommandRequestSpec.
+func (in *DeviceCommandRequestSpec) DeepCopy() *DeviceCommandRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceCommandRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceCommandRequestStatus) DeepCopyInto(out *DeviceCommandRequestStatus) {
+	*out = *in
+	if in.SentTime != nil {
+		in, out := &in.SentTime, &out.SentTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceCommandRequestStatus.
+func (in *DeviceCommandRequestStatus) DeepCopy() *DeviceCommandRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceCommandRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeviceData) DeepCopyInto(out *DeviceData) {
 	*out = *in
@@ -240,6 +526,13 @@ func (in *DeviceModelSpec) DeepCopyInto(out *DeviceModelSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]DeviceCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -672,6 +965,11 @@ func (in *Twin) DeepCopyInto(out *Twin) {
 	*out = *in
 	in.Desired.DeepCopyInto(&out.Desired)
 	in.Reported.DeepCopyInto(&out.Reported)
+	if in.DesiredVersion != nil {
+		in, out := &in.DesiredVersion, &out.DesiredVersion
+		*out = new(TwinVersion)
+		**out = **in
+	}
 	return
 }
 
@@ -708,6 +1006,22 @@ func (in *TwinProperty) DeepCopy() *TwinProperty {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TwinVersion) DeepCopyInto(out *TwinVersion) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TwinVersion.
+func (in *TwinVersion) DeepCopy() *TwinVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(TwinVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VisitorConfig) DeepCopyInto(out *VisitorConfig) {
 	*out = *in
@@ -34,6 +34,10 @@ type DeviceManagerService interface {
 	// When the mapper collects some properties of a device, it can make them a map of device twins
 	// and report it to the device manager through the interface of ReportDeviceStatus.
 	ReportDeviceStatus(*dmiapi.ReportDeviceStatusRequest) (*dmiapi.ReportDeviceStatusResponse, error)
+	// QueryDeviceData queries the history of a device property kept by device manager.
+	// When the time-series buffer of device twin is enabled, device manager keeps the values
+	// reported through ReportDeviceStatus, and returns the raw values or the aggregates of them in a time range.
+	QueryDeviceData(*dmiapi.QueryDeviceDataRequest) (*dmiapi.QueryDeviceDataResponse, error)
 }
 
 // DeviceMapperService defines the public APIS for remote device management.
@@ -75,6 +79,13 @@ type DeviceMapperService interface {
 	// When the mapper gets the request of querying with the device name,
 	// it should return the device information.
 	GetDevice(*dmiapi.GetDeviceRequest) (*dmiapi.GetDeviceResponse, error)
+	// InvokeDeviceCommand invokes a command on a device through the device mapper.
+	// Device manager sends the device name, the command name and the parameters
+	// to the mapper through the interface of InvokeDeviceCommand.
+	// The command must be declared by the device model of the device.
+	// When the mapper gets the request of invoking a command,
+	// it should execute the command on the real physical device and return the result before the timeout.
+	InvokeDeviceCommand(*dmiapi.InvokeDeviceCommandRequest) (*dmiapi.InvokeDeviceCommandResponse, error)
 
 	// CreateDeviceModel creates a device model to the device mapper.
 	// Device manager sends the information of device model to the mapper
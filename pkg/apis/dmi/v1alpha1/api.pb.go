@@ -19,7 +19,7 @@ Copyright 2022 The KubeEdge Authors.
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
+// 	protoc-gen-go v1.31.0
 // 	protoc        v3.21.1
 // source: api.proto
 
@@ -2296,7 +2296,8 @@ type MapperInfo struct {
 	ApiVersion string `protobuf:"bytes,3,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
 	// the protocol of the mapper.
 	Protocol string `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
-	// the address of the mapper. it is a unix domain socket of grpc.
+	// the address of the mapper. it is a unix domain socket of grpc,
+	// or tcp://host:port for the mapper on another host which is called with mutual TLS.
 	Address []byte `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
 	// the state of the mapper.
 	State string `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
@@ -2501,6 +2502,8 @@ type Twin struct {
 	Desired *TwinProperty `protobuf:"bytes,2,opt,name=desired,proto3" json:"desired,omitempty"`
 	// the reported value of the property from the real device.
 	Reported *TwinProperty `protobuf:"bytes,3,opt,name=reported,proto3" json:"reported,omitempty"`
+	// the version of the desired value merged by edgecore.
+	DesiredVersion *TwinVersion `protobuf:"bytes,4,opt,name=desiredVersion,proto3" json:"desiredVersion,omitempty"`
 }
 
 func (x *Twin) Reset() {
@@ -2556,6 +2559,71 @@ func (x *Twin) GetReported() *TwinProperty {
 	return nil
 }
 
+func (x *Twin) GetDesiredVersion() *TwinVersion {
+	if x != nil {
+		return x.DesiredVersion
+	}
+	return nil
+}
+
+// TwinVersion is the version of the twin value updated by the cloud and the edge.
+type TwinVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the version of the last update from the cloud.
+	Cloud int64 `protobuf:"varint,1,opt,name=cloud,proto3" json:"cloud,omitempty"`
+	// the version of the last update on the edge.
+	Edge int64 `protobuf:"varint,2,opt,name=edge,proto3" json:"edge,omitempty"`
+}
+
+func (x *TwinVersion) Reset() {
+	*x = TwinVersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TwinVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TwinVersion) ProtoMessage() {}
+
+func (x *TwinVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TwinVersion.ProtoReflect.Descriptor instead.
+func (*TwinVersion) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TwinVersion) GetCloud() int64 {
+	if x != nil {
+		return x.Cloud
+	}
+	return 0
+}
+
+func (x *TwinVersion) GetEdge() int64 {
+	if x != nil {
+		return x.Edge
+	}
+	return 0
+}
+
 // TwinProperty is the specification of the property.
 type TwinProperty struct {
 	state         protoimpl.MessageState
@@ -2571,7 +2639,7 @@ type TwinProperty struct {
 func (x *TwinProperty) Reset() {
 	*x = TwinProperty{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[35]
+		mi := &file_api_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2584,7 +2652,7 @@ func (x *TwinProperty) String() string {
 func (*TwinProperty) ProtoMessage() {}
 
 func (x *TwinProperty) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[35]
+	mi := &file_api_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2597,7 +2665,7 @@ func (x *TwinProperty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TwinProperty.ProtoReflect.Descriptor instead.
 func (*TwinProperty) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{35}
+	return file_api_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *TwinProperty) GetValue() string {
@@ -2623,7 +2691,7 @@ type ReportDeviceStatusResponse struct {
 func (x *ReportDeviceStatusResponse) Reset() {
 	*x = ReportDeviceStatusResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[36]
+		mi := &file_api_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2636,7 +2704,7 @@ func (x *ReportDeviceStatusResponse) String() string {
 func (*ReportDeviceStatusResponse) ProtoMessage() {}
 
 func (x *ReportDeviceStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[36]
+	mi := &file_api_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2649,7 +2717,286 @@ func (x *ReportDeviceStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportDeviceStatusResponse.ProtoReflect.Descriptor instead.
 func (*ReportDeviceStatusResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{36}
+	return file_api_proto_rawDescGZIP(), []int{37}
+}
+
+type QueryDeviceDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the name of the device.
+	DeviceName string `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	// the name of the property.
+	PropertyName string `protobuf:"bytes,2,opt,name=propertyName,proto3" json:"propertyName,omitempty"`
+	// the start of the time range in unix milliseconds, 0 means unbounded.
+	Start int64 `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	// the end of the time range in unix milliseconds, 0 means unbounded.
+	End int64 `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	// the step in milliseconds to aggregate the values, 0 means the raw values are returned.
+	Step int64 `protobuf:"varint,5,opt,name=step,proto3" json:"step,omitempty"`
+}
+
+func (x *QueryDeviceDataRequest) Reset() {
+	*x = QueryDeviceDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryDeviceDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDeviceDataRequest) ProtoMessage() {}
+
+func (x *QueryDeviceDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDeviceDataRequest.ProtoReflect.Descriptor instead.
+func (*QueryDeviceDataRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *QueryDeviceDataRequest) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *QueryDeviceDataRequest) GetPropertyName() string {
+	if x != nil {
+		return x.PropertyName
+	}
+	return ""
+}
+
+func (x *QueryDeviceDataRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *QueryDeviceDataRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *QueryDeviceDataRequest) GetStep() int64 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+type QueryDeviceDataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the raw values of the property, set when step is 0.
+	Points []*DataPoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	// the aggregates of the numeric values of the property, set when step is greater than 0.
+	Aggregates []*DataAggregate `protobuf:"bytes,2,rep,name=aggregates,proto3" json:"aggregates,omitempty"`
+}
+
+func (x *QueryDeviceDataResponse) Reset() {
+	*x = QueryDeviceDataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryDeviceDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDeviceDataResponse) ProtoMessage() {}
+
+func (x *QueryDeviceDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDeviceDataResponse.ProtoReflect.Descriptor instead.
+func (*QueryDeviceDataResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *QueryDeviceDataResponse) GetPoints() []*DataPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *QueryDeviceDataResponse) GetAggregates() []*DataAggregate {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+// DataPoint is a value of the property reported at the timestamp.
+type DataPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the timestamp in unix milliseconds.
+	Timestamp int64  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Value     string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *DataPoint) Reset() {
+	*x = DataPoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataPoint) ProtoMessage() {}
+
+func (x *DataPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataPoint.ProtoReflect.Descriptor instead.
+func (*DataPoint) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DataPoint) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *DataPoint) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// DataAggregate summarizes the numeric values of the property in the step starting at the timestamp.
+type DataAggregate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the timestamp in unix milliseconds.
+	Timestamp int64   `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Count     int64   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Min       float64 `protobuf:"fixed64,3,opt,name=min,proto3" json:"min,omitempty"`
+	Max       float64 `protobuf:"fixed64,4,opt,name=max,proto3" json:"max,omitempty"`
+	Avg       float64 `protobuf:"fixed64,5,opt,name=avg,proto3" json:"avg,omitempty"`
+}
+
+func (x *DataAggregate) Reset() {
+	*x = DataAggregate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataAggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataAggregate) ProtoMessage() {}
+
+func (x *DataAggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataAggregate.ProtoReflect.Descriptor instead.
+func (*DataAggregate) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DataAggregate) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *DataAggregate) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DataAggregate) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *DataAggregate) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *DataAggregate) GetAvg() float64 {
+	if x != nil {
+		return x.Avg
+	}
+	return 0
 }
 
 type RegisterDeviceRequest struct {
@@ -2663,7 +3010,7 @@ type RegisterDeviceRequest struct {
 func (x *RegisterDeviceRequest) Reset() {
 	*x = RegisterDeviceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[37]
+		mi := &file_api_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2676,7 +3023,7 @@ func (x *RegisterDeviceRequest) String() string {
 func (*RegisterDeviceRequest) ProtoMessage() {}
 
 func (x *RegisterDeviceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[37]
+	mi := &file_api_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2689,7 +3036,7 @@ func (x *RegisterDeviceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterDeviceRequest.ProtoReflect.Descriptor instead.
 func (*RegisterDeviceRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{37}
+	return file_api_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *RegisterDeviceRequest) GetDevice() *Device {
@@ -2710,7 +3057,7 @@ type RegisterDeviceResponse struct {
 func (x *RegisterDeviceResponse) Reset() {
 	*x = RegisterDeviceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[38]
+		mi := &file_api_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2723,7 +3070,7 @@ func (x *RegisterDeviceResponse) String() string {
 func (*RegisterDeviceResponse) ProtoMessage() {}
 
 func (x *RegisterDeviceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[38]
+	mi := &file_api_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2736,7 +3083,7 @@ func (x *RegisterDeviceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterDeviceResponse.ProtoReflect.Descriptor instead.
 func (*RegisterDeviceResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{38}
+	return file_api_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *RegisterDeviceResponse) GetDeviceName() string {
@@ -2757,7 +3104,7 @@ type CreateDeviceModelRequest struct {
 func (x *CreateDeviceModelRequest) Reset() {
 	*x = CreateDeviceModelRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[39]
+		mi := &file_api_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2770,7 +3117,7 @@ func (x *CreateDeviceModelRequest) String() string {
 func (*CreateDeviceModelRequest) ProtoMessage() {}
 
 func (x *CreateDeviceModelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[39]
+	mi := &file_api_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2783,7 +3130,7 @@ func (x *CreateDeviceModelRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateDeviceModelRequest.ProtoReflect.Descriptor instead.
 func (*CreateDeviceModelRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{39}
+	return file_api_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *CreateDeviceModelRequest) GetModel() *DeviceModel {
@@ -2804,7 +3151,7 @@ type CreateDeviceModelResponse struct {
 func (x *CreateDeviceModelResponse) Reset() {
 	*x = CreateDeviceModelResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[40]
+		mi := &file_api_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2817,7 +3164,7 @@ func (x *CreateDeviceModelResponse) String() string {
 func (*CreateDeviceModelResponse) ProtoMessage() {}
 
 func (x *CreateDeviceModelResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[40]
+	mi := &file_api_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2830,7 +3177,7 @@ func (x *CreateDeviceModelResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateDeviceModelResponse.ProtoReflect.Descriptor instead.
 func (*CreateDeviceModelResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{40}
+	return file_api_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *CreateDeviceModelResponse) GetDeviceModelName() string {
@@ -2851,7 +3198,7 @@ type RemoveDeviceRequest struct {
 func (x *RemoveDeviceRequest) Reset() {
 	*x = RemoveDeviceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[41]
+		mi := &file_api_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2864,7 +3211,7 @@ func (x *RemoveDeviceRequest) String() string {
 func (*RemoveDeviceRequest) ProtoMessage() {}
 
 func (x *RemoveDeviceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[41]
+	mi := &file_api_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2877,7 +3224,7 @@ func (x *RemoveDeviceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveDeviceRequest.ProtoReflect.Descriptor instead.
 func (*RemoveDeviceRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{41}
+	return file_api_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *RemoveDeviceRequest) GetDeviceName() string {
@@ -2896,7 +3243,7 @@ type RemoveDeviceResponse struct {
 func (x *RemoveDeviceResponse) Reset() {
 	*x = RemoveDeviceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[42]
+		mi := &file_api_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2909,7 +3256,7 @@ func (x *RemoveDeviceResponse) String() string {
 func (*RemoveDeviceResponse) ProtoMessage() {}
 
 func (x *RemoveDeviceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[42]
+	mi := &file_api_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2922,7 +3269,7 @@ func (x *RemoveDeviceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveDeviceResponse.ProtoReflect.Descriptor instead.
 func (*RemoveDeviceResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{42}
+	return file_api_proto_rawDescGZIP(), []int{47}
 }
 
 type RemoveDeviceModelRequest struct {
@@ -2936,7 +3283,7 @@ type RemoveDeviceModelRequest struct {
 func (x *RemoveDeviceModelRequest) Reset() {
 	*x = RemoveDeviceModelRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[43]
+		mi := &file_api_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2949,7 +3296,7 @@ func (x *RemoveDeviceModelRequest) String() string {
 func (*RemoveDeviceModelRequest) ProtoMessage() {}
 
 func (x *RemoveDeviceModelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[43]
+	mi := &file_api_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2962,7 +3309,7 @@ func (x *RemoveDeviceModelRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveDeviceModelRequest.ProtoReflect.Descriptor instead.
 func (*RemoveDeviceModelRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{43}
+	return file_api_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *RemoveDeviceModelRequest) GetModelName() string {
@@ -2981,7 +3328,7 @@ type RemoveDeviceModelResponse struct {
 func (x *RemoveDeviceModelResponse) Reset() {
 	*x = RemoveDeviceModelResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[44]
+		mi := &file_api_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2994,7 +3341,7 @@ func (x *RemoveDeviceModelResponse) String() string {
 func (*RemoveDeviceModelResponse) ProtoMessage() {}
 
 func (x *RemoveDeviceModelResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[44]
+	mi := &file_api_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3007,7 +3354,7 @@ func (x *RemoveDeviceModelResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveDeviceModelResponse.ProtoReflect.Descriptor instead.
 func (*RemoveDeviceModelResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{44}
+	return file_api_proto_rawDescGZIP(), []int{49}
 }
 
 type UpdateDeviceRequest struct {
@@ -3021,7 +3368,7 @@ type UpdateDeviceRequest struct {
 func (x *UpdateDeviceRequest) Reset() {
 	*x = UpdateDeviceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[45]
+		mi := &file_api_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3034,7 +3381,7 @@ func (x *UpdateDeviceRequest) String() string {
 func (*UpdateDeviceRequest) ProtoMessage() {}
 
 func (x *UpdateDeviceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[45]
+	mi := &file_api_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3047,7 +3394,7 @@ func (x *UpdateDeviceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDeviceRequest.ProtoReflect.Descriptor instead.
 func (*UpdateDeviceRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{45}
+	return file_api_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *UpdateDeviceRequest) GetDevice() *Device {
@@ -3066,7 +3413,7 @@ type UpdateDeviceResponse struct {
 func (x *UpdateDeviceResponse) Reset() {
 	*x = UpdateDeviceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[46]
+		mi := &file_api_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3079,7 +3426,7 @@ func (x *UpdateDeviceResponse) String() string {
 func (*UpdateDeviceResponse) ProtoMessage() {}
 
 func (x *UpdateDeviceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[46]
+	mi := &file_api_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3092,7 +3439,7 @@ func (x *UpdateDeviceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDeviceResponse.ProtoReflect.Descriptor instead.
 func (*UpdateDeviceResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{46}
+	return file_api_proto_rawDescGZIP(), []int{51}
 }
 
 type UpdateDeviceModelRequest struct {
@@ -3106,7 +3453,7 @@ type UpdateDeviceModelRequest struct {
 func (x *UpdateDeviceModelRequest) Reset() {
 	*x = UpdateDeviceModelRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[47]
+		mi := &file_api_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3119,7 +3466,7 @@ func (x *UpdateDeviceModelRequest) String() string {
 func (*UpdateDeviceModelRequest) ProtoMessage() {}
 
 func (x *UpdateDeviceModelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[47]
+	mi := &file_api_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3132,7 +3479,7 @@ func (x *UpdateDeviceModelRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDeviceModelRequest.ProtoReflect.Descriptor instead.
 func (*UpdateDeviceModelRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{47}
+	return file_api_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *UpdateDeviceModelRequest) GetModel() *DeviceModel {
@@ -3151,7 +3498,7 @@ type UpdateDeviceModelResponse struct {
 func (x *UpdateDeviceModelResponse) Reset() {
 	*x = UpdateDeviceModelResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[48]
+		mi := &file_api_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3164,7 +3511,7 @@ func (x *UpdateDeviceModelResponse) String() string {
 func (*UpdateDeviceModelResponse) ProtoMessage() {}
 
 func (x *UpdateDeviceModelResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[48]
+	mi := &file_api_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3177,7 +3524,7 @@ func (x *UpdateDeviceModelResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDeviceModelResponse.ProtoReflect.Descriptor instead.
 func (*UpdateDeviceModelResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{48}
+	return file_api_proto_rawDescGZIP(), []int{53}
 }
 
 type UpdateDeviceStatusRequest struct {
@@ -3192,7 +3539,7 @@ type UpdateDeviceStatusRequest struct {
 func (x *UpdateDeviceStatusRequest) Reset() {
 	*x = UpdateDeviceStatusRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[49]
+		mi := &file_api_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3205,7 +3552,7 @@ func (x *UpdateDeviceStatusRequest) String() string {
 func (*UpdateDeviceStatusRequest) ProtoMessage() {}
 
 func (x *UpdateDeviceStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[49]
+	mi := &file_api_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3218,7 +3565,7 @@ func (x *UpdateDeviceStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDeviceStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateDeviceStatusRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{49}
+	return file_api_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *UpdateDeviceStatusRequest) GetDeviceName() string {
@@ -3244,7 +3591,7 @@ type UpdateDeviceStatusResponse struct {
 func (x *UpdateDeviceStatusResponse) Reset() {
 	*x = UpdateDeviceStatusResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[50]
+		mi := &file_api_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3257,7 +3604,7 @@ func (x *UpdateDeviceStatusResponse) String() string {
 func (*UpdateDeviceStatusResponse) ProtoMessage() {}
 
 func (x *UpdateDeviceStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[50]
+	mi := &file_api_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3270,7 +3617,7 @@ func (x *UpdateDeviceStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDeviceStatusResponse.ProtoReflect.Descriptor instead.
 func (*UpdateDeviceStatusResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{50}
+	return file_api_proto_rawDescGZIP(), []int{55}
 }
 
 type GetDeviceRequest struct {
@@ -3284,7 +3631,7 @@ type GetDeviceRequest struct {
 func (x *GetDeviceRequest) Reset() {
 	*x = GetDeviceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[51]
+		mi := &file_api_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3297,7 +3644,7 @@ func (x *GetDeviceRequest) String() string {
 func (*GetDeviceRequest) ProtoMessage() {}
 
 func (x *GetDeviceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[51]
+	mi := &file_api_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3310,7 +3657,7 @@ func (x *GetDeviceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDeviceRequest.ProtoReflect.Descriptor instead.
 func (*GetDeviceRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{51}
+	return file_api_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *GetDeviceRequest) GetDeviceName() string {
@@ -3331,7 +3678,7 @@ type GetDeviceResponse struct {
 func (x *GetDeviceResponse) Reset() {
 	*x = GetDeviceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_msgTypes[52]
+		mi := &file_api_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3344,7 +3691,7 @@ func (x *GetDeviceResponse) String() string {
 func (*GetDeviceResponse) ProtoMessage() {}
 
 func (x *GetDeviceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[52]
+	mi := &file_api_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3357,7 +3704,7 @@ func (x *GetDeviceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDeviceResponse.ProtoReflect.Descriptor instead.
 func (*GetDeviceResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{52}
+	return file_api_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *GetDeviceResponse) GetDevice() *Device {
@@ -3367,6 +3714,138 @@ func (x *GetDeviceResponse) GetDevice() *Device {
 	return nil
 }
 
+type InvokeDeviceCommandRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the name of the device.
+	DeviceName string `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	// the name of the command declared by the device model.
+	CommandName string `protobuf:"bytes,2,opt,name=commandName,proto3" json:"commandName,omitempty"`
+	// the parameters of this invocation.
+	Parameters map[string]string `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the timeout of this invocation in seconds.
+	Timeout int64 `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *InvokeDeviceCommandRequest) Reset() {
+	*x = InvokeDeviceCommandRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvokeDeviceCommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeDeviceCommandRequest) ProtoMessage() {}
+
+func (x *InvokeDeviceCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeDeviceCommandRequest.ProtoReflect.Descriptor instead.
+func (*InvokeDeviceCommandRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *InvokeDeviceCommandRequest) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *InvokeDeviceCommandRequest) GetCommandName() string {
+	if x != nil {
+		return x.CommandName
+	}
+	return ""
+}
+
+func (x *InvokeDeviceCommandRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *InvokeDeviceCommandRequest) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+type InvokeDeviceCommandResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the status code which the command returns.
+	StatusCode string `protobuf:"bytes,1,opt,name=statusCode,proto3" json:"statusCode,omitempty"`
+	// the result which the command returns.
+	Result []byte `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *InvokeDeviceCommandResponse) Reset() {
+	*x = InvokeDeviceCommandResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvokeDeviceCommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeDeviceCommandResponse) ProtoMessage() {}
+
+func (x *InvokeDeviceCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeDeviceCommandResponse.ProtoReflect.Descriptor instead.
+func (*InvokeDeviceCommandResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *InvokeDeviceCommandResponse) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *InvokeDeviceCommandResponse) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
 var File_api_proto protoreflect.FileDescriptor
 
 var file_api_proto_rawDesc = []byte{
@@ -3721,7 +4200,7 @@ var file_api_proto_rawDesc = []byte{
 	0x73, 0x12, 0x24, 0x0a, 0x05, 0x74, 0x77, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x0e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x77, 0x69, 0x6e,
 	0x52, 0x05, 0x74, 0x77, 0x69, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x90, 0x01,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0xcf, 0x01,
 	0x0a, 0x04, 0x54, 0x77, 0x69, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72,
 	0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72,
 	0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x64, 0x65,
@@ -3731,19 +4210,56 @@ var file_api_proto_rawDesc = []byte{
 	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
 	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x77, 0x69, 0x6e, 0x50, 0x72,
 	0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
-	0x22, 0xa3, 0x01, 0x0a, 0x0c, 0x54, 0x77, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x40, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x77, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
-	0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1c, 0x0a, 0x1a, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x41, 0x0a, 0x15, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x12, 0x3d, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x54, 0x77, 0x69, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x0e, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0x37, 0x0a, 0x0b, 0x54, 0x77, 0x69, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x65, 0x64, 0x67, 0x65, 0x22, 0xa3, 0x01, 0x0a, 0x0c, 0x54, 0x77, 0x69,
+	0x6e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x40, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x77, 0x69,
+	0x6e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1c,
+	0x0a, 0x1a, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x98, 0x01, 0x0a,
+	0x16, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x70, 0x65,
+	0x72, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70,
+	0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x65, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x22, 0x7f, 0x0a, 0x17, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12,
+	0x37, 0x0a, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x22, 0x3f, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x79, 0x0a, 0x0d, 0x44, 0x61, 0x74,
+	0x61, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e,
+	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d,
+	0x61, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x76, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x03, 0x61, 0x76, 0x67, 0x22, 0x41, 0x0a, 0x15, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
 	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a,
 	0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
 	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52,
@@ -3799,67 +4315,101 @@ var file_api_proto_rawDesc = []byte{
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69,
 	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
 	0x68, 0x61, 0x31, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x32, 0xd0, 0x01, 0x0a, 0x14, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x61, 0x6e,
-	0x61, 0x67, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a, 0x0e, 0x4d,
-	0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1f, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x61, 0x70, 0x70, 0x65, 0x72,
-	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x61, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xd9, 0x05, 0x0a, 0x13, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x4d, 0x61, 0x70, 0x70, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a,
-	0x0e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x1f, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x20, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44,
+	0x63, 0x65, 0x22, 0x8d, 0x02, 0x0a, 0x1a, 0x49, 0x6e, 0x76, 0x6f, 0x6b, 0x65, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x54, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x6b, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x55, 0x0a, 0x1b, 0x49, 0x6e, 0x76, 0x6f, 0x6b, 0x65, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x32, 0xaa, 0x02, 0x0a, 0x14, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x55, 0x0a, 0x0e, 0x4d, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x4d, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x4d, 0x61, 0x70, 0x70, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x12, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x23, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0f,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x20, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x21, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xbf, 0x06, 0x0a, 0x13, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x4d, 0x61, 0x70, 0x70, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x55,
+	0x0a, 0x0e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x1f, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x20, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44,
 	0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x22, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x23, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x22, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x23, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
-	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x22, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x23, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x2e, 0x76,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x22, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x23, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x22, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x23, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x22, 0x2e, 0x76,
 	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x24, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x09, 0x47, 0x65, 0x74,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65,
-	0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x3b, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x23, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x64, 0x0a, 0x13, 0x49, 0x6e, 0x76, 0x6f, 0x6b, 0x65, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x24, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x6b, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x6b,
+	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x3b, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3874,65 +4424,73 @@ func file_api_proto_rawDescGZIP() []byte {
 	return file_api_proto_rawDescData
 }
 
-var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 56)
+var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 64)
 var file_api_proto_goTypes = []interface{}{
-	(*MapperRegisterRequest)(nil),      // 0: v1alpha1.MapperRegisterRequest
-	(*MapperRegisterResponse)(nil),     // 1: v1alpha1.MapperRegisterResponse
-	(*DeviceModel)(nil),                // 2: v1alpha1.DeviceModel
-	(*DeviceModelSpec)(nil),            // 3: v1alpha1.DeviceModelSpec
-	(*DeviceProperty)(nil),             // 4: v1alpha1.DeviceProperty
-	(*PropertyType)(nil),               // 5: v1alpha1.PropertyType
-	(*PropertyTypeInt64)(nil),          // 6: v1alpha1.PropertyTypeInt64
-	(*PropertyTypeString)(nil),         // 7: v1alpha1.PropertyTypeString
-	(*PropertyTypeDouble)(nil),         // 8: v1alpha1.PropertyTypeDouble
-	(*PropertyTypeFloat)(nil),          // 9: v1alpha1.PropertyTypeFloat
-	(*PropertyTypeBoolean)(nil),        // 10: v1alpha1.PropertyTypeBoolean
-	(*PropertyTypeBytes)(nil),          // 11: v1alpha1.PropertyTypeBytes
-	(*DeviceCommand)(nil),              // 12: v1alpha1.DeviceCommand
-	(*Device)(nil),                     // 13: v1alpha1.Device
-	(*DeviceSpec)(nil),                 // 14: v1alpha1.DeviceSpec
-	(*ProtocolConfig)(nil),             // 15: v1alpha1.ProtocolConfig
-	(*ProtocolConfigOpcUA)(nil),        // 16: v1alpha1.ProtocolConfigOpcUA
-	(*ProtocolConfigModbus)(nil),       // 17: v1alpha1.ProtocolConfigModbus
-	(*ProtocolConfigBluetooth)(nil),    // 18: v1alpha1.ProtocolConfigBluetooth
-	(*ProtocolConfigCommon)(nil),       // 19: v1alpha1.ProtocolConfigCommon
-	(*ProtocolConfigCOM)(nil),          // 20: v1alpha1.ProtocolConfigCOM
-	(*ProtocolConfigTCP)(nil),          // 21: v1alpha1.ProtocolConfigTCP
-	(*CustomizedValue)(nil),            // 22: v1alpha1.CustomizedValue
-	(*ProtocolConfigCustomized)(nil),   // 23: v1alpha1.ProtocolConfigCustomized
-	(*DevicePropertyVisitor)(nil),      // 24: v1alpha1.DevicePropertyVisitor
-	(*VisitorConfigOPCUA)(nil),         // 25: v1alpha1.VisitorConfigOPCUA
-	(*VisitorConfigModbus)(nil),        // 26: v1alpha1.VisitorConfigModbus
-	(*VisitorConfigBluetooth)(nil),     // 27: v1alpha1.VisitorConfigBluetooth
-	(*BluetoothReadConverter)(nil),     // 28: v1alpha1.BluetoothReadConverter
-	(*BluetoothOperations)(nil),        // 29: v1alpha1.BluetoothOperations
-	(*VisitorConfigCustomized)(nil),    // 30: v1alpha1.VisitorConfigCustomized
-	(*MapperInfo)(nil),                 // 31: v1alpha1.MapperInfo
-	(*ReportDeviceStatusRequest)(nil),  // 32: v1alpha1.ReportDeviceStatusRequest
-	(*DeviceStatus)(nil),               // 33: v1alpha1.DeviceStatus
-	(*Twin)(nil),                       // 34: v1alpha1.Twin
-	(*TwinProperty)(nil),               // 35: v1alpha1.TwinProperty
-	(*ReportDeviceStatusResponse)(nil), // 36: v1alpha1.ReportDeviceStatusResponse
-	(*RegisterDeviceRequest)(nil),      // 37: v1alpha1.RegisterDeviceRequest
-	(*RegisterDeviceResponse)(nil),     // 38: v1alpha1.RegisterDeviceResponse
-	(*CreateDeviceModelRequest)(nil),   // 39: v1alpha1.CreateDeviceModelRequest
-	(*CreateDeviceModelResponse)(nil),  // 40: v1alpha1.CreateDeviceModelResponse
-	(*RemoveDeviceRequest)(nil),        // 41: v1alpha1.RemoveDeviceRequest
-	(*RemoveDeviceResponse)(nil),       // 42: v1alpha1.RemoveDeviceResponse
-	(*RemoveDeviceModelRequest)(nil),   // 43: v1alpha1.RemoveDeviceModelRequest
-	(*RemoveDeviceModelResponse)(nil),  // 44: v1alpha1.RemoveDeviceModelResponse
-	(*UpdateDeviceRequest)(nil),        // 45: v1alpha1.UpdateDeviceRequest
-	(*UpdateDeviceResponse)(nil),       // 46: v1alpha1.UpdateDeviceResponse
-	(*UpdateDeviceModelRequest)(nil),   // 47: v1alpha1.UpdateDeviceModelRequest
-	(*UpdateDeviceModelResponse)(nil),  // 48: v1alpha1.UpdateDeviceModelResponse
-	(*UpdateDeviceStatusRequest)(nil),  // 49: v1alpha1.UpdateDeviceStatusRequest
-	(*UpdateDeviceStatusResponse)(nil), // 50: v1alpha1.UpdateDeviceStatusResponse
-	(*GetDeviceRequest)(nil),           // 51: v1alpha1.GetDeviceRequest
-	(*GetDeviceResponse)(nil),          // 52: v1alpha1.GetDeviceResponse
-	nil,                                // 53: v1alpha1.CustomizedValue.DataEntry
-	nil,                                // 54: v1alpha1.VisitorConfigBluetooth.DataWriteEntry
-	nil,                                // 55: v1alpha1.TwinProperty.MetadataEntry
-	(*anypb.Any)(nil),                  // 56: google.protobuf.Any
+	(*MapperRegisterRequest)(nil),       // 0: v1alpha1.MapperRegisterRequest
+	(*MapperRegisterResponse)(nil),      // 1: v1alpha1.MapperRegisterResponse
+	(*DeviceModel)(nil),                 // 2: v1alpha1.DeviceModel
+	(*DeviceModelSpec)(nil),             // 3: v1alpha1.DeviceModelSpec
+	(*DeviceProperty)(nil),              // 4: v1alpha1.DeviceProperty
+	(*PropertyType)(nil),                // 5: v1alpha1.PropertyType
+	(*PropertyTypeInt64)(nil),           // 6: v1alpha1.PropertyTypeInt64
+	(*PropertyTypeString)(nil),          // 7: v1alpha1.PropertyTypeString
+	(*PropertyTypeDouble)(nil),          // 8: v1alpha1.PropertyTypeDouble
+	(*PropertyTypeFloat)(nil),           // 9: v1alpha1.PropertyTypeFloat
+	(*PropertyTypeBoolean)(nil),         // 10: v1alpha1.PropertyTypeBoolean
+	(*PropertyTypeBytes)(nil),           // 11: v1alpha1.PropertyTypeBytes
+	(*DeviceCommand)(nil),               // 12: v1alpha1.DeviceCommand
+	(*Device)(nil),                      // 13: v1alpha1.Device
+	(*DeviceSpec)(nil),                  // 14: v1alpha1.DeviceSpec
+	(*ProtocolConfig)(nil),              // 15: v1alpha1.ProtocolConfig
+	(*ProtocolConfigOpcUA)(nil),         // 16: v1alpha1.ProtocolConfigOpcUA
+	(*ProtocolConfigModbus)(nil),        // 17: v1alpha1.ProtocolConfigModbus
+	(*ProtocolConfigBluetooth)(nil),     // 18: v1alpha1.ProtocolConfigBluetooth
+	(*ProtocolConfigCommon)(nil),        // 19: v1alpha1.ProtocolConfigCommon
+	(*ProtocolConfigCOM)(nil),           // 20: v1alpha1.ProtocolConfigCOM
+	(*ProtocolConfigTCP)(nil),           // 21: v1alpha1.ProtocolConfigTCP
+	(*CustomizedValue)(nil),             // 22: v1alpha1.CustomizedValue
+	(*ProtocolConfigCustomized)(nil),    // 23: v1alpha1.ProtocolConfigCustomized
+	(*DevicePropertyVisitor)(nil),       // 24: v1alpha1.DevicePropertyVisitor
+	(*VisitorConfigOPCUA)(nil),          // 25: v1alpha1.VisitorConfigOPCUA
+	(*VisitorConfigModbus)(nil),         // 26: v1alpha1.VisitorConfigModbus
+	(*VisitorConfigBluetooth)(nil),      // 27: v1alpha1.VisitorConfigBluetooth
+	(*BluetoothReadConverter)(nil),      // 28: v1alpha1.BluetoothReadConverter
+	(*BluetoothOperations)(nil),         // 29: v1alpha1.BluetoothOperations
+	(*VisitorConfigCustomized)(nil),     // 30: v1alpha1.VisitorConfigCustomized
+	(*MapperInfo)(nil),                  // 31: v1alpha1.MapperInfo
+	(*ReportDeviceStatusRequest)(nil),   // 32: v1alpha1.ReportDeviceStatusRequest
+	(*DeviceStatus)(nil),                // 33: v1alpha1.DeviceStatus
+	(*Twin)(nil),                        // 34: v1alpha1.Twin
+	(*TwinVersion)(nil),                 // 35: v1alpha1.TwinVersion
+	(*TwinProperty)(nil),                // 36: v1alpha1.TwinProperty
+	(*ReportDeviceStatusResponse)(nil),  // 37: v1alpha1.ReportDeviceStatusResponse
+	(*QueryDeviceDataRequest)(nil),      // 38: v1alpha1.QueryDeviceDataRequest
+	(*QueryDeviceDataResponse)(nil),     // 39: v1alpha1.QueryDeviceDataResponse
+	(*DataPoint)(nil),                   // 40: v1alpha1.DataPoint
+	(*DataAggregate)(nil),               // 41: v1alpha1.DataAggregate
+	(*RegisterDeviceRequest)(nil),       // 42: v1alpha1.RegisterDeviceRequest
+	(*RegisterDeviceResponse)(nil),      // 43: v1alpha1.RegisterDeviceResponse
+	(*CreateDeviceModelRequest)(nil),    // 44: v1alpha1.CreateDeviceModelRequest
+	(*CreateDeviceModelResponse)(nil),   // 45: v1alpha1.CreateDeviceModelResponse
+	(*RemoveDeviceRequest)(nil),         // 46: v1alpha1.RemoveDeviceRequest
+	(*RemoveDeviceResponse)(nil),        // 47: v1alpha1.RemoveDeviceResponse
+	(*RemoveDeviceModelRequest)(nil),    // 48: v1alpha1.RemoveDeviceModelRequest
+	(*RemoveDeviceModelResponse)(nil),   // 49: v1alpha1.RemoveDeviceModelResponse
+	(*UpdateDeviceRequest)(nil),         // 50: v1alpha1.UpdateDeviceRequest
+	(*UpdateDeviceResponse)(nil),        // 51: v1alpha1.UpdateDeviceResponse
+	(*UpdateDeviceModelRequest)(nil),    // 52: v1alpha1.UpdateDeviceModelRequest
+	(*UpdateDeviceModelResponse)(nil),   // 53: v1alpha1.UpdateDeviceModelResponse
+	(*UpdateDeviceStatusRequest)(nil),   // 54: v1alpha1.UpdateDeviceStatusRequest
+	(*UpdateDeviceStatusResponse)(nil),  // 55: v1alpha1.UpdateDeviceStatusResponse
+	(*GetDeviceRequest)(nil),            // 56: v1alpha1.GetDeviceRequest
+	(*GetDeviceResponse)(nil),           // 57: v1alpha1.GetDeviceResponse
+	(*InvokeDeviceCommandRequest)(nil),  // 58: v1alpha1.InvokeDeviceCommandRequest
+	(*InvokeDeviceCommandResponse)(nil), // 59: v1alpha1.InvokeDeviceCommandResponse
+	nil,                                 // 60: v1alpha1.CustomizedValue.DataEntry
+	nil,                                 // 61: v1alpha1.VisitorConfigBluetooth.DataWriteEntry
+	nil,                                 // 62: v1alpha1.TwinProperty.MetadataEntry
+	nil,                                 // 63: v1alpha1.InvokeDeviceCommandRequest.ParametersEntry
+	(*anypb.Any)(nil),                   // 64: google.protobuf.Any
 }
 var file_api_proto_depIdxs = []int32{
 	31, // 0: v1alpha1.MapperRegisterRequest.mapper:type_name -> v1alpha1.MapperInfo
@@ -3960,54 +4518,62 @@ var file_api_proto_depIdxs = []int32{
 	20, // 22: v1alpha1.ProtocolConfigCommon.com:type_name -> v1alpha1.ProtocolConfigCOM
 	21, // 23: v1alpha1.ProtocolConfigCommon.tcp:type_name -> v1alpha1.ProtocolConfigTCP
 	22, // 24: v1alpha1.ProtocolConfigCommon.customizedValues:type_name -> v1alpha1.CustomizedValue
-	53, // 25: v1alpha1.CustomizedValue.data:type_name -> v1alpha1.CustomizedValue.DataEntry
+	60, // 25: v1alpha1.CustomizedValue.data:type_name -> v1alpha1.CustomizedValue.DataEntry
 	22, // 26: v1alpha1.ProtocolConfigCustomized.configData:type_name -> v1alpha1.CustomizedValue
 	22, // 27: v1alpha1.DevicePropertyVisitor.customizedValues:type_name -> v1alpha1.CustomizedValue
 	25, // 28: v1alpha1.DevicePropertyVisitor.opcua:type_name -> v1alpha1.VisitorConfigOPCUA
 	26, // 29: v1alpha1.DevicePropertyVisitor.modbus:type_name -> v1alpha1.VisitorConfigModbus
 	27, // 30: v1alpha1.DevicePropertyVisitor.bluetooth:type_name -> v1alpha1.VisitorConfigBluetooth
 	30, // 31: v1alpha1.DevicePropertyVisitor.customizedProtocol:type_name -> v1alpha1.VisitorConfigCustomized
-	54, // 32: v1alpha1.VisitorConfigBluetooth.dataWrite:type_name -> v1alpha1.VisitorConfigBluetooth.DataWriteEntry
+	61, // 32: v1alpha1.VisitorConfigBluetooth.dataWrite:type_name -> v1alpha1.VisitorConfigBluetooth.DataWriteEntry
 	28, // 33: v1alpha1.VisitorConfigBluetooth.dataConverter:type_name -> v1alpha1.BluetoothReadConverter
 	29, // 34: v1alpha1.BluetoothReadConverter.orderOfOperations:type_name -> v1alpha1.BluetoothOperations
 	22, // 35: v1alpha1.VisitorConfigCustomized.configData:type_name -> v1alpha1.CustomizedValue
 	33, // 36: v1alpha1.ReportDeviceStatusRequest.reportedDevice:type_name -> v1alpha1.DeviceStatus
 	34, // 37: v1alpha1.DeviceStatus.twins:type_name -> v1alpha1.Twin
-	35, // 38: v1alpha1.Twin.desired:type_name -> v1alpha1.TwinProperty
-	35, // 39: v1alpha1.Twin.reported:type_name -> v1alpha1.TwinProperty
-	55, // 40: v1alpha1.TwinProperty.metadata:type_name -> v1alpha1.TwinProperty.MetadataEntry
-	13, // 41: v1alpha1.RegisterDeviceRequest.device:type_name -> v1alpha1.Device
-	2,  // 42: v1alpha1.CreateDeviceModelRequest.model:type_name -> v1alpha1.DeviceModel
-	13, // 43: v1alpha1.UpdateDeviceRequest.device:type_name -> v1alpha1.Device
-	2,  // 44: v1alpha1.UpdateDeviceModelRequest.model:type_name -> v1alpha1.DeviceModel
-	33, // 45: v1alpha1.UpdateDeviceStatusRequest.desiredDevice:type_name -> v1alpha1.DeviceStatus
-	13, // 46: v1alpha1.GetDeviceResponse.device:type_name -> v1alpha1.Device
-	56, // 47: v1alpha1.CustomizedValue.DataEntry.value:type_name -> google.protobuf.Any
-	0,  // 48: v1alpha1.DeviceManagerService.MapperRegister:input_type -> v1alpha1.MapperRegisterRequest
-	32, // 49: v1alpha1.DeviceManagerService.ReportDeviceStatus:input_type -> v1alpha1.ReportDeviceStatusRequest
-	37, // 50: v1alpha1.DeviceMapperService.RegisterDevice:input_type -> v1alpha1.RegisterDeviceRequest
-	41, // 51: v1alpha1.DeviceMapperService.RemoveDevice:input_type -> v1alpha1.RemoveDeviceRequest
-	45, // 52: v1alpha1.DeviceMapperService.UpdateDevice:input_type -> v1alpha1.UpdateDeviceRequest
-	39, // 53: v1alpha1.DeviceMapperService.CreateDeviceModel:input_type -> v1alpha1.CreateDeviceModelRequest
-	43, // 54: v1alpha1.DeviceMapperService.RemoveDeviceModel:input_type -> v1alpha1.RemoveDeviceModelRequest
-	47, // 55: v1alpha1.DeviceMapperService.UpdateDeviceModel:input_type -> v1alpha1.UpdateDeviceModelRequest
-	49, // 56: v1alpha1.DeviceMapperService.UpdateDeviceStatus:input_type -> v1alpha1.UpdateDeviceStatusRequest
-	51, // 57: v1alpha1.DeviceMapperService.GetDevice:input_type -> v1alpha1.GetDeviceRequest
-	1,  // 58: v1alpha1.DeviceManagerService.MapperRegister:output_type -> v1alpha1.MapperRegisterResponse
-	36, // 59: v1alpha1.DeviceManagerService.ReportDeviceStatus:output_type -> v1alpha1.ReportDeviceStatusResponse
-	38, // 60: v1alpha1.DeviceMapperService.RegisterDevice:output_type -> v1alpha1.RegisterDeviceResponse
-	42, // 61: v1alpha1.DeviceMapperService.RemoveDevice:output_type -> v1alpha1.RemoveDeviceResponse
-	46, // 62: v1alpha1.DeviceMapperService.UpdateDevice:output_type -> v1alpha1.UpdateDeviceResponse
-	40, // 63: v1alpha1.DeviceMapperService.CreateDeviceModel:output_type -> v1alpha1.CreateDeviceModelResponse
-	44, // 64: v1alpha1.DeviceMapperService.RemoveDeviceModel:output_type -> v1alpha1.RemoveDeviceModelResponse
-	48, // 65: v1alpha1.DeviceMapperService.UpdateDeviceModel:output_type -> v1alpha1.UpdateDeviceModelResponse
-	50, // 66: v1alpha1.DeviceMapperService.UpdateDeviceStatus:output_type -> v1alpha1.UpdateDeviceStatusResponse
-	52, // 67: v1alpha1.DeviceMapperService.GetDevice:output_type -> v1alpha1.GetDeviceResponse
-	58, // [58:68] is the sub-list for method output_type
-	48, // [48:58] is the sub-list for method input_type
-	48, // [48:48] is the sub-list for extension type_name
-	48, // [48:48] is the sub-list for extension extendee
-	0,  // [0:48] is the sub-list for field type_name
+	36, // 38: v1alpha1.Twin.desired:type_name -> v1alpha1.TwinProperty
+	36, // 39: v1alpha1.Twin.reported:type_name -> v1alpha1.TwinProperty
+	35, // 40: v1alpha1.Twin.desiredVersion:type_name -> v1alpha1.TwinVersion
+	62, // 41: v1alpha1.TwinProperty.metadata:type_name -> v1alpha1.TwinProperty.MetadataEntry
+	40, // 42: v1alpha1.QueryDeviceDataResponse.points:type_name -> v1alpha1.DataPoint
+	41, // 43: v1alpha1.QueryDeviceDataResponse.aggregates:type_name -> v1alpha1.DataAggregate
+	13, // 44: v1alpha1.RegisterDeviceRequest.device:type_name -> v1alpha1.Device
+	2,  // 45: v1alpha1.CreateDeviceModelRequest.model:type_name -> v1alpha1.DeviceModel
+	13, // 46: v1alpha1.UpdateDeviceRequest.device:type_name -> v1alpha1.Device
+	2,  // 47: v1alpha1.UpdateDeviceModelRequest.model:type_name -> v1alpha1.DeviceModel
+	33, // 48: v1alpha1.UpdateDeviceStatusRequest.desiredDevice:type_name -> v1alpha1.DeviceStatus
+	13, // 49: v1alpha1.GetDeviceResponse.device:type_name -> v1alpha1.Device
+	63, // 50: v1alpha1.InvokeDeviceCommandRequest.parameters:type_name -> v1alpha1.InvokeDeviceCommandRequest.ParametersEntry
+	64, // 51: v1alpha1.CustomizedValue.DataEntry.value:type_name -> google.protobuf.Any
+	0,  // 52: v1alpha1.DeviceManagerService.MapperRegister:input_type -> v1alpha1.MapperRegisterRequest
+	32, // 53: v1alpha1.DeviceManagerService.ReportDeviceStatus:input_type -> v1alpha1.ReportDeviceStatusRequest
+	38, // 54: v1alpha1.DeviceManagerService.QueryDeviceData:input_type -> v1alpha1.QueryDeviceDataRequest
+	42, // 55: v1alpha1.DeviceMapperService.RegisterDevice:input_type -> v1alpha1.RegisterDeviceRequest
+	46, // 56: v1alpha1.DeviceMapperService.RemoveDevice:input_type -> v1alpha1.RemoveDeviceRequest
+	50, // 57: v1alpha1.DeviceMapperService.UpdateDevice:input_type -> v1alpha1.UpdateDeviceRequest
+	44, // 58: v1alpha1.DeviceMapperService.CreateDeviceModel:input_type -> v1alpha1.CreateDeviceModelRequest
+	48, // 59: v1alpha1.DeviceMapperService.RemoveDeviceModel:input_type -> v1alpha1.RemoveDeviceModelRequest
+	52, // 60: v1alpha1.DeviceMapperService.UpdateDeviceModel:input_type -> v1alpha1.UpdateDeviceModelRequest
+	54, // 61: v1alpha1.DeviceMapperService.UpdateDeviceStatus:input_type -> v1alpha1.UpdateDeviceStatusRequest
+	56, // 62: v1alpha1.DeviceMapperService.GetDevice:input_type -> v1alpha1.GetDeviceRequest
+	58, // 63: v1alpha1.DeviceMapperService.InvokeDeviceCommand:input_type -> v1alpha1.InvokeDeviceCommandRequest
+	1,  // 64: v1alpha1.DeviceManagerService.MapperRegister:output_type -> v1alpha1.MapperRegisterResponse
+	37, // 65: v1alpha1.DeviceManagerService.ReportDeviceStatus:output_type -> v1alpha1.ReportDeviceStatusResponse
+	39, // 66: v1alpha1.DeviceManagerService.QueryDeviceData:output_type -> v1alpha1.QueryDeviceDataResponse
+	43, // 67: v1alpha1.DeviceMapperService.RegisterDevice:output_type -> v1alpha1.RegisterDeviceResponse
+	47, // 68: v1alpha1.DeviceMapperService.RemoveDevice:output_type -> v1alpha1.RemoveDeviceResponse
+	51, // 69: v1alpha1.DeviceMapperService.UpdateDevice:output_type -> v1alpha1.UpdateDeviceResponse
+	45, // 70: v1alpha1.DeviceMapperService.CreateDeviceModel:output_type -> v1alpha1.CreateDeviceModelResponse
+	49, // 71: v1alpha1.DeviceMapperService.RemoveDeviceModel:output_type -> v1alpha1.RemoveDeviceModelResponse
+	53, // 72: v1alpha1.DeviceMapperService.UpdateDeviceModel:output_type -> v1alpha1.UpdateDeviceModelResponse
+	55, // 73: v1alpha1.DeviceMapperService.UpdateDeviceStatus:output_type -> v1alpha1.UpdateDeviceStatusResponse
+	57, // 74: v1alpha1.DeviceMapperService.GetDevice:output_type -> v1alpha1.GetDeviceResponse
+	59, // 75: v1alpha1.DeviceMapperService.InvokeDeviceCommand:output_type -> v1alpha1.InvokeDeviceCommandResponse
+	64, // [64:76] is the sub-list for method output_type
+	52, // [52:64] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_init() }
@@ -4437,7 +5003,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TwinProperty); i {
+			switch v := v.(*TwinVersion); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4449,7 +5015,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportDeviceStatusResponse); i {
+			switch v := v.(*TwinProperty); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4461,7 +5027,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterDeviceRequest); i {
+			switch v := v.(*ReportDeviceStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4473,7 +5039,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterDeviceResponse); i {
+			switch v := v.(*QueryDeviceDataRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4485,7 +5051,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateDeviceModelRequest); i {
+			switch v := v.(*QueryDeviceDataResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4497,7 +5063,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateDeviceModelResponse); i {
+			switch v := v.(*DataPoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4509,7 +5075,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveDeviceRequest); i {
+			switch v := v.(*DataAggregate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4521,7 +5087,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveDeviceResponse); i {
+			switch v := v.(*RegisterDeviceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4533,7 +5099,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveDeviceModelRequest); i {
+			switch v := v.(*RegisterDeviceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4545,7 +5111,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveDeviceModelResponse); i {
+			switch v := v.(*CreateDeviceModelRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4557,7 +5123,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateDeviceRequest); i {
+			switch v := v.(*CreateDeviceModelResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4569,7 +5135,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateDeviceResponse); i {
+			switch v := v.(*RemoveDeviceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4581,7 +5147,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateDeviceModelRequest); i {
+			switch v := v.(*RemoveDeviceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4593,7 +5159,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateDeviceModelResponse); i {
+			switch v := v.(*RemoveDeviceModelRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4605,7 +5171,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateDeviceStatusRequest); i {
+			switch v := v.(*RemoveDeviceModelResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4617,7 +5183,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateDeviceStatusResponse); i {
+			switch v := v.(*UpdateDeviceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4629,7 +5195,7 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetDeviceRequest); i {
+			switch v := v.(*UpdateDeviceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4641,6 +5207,66 @@ func file_api_proto_init() {
 			}
 		}
 		file_api_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDeviceModelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDeviceModelResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDeviceStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDeviceStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDeviceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetDeviceResponse); i {
 			case 0:
 				return &v.state
@@ -4652,6 +5278,30 @@ func file_api_proto_init() {
 				return nil
 			}
 		}
+		file_api_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvokeDeviceCommandRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvokeDeviceCommandResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -4659,7 +5309,7 @@ func file_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   56,
+			NumMessages:   64,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -4693,6 +5343,10 @@ type DeviceManagerServiceClient interface {
 	// When the mapper collects some properties of a device, it can make them a map of device twins
 	// and report it to the device manager through the interface of ReportDeviceStatus.
 	ReportDeviceStatus(ctx context.Context, in *ReportDeviceStatusRequest, opts ...grpc.CallOption) (*ReportDeviceStatusResponse, error)
+	// QueryDeviceData queries the history of a device property kept by device manager.
+	// When the time-series buffer of device twin is enabled, device manager keeps the values
+	// reported through ReportDeviceStatus, and returns the raw values or the aggregates of them in a time range.
+	QueryDeviceData(ctx context.Context, in *QueryDeviceDataRequest, opts ...grpc.CallOption) (*QueryDeviceDataResponse, error)
 }
 
 type deviceManagerServiceClient struct {
@@ -4721,6 +5375,15 @@ func (c *deviceManagerServiceClient) ReportDeviceStatus(ctx context.Context, in
 	return out, nil
 }
 
+func (c *deviceManagerServiceClient) QueryDeviceData(ctx context.Context, in *QueryDeviceDataRequest, opts ...grpc.CallOption) (*QueryDeviceDataResponse, error) {
+	out := new(QueryDeviceDataResponse)
+	err := c.cc.Invoke(ctx, "/v1alpha1.DeviceManagerService/QueryDeviceData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeviceManagerServiceServer is the server API for DeviceManagerService service.
 type DeviceManagerServiceServer interface {
 	// MapperRegister registers the information of the mapper to device manager
@@ -4731,6 +5394,10 @@ type DeviceManagerServiceServer interface {
 	// When the mapper collects some properties of a device, it can make them a map of device twins
 	// and report it to the device manager through the interface of ReportDeviceStatus.
 	ReportDeviceStatus(context.Context, *ReportDeviceStatusRequest) (*ReportDeviceStatusResponse, error)
+	// QueryDeviceData queries the history of a device property kept by device manager.
+	// When the time-series buffer of device twin is enabled, device manager keeps the values
+	// reported through ReportDeviceStatus, and returns the raw values or the aggregates of them in a time range.
+	QueryDeviceData(context.Context, *QueryDeviceDataRequest) (*QueryDeviceDataResponse, error)
 }
 
 // UnimplementedDeviceManagerServiceServer can be embedded to have forward compatible implementations.
@@ -4743,6 +5410,9 @@ func (*UnimplementedDeviceManagerServiceServer) MapperRegister(context.Context,
 func (*UnimplementedDeviceManagerServiceServer) ReportDeviceStatus(context.Context, *ReportDeviceStatusRequest) (*ReportDeviceStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReportDeviceStatus not implemented")
 }
+func (*UnimplementedDeviceManagerServiceServer) QueryDeviceData(context.Context, *QueryDeviceDataRequest) (*QueryDeviceDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryDeviceData not implemented")
+}
 
 func RegisterDeviceManagerServiceServer(s *grpc.Server, srv DeviceManagerServiceServer) {
 	s.RegisterService(&_DeviceManagerService_serviceDesc, srv)
@@ -4784,6 +5454,24 @@ func _DeviceManagerService_ReportDeviceStatus_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeviceManagerService_QueryDeviceData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDeviceDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceManagerServiceServer).QueryDeviceData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1alpha1.DeviceManagerService/QueryDeviceData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceManagerServiceServer).QueryDeviceData(ctx, req.(*QueryDeviceDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DeviceManagerService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1alpha1.DeviceManagerService",
 	HandlerType: (*DeviceManagerServiceServer)(nil),
@@ -4796,6 +5484,10 @@ var _DeviceManagerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReportDeviceStatus",
 			Handler:    _DeviceManagerService_ReportDeviceStatus_Handler,
 		},
+		{
+			MethodName: "QueryDeviceData",
+			Handler:    _DeviceManagerService_QueryDeviceData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
@@ -4855,6 +5547,13 @@ type DeviceMapperServiceClient interface {
 	// When the mapper gets the request of querying with the device name,
 	// it should return the device information.
 	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error)
+	// InvokeDeviceCommand invokes a command on a device through the device mapper.
+	// Device manager sends the device name, the command name and the parameters
+	// to the mapper through the interface of InvokeDeviceCommand.
+	// The command must be declared by the device model of the device.
+	// When the mapper gets the request of invoking a command,
+	// it should execute the command on the real physical device and return the result before the timeout.
+	InvokeDeviceCommand(ctx context.Context, in *InvokeDeviceCommandRequest, opts ...grpc.CallOption) (*InvokeDeviceCommandResponse, error)
 }
 
 type deviceMapperServiceClient struct {
@@ -4937,6 +5636,15 @@ func (c *deviceMapperServiceClient) GetDevice(ctx context.Context, in *GetDevice
 	return out, nil
 }
 
+func (c *deviceMapperServiceClient) InvokeDeviceCommand(ctx context.Context, in *InvokeDeviceCommandRequest, opts ...grpc.CallOption) (*InvokeDeviceCommandResponse, error) {
+	out := new(InvokeDeviceCommandResponse)
+	err := c.cc.Invoke(ctx, "/v1alpha1.DeviceMapperService/InvokeDeviceCommand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeviceMapperServiceServer is the server API for DeviceMapperService service.
 type DeviceMapperServiceServer interface {
 	// RegisterDevice registers a device to the device mapper.
@@ -4989,6 +5697,13 @@ type DeviceMapperServiceServer interface {
 	// When the mapper gets the request of querying with the device name,
 	// it should return the device information.
 	GetDevice(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error)
+	// InvokeDeviceCommand invokes a command on a device through the device mapper.
+	// Device manager sends the device name, the command name and the parameters
+	// to the mapper through the interface of InvokeDeviceCommand.
+	// The command must be declared by the device model of the device.
+	// When the mapper gets the request of invoking a command,
+	// it should execute the command on the real physical device and return the result before the timeout.
+	InvokeDeviceCommand(context.Context, *InvokeDeviceCommandRequest) (*InvokeDeviceCommandResponse, error)
 }
 
 // UnimplementedDeviceMapperServiceServer can be embedded to have forward compatible implementations.
@@ -5019,6 +5734,9 @@ func (*UnimplementedDeviceMapperServiceServer) UpdateDeviceStatus(context.Contex
 func (*UnimplementedDeviceMapperServiceServer) GetDevice(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDevice not implemented")
 }
+func (*UnimplementedDeviceMapperServiceServer) InvokeDeviceCommand(context.Context, *InvokeDeviceCommandRequest) (*InvokeDeviceCommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvokeDeviceCommand not implemented")
+}
 
 func RegisterDeviceMapperServiceServer(s *grpc.Server, srv DeviceMapperServiceServer) {
 	s.RegisterService(&_DeviceMapperService_serviceDesc, srv)
@@ -5168,6 +5886,24 @@ func _DeviceMapperService_GetDevice_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeviceMapperService_InvokeDeviceCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeDeviceCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceMapperServiceServer).InvokeDeviceCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1alpha1.DeviceMapperService/InvokeDeviceCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceMapperServiceServer).InvokeDeviceCommand(ctx, req.(*InvokeDeviceCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DeviceMapperService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1alpha1.DeviceMapperService",
 	HandlerType: (*DeviceMapperServiceServer)(nil),
@@ -5204,6 +5940,10 @@ var _DeviceMapperService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDevice",
 			Handler:    _DeviceMapperService_GetDevice_Handler,
 		},
+		{
+			MethodName: "InvokeDeviceCommand",
+			Handler:    _DeviceMapperService_InvokeDeviceCommand_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
@@ -0,0 +1,195 @@
+/*
+Copyright The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	"context"
+	"time"
+
+	v1alpha2 "github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	scheme "github.com/kubeedge/kubeedge/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// DeviceCommandRequestsGetter has a method to return a DeviceCommandRequestInterface.
+// A group's client should implement this interface.
+type DeviceCommandRequestsGetter interface {
+	DeviceCommandRequests(namespace string) DeviceCommandRequestInterface
+}
+
+// DeviceCommandRequestInterface has methods to work with DeviceCommandRequest resources.
+type DeviceCommandRequestInterface interface {
+	Create(ctx context.Context, deviceCommandRequest *v1alpha2.DeviceCommandRequest, opts v1.CreateOptions) (*v1alpha2.DeviceCommandRequest, error)
+	Update(ctx context.Context, deviceCommandRequest *v1alpha2.DeviceCommandRequest, opts v1.UpdateOptions) (*v1alpha2.DeviceCommandRequest, error)
+	UpdateStatus(ctx context.Context, deviceCommandRequest *v1alpha2.DeviceCommandRequest, opts v1.UpdateOptions) (*v1alpha2.DeviceCommandRequest, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha2.DeviceCommandRequest, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha2.DeviceCommandRequestList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.DeviceCommandRequest, err error)
+	DeviceCommandRequestExpansion
+}
+
+// deviceCommandRequests implements DeviceCommandRequestInterface
+type deviceCommandRequests struct {
+	client rest.Interface
+	ns     string
+}
+
+// newDeviceCommandRequests returns a DeviceCommandRequests
+func newDeviceCommandRequests(c *DevicesV1alpha2Client, namespace string) *deviceCommandRequests {
+	return &deviceCommandRequests{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the deviceCommandRequest, and returns the corresponding deviceCommandRequest object, and an error if there is any.
+func (c *deviceCommandRequests) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.DeviceCommandRequest, err error) {
+	result = &v1alpha2.DeviceCommandRequest{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("devicecommandrequests").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of DeviceCommandRequests that match those selectors.
+func (c *deviceCommandRequests) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.DeviceCommandRequestList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha2.DeviceCommandRequestList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("devicecommandrequests").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested deviceCommandRequests.
+func (c *deviceCommandRequests) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("devicecommandrequests").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a deviceCommandRequest and creates it.  Returns the server's representation of the deviceCommandRequest, and an error, if there is any.
+func (c *deviceCommandRequests) Create(ctx context.Context, deviceCommandRequest *v1alpha2.DeviceCommandRequest, opts v1.CreateOptions) (result *v1alpha2.DeviceCommandRequest, err error) {
+	result = &v1alpha2.DeviceCommandRequest{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("devicecommandrequests").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(deviceCommandRequest).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a deviceCommandRequest and updates it. Returns the server's representation of the deviceCommandRequest, and an error, if there is any.
+func (c *deviceCommandRequests) Update(ctx context.Context, deviceCommandRequest *v1alpha2.DeviceCommandRequest, opts v1.UpdateOptions) (result *v1alpha2.DeviceCommandRequest, err error) {
+	result = &v1alpha2.DeviceCommandRequest{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("devicecommandrequests").
+		Name(deviceCommandRequest.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(deviceCommandRequest).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *deviceCommandRequests) UpdateStatus(ctx context.Context, deviceCommandRequest *v1alpha2.DeviceCommandRequest, opts v1.UpdateOptions) (result *v1alpha2.DeviceCommandRequest, err error) {
+	result = &v1alpha2.DeviceCommandRequest{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("devicecommandrequests").
+		Name(deviceCommandRequest.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(deviceCommandRequest).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the deviceCommandRequest and deletes it. Returns an error if one occurs.
+func (c *deviceCommandRequests) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("devicecommandrequests").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *deviceCommandRequests) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("devicecommandrequests").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched deviceCommandRequest.
+func (c *deviceCommandRequests) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.DeviceCommandRequest, err error) {
+	result = &v1alpha2.DeviceCommandRequest{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("devicecommandrequests").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -29,6 +29,8 @@ import (
 type DevicesV1alpha2Interface interface {
 	RESTClient() rest.Interface
 	DevicesGetter
+	DeviceAlertRulesGetter
+	DeviceCommandRequestsGetter
 	DeviceModelsGetter
 }
 
@@ -41,6 +43,14 @@ func (c *DevicesV1alpha2Client) Devices(namespace string) DeviceInterface {
 	return newDevices(c, namespace)
 }
 
+func (c *DevicesV1alpha2Client) DeviceAlertRules(namespace string) DeviceAlertRuleInterface {
+	return newDeviceAlertRules(c, namespace)
+}
+
+func (c *DevicesV1alpha2Client) DeviceCommandRequests(namespace string) DeviceCommandRequestInterface {
+	return newDeviceCommandRequests(c, namespace)
+}
+
 func (c *DevicesV1alpha2Client) DeviceModels(namespace string) DeviceModelInterface {
 	return newDeviceModels(c, namespace)
 }
@@ -0,0 +1,142 @@
+/*
+Copyright The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha2 "github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeDeviceAlertRules implements DeviceAlertRuleInterface
+type FakeDeviceAlertRules struct {
+	Fake *FakeDevicesV1alpha2
+	ns   string
+}
+
+var devicealertrulesResource = schema.GroupVersionResource{Group: "devices", Version: "v1alpha2", Resource: "devicealertrules"}
+
+var devicealertrulesKind = schema.GroupVersionKind{Group: "devices", Version: "v1alpha2", Kind: "DeviceAlertRule"}
+
+// Get takes name of the deviceAlertRule, and returns the corresponding deviceAlertRule object, and an error if there is any.
+func (c *FakeDeviceAlertRules) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.DeviceAlertRule, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(devicealertrulesResource, c.ns, name), &v1alpha2.DeviceAlertRule{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.DeviceAlertRule), err
+}
+
+// List takes label and field selectors, and returns the list of DeviceAlertRules that match those selectors.
+func (c *FakeDeviceAlertRules) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.DeviceAlertRuleList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(devicealertrulesResource, devicealertrulesKind, c.ns, opts), &v1alpha2.DeviceAlertRuleList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha2.DeviceAlertRuleList{ListMeta: obj.(*v1alpha2.DeviceAlertRuleList).ListMeta}
+	for _, item := range obj.(*v1alpha2.DeviceAlertRuleList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested deviceAlertRules.
+func (c *FakeDeviceAlertRules) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(devicealertrulesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a deviceAlertRule and creates it.  Returns the server's representation of the deviceAlertRule, and an error, if there is any.
+func (c *FakeDeviceAlertRules) Create(ctx context.Context, deviceAlertRule *v1alpha2.DeviceAlertRule, opts v1.CreateOptions) (result *v1alpha2.DeviceAlertRule, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(devicealertrulesResource, c.ns, deviceAlertRule), &v1alpha2.DeviceAlertRule{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.DeviceAlertRule), err
+}
+
+// Update takes the representation of a deviceAlertRule and updates it. Returns the server's representation of the deviceAlertRule, and an error, if there is any.
+func (c *FakeDeviceAlertRules) Update(ctx context.Context, deviceAlertRule *v1alpha2.DeviceAlertRule, opts v1.UpdateOptions) (result *v1alpha2.DeviceAlertRule, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(devicealertrulesResource, c.ns, deviceAlertRule), &v1alpha2.DeviceAlertRule{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.DeviceAlertRule), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeDeviceAlertRules) UpdateStatus(ctx context.Context, deviceAlertRule *v1alpha2.DeviceAlertRule, opts v1.UpdateOptions) (*v1alpha2.DeviceAlertRule, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(devicealertrulesResource, "status", c.ns, deviceAlertRule), &v1alpha2.DeviceAlertRule{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.DeviceAlertRule), err
+}
+
+// Delete takes name of the deviceAlertRule and deletes it. Returns an error if one occurs.
+func (c *FakeDeviceAlertRules) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(devicealertrulesResource, c.ns, name, opts), &v1alpha2.DeviceAlertRule{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDeviceAlertRules) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(devicealertrulesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha2.DeviceAlertRuleList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched deviceAlertRule.
+func (c *FakeDeviceAlertRules) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.DeviceAlertRule, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(devicealertrulesResource, c.ns, name, pt, data, subresources...), &v1alpha2.DeviceAlertRule{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.DeviceAlertRule), err
+}
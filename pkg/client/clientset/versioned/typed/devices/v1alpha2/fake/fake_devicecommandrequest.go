@@ -0,0 +1,142 @@
+/*
+Copyright The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha2 "github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeDeviceCommandRequests implements DeviceCommandRequestInterface
+type FakeDeviceCommandRequests struct {
+	Fake *FakeDevicesV1alpha2
+	ns   string
+}
+
+var devicecommandrequestsResource = schema.GroupVersionResource{Group: "devices", Version: "v1alpha2", Resource: "devicecommandrequests"}
+
+var devicecommandrequestsKind = schema.GroupVersionKind{Group: "devices", Version: "v1alpha2", Kind: "DeviceCommandRequest"}
+
+// Get takes name of the deviceCommandRequest, and returns the corresponding deviceCommandRequest object, and an error if there is any.
+func (c *FakeDeviceCommandRequests) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.DeviceCommandRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(devicecommandrequestsResource, c.ns, name), &v1alpha2.DeviceCommandRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.DeviceCommandRequest), err
+}
+
+// List takes label and field selectors, and returns the list of DeviceCommandRequests that match those selectors.
+func (c *FakeDeviceCommandRequests) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.DeviceCommandRequestList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(devicecommandrequestsResource, devicecommandrequestsKind, c.ns, opts), &v1alpha2.DeviceCommandRequestList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha2.DeviceCommandRequestList{ListMeta: obj.(*v1alpha2.DeviceCommandRequestList).ListMeta}
+	for _, item := range obj.(*v1alpha2.DeviceCommandRequestList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested deviceCommandRequests.
+func (c *FakeDeviceCommandRequests) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(devicecommandrequestsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a deviceCommandRequest and creates it.  Returns the server's representation of the deviceCommandRequest, and an error, if there is any.
+func (c *FakeDeviceCommandRequests) Create(ctx context.Context, deviceCommandRequest *v1alpha2.DeviceCommandRequest, opts v1.CreateOptions) (result *v1alpha2.DeviceCommandRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(devicecommandrequestsResource, c.ns, deviceCommandRequest), &v1alpha2.DeviceCommandRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.DeviceCommandRequest), err
+}
+
+// Update takes the representation of a deviceCommandRequest and updates it. Returns the server's representation of the deviceCommandRequest, and an error, if there is any.
+func (c *FakeDeviceCommandRequests) Update(ctx context.Context, deviceCommandRequest *v1alpha2.DeviceCommandRequest, opts v1.UpdateOptions) (result *v1alpha2.DeviceCommandRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(devicecommandrequestsResource, c.ns, deviceCommandRequest), &v1alpha2.DeviceCommandRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.DeviceCommandRequest), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeDeviceCommandRequests) UpdateStatus(ctx context.Context, deviceCommandRequest *v1alpha2.DeviceCommandRequest, opts v1.UpdateOptions) (*v1alpha2.DeviceCommandRequest, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(devicecommandrequestsResource, "status", c.ns, deviceCommandRequest), &v1alpha2.DeviceCommandRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.DeviceCommandRequest), err
+}
+
+// Delete takes name of the deviceCommandRequest and deletes it. Returns an error if one occurs.
+func (c *FakeDeviceCommandRequests) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(devicecommandrequestsResource, c.ns, name, opts), &v1alpha2.DeviceCommandRequest{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDeviceCommandRequests) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(devicecommandrequestsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha2.DeviceCommandRequestList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched deviceCommandRequest.
+func (c *FakeDeviceCommandRequests) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.DeviceCommandRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(devicecommandrequestsResource, c.ns, name, pt, data, subresources...), &v1alpha2.DeviceCommandRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.DeviceCommandRequest), err
+}
@@ -32,6 +32,14 @@ func (c *FakeDevicesV1alpha2) Devices(namespace string) v1alpha2.DeviceInterface
 	return &FakeDevices{c, namespace}
 }
 
+func (c *FakeDevicesV1alpha2) DeviceAlertRules(namespace string) v1alpha2.DeviceAlertRuleInterface {
+	return &FakeDeviceAlertRules{c, namespace}
+}
+
+func (c *FakeDevicesV1alpha2) DeviceCommandRequests(namespace string) v1alpha2.DeviceCommandRequestInterface {
+	return &FakeDeviceCommandRequests{c, namespace}
+}
+
 func (c *FakeDevicesV1alpha2) DeviceModels(namespace string) v1alpha2.DeviceModelInterface {
 	return &FakeDeviceModels{c, namespace}
 }
@@ -20,4 +20,8 @@ package v1alpha2
 
 type DeviceExpansion interface{}
 
+type DeviceAlertRuleExpansion interface{}
+
+type DeviceCommandRequestExpansion interface{}
+
 type DeviceModelExpansion interface{}
@@ -0,0 +1,90 @@
+/*
+Copyright The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	"context"
+	time "time"
+
+	devicesv1alpha2 "github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	versioned "github.com/kubeedge/kubeedge/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/kubeedge/kubeedge/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha2 "github.com/kubeedge/kubeedge/pkg/client/listers/devices/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// DeviceCommandRequestInformer provides access to a shared informer and lister for
+// DeviceCommandRequests.
+type DeviceCommandRequestInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha2.DeviceCommandRequestLister
+}
+
+type deviceCommandRequestInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewDeviceCommandRequestInformer constructs a new informer for DeviceCommandRequest type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewDeviceCommandRequestInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredDeviceCommandRequestInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredDeviceCommandRequestInformer constructs a new informer for DeviceCommandRequest type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredDeviceCommandRequestInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.DevicesV1alpha2().DeviceCommandRequests(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.DevicesV1alpha2().DeviceCommandRequests(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&devicesv1alpha2.DeviceCommandRequest{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *deviceCommandRequestInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredDeviceCommandRequestInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *deviceCommandRequestInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&devicesv1alpha2.DeviceCommandRequest{}, f.defaultInformer)
+}
+
+func (f *deviceCommandRequestInformer) Lister() v1alpha2.DeviceCommandRequestLister {
+	return v1alpha2.NewDeviceCommandRequestLister(f.Informer().GetIndexer())
+}
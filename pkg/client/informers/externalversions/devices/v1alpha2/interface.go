@@ -26,6 +26,10 @@ import (
 type Interface interface {
 	// Devices returns a DeviceInformer.
 	Devices() DeviceInformer
+	// DeviceAlertRules returns a DeviceAlertRuleInformer.
+	DeviceAlertRules() DeviceAlertRuleInformer
+	// DeviceCommandRequests returns a DeviceCommandRequestInformer.
+	DeviceCommandRequests() DeviceCommandRequestInformer
 	// DeviceModels returns a DeviceModelInformer.
 	DeviceModels() DeviceModelInformer
 }
@@ -46,6 +50,16 @@ func (v *version) Devices() DeviceInformer {
 	return &deviceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DeviceAlertRules returns a DeviceAlertRuleInformer.
+func (v *version) DeviceAlertRules() DeviceAlertRuleInformer {
+	return &deviceAlertRuleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DeviceCommandRequests returns a DeviceCommandRequestInformer.
+func (v *version) DeviceCommandRequests() DeviceCommandRequestInformer {
+	return &deviceCommandRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DeviceModels returns a DeviceModelInformer.
 func (v *version) DeviceModels() DeviceModelInformer {
 	return &deviceModelInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
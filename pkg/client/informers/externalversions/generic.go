@@ -66,6 +66,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=devices, Version=v1alpha2
 	case v1alpha2.SchemeGroupVersion.WithResource("devices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Devices().V1alpha2().Devices().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("devicealertrules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Devices().V1alpha2().DeviceAlertRules().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("devicecommandrequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Devices().V1alpha2().DeviceCommandRequests().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("devicemodels"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Devices().V1alpha2().DeviceModels().Informer()}, nil
 
@@ -0,0 +1,99 @@
+/*
+Copyright The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	v1alpha2 "github.com/kubeedge/kubeedge/pkg/apis/devices/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DeviceCommandRequestLister helps list DeviceCommandRequests.
+// All objects returned here must be treated as read-only.
+type DeviceCommandRequestLister interface {
+	// List lists all DeviceCommandRequests in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.DeviceCommandRequest, err error)
+	// DeviceCommandRequests returns an object that can list and get DeviceCommandRequests.
+	DeviceCommandRequests(namespace string) DeviceCommandRequestNamespaceLister
+	DeviceCommandRequestListerExpansion
+}
+
+// deviceCommandRequestLister implements the DeviceCommandRequestLister interface.
+type deviceCommandRequestLister struct {
+	indexer cache.Indexer
+}
+
+// NewDeviceCommandRequestLister returns a new DeviceCommandRequestLister.
+func NewDeviceCommandRequestLister(indexer cache.Indexer) DeviceCommandRequestLister {
+	return &deviceCommandRequestLister{indexer: indexer}
+}
+
+// List lists all DeviceCommandRequests in the indexer.
+func (s *deviceCommandRequestLister) List(selector labels.Selector) (ret []*v1alpha2.DeviceCommandRequest, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.DeviceCommandRequest))
+	})
+	return ret, err
+}
+
+// DeviceCommandRequests returns an object that can list and get DeviceCommandRequests.
+func (s *deviceCommandRequestLister) DeviceCommandRequests(namespace string) DeviceCommandRequestNamespaceLister {
+	return deviceCommandRequestNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// DeviceCommandRequestNamespaceLister helps list and get DeviceCommandRequests.
+// All objects returned here must be treated as read-only.
+type DeviceCommandRequestNamespaceLister interface {
+	// List lists all DeviceCommandRequests in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.DeviceCommandRequest, err error)
+	// Get retrieves the DeviceCommandRequest from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha2.DeviceCommandRequest, error)
+	DeviceCommandRequestNamespaceListerExpansion
+}
+
+// deviceCommandRequestNamespaceLister implements the DeviceCommandRequestNamespaceLister
+// interface.
+type deviceCommandRequestNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all DeviceCommandRequests in the indexer for a given namespace.
+func (s deviceCommandRequestNamespaceLister) List(selector labels.Selector) (ret []*v1alpha2.DeviceCommandRequest, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.DeviceCommandRequest))
+	})
+	return ret, err
+}
+
+// Get retrieves the DeviceCommandRequest from the indexer for a given namespace and name.
+func (s deviceCommandRequestNamespaceLister) Get(name string) (*v1alpha2.DeviceCommandRequest, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha2.Resource("devicecommandrequest"), name)
+	}
+	return obj.(*v1alpha2.DeviceCommandRequest), nil
+}
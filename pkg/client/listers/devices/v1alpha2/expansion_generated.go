@@ -26,6 +26,22 @@ type DeviceListerExpansion interface{}
 // DeviceNamespaceLister.
 type DeviceNamespaceListerExpansion interface{}
 
+// DeviceAlertRuleListerExpansion allows custom methods to be added to
+// DeviceAlertRuleLister.
+type DeviceAlertRuleListerExpansion interface{}
+
+// DeviceAlertRuleNamespaceListerExpansion allows custom methods to be added to
+// DeviceAlertRuleNamespaceLister.
+type DeviceAlertRuleNamespaceListerExpansion interface{}
+
+// DeviceCommandRequestListerExpansion allows custom methods to be added to
+// DeviceCommandRequestLister.
+type DeviceCommandRequestListerExpansion interface{}
+
+// DeviceCommandRequestNamespaceListerExpansion allows custom methods to be added to
+// DeviceCommandRequestNamespaceLister.
+type DeviceCommandRequestNamespaceListerExpansion interface{}
+
 // DeviceModelListerExpansion allows custom methods to be added to
 // DeviceModelLister.
 type DeviceModelListerExpansion interface{}
@@ -49,6 +49,8 @@ const (
 	Update       ApplicationVerb = "update"
 	UpdateStatus ApplicationVerb = "updatestatus"
 	Patch        ApplicationVerb = "patch"
+	// Discovery gets the discovery document of the path in Application.Key
+	Discovery ApplicationVerb = "discovery"
 )
 
 type PatchInfo struct {
@@ -0,0 +1,29 @@
+package metaserver
+
+import (
+	"strings"
+)
+
+// IsDiscoveryPath checks whether the path is the path of a discovery document, like
+// /version, /api, /api/{version}, /apis, /apis/{group} and /apis/{group}/{version}
+func IsDiscoveryPath(path string) bool {
+	if !strings.HasPrefix(path, "/") {
+		return false
+	}
+	tokens := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for _, token := range tokens {
+		if token == "" || token == "." || token == ".." {
+			return false
+		}
+	}
+	switch tokens[0] {
+	case "version":
+		return len(tokens) == 1
+	case "api":
+		return len(tokens) <= 2
+	case "apis":
+		return len(tokens) <= 3
+	default:
+		return false
+	}
+}
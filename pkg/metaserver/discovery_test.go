@@ -0,0 +1,35 @@
+package metaserver
+
+import (
+	"testing"
+)
+
+func TestIsDiscoveryPath(t *testing.T) {
+	cases := map[string]bool{
+		"/version":                      true,
+		"/api":                          true,
+		"/api/v1":                       true,
+		"/apis":                         true,
+		"/apis/example.com":             true,
+		"/apis/example.com/v1":          true,
+		"":                              false,
+		"/":                             false,
+		"api":                           false,
+		"/api/":                         false,
+		"/api/v1/pods":                  false,
+		"/apis/example.com/v1/widgets":  false,
+		"/apis/example.com/../v1":       false,
+		"/version/foo":                  false,
+		"/healthz":                      false,
+		"/apis//v1":                     false,
+		"/api/v1/namespaces/default/x":  false,
+		"/openapi/v2":                   false,
+		"/apis/example.com/v1/":         false,
+		"/apis/example.com/v1/widgets/": false,
+	}
+	for path, expected := range cases {
+		if got := IsDiscoveryPath(path); got != expected {
+			t.Errorf("IsDiscoveryPath(%q) = %v, expected %v", path, got, expected)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package util
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -9,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/storage"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
@@ -96,31 +99,90 @@ func UnsafeKindToResource(k string) string {
 	return r + "s"
 }
 
-func UnstructuredAttr(obj runtime.Object) (labels.Set, fields.Set, error) {
-	switch obj.GetObjectKind().GroupVersionKind().Kind {
-	case "Pod":
-		metadata, err := meta.Accessor(obj)
-		if err != nil {
-			return nil, nil, err
-		}
-		setMap := make(fields.Set)
-		if metadata.GetName() != "" {
-			setMap["metadata.name"] = metadata.GetName()
-		}
-		if metadata.GetNamespace() != "" {
-			setMap["metadata.namespaces"] = metadata.GetNamespace()
-		}
-		unstrObj, ok := obj.(*unstructured.Unstructured)
-		if ok {
-			value, found, err := unstructured.NestedString(unstrObj.Object, "spec", "nodeName")
-			if found && err == nil {
-				setMap["spec.nodeName"] = value
-			}
-		}
-		return metadata.GetLabels(), setMap, nil
+// selectableField is a field of the object that can be used in field selector
+type selectableField struct {
+	path []string
+	// defaultValue is the value of the field when it is not set
+	defaultValue string
+}
+
+// selectableFields are the fields besides metadata.name and metadata.namespace that can be used
+// in field selectors of the resources, they are the same as the ones supported by kube-apiserver
+var selectableFields = map[schema.GroupKind]map[string]selectableField{
+	{Kind: "Pod"}: {
+		"spec.nodeName":            {path: []string{"spec", "nodeName"}},
+		"spec.restartPolicy":       {path: []string{"spec", "restartPolicy"}},
+		"spec.schedulerName":       {path: []string{"spec", "schedulerName"}},
+		"spec.serviceAccountName":  {path: []string{"spec", "serviceAccountName"}},
+		"status.phase":             {path: []string{"status", "phase"}},
+		"status.podIP":             {path: []string{"status", "podIP"}},
+		"status.nominatedNodeName": {path: []string{"status", "nominatedNodeName"}},
+	},
+	{Kind: "Node"}: {
+		"spec.unschedulable": {path: []string{"spec", "unschedulable"}, defaultValue: "false"},
+	},
+	{Kind: "Namespace"}: {
+		"status.phase": {path: []string{"status", "phase"}},
+	},
+	{Kind: "Secret"}: {
+		"type": {path: []string{"type"}},
+	},
+	{Kind: "Event"}: {
+		"involvedObject.kind":            {path: []string{"involvedObject", "kind"}},
+		"involvedObject.namespace":       {path: []string{"involvedObject", "namespace"}},
+		"involvedObject.name":            {path: []string{"involvedObject", "name"}},
+		"involvedObject.uid":             {path: []string{"involvedObject", "uid"}},
+		"involvedObject.apiVersion":      {path: []string{"involvedObject", "apiVersion"}},
+		"involvedObject.resourceVersion": {path: []string{"involvedObject", "resourceVersion"}},
+		"involvedObject.fieldPath":       {path: []string{"involvedObject", "fieldPath"}},
+		"reason":                         {path: []string{"reason"}},
+		"reportingComponent":             {path: []string{"reportingComponent"}},
+		"source":                         {path: []string{"source", "component"}},
+		"type":                           {path: []string{"type"}},
+	},
+	{Kind: "ReplicationController"}: {
+		"status.replicas": {path: []string{"status", "replicas"}, defaultValue: "0"},
+	},
+	{Group: "apps", Kind: "ReplicaSet"}: {
+		"status.replicas": {path: []string{"status", "replicas"}, defaultValue: "0"},
+	},
+	{Group: "batch", Kind: "Job"}: {
+		"status.successful": {path: []string{"status", "successful"}, defaultValue: "0"},
+	},
+	{Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"}: {
+		"spec.signerName": {path: []string{"spec", "signerName"}},
+	},
+}
+
+func (f selectableField) value(obj map[string]interface{}) string {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, f.path...)
+	if err != nil || !found || value == nil {
+		return f.defaultValue
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
 	default:
-		return storage.DefaultNamespaceScopedAttr(obj)
+		return fmt.Sprint(v)
+	}
+}
+
+// UnstructuredAttr returns the labels and the fields can be used in field selector of the object
+func UnstructuredAttr(obj runtime.Object) (labels.Set, fields.Set, error) {
+	labelSet, fieldSet, err := storage.DefaultNamespaceScopedAttr(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	unstrObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return labelSet, fieldSet, nil
+	}
+	for name, field := range selectableFields[unstrObj.GroupVersionKind().GroupKind()] {
+		fieldSet[name] = field.value(unstrObj.Object)
 	}
+	return labelSet, fieldSet, nil
 }
 
 // GetMessageUID returns the UID of the object in message
@@ -242,6 +242,14 @@ func TestUnstructuredAttr(t *testing.T) {
 		"metadata.namespaces": "test",
 	})
 	_ = unstructured.SetNestedField(uns.Object, "node1", "spec", "nodeName")
+	node := &unstructured.Unstructured{}
+	node.SetName("node1")
+	node.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Node"})
+	_ = unstructured.SetNestedField(node.Object, true, "spec", "unschedulable")
+	job := &unstructured.Unstructured{}
+	job.SetName("job1")
+	job.SetNamespace("test")
+	job.SetGroupVersionKind(schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"})
 	type args struct {
 		obj runtime.Object
 	}
@@ -267,9 +275,37 @@ func TestUnstructuredAttr(t *testing.T) {
 			args: args{obj: uns},
 			want: uns.GetLabels(),
 			want1: map[string]string{
-				"metadata.name":       "uns1",
-				"metadata.namespaces": "test",
-				"spec.nodeName":       "node1",
+				"metadata.name":            "uns1",
+				"metadata.namespace":       "test",
+				"spec.nodeName":            "node1",
+				"spec.restartPolicy":       "",
+				"spec.schedulerName":       "",
+				"spec.serviceAccountName":  "",
+				"status.phase":             "",
+				"status.podIP":             "",
+				"status.nominatedNodeName": "",
+			},
+			wantErr: false,
+		},
+		{
+			name: "TestUnstructuredAttr(): Case 3: Node",
+			args: args{obj: node},
+			want: nil,
+			want1: map[string]string{
+				"metadata.name":      "node1",
+				"metadata.namespace": "",
+				"spec.unschedulable": "true",
+			},
+			wantErr: false,
+		},
+		{
+			name: "TestUnstructuredAttr(): Case 4: Job",
+			args: args{obj: job},
+			want: nil,
+			want1: map[string]string{
+				"metadata.name":      "job1",
+				"metadata.namespace": "test",
+				"status.successful":  "0",
 			},
 			wantErr: false,
 		},
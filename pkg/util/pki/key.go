@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+)
+
+// key algorithms of edge certificates
+const (
+	KeyAlgorithmECDSAP256 = "ECDSA-P256"
+	KeyAlgorithmECDSAP384 = "ECDSA-P384"
+	KeyAlgorithmRSA2048   = "RSA-2048"
+	KeyAlgorithmRSA4096   = "RSA-4096"
+	KeyAlgorithmEd25519   = "Ed25519"
+)
+
+// SupportedKeyAlgorithms is the key algorithms supported by edge certificates
+var SupportedKeyAlgorithms = []string{
+	KeyAlgorithmECDSAP256,
+	KeyAlgorithmECDSAP384,
+	KeyAlgorithmRSA2048,
+	KeyAlgorithmRSA4096,
+	KeyAlgorithmEd25519,
+}
+
+// IsSupportedKeyAlgorithm checks whether the key algorithm is supported
+func IsSupportedKeyAlgorithm(algorithm string) bool {
+	for _, supported := range SupportedKeyAlgorithms {
+		if algorithm == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateKey generates a private key with the key algorithm
+func GenerateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case KeyAlgorithmECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyAlgorithmECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case KeyAlgorithmRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case KeyAlgorithmRSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	case KeyAlgorithmEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q", algorithm)
+	}
+}
+
+// KeyAlgorithmOf returns the key algorithm of the public key
+func KeyAlgorithmOf(publicKey crypto.PublicKey) (string, error) {
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return KeyAlgorithmECDSAP256, nil
+		case elliptic.P384():
+			return KeyAlgorithmECDSAP384, nil
+		}
+		return "", fmt.Errorf("unsupported ECDSA curve %s", key.Curve.Params().Name)
+	case *rsa.PublicKey:
+		switch key.N.BitLen() {
+		case 2048:
+			return KeyAlgorithmRSA2048, nil
+		case 4096:
+			return KeyAlgorithmRSA4096, nil
+		}
+		return "", fmt.Errorf("unsupported RSA key size %d", key.N.BitLen())
+	case ed25519.PublicKey:
+		return KeyAlgorithmEd25519, nil
+	default:
+		return "", fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The KubeEdge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+)
+
+func TestGenerateKey(t *testing.T) {
+	for _, algorithm := range []string{KeyAlgorithmECDSAP256, KeyAlgorithmECDSAP384, KeyAlgorithmRSA2048, KeyAlgorithmEd25519} {
+		t.Run(algorithm, func(t *testing.T) {
+			key, err := GenerateKey(algorithm)
+			if err != nil {
+				t.Fatalf("failed to generate key: %v", err)
+			}
+			got, err := KeyAlgorithmOf(key.Public())
+			if err != nil {
+				t.Fatalf("failed to get key algorithm: %v", err)
+			}
+			if got != algorithm {
+				t.Errorf("KeyAlgorithmOf() = %s, want %s", got, algorithm)
+			}
+		})
+	}
+
+	if _, err := GenerateKey("DSA-1024"); err == nil {
+		t.Errorf("expected error for unsupported key algorithm")
+	}
+}
+
+func TestKeyAlgorithmOfUnsupportedKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	if _, err := KeyAlgorithmOf(key.Public()); err == nil {
+		t.Errorf("expected error for unsupported ECDSA curve")
+	}
+	if _, err := KeyAlgorithmOf("key"); err == nil {
+		t.Errorf("expected error for unsupported public key type")
+	}
+}
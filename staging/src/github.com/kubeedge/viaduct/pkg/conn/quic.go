@@ -37,6 +37,7 @@ type QuicConnection struct {
 	ctrlLan            lane.Lane
 	state              *ConnectionState
 	streamManager      *smgr.StreamManager
+	classStreams       *smgr.ClassStreamManager
 	consumer           io.Writer
 	connUse            api.UseType
 	syncKeeper         *keeper.SyncKeeper
@@ -61,6 +62,7 @@ func NewQuicConn(options *ConnectionOptions) *QuicConnection {
 		messageFifo:        fifo.NewMessageFifo(),
 		OnReadTransportErr: options.OnReadTransportErr,
 		streamManager:      smgr.NewStreamManager(smgr.NumStreamsMax, autoFree, quicSession),
+		classStreams:       smgr.NewClassStreamManager(),
 	}
 }
 
@@ -223,7 +225,10 @@ func (conn *QuicConnection) handleMessage(stream *smgr.Stream) {
 			if !errors.Is(err, io.EOF) {
 				klog.Errorf("failed to read message, error: %+v", err)
 			}
-			conn.streamManager.FreeStream(stream)
+			// the class streams are not in the stream pool
+			if !conn.classStreams.DropStream(stream.Stream) {
+				conn.streamManager.FreeStream(stream)
+			}
 			return
 		}
 
@@ -258,7 +263,27 @@ func (conn *QuicConnection) handleMessage(stream *smgr.Stream) {
 func (conn *QuicConnection) Close() error {
 	conn.state.State = api.StatDisconnected
 	conn.streamManager.Destroy()
-	return conn.session.Close()
+	// close the session first to unblock the writers of class streams
+	err := conn.session.Close()
+	conn.classStreams.Destroy()
+	return err
+}
+
+// write message into the dedicated stream of its class,
+// messages of different classes are written concurrently
+func (conn *QuicConnection) writeClassMessage(msg *model.Message) error {
+	class := smgr.ClassifyMessage(msg)
+	stream, err := conn.classStreams.AcquireStream(class, conn.openStreamSync)
+	if err != nil {
+		klog.Errorf("failed to acquire stream sync, error:%+v", err)
+		return fmt.Errorf("failed to acquire stream sync, error:%+v", err)
+	}
+
+	lane := lane.NewLane(api.ProtocolTypeQuic, stream.Stream)
+	_ = lane.SetWriteDeadline(conn.writeDeadline)
+	err = lane.WriteMessage(msg)
+	conn.classStreams.ReleaseStream(class, err != nil)
+	return err
 }
 
 // WriteMessageSync write sync message
@@ -269,20 +294,8 @@ func (conn *QuicConnection) WriteMessageSync(msg *model.Message) (*model.Message
 		return nil, fmt.Errorf("bad connection session")
 	}
 
-	conn.locker.Lock()
-	defer conn.locker.Unlock()
-
-	stream, err := conn.streamManager.GetStream(api.UseTypeMessage, true, conn.openStreamSync)
-	if err != nil {
-		klog.Errorf("failed to acquire stream sync, error:%+v", err)
-		return nil, fmt.Errorf("failed to acquire stream sync, error:%+v", err)
-	}
-	defer conn.streamManager.ReleaseStream(api.UseTypeMessage, stream)
-
-	lane := lane.NewLane(api.ProtocolTypeQuic, stream)
-	_ = lane.SetWriteDeadline(conn.writeDeadline)
 	msg.Header.Sync = true
-	err = lane.WriteMessage(msg)
+	err := conn.writeClassMessage(msg)
 	if err != nil {
 		return nil, err
 	}
@@ -299,21 +312,8 @@ func (conn *QuicConnection) WriteMessageAsync(msg *model.Message) error {
 		return fmt.Errorf("bad connection session")
 	}
 
-	conn.locker.Lock()
-	defer conn.locker.Unlock()
-
-	stream, err := conn.streamManager.GetStream(api.UseTypeMessage, true, conn.openStreamSync)
-	if err != nil {
-		klog.Errorf("failed to acquire stream sync, error:%+v", err)
-		return fmt.Errorf("failed to acquire stream sync, error:%+v", err)
-	}
-	defer conn.streamManager.ReleaseStream(api.UseTypeMessage, stream)
-
-	lane := lane.NewLane(api.ProtocolTypeQuic, stream)
-	_ = lane.SetWriteDeadline(conn.writeDeadline)
 	msg.Header.Sync = false
-
-	return lane.WriteMessage(msg)
+	return conn.writeClassMessage(msg)
 }
 
 // ReadMessage read message from fifo
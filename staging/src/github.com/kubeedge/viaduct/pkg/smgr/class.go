@@ -0,0 +1,168 @@
+package smgr
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/lucas-clemente/quic-go"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+	"github.com/kubeedge/viaduct/pkg/api"
+)
+
+// StreamClass is the class of resources whose messages share one
+// dedicated quic stream. Messages of the same class keep their order,
+// while different classes are carried by different streams, so that
+// a large message of one class never blocks the others
+type StreamClass string
+
+const (
+	StreamClassDefault StreamClass = "default"
+	StreamClassPod     StreamClass = "pod"
+	StreamClassConfig  StreamClass = "config"
+	StreamClassTwin    StreamClass = "twin"
+	StreamClassUpgrade StreamClass = "upgrade"
+)
+
+// the resource types in message router resource and their classes
+var resourceClasses = map[string]StreamClass{
+	model.ResourceTypePod:                 StreamClassPod,
+	model.ResourceTypePodlist:             StreamClassPod,
+	model.ResourceTypePodStatus:           StreamClassPod,
+	model.ResourceTypePodPatch:            StreamClassPod,
+	model.ResourceTypeConfigmap:           StreamClassConfig,
+	model.ResourceTypeSecret:              StreamClassConfig,
+	model.ResourceTypeServiceAccountToken: StreamClassConfig,
+	"twin":                                StreamClassTwin,
+	"membership":                          StreamClassTwin,
+	"device":                              StreamClassTwin,
+	"upgrade":                             StreamClassUpgrade,
+	"nodeupgradejob":                      StreamClassUpgrade,
+}
+
+// ClassifyMessage returns the stream class of the message
+// based on its router group and resource
+func ClassifyMessage(msg *model.Message) StreamClass {
+	if msg == nil {
+		return StreamClassDefault
+	}
+	if class, ok := resourceClasses[msg.GetGroup()]; ok {
+		return class
+	}
+	for _, segment := range strings.Split(msg.GetResource(), "/") {
+		if class, ok := resourceClasses[segment]; ok {
+			return class
+		}
+	}
+	return StreamClassDefault
+}
+
+// the dedicated stream of one class
+type classStream struct {
+	// writeLock serializes the writers of the class
+	writeLock sync.Mutex
+	// stream is guarded by the lock of ClassStreamManager
+	stream *Stream
+}
+
+// ClassStreamManager keeps one message stream per class
+type ClassStreamManager struct {
+	streams map[StreamClass]*classStream
+	// owned records the ids of the streams opened for the classes, which
+	// are not in the stream pool and are dropped by DropStream when broken
+	owned map[quic.StreamID]StreamClass
+	lock  sync.Mutex
+}
+
+func NewClassStreamManager() *ClassStreamManager {
+	return &ClassStreamManager{
+		streams: make(map[StreamClass]*classStream),
+		owned:   make(map[quic.StreamID]StreamClass),
+	}
+}
+
+func (mgr *ClassStreamManager) getClassStream(class StreamClass) *classStream {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+	cs, ok := mgr.streams[class]
+	if !ok {
+		cs = &classStream{}
+		mgr.streams[class] = cs
+	}
+	return cs
+}
+
+// AcquireStream locks the stream of the class, the stream is opened by
+// getFuncEx if it does not exist yet. ReleaseStream must be called after use
+func (mgr *ClassStreamManager) AcquireStream(class StreamClass, getFuncEx GetFuncEx) (*Stream, error) {
+	cs := mgr.getClassStream(class)
+	cs.writeLock.Lock()
+	mgr.lock.Lock()
+	stream := cs.stream
+	mgr.lock.Unlock()
+	if stream != nil {
+		return stream, nil
+	}
+
+	stream, err := getFuncEx(api.UseTypeMessage, true)
+	if err != nil {
+		cs.writeLock.Unlock()
+		return nil, fmt.Errorf("failed to open stream for class(%s), error: %+v", class, err)
+	}
+	klog.Infof("open stream(%d) for class(%s)", stream.Stream.StreamID(), class)
+	mgr.lock.Lock()
+	cs.stream = stream
+	mgr.owned[stream.Stream.StreamID()] = class
+	mgr.lock.Unlock()
+	return stream, nil
+}
+
+// ReleaseStream unlocks the stream of the class, the stream will be
+// dropped and reopened at next acquire if broken is true
+func (mgr *ClassStreamManager) ReleaseStream(class StreamClass, broken bool) {
+	cs := mgr.getClassStream(class)
+	if broken {
+		mgr.lock.Lock()
+		if cs.stream != nil {
+			klog.Warningf("drop broken stream(%d) of class(%s)", cs.stream.Stream.StreamID(), class)
+			cs.stream.Stream.Close()
+			cs.stream = nil
+		}
+		mgr.lock.Unlock()
+	}
+	cs.writeLock.Unlock()
+}
+
+// DropStream drops the stream if it is opened for a class, it returns false
+// if the stream is not a class stream. The writers of the class are not waited
+// for, a writer holding the dropped stream fails and reopens it at next acquire
+func (mgr *ClassStreamManager) DropStream(stream quic.Stream) bool {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+	class, ok := mgr.owned[stream.StreamID()]
+	if !ok {
+		return false
+	}
+	delete(mgr.owned, stream.StreamID())
+	if cs := mgr.streams[class]; cs != nil && cs.stream != nil && cs.stream.Stream.StreamID() == stream.StreamID() {
+		klog.Warningf("drop stream(%d) of class(%s)", stream.StreamID(), class)
+		cs.stream = nil
+	}
+	stream.Close()
+	return true
+}
+
+// Destroy closes all the class streams
+func (mgr *ClassStreamManager) Destroy() {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+	for _, cs := range mgr.streams {
+		if cs.stream != nil {
+			cs.stream.Stream.Close()
+			cs.stream = nil
+		}
+	}
+	mgr.owned = make(map[quic.StreamID]StreamClass)
+}
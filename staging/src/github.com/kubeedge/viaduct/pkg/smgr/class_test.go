@@ -0,0 +1,69 @@
+package smgr
+
+import (
+	"testing"
+
+	"github.com/kubeedge/beehive/pkg/core/model"
+)
+
+// TestClassifyMessage is function to test ClassifyMessage().
+func TestClassifyMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *model.Message
+		want StreamClass
+	}{
+		{
+			name: "NilMessage",
+			msg:  nil,
+			want: StreamClassDefault,
+		},
+		{
+			name: "CloudPod",
+			msg:  model.NewMessage("").BuildRouter("edgecontroller", "resource", "node/edge-node/default/pod/nginx", model.UpdateOperation),
+			want: StreamClassPod,
+		},
+		{
+			name: "EdgePodStatus",
+			msg:  model.NewMessage("").BuildRouter("metaManager", "meta", "default/podstatus/nginx", model.UpdateOperation),
+			want: StreamClassPod,
+		},
+		{
+			name: "ConfigMap",
+			msg:  model.NewMessage("").BuildRouter("edgecontroller", "resource", "node/edge-node/default/configmap/cm", model.UpdateOperation),
+			want: StreamClassConfig,
+		},
+		{
+			name: "Secret",
+			msg:  model.NewMessage("").BuildRouter("edgecontroller", "resource", "node/edge-node/default/secret/s", model.InsertOperation),
+			want: StreamClassConfig,
+		},
+		{
+			name: "TwinGroup",
+			msg:  model.NewMessage("").BuildRouter("twin", "twin", "$hw/events/device/dev/twin/update", model.UpdateOperation),
+			want: StreamClassTwin,
+		},
+		{
+			name: "Membership",
+			msg:  model.NewMessage("").BuildRouter("devicecontroller", "resource", "node/edge-node/membership", model.UpdateOperation),
+			want: StreamClassTwin,
+		},
+		{
+			name: "Upgrade",
+			msg:  model.NewMessage("").BuildRouter("nodeupgradejobcontroller", "nodeupgradejobcontroller", "upgrade/id/node/edge-node", model.UpdateOperation),
+			want: StreamClassUpgrade,
+		},
+		{
+			name: "Default",
+			msg:  model.NewMessage("").BuildRouter("edgecontroller", "resource", "node/edge-node/default/service/svc", model.UpdateOperation),
+			want: StreamClassDefault,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyMessage(tt.msg); got != tt.want {
+				t.Errorf("ClassifyMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}